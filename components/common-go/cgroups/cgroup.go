@@ -65,6 +65,16 @@ func ReadSingleValue(path string) (uint64, error) {
 	return max, nil
 }
 
+// WriteSingleValue writes a single value to a cgroup interface file. math.MaxUint64 is written as "max".
+func WriteSingleValue(path string, value uint64) error {
+	content := strconv.FormatUint(value, 10)
+	if value == math.MaxUint64 {
+		content = "max"
+	}
+
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
 func ReadFlatKeyedFile(path string) (map[string]uint64, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
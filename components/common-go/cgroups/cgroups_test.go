@@ -135,6 +135,46 @@ func TestReadSingleValue(t *testing.T) {
 	}
 }
 
+func TestWriteSingleValue(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		value    uint64
+		expected string
+	}{
+		{
+			name:     "max value",
+			value:    math.MaxUint64,
+			expected: "max",
+		},
+		{
+			name:     "valid value",
+			value:    100_000,
+			expected: "100000",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "memory.high")
+			if err := WriteSingleValue(path, s.value); err != nil {
+				t.Fatal(err)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, s.expected, string(content))
+
+			v, err := ReadSingleValue(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, s.value, v)
+		})
+	}
+}
+
 func TestReadPSI(t *testing.T) {
 	scenarios := []struct {
 		name     string
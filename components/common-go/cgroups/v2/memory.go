@@ -52,6 +52,25 @@ func (c *Memory) High() (uint64, error) {
 	return cgroups.ReadSingleValue(path)
 }
 
+// SetHigh sets the memory usage throttle limit in bytes.
+// math.MaxUint64 removes the limit.
+func (c *Memory) SetHigh(value uint64) error {
+	path := filepath.Join(c.path, "memory.high")
+	return cgroups.WriteSingleValue(path, value)
+}
+
+// SwapMax returns the swap usage hard limit in bytes.
+func (c *Memory) SwapMax() (uint64, error) {
+	path := filepath.Join(c.path, "memory.swap.max")
+	return cgroups.ReadSingleValue(path)
+}
+
+// SetSwapMax sets the swap usage hard limit in bytes. Zero disables swap for the cgroup.
+func (c *Memory) SetSwapMax(value uint64) error {
+	path := filepath.Join(c.path, "memory.swap.max")
+	return cgroups.WriteSingleValue(path, value)
+}
+
 func (m *Memory) Stat() (*cgroups.MemoryStats, error) {
 	path := filepath.Join(m.path, "memory.stat")
 	statMap, err := cgroups.ReadFlatKeyedFile(path)
@@ -36,6 +36,12 @@ const (
 	// TeamLabel is the label for the workspace's team
 	TeamLabel = "team"
 
+	// TenantLabel is the label for the workspace's tenant
+	TenantLabel = "tenant"
+
+	// WorkspaceClassLabel is the label for the workspace's class
+	WorkspaceClassLabel = "gitpod.io/workspaceClass"
+
 	// TypeLabel marks the workspace type
 	TypeLabel = "workspaceType"
 
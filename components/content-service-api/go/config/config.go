@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
+	"github.com/gitpod-io/gitpod/common-go/util"
 )
 
 // StorageConfig configures the remote storage we use
@@ -27,6 +28,12 @@ type StorageConfig struct {
 	// S3Config configures the S3 remote storage
 	S3Config *S3Config `json:"s3,omitempty"`
 
+	// FilesystemConfig configures the local filesystem remote storage
+	FilesystemConfig *FilesystemConfig `json:"filesystem,omitempty"`
+
+	// Encryption enables client-side encryption of workspace backups and snapshots
+	Encryption *EncryptionConfig `json:"encryption,omitempty"`
+
 	BlobQuota int64 `json:"blobQuota"`
 }
 
@@ -58,6 +65,10 @@ const (
 	// exist in the environment. See https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/config#LoadDefaultConfig for more details.
 	S3Storage RemoteStorageType = "s3"
 
+	// FilesystemStorage stores workspaces in a locally mounted directory, e.g. an NFS share or a persistent volume.
+	// Presigned URLs are served by content-service itself.
+	FilesystemStorage RemoteStorageType = "filesystem"
+
 	// NullStorage does not synchronize workspaces at all
 	NullStorage RemoteStorageType = ""
 )
@@ -116,6 +127,38 @@ type S3Config struct {
 	CredentialsFile string `json:"credentialsFile"`
 }
 
+// FilesystemConfig configures the local filesystem remote storage backend
+type FilesystemConfig struct {
+	// Root is the directory under which all buckets are stored. It must be mounted
+	// at the same location in all components which access the storage.
+	Root string `json:"root"`
+
+	// BaseURL is the externally reachable URL under which content-service serves
+	// signed up- and downloads, e.g. http://content-service:8080/storage
+	BaseURL string `json:"baseURL"`
+
+	// SigningKey is the secret used to sign up- and download URLs
+	SigningKey     string `json:"signingKey"`
+	SigningKeyFile string `json:"signingKeyFile"`
+}
+
+// EncryptionConfig configures the client-side envelope encryption of workspace backups
+type EncryptionConfig struct {
+	// KeyringFile points to a JSON file which contains the key-encryption keys, e.g.
+	//   {"primary": "2023-01", "keys": [{"id": "2023-01", "key": "<base64 encoded 32 bytes>"}]}
+	// The primary key wraps new data keys, all other keys are kept to decrypt existing backups.
+	KeyringFile string `json:"keyringFile"`
+
+	// HeadlessLogsBaseURL is the externally reachable URL under which content-service serves decrypted
+	// headless logs, e.g. http://content-service:8080/headless-logs. Encrypted headless logs cannot be
+	// downloaded from the storage directly.
+	HeadlessLogsBaseURL string `json:"headlessLogsBaseURL"`
+
+	// SigningKey is the secret used to sign headless log download URLs
+	SigningKey     string `json:"signingKey"`
+	SigningKeyFile string `json:"signingKeyFile"`
+}
+
 type PProf struct {
 	Addr string `json:"address"`
 }
@@ -125,9 +168,36 @@ type UsageReportConfig struct {
 	BucketName string `json:"bucketName"`
 }
 
+// RetentionConfig configures the deletion of old workspace content from the remote storage
+type RetentionConfig struct {
+	// Interval is the time between two retention runs
+	Interval util.Duration `json:"interval"`
+
+	// DryRun reports the content which would be deleted without deleting it
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// KeepInstanceBackups is the number of most recent instance backups kept per workspace. Zero keeps all instance backups.
+	KeepInstanceBackups int `json:"keepInstanceBackups,omitempty"`
+
+	// SnapshotMaxAge is the age after which snapshots are deleted. Zero keeps snapshots forever.
+	SnapshotMaxAge util.Duration `json:"snapshotMaxAge,omitempty"`
+
+	// MaxBytesPerUser caps the size of all workspace content of a user. The oldest instance backups and
+	// snapshots are deleted first, the latest backup of a workspace is never deleted. Zero disables the cap.
+	MaxBytesPerUser int64 `json:"maxBytesPerUser,omitempty"`
+
+	// UsageServiceAddress is the address of the usage service, which charges the storage of the content retained
+	// at the end of each run for the last complete interval. Storage is not charged if this is empty.
+	UsageServiceAddress string `json:"usageServiceAddress,omitempty"`
+}
+
 type ServiceConfig struct {
 	Service baseserver.ServerConfiguration `json:"service"`
 	Storage StorageConfig                  `json:"storage"`
+	// HTTP configures the HTTP server used to serve signed URLs of the filesystem storage
+	HTTP *baseserver.ServerConfiguration `json:"http,omitempty"`
+	// Retention configures the periodic deletion of old workspace content. Retention is disabled if this is nil.
+	Retention *RetentionConfig `json:"retention,omitempty"`
 	// Deprecated
 	_ UsageReportConfig `json:"usageReport"`
 }
@@ -50,21 +50,16 @@ func extractSecretsFromInitializer(init *WorkspaceInitializer, replaceValue bool
 	res := make(map[string]string)
 
 	_ = WalkInitializer([]string{"initializer"}, init, func(path []string, init *WorkspaceInitializer) error {
-		git, ok := init.Spec.(*WorkspaceInitializer_Git)
-		if !ok {
-			return nil
-		}
-
-		pwd := git.Git.Config.AuthPassword
-		if pwd == "" || strings.HasPrefix(pwd, extractedSecretPrefix) {
+		secret := initializerSecret(init)
+		if secret == nil || *secret == "" || strings.HasPrefix(*secret, extractedSecretPrefix) {
 			return nil
 		}
 
 		name := strings.Join(path, ".")
-		res[name] = pwd
+		res[name] = *secret
 
 		if replaceValue {
-			git.Git.Config.AuthPassword = extractedSecretPrefix + name
+			*secret = extractedSecretPrefix + name
 		}
 
 		return nil
@@ -76,28 +71,39 @@ func extractSecretsFromInitializer(init *WorkspaceInitializer, replaceValue bool
 // InjectSecretsToInitializer injects secrets to the initializer. This is the counterpart of ExtractSecretsFromInitializer.
 func InjectSecretsToInitializer(init *WorkspaceInitializer, secrets map[string][]byte) error {
 	return WalkInitializer([]string{"initializer"}, init, func(path []string, init *WorkspaceInitializer) error {
-		git, ok := init.Spec.(*WorkspaceInitializer_Git)
-		if !ok {
+		secret := initializerSecret(init)
+		if secret == nil || !strings.HasPrefix(*secret, extractedSecretPrefix) {
 			return nil
 		}
 
-		pwd := git.Git.Config.AuthPassword
-		if !strings.HasPrefix(pwd, extractedSecretPrefix) {
-			return nil
-		}
-
-		name := strings.TrimPrefix(pwd, extractedSecretPrefix)
+		name := strings.TrimPrefix(*secret, extractedSecretPrefix)
 		val, ok := secrets[name]
 		if !ok {
 			return xerrors.Errorf("secret %s not found", name)
 		}
 
-		git.Git.Config.AuthPassword = string(val)
+		*secret = string(val)
 
 		return nil
 	})
 }
 
+// initializerSecret returns the secret field of an initializer, i.e. the password of a Git initializer
+// or the pull secret of an archive initializer. It returns nil if the initializer has no secret.
+func initializerSecret(init *WorkspaceInitializer) *string {
+	switch spec := init.Spec.(type) {
+	case *WorkspaceInitializer_Git:
+		if spec.Git.Config == nil {
+			return nil
+		}
+		return &spec.Git.Config.AuthPassword
+	case *WorkspaceInitializer_Archive:
+		return &spec.Archive.PullSecret
+	default:
+		return nil
+	}
+}
+
 // WalkInitializer walks the initializer structure
 func WalkInitializer(path []string, init *WorkspaceInitializer, visitor func(path []string, init *WorkspaceInitializer) error) error {
 	if init == nil {
@@ -139,6 +145,8 @@ func WalkInitializer(path []string, init *WorkspaceInitializer, visitor func(pat
 		return nil
 	case *WorkspaceInitializer_Download:
 		return visitor(append(path, "download"), init)
+	case *WorkspaceInitializer_Archive:
+		return visitor(append(path, "archive"), init)
 	case *WorkspaceInitializer_Backup:
 		return visitor(append(path, "backup"), init)
 
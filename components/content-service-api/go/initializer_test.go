@@ -185,6 +185,46 @@ func TestExtractInjectSecretsFromInitializer(t *testing.T) {
 				"initializer.prebuild.1.git": "some value",
 			},
 		},
+		{
+			Name: "archive initializer",
+			Input: &api.WorkspaceInitializer{
+				Spec: &api.WorkspaceInitializer_Composite{
+					Composite: &api.CompositeInitializer{
+						Initializer: []*api.WorkspaceInitializer{
+							{
+								Spec: &api.WorkspaceInitializer_Archive{
+									Archive: &api.ArchiveInitializer{
+										Source:     &api.ArchiveInitializer_OciReference{OciReference: "registry.example.com/datasets/mnist:v1"},
+										PullSecret: `{"auths":{}}`,
+									},
+								},
+							},
+							{
+								Spec: &api.WorkspaceInitializer_Archive{
+									Archive: &api.ArchiveInitializer{
+										Source: &api.ArchiveInitializer_OciReference{OciReference: "registry.example.com/datasets/public:v1"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Expectation: map[string]string{
+				"initializer.composite.0.archive": `{"auths":{}}`,
+			},
+		},
+	}
+
+	secretOf := func(init *api.WorkspaceInitializer) string {
+		switch spec := init.Spec.(type) {
+		case *api.WorkspaceInitializer_Git:
+			return spec.Git.Config.AuthPassword
+		case *api.WorkspaceInitializer_Archive:
+			return spec.Archive.PullSecret
+		default:
+			return ""
+		}
 	}
 
 	for _, test := range tests {
@@ -201,6 +241,10 @@ func TestExtractInjectSecretsFromInitializer(t *testing.T) {
 				api.GitInitializer{},
 				api.GitConfig{},
 				api.PrebuildInitializer{},
+				api.CompositeInitializer{},
+				api.WorkspaceInitializer_Composite{},
+				api.ArchiveInitializer{},
+				api.WorkspaceInitializer_Archive{},
 			}
 			if diff := cmp.Diff(original, test.Input, cmpopts.IgnoreUnexported(ignoreUnexported...)); diff != "" {
 				t.Errorf("unexpected alteration from GatherSecretsFromInitializer (-want +got):\n%s", diff)
@@ -212,12 +256,8 @@ func TestExtractInjectSecretsFromInitializer(t *testing.T) {
 			}
 
 			_ = api.WalkInitializer(nil, test.Input, func(path []string, init *api.WorkspaceInitializer) error {
-				git, ok := init.Spec.(*api.WorkspaceInitializer_Git)
-				if !ok {
-					return nil
-				}
-				if pwd := git.Git.Config.AuthPassword; pwd != "" && !strings.HasPrefix(pwd, "extracted-secret/") {
-					t.Errorf("expected authPassword to be extracted, but got %s at %s", pwd, filepath.Join(path...))
+				if secret := secretOf(init); secret != "" && !strings.HasPrefix(secret, "extracted-secret/") {
+					t.Errorf("expected secret to be extracted, but got %s at %s", secret, filepath.Join(path...))
 				}
 
 				return nil
@@ -234,12 +274,8 @@ func TestExtractInjectSecretsFromInitializer(t *testing.T) {
 			}
 
 			_ = api.WalkInitializer(nil, test.Input, func(path []string, init *api.WorkspaceInitializer) error {
-				git, ok := init.Spec.(*api.WorkspaceInitializer_Git)
-				if !ok {
-					return nil
-				}
-				if pwd := git.Git.Config.AuthPassword; pwd != "" && strings.HasPrefix(pwd, "extracted-secret/") {
-					t.Errorf("expected authPassword to be injected, but got %s at %s", pwd, filepath.Join(path...))
+				if secret := secretOf(init); secret != "" && strings.HasPrefix(secret, "extracted-secret/") {
+					t.Errorf("expected secret to be injected, but got %s at %s", secret, filepath.Join(path...))
 				}
 
 				return nil
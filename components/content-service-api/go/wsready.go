@@ -38,6 +38,18 @@ type InitializerMetric struct {
 
 	// Size of the data that was initialized in bytes
 	Size uint64 `json:"size"`
+
+	// RetriedBytes is the number of bytes which had to be downloaded again after transient failures
+	RetriedBytes uint64 `json:"retriedBytes,omitempty"`
+
+	// CloneFilter is the partial clone filter used by Git initializers
+	CloneFilter string `json:"cloneFilter,omitempty"`
+
+	// CloneDepth is the history depth of the clone made by Git initializers
+	CloneDepth int `json:"cloneDepth,omitempty"`
+
+	// SparseCheckoutPatterns is the number of sparse checkout patterns the working copy was restricted to
+	SparseCheckoutPatterns int `json:"sparseCheckoutPatterns,omitempty"`
 }
 
 type InitializerMetrics []InitializerMetric
@@ -49,6 +49,11 @@ export class WorkspaceInitializer extends jspb.Message {
     getBackup(): FromBackupInitializer | undefined;
     setBackup(value?: FromBackupInitializer): WorkspaceInitializer;
 
+    hasArchive(): boolean;
+    clearArchive(): void;
+    getArchive(): ArchiveInitializer | undefined;
+    setArchive(value?: ArchiveInitializer): WorkspaceInitializer;
+
     getSpecCase(): WorkspaceInitializer.SpecCase;
 
     serializeBinary(): Uint8Array;
@@ -70,6 +75,7 @@ export namespace WorkspaceInitializer {
         composite?: CompositeInitializer.AsObject,
         download?: FileDownloadInitializer.AsObject,
         backup?: FromBackupInitializer.AsObject,
+        archive?: ArchiveInitializer.AsObject,
     }
 
     export enum SpecCase {
@@ -81,6 +87,7 @@ export namespace WorkspaceInitializer {
         COMPOSITE = 5,
         DOWNLOAD = 6,
         BACKUP = 7,
+        ARCHIVE = 8,
     }
 
 }
@@ -160,6 +167,56 @@ export namespace FileDownloadInitializer {
 
 }
 
+export class ArchiveInitializer extends jspb.Message {
+
+    hasUrl(): boolean;
+    clearUrl(): void;
+    getUrl(): string;
+    setUrl(value: string): ArchiveInitializer;
+
+    hasOciReference(): boolean;
+    clearOciReference(): void;
+    getOciReference(): string;
+    setOciReference(value: string): ArchiveInitializer;
+    getDigest(): string;
+    setDigest(value: string): ArchiveInitializer;
+    getFormat(): ArchiveFormat;
+    setFormat(value: ArchiveFormat): ArchiveInitializer;
+    getTargetLocation(): string;
+    setTargetLocation(value: string): ArchiveInitializer;
+    getPullSecret(): string;
+    setPullSecret(value: string): ArchiveInitializer;
+
+    getSourceCase(): ArchiveInitializer.SourceCase;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ArchiveInitializer.AsObject;
+    static toObject(includeInstance: boolean, msg: ArchiveInitializer): ArchiveInitializer.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ArchiveInitializer, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ArchiveInitializer;
+    static deserializeBinaryFromReader(message: ArchiveInitializer, reader: jspb.BinaryReader): ArchiveInitializer;
+}
+
+export namespace ArchiveInitializer {
+    export type AsObject = {
+        url: string,
+        ociReference: string,
+        digest: string,
+        format: ArchiveFormat,
+        targetLocation: string,
+        pullSecret: string,
+    }
+
+    export enum SourceCase {
+        SOURCE_NOT_SET = 0,
+        URL = 1,
+        OCI_REFERENCE = 2,
+    }
+
+}
+
 export class EmptyInitializer extends jspb.Message {
 
     serializeBinary(): Uint8Array;
@@ -193,6 +250,12 @@ export class GitInitializer extends jspb.Message {
     clearConfig(): void;
     getConfig(): GitConfig | undefined;
     setConfig(value?: GitConfig): GitInitializer;
+    getCloneDepth(): number;
+    setCloneDepth(value: number): GitInitializer;
+    clearSparseCheckoutPatternsList(): void;
+    getSparseCheckoutPatternsList(): Array<string>;
+    setSparseCheckoutPatternsList(value: Array<string>): GitInitializer;
+    addSparseCheckoutPatterns(value: string, index?: number): string;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): GitInitializer.AsObject;
@@ -212,6 +275,8 @@ export namespace GitInitializer {
         cloneTaget: string,
         checkoutLocation: string,
         config?: GitConfig.AsObject,
+        cloneDepth: number,
+        sparseCheckoutPatternsList: Array<string>,
     }
 }
 
@@ -227,6 +292,8 @@ export class GitConfig extends jspb.Message {
     setAuthPassword(value: string): GitConfig;
     getAuthOts(): string;
     setAuthOts(value: string): GitConfig;
+    getPartialCloneFilter(): PartialCloneFilter;
+    setPartialCloneFilter(value: PartialCloneFilter): GitConfig;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): GitConfig.AsObject;
@@ -246,6 +313,7 @@ export namespace GitConfig {
         authUser: string,
         authPassword: string,
         authOts: string,
+        partialCloneFilter: PartialCloneFilter,
     }
 }
 
@@ -370,6 +438,14 @@ export namespace GitStatus {
     }
 }
 
+export enum ArchiveFormat {
+    ARCHIVE_FORMAT_DETECT = 0,
+    ARCHIVE_FORMAT_TAR = 1,
+    ARCHIVE_FORMAT_TAR_GZ = 2,
+    ARCHIVE_FORMAT_TAR_ZSTD = 3,
+    ARCHIVE_FORMAT_ZIP = 4,
+}
+
 export enum CloneTargetMode {
     REMOTE_HEAD = 0,
     REMOTE_COMMIT = 1,
@@ -382,3 +458,9 @@ export enum GitAuthMethod {
     BASIC_AUTH = 1,
     BASIC_AUTH_OTS = 2,
 }
+
+export enum PartialCloneFilter {
+    NO_FILTER = 0,
+    BLOB_NONE = 1,
+    TREE_ZERO = 2,
+}
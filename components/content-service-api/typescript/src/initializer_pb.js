@@ -21,6 +21,9 @@ var jspb = require('google-protobuf');
 var goog = jspb;
 var global = (function() { return this || window || global || self || Function('return this')(); }).call(null);
 
+goog.exportSymbol('proto.contentservice.ArchiveFormat', null, global);
+goog.exportSymbol('proto.contentservice.ArchiveInitializer', null, global);
+goog.exportSymbol('proto.contentservice.ArchiveInitializer.SourceCase', null, global);
 goog.exportSymbol('proto.contentservice.CloneTargetMode', null, global);
 goog.exportSymbol('proto.contentservice.CompositeInitializer', null, global);
 goog.exportSymbol('proto.contentservice.EmptyInitializer', null, global);
@@ -31,6 +34,7 @@ goog.exportSymbol('proto.contentservice.GitAuthMethod', null, global);
 goog.exportSymbol('proto.contentservice.GitConfig', null, global);
 goog.exportSymbol('proto.contentservice.GitInitializer', null, global);
 goog.exportSymbol('proto.contentservice.GitStatus', null, global);
+goog.exportSymbol('proto.contentservice.PartialCloneFilter', null, global);
 goog.exportSymbol('proto.contentservice.PrebuildInitializer', null, global);
 goog.exportSymbol('proto.contentservice.SnapshotInitializer', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceInitializer', null, global);
@@ -119,6 +123,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.contentservice.FileDownloadInitializer.FileInfo.displayName = 'proto.contentservice.FileDownloadInitializer.FileInfo';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.ArchiveInitializer = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, proto.contentservice.ArchiveInitializer.oneofGroups_);
+};
+goog.inherits(proto.contentservice.ArchiveInitializer, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.ArchiveInitializer.displayName = 'proto.contentservice.ArchiveInitializer';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -151,7 +176,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.contentservice.GitInitializer = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.GitInitializer.repeatedFields_, null);
 };
 goog.inherits(proto.contentservice.GitInitializer, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -275,7 +300,7 @@ if (goog.DEBUG && !COMPILED) {
  * @private {!Array<!Array<number>>}
  * @const
  */
-proto.contentservice.WorkspaceInitializer.oneofGroups_ = [[1,2,3,4,5,6,7]];
+proto.contentservice.WorkspaceInitializer.oneofGroups_ = [[1,2,3,4,5,6,7,8]];
 
 /**
  * @enum {number}
@@ -288,7 +313,8 @@ proto.contentservice.WorkspaceInitializer.SpecCase = {
   PREBUILD: 4,
   COMPOSITE: 5,
   DOWNLOAD: 6,
-  BACKUP: 7
+  BACKUP: 7,
+  ARCHIVE: 8
 };
 
 /**
@@ -335,7 +361,8 @@ proto.contentservice.WorkspaceInitializer.toObject = function(includeInstance, m
     prebuild: (f = msg.getPrebuild()) && proto.contentservice.PrebuildInitializer.toObject(includeInstance, f),
     composite: (f = msg.getComposite()) && proto.contentservice.CompositeInitializer.toObject(includeInstance, f),
     download: (f = msg.getDownload()) && proto.contentservice.FileDownloadInitializer.toObject(includeInstance, f),
-    backup: (f = msg.getBackup()) && proto.contentservice.FromBackupInitializer.toObject(includeInstance, f)
+    backup: (f = msg.getBackup()) && proto.contentservice.FromBackupInitializer.toObject(includeInstance, f),
+    archive: (f = msg.getArchive()) && proto.contentservice.ArchiveInitializer.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -407,6 +434,11 @@ proto.contentservice.WorkspaceInitializer.deserializeBinaryFromReader = function
       reader.readMessage(value,proto.contentservice.FromBackupInitializer.deserializeBinaryFromReader);
       msg.setBackup(value);
       break;
+    case 8:
+      var value = new proto.contentservice.ArchiveInitializer;
+      reader.readMessage(value,proto.contentservice.ArchiveInitializer.deserializeBinaryFromReader);
+      msg.setArchive(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -492,6 +524,14 @@ proto.contentservice.WorkspaceInitializer.serializeBinaryToWriter = function(mes
       proto.contentservice.FromBackupInitializer.serializeBinaryToWriter
     );
   }
+  f = message.getArchive();
+  if (f != null) {
+    writer.writeMessage(
+      8,
+      f,
+      proto.contentservice.ArchiveInitializer.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -754,6 +794,43 @@ proto.contentservice.WorkspaceInitializer.prototype.hasBackup = function() {
 };
 
 
+/**
+ * optional ArchiveInitializer archive = 8;
+ * @return {?proto.contentservice.ArchiveInitializer}
+ */
+proto.contentservice.WorkspaceInitializer.prototype.getArchive = function() {
+  return /** @type{?proto.contentservice.ArchiveInitializer} */ (
+    jspb.Message.getWrapperField(this, proto.contentservice.ArchiveInitializer, 8));
+};
+
+
+/**
+ * @param {?proto.contentservice.ArchiveInitializer|undefined} value
+ * @return {!proto.contentservice.WorkspaceInitializer} returns this
+*/
+proto.contentservice.WorkspaceInitializer.prototype.setArchive = function(value) {
+  return jspb.Message.setOneofWrapperField(this, 8, proto.contentservice.WorkspaceInitializer.oneofGroups_[0], value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.contentservice.WorkspaceInitializer} returns this
+ */
+proto.contentservice.WorkspaceInitializer.prototype.clearArchive = function() {
+  return this.setArchive(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.contentservice.WorkspaceInitializer.prototype.hasArchive = function() {
+  return jspb.Message.getField(this, 8) != null;
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -1295,6 +1372,348 @@ proto.contentservice.FileDownloadInitializer.prototype.setTargetLocation = funct
 
 
 
+/**
+ * Oneof group definitions for this message. Each group defines the field
+ * numbers belonging to that group. When of these fields' value is set, all
+ * other fields in the group are cleared. During deserialization, if multiple
+ * fields are encountered for a group, only the last value seen will be kept.
+ * @private {!Array<!Array<number>>}
+ * @const
+ */
+proto.contentservice.ArchiveInitializer.oneofGroups_ = [[1,2]];
+
+/**
+ * @enum {number}
+ */
+proto.contentservice.ArchiveInitializer.SourceCase = {
+  SOURCE_NOT_SET: 0,
+  URL: 1,
+  OCI_REFERENCE: 2
+};
+
+/**
+ * @return {proto.contentservice.ArchiveInitializer.SourceCase}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getSourceCase = function() {
+  return /** @type {proto.contentservice.ArchiveInitializer.SourceCase} */(jspb.Message.computeOneofCase(this, proto.contentservice.ArchiveInitializer.oneofGroups_[0]));
+};
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.ArchiveInitializer.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.ArchiveInitializer.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.ArchiveInitializer} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ArchiveInitializer.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    url: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    ociReference: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    digest: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    format: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    targetLocation: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    pullSecret: jspb.Message.getFieldWithDefault(msg, 6, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.ArchiveInitializer}
+ */
+proto.contentservice.ArchiveInitializer.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.ArchiveInitializer;
+  return proto.contentservice.ArchiveInitializer.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.ArchiveInitializer} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.ArchiveInitializer}
+ */
+proto.contentservice.ArchiveInitializer.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrl(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOciReference(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDigest(value);
+      break;
+    case 4:
+      var value = /** @type {!proto.contentservice.ArchiveFormat} */ (reader.readEnum());
+      msg.setFormat(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTargetLocation(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPullSecret(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.ArchiveInitializer.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.ArchiveInitializer.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.ArchiveInitializer} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ArchiveInitializer.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = /** @type {string} */ (jspb.Message.getField(message, 1));
+  if (f != null) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = /** @type {string} */ (jspb.Message.getField(message, 2));
+  if (f != null) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getDigest();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getFormat();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      4,
+      f
+    );
+  }
+  f = message.getTargetLocation();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getPullSecret();
+  if (f.length > 0) {
+    writer.writeString(
+      6,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string url = 1;
+ * @return {string}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getUrl = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.setUrl = function(value) {
+  return jspb.Message.setOneofField(this, 1, proto.contentservice.ArchiveInitializer.oneofGroups_[0], value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.clearUrl = function() {
+  return jspb.Message.setOneofField(this, 1, proto.contentservice.ArchiveInitializer.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.contentservice.ArchiveInitializer.prototype.hasUrl = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional string oci_reference = 2;
+ * @return {string}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getOciReference = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.setOciReference = function(value) {
+  return jspb.Message.setOneofField(this, 2, proto.contentservice.ArchiveInitializer.oneofGroups_[0], value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.clearOciReference = function() {
+  return jspb.Message.setOneofField(this, 2, proto.contentservice.ArchiveInitializer.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.contentservice.ArchiveInitializer.prototype.hasOciReference = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional string digest = 3;
+ * @return {string}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getDigest = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.setDigest = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional ArchiveFormat format = 4;
+ * @return {!proto.contentservice.ArchiveFormat}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getFormat = function() {
+  return /** @type {!proto.contentservice.ArchiveFormat} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {!proto.contentservice.ArchiveFormat} value
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.setFormat = function(value) {
+  return jspb.Message.setProto3EnumField(this, 4, value);
+};
+
+
+/**
+ * optional string target_location = 5;
+ * @return {string}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getTargetLocation = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.setTargetLocation = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+/**
+ * optional string pull_secret = 6;
+ * @return {string}
+ */
+proto.contentservice.ArchiveInitializer.prototype.getPullSecret = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ArchiveInitializer} returns this
+ */
+proto.contentservice.ArchiveInitializer.prototype.setPullSecret = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
+};
+
+
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1396,6 +1815,13 @@ proto.contentservice.EmptyInitializer.serializeBinaryToWriter = function(message
 
 
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.GitInitializer.repeatedFields_ = [8];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1432,7 +1858,9 @@ proto.contentservice.GitInitializer.toObject = function(includeInstance, msg) {
     targetMode: jspb.Message.getFieldWithDefault(msg, 3, 0),
     cloneTaget: jspb.Message.getFieldWithDefault(msg, 4, ""),
     checkoutLocation: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    config: (f = msg.getConfig()) && proto.contentservice.GitConfig.toObject(includeInstance, f)
+    config: (f = msg.getConfig()) && proto.contentservice.GitConfig.toObject(includeInstance, f),
+    cloneDepth: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    sparseCheckoutPatternsList: (f = jspb.Message.getRepeatedField(msg, 8)) == null ? undefined : f
   };
 
   if (includeInstance) {
@@ -1494,6 +1922,14 @@ proto.contentservice.GitInitializer.deserializeBinaryFromReader = function(msg,
       reader.readMessage(value,proto.contentservice.GitConfig.deserializeBinaryFromReader);
       msg.setConfig(value);
       break;
+    case 7:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setCloneDepth(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addSparseCheckoutPatterns(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1566,6 +2002,20 @@ proto.contentservice.GitInitializer.serializeBinaryToWriter = function(message,
       proto.contentservice.GitConfig.serializeBinaryToWriter
     );
   }
+  f = message.getCloneDepth();
+  if (f !== 0) {
+    writer.writeUint32(
+      7,
+      f
+    );
+  }
+  f = message.getSparseCheckoutPatternsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      8,
+      f
+    );
+  }
 };
 
 
@@ -1696,6 +2146,61 @@ proto.contentservice.GitInitializer.prototype.hasConfig = function() {
 };
 
 
+/**
+ * optional uint32 clone_depth = 7;
+ * @return {number}
+ */
+proto.contentservice.GitInitializer.prototype.getCloneDepth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setCloneDepth = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * repeated string sparse_checkout_patterns = 8;
+ * @return {!Array<string>}
+ */
+proto.contentservice.GitInitializer.prototype.getSparseCheckoutPatternsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 8));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setSparseCheckoutPatternsList = function(value) {
+  return jspb.Message.setField(this, 8, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.addSparseCheckoutPatterns = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 8, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.clearSparseCheckoutPatternsList = function() {
+  return this.setSparseCheckoutPatternsList([]);
+};
+
+
 
 
 
@@ -1732,7 +2237,8 @@ proto.contentservice.GitConfig.toObject = function(includeInstance, msg) {
     authentication: jspb.Message.getFieldWithDefault(msg, 2, 0),
     authUser: jspb.Message.getFieldWithDefault(msg, 3, ""),
     authPassword: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    authOts: jspb.Message.getFieldWithDefault(msg, 5, "")
+    authOts: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    partialCloneFilter: jspb.Message.getFieldWithDefault(msg, 6, 0)
   };
 
   if (includeInstance) {
@@ -1791,6 +2297,10 @@ proto.contentservice.GitConfig.deserializeBinaryFromReader = function(msg, reade
       var value = /** @type {string} */ (reader.readString());
       msg.setAuthOts(value);
       break;
+    case 6:
+      var value = /** @type {!proto.contentservice.PartialCloneFilter} */ (reader.readEnum());
+      msg.setPartialCloneFilter(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1852,6 +2362,13 @@ proto.contentservice.GitConfig.serializeBinaryToWriter = function(message, write
       f
     );
   }
+  f = message.getPartialCloneFilter();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      6,
+      f
+    );
+  }
 };
 
 
@@ -1949,6 +2466,24 @@ proto.contentservice.GitConfig.prototype.setAuthOts = function(value) {
 };
 
 
+/**
+ * optional PartialCloneFilter partial_clone_filter = 6;
+ * @return {!proto.contentservice.PartialCloneFilter}
+ */
+proto.contentservice.GitConfig.prototype.getPartialCloneFilter = function() {
+  return /** @type {!proto.contentservice.PartialCloneFilter} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {!proto.contentservice.PartialCloneFilter} value
+ * @return {!proto.contentservice.GitConfig} returns this
+ */
+proto.contentservice.GitConfig.prototype.setPartialCloneFilter = function(value) {
+  return jspb.Message.setProto3EnumField(this, 6, value);
+};
+
+
 
 
 
@@ -2884,6 +3419,17 @@ proto.contentservice.GitStatus.prototype.setTotalUnpushedCommits = function(valu
 };
 
 
+/**
+ * @enum {number}
+ */
+proto.contentservice.ArchiveFormat = {
+  ARCHIVE_FORMAT_DETECT: 0,
+  ARCHIVE_FORMAT_TAR: 1,
+  ARCHIVE_FORMAT_TAR_GZ: 2,
+  ARCHIVE_FORMAT_TAR_ZSTD: 3,
+  ARCHIVE_FORMAT_ZIP: 4
+};
+
 /**
  * @enum {number}
  */
@@ -2903,4 +3449,13 @@ proto.contentservice.GitAuthMethod = {
   BASIC_AUTH_OTS: 2
 };
 
+/**
+ * @enum {number}
+ */
+proto.contentservice.PartialCloneFilter = {
+  NO_FILTER: 0,
+  BLOB_NONE: 1,
+  TREE_ZERO: 2
+};
+
 goog.object.extend(exports, proto.contentservice);
@@ -9,6 +9,28 @@
 var grpc = require('@grpc/grpc-js');
 var workspace_pb = require('./workspace_pb.js');
 
+function serialize_contentservice_DeleteSnapshotRequest(arg) {
+  if (!(arg instanceof workspace_pb.DeleteSnapshotRequest)) {
+    throw new Error('Expected argument of type contentservice.DeleteSnapshotRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_DeleteSnapshotRequest(buffer_arg) {
+  return workspace_pb.DeleteSnapshotRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_contentservice_DeleteSnapshotResponse(arg) {
+  if (!(arg instanceof workspace_pb.DeleteSnapshotResponse)) {
+    throw new Error('Expected argument of type contentservice.DeleteSnapshotResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_DeleteSnapshotResponse(buffer_arg) {
+  return workspace_pb.DeleteSnapshotResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_contentservice_DeleteWorkspaceRequest(arg) {
   if (!(arg instanceof workspace_pb.DeleteWorkspaceRequest)) {
     throw new Error('Expected argument of type contentservice.DeleteWorkspaceRequest');
@@ -113,6 +135,18 @@ workspaceSnapshotExists: {
     responseSerialize: serialize_contentservice_WorkspaceSnapshotExistsResponse,
     responseDeserialize: deserialize_contentservice_WorkspaceSnapshotExistsResponse,
   },
+  // DeleteSnapshot deletes the content of a single snapshot
+deleteSnapshot: {
+    path: '/contentservice.WorkspaceService/DeleteSnapshot',
+    requestStream: false,
+    responseStream: false,
+    requestType: workspace_pb.DeleteSnapshotRequest,
+    responseType: workspace_pb.DeleteSnapshotResponse,
+    requestSerialize: serialize_contentservice_DeleteSnapshotRequest,
+    requestDeserialize: deserialize_contentservice_DeleteSnapshotRequest,
+    responseSerialize: serialize_contentservice_DeleteSnapshotResponse,
+    responseDeserialize: deserialize_contentservice_DeleteSnapshotResponse,
+  },
 };
 
 exports.WorkspaceServiceClient = grpc.makeGenericClientConstructor(WorkspaceServiceService);
@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/retention"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+var retentionDryRun bool
+
+// retentionCmd enforces the retention rules once and prints a report
+var retentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Deletes old workspace content according to the retention rules once and prints a report",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := getConfig()
+		if cfg.Retention == nil {
+			return fmt.Errorf("no retention rules configured")
+		}
+		rcfg := *cfg.Retention
+		if retentionDryRun {
+			rcfg.DryRun = true
+		}
+
+		presigned, err := storage.NewPresignedAccess(&cfg.Storage)
+		if err != nil {
+			return err
+		}
+		enforcer, err := retention.NewEnforcer(rcfg, presigned)
+		if err != nil {
+			return err
+		}
+
+		report, err := enforcer.Enforce(context.Background())
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(retentionCmd)
+	retentionCmd.Flags().BoolVar(&retentionDryRun, "dry-run", false, "report the content which would be deleted without deleting it")
+}
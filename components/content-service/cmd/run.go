@@ -5,11 +5,25 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/retention"
 	"github.com/gitpod-io/gitpod/content-service/pkg/service"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	usage "github.com/gitpod-io/gitpod/usage-api/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // runCmd starts the content service
@@ -20,14 +34,29 @@ var runCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := getConfig()
 
-		srv, err := baseserver.New("content-service",
+		opts := []baseserver.Option{
 			baseserver.WithGRPC(&cfg.Service),
 			baseserver.WithVersion(Version),
-		)
+		}
+		if cfg.HTTP != nil {
+			opts = append(opts, baseserver.WithHTTP(cfg.HTTP))
+		}
+
+		srv, err := baseserver.New("content-service", opts...)
 		if err != nil {
 			log.WithError(err).Fatal("Failed to create server.")
 		}
 
+		if cfg.Storage.Kind == config.FilesystemStorage {
+			if cfg.HTTP == nil {
+				log.Fatal("filesystem storage requires the HTTP server to be configured")
+			}
+			err = registerFilesystemHandler(srv, cfg.Storage.FilesystemConfig)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot register filesystem storage handler")
+			}
+		}
+
 		contentService, err := service.NewContentService(cfg.Storage)
 		if err != nil {
 			log.WithError(err).Fatalf("Cannot create content service")
@@ -51,6 +80,15 @@ var runCmd = &cobra.Command{
 			log.WithError(err).Fatalf("Cannot create log service")
 		}
 		api.RegisterHeadlessLogServiceServer(srv.GRPC(), headlessLogService)
+		if cfg.Storage.Encryption != nil {
+			if cfg.HTTP == nil {
+				log.Fatal("encrypted storage requires the HTTP server to be configured")
+			}
+			err = registerHeadlessLogHandler(srv, cfg.Storage.Encryption, headlessLogService)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot register headless log handler")
+			}
+		}
 
 		idePluginService, err := service.NewIDEPluginService(cfg.Storage)
 		if err != nil {
@@ -58,6 +96,13 @@ var runCmd = &cobra.Command{
 		}
 		api.RegisterIDEPluginServiceServer(srv.GRPC(), idePluginService)
 
+		if cfg.Retention != nil {
+			err = startRetention(srv, cfg)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot start content retention")
+			}
+		}
+
 		err = srv.ListenAndServe()
 		if err != nil {
 			log.WithError(err).Fatal("Cannot start server")
@@ -65,6 +110,110 @@ var runCmd = &cobra.Command{
 	},
 }
 
+// registerFilesystemHandler serves the signed URLs of the filesystem storage under the path of its base URL
+func registerFilesystemHandler(srv *baseserver.Server, cfg *config.FilesystemConfig) error {
+	handler, err := storage.NewFilesystemHandler(cfg)
+	if err != nil {
+		return err
+	}
+
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	prefix := strings.TrimSuffix(baseURL.Path, "/")
+	srv.HTTPMux().Handle(prefix+"/", http.StripPrefix(prefix, handler))
+
+	return nil
+}
+
+// registerHeadlessLogHandler serves the decrypted headless logs under the path of their base URL
+func registerHeadlessLogHandler(srv *baseserver.Server, cfg *config.EncryptionConfig, handler http.Handler) error {
+	baseURL, err := url.Parse(cfg.HeadlessLogsBaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid headless logs base URL: %w", err)
+	}
+	prefix := strings.TrimSuffix(baseURL.Path, "/")
+	srv.HTTPMux().Handle(prefix+"/", http.StripPrefix(prefix, handler))
+
+	return nil
+}
+
+// startRetention periodically deletes old workspace content in the background
+func startRetention(srv *baseserver.Server, cfg *config.ServiceConfig) error {
+	presigned, err := storage.NewPresignedAccess(&cfg.Storage)
+	if err != nil {
+		return err
+	}
+	enforcer, err := retention.NewEnforcer(*cfg.Retention, presigned)
+	if err != nil {
+		return err
+	}
+	err = enforcer.Metrics.Register(srv.MetricsRegistry())
+	if err != nil {
+		return err
+	}
+
+	// Incremental backups are not available with encryption, hence their manifests and chunks are never encrypted.
+	chunkCfg := cfg.Storage
+	chunkCfg.Encryption = nil
+	da, err := storage.NewDirectAccess(&chunkCfg)
+	if err != nil {
+		return err
+	}
+	if _, ok := da.(storage.ChunkAccess); ok {
+		enforcer.Chunks = func(ctx context.Context, owner string) (storage.ChunkAccess, error) {
+			da, err := storage.NewDirectAccess(&chunkCfg)
+			if err != nil {
+				return nil, err
+			}
+			// the chunk store belongs to the owner, but the storage insists on a workspace
+			err = da.Init(ctx, owner, "retention", "")
+			if err != nil {
+				return nil, err
+			}
+			return da.(storage.ChunkAccess), nil
+		}
+	}
+
+	if addr := cfg.Retention.UsageServiceAddress; addr != "" {
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("cannot connect to usage service: %w", err)
+		}
+		client := usage.NewUsageServiceClient(conn)
+		enforcer.ReportStorage = func(ctx context.Context, from, to time.Time, retained []retention.WorkspaceStorage) error {
+			// the usage service charges each workspace once per interval, hence the storage can be reported in batches
+			const batchSize = 1000
+			for len(retained) > 0 {
+				n := len(retained)
+				if n > batchSize {
+					n = batchSize
+				}
+				req := &usage.ReportStorageUsageRequest{
+					From: timestamppb.New(from),
+					To:   timestamppb.New(to),
+				}
+				for _, ws := range retained[:n] {
+					req.Workspaces = append(req.Workspaces, &usage.WorkspaceStorageUsage{
+						WorkspaceId: ws.Workspace,
+						Bytes:       ws.Bytes,
+					})
+				}
+				_, err := client.ReportStorageUsage(ctx, req)
+				if err != nil {
+					return err
+				}
+				retained = retained[n:]
+			}
+			return nil
+		}
+	}
+
+	go enforcer.Start(context.Background())
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(runCmd)
 }
@@ -28,6 +28,10 @@ import (
 type TarConfig struct {
 	UIDMaps []IDMapping
 	GIDMaps []IDMapping
+
+	// DefaultUID and DefaultGID are used for archive formats which carry no ownership information
+	DefaultUID int
+	DefaultGID int
 }
 
 // BuildTarbalOption configures the tarbal creation
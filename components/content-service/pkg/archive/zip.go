@@ -0,0 +1,243 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+)
+
+// zipUnixExtraID identifies the Info-ZIP "new Unix" extra field which carries the owner of an entry
+const zipUnixExtraID = 0x7875
+
+// WithDefaultOwner sets the container user and group ID of extracted files if the archive carries no ownership information
+func WithDefaultOwner(uid, gid int) TarOption {
+	return func(o *TarConfig) {
+		o.DefaultUID = uid
+		o.DefaultGID = gid
+	}
+}
+
+// ExtractZip extracts the zip archive src of the given size to the folder dst.
+// Entries which would end up outside of dst are rejected. Symlinks are created once all
+// other entries are extracted so that no entry is written through a symlink of the same archive.
+func ExtractZip(ctx context.Context, src io.ReaderAt, size int64, dst string, opts ...TarOption) (err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "extractZip")
+	span.LogKV("dst", dst)
+	defer tracing.FinishSpan(span, &err)
+
+	var cfg TarConfig
+	start := time.Now()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	zr, err := zip.NewReader(src, size)
+	if err != nil {
+		return xerrors.Errorf("cannot read zip archive: %w", err)
+	}
+
+	type entry struct {
+		Path     string
+		UID, GID int
+		Mode     os.FileMode
+		ModTime  time.Time
+	}
+	var (
+		dirs     []entry
+		symlinks []*zip.File
+		created  []entry
+	)
+	// mkdir creates the parent directories of an entry which are not part of the archive themselves
+	mkdir := func(p string) error {
+		var missing []string
+		for d := filepath.Dir(p); d != filepath.Clean(dst) && d != "/"; d = filepath.Dir(d) {
+			if _, err := os.Lstat(d); err == nil {
+				break
+			}
+			missing = append(missing, d)
+		}
+		for i := len(missing) - 1; i >= 0; i-- {
+			err := os.Mkdir(missing[i], 0755)
+			if err != nil {
+				return xerrors.Errorf("cannot create directory %s: %w", missing[i], err)
+			}
+			dirs = append(dirs, entry{Path: missing[i], UID: cfg.DefaultUID, GID: cfg.DefaultGID, Mode: os.ModeDir | 0755})
+		}
+		return nil
+	}
+	for _, f := range zr.File {
+		p, err := zipEntryPath(dst, f.Name)
+		if err != nil {
+			return err
+		}
+		uid, gid := zipEntryOwner(f, cfg.DefaultUID, cfg.DefaultGID)
+		e := entry{Path: p, UID: uid, GID: gid, Mode: f.Mode(), ModTime: f.Modified}
+
+		if f.Mode()&os.ModeSymlink != 0 {
+			symlinks = append(symlinks, f)
+			continue
+		}
+		err = mkdir(p)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case f.Mode().IsDir():
+			err = os.Mkdir(p, 0755)
+			if err != nil && !os.IsExist(err) {
+				return xerrors.Errorf("cannot create directory %s: %w", f.Name, err)
+			}
+			dirs = append(dirs, e)
+		case f.Mode().IsRegular():
+			err = extractZipFile(f, p)
+			if err != nil {
+				return err
+			}
+			created = append(created, e)
+		default:
+			log.WithField("name", f.Name).WithField("mode", f.Mode()).Debug("skipping unsupported zip entry")
+		}
+	}
+
+	for _, f := range symlinks {
+		p, _ := zipEntryPath(dst, f.Name)
+		target, err := readZipFile(f)
+		if err != nil {
+			return err
+		}
+		err = mkdir(p)
+		if err != nil {
+			return err
+		}
+		_ = os.Remove(p)
+		err = os.Symlink(string(target), p)
+		if err != nil {
+			return xerrors.Errorf("cannot create symlink %s: %w", f.Name, err)
+		}
+
+		uid, gid := zipEntryOwner(f, cfg.DefaultUID, cfg.DefaultGID)
+		err = os.Lchown(p, toHostID(uid, cfg.UIDMaps), toHostID(gid, cfg.GIDMaps))
+		if err != nil {
+			log.WithError(err).WithField("path", p).Debug("cannot chown")
+		}
+	}
+
+	// Directories are handled last so that their modification times are not changed by their content.
+	for _, e := range append(created, dirs...) {
+		uid := toHostID(e.UID, cfg.UIDMaps)
+		gid := toHostID(e.GID, cfg.GIDMaps)
+		err = os.Lchown(e.Path, uid, gid)
+		if err != nil {
+			log.WithError(err).WithField("uid", uid).WithField("gid", gid).WithField("path", e.Path).Debug("cannot chown")
+		}
+		// changing UID or GID can break files with suid/sgid
+		err = os.Chmod(e.Path, e.Mode.Perm()|e.Mode&(os.ModeSetuid|os.ModeSetgid|os.ModeSticky))
+		if err != nil {
+			return xerrors.Errorf("cannot chmod %s: %w", e.Path, err)
+		}
+		if !e.ModTime.IsZero() {
+			_ = os.Chtimes(e.Path, e.ModTime, e.ModTime)
+		}
+	}
+
+	log.WithField("duration", time.Since(start).Milliseconds()).Debug("unzip complete")
+	return nil
+}
+
+// zipEntryPath returns the location of a zip entry within dst and makes sure it does not escape dst
+func zipEntryPath(dst, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", xerrors.Errorf("zip entry %s has an absolute path", name)
+	}
+	p := filepath.Join(dst, name)
+	if p != filepath.Clean(dst) && !strings.HasPrefix(p, filepath.Clean(dst)+string(filepath.Separator)) {
+		return "", xerrors.Errorf("zip entry %s is outside of the target location", name)
+	}
+	return p, nil
+}
+
+// zipEntryOwner reads the owner of a zip entry from the Info-ZIP Unix extra field
+func zipEntryOwner(f *zip.File, defaultUID, defaultGID int) (uid, gid int) {
+	extra := f.Extra
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra[0:2])
+		size := int(binary.LittleEndian.Uint16(extra[2:4]))
+		if len(extra) < 4+size {
+			break
+		}
+		field := extra[4 : 4+size]
+		extra = extra[4+size:]
+		if id != zipUnixExtraID || len(field) < 1 || field[0] != 1 {
+			continue
+		}
+
+		field = field[1:]
+		var ids []int
+		for i := 0; i < 2; i++ {
+			if len(field) < 1 {
+				break
+			}
+			n := int(field[0])
+			if len(field) < 1+n {
+				break
+			}
+			var v uint64
+			for j := n; j > 0; j-- {
+				v = v<<8 | uint64(field[j])
+			}
+			ids = append(ids, int(v))
+			field = field[1+n:]
+		}
+		if len(ids) == 2 {
+			return ids[0], ids[1]
+		}
+	}
+	return defaultUID, defaultGID
+}
+
+func extractZipFile(f *zip.File, dst string) (err error) {
+	rc, err := f.Open()
+	if err != nil {
+		return xerrors.Errorf("cannot open zip entry %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm())
+	if err != nil {
+		return xerrors.Errorf("cannot create %s: %w", f.Name, err)
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	if err != nil {
+		return xerrors.Errorf("cannot extract %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot open zip entry %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestExtractZip(t *testing.T) {
+	type file struct {
+		Name    string
+		Content string
+		Mode    os.FileMode
+	}
+	tests := []struct {
+		Name          string
+		Files         []file
+		Options       []TarOption
+		ExpectedUID   int
+		ExpectedError string
+	}{
+		{
+			Name: "simple-test",
+			Files: []file{
+				{Name: "file.txt", Content: "hello", Mode: 0644},
+				{Name: "some/nested/dir/file.sh", Content: "#!/bin/sh", Mode: 0755},
+			},
+		},
+		{
+			Name: "symlinks",
+			Files: []file{
+				{Name: "file.txt", Content: "hello", Mode: 0644},
+				{Name: "link.txt", Content: "file.txt", Mode: os.ModeSymlink | 0777},
+			},
+		},
+		{
+			Name: "remapped owner",
+			Files: []file{
+				{Name: "dir/file.txt", Content: "hello", Mode: 0644},
+			},
+			Options: []TarOption{
+				WithDefaultOwner(33333, 33333),
+				WithUIDMapping([]IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}}),
+				WithGIDMapping([]IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}}),
+			},
+			ExpectedUID: 133333,
+		},
+		{
+			Name: "zip slip",
+			Files: []file{
+				{Name: "../evil.txt", Content: "evil", Mode: 0644},
+			},
+			ExpectedError: "zip entry ../evil.txt is outside of the target location",
+		},
+		{
+			Name:  "empty-zip",
+			Files: []file{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				buf = bytes.NewBuffer(nil)
+				zw  = zip.NewWriter(buf)
+			)
+			for _, f := range test.Files {
+				hdr := &zip.FileHeader{Name: f.Name, Method: zip.Deflate}
+				hdr.SetMode(f.Mode)
+				w, err := zw.CreateHeader(hdr)
+				if err != nil {
+					t.Fatalf("cannot prepare archive: %q", err)
+				}
+				_, err = w.Write([]byte(f.Content))
+				if err != nil {
+					t.Fatalf("cannot prepare archive: %q", err)
+				}
+			}
+			err := zw.Close()
+			if err != nil {
+				t.Fatalf("cannot prepare archive: %q", err)
+			}
+
+			targetFolder := filepath.Join(t.TempDir(), "target")
+			err = os.MkdirAll(targetFolder, 0777)
+			if err != nil {
+				t.Fatalf("cannot prepare test: %v", err)
+			}
+
+			err = ExtractZip(context.Background(), bytes.NewReader(buf.Bytes()), int64(buf.Len()), targetFolder, test.Options...)
+			if test.ExpectedError != "" {
+				if err == nil || err.Error() != test.ExpectedError {
+					t.Fatalf("error is %v but expected %s", err, test.ExpectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot extract zip content: %v", err)
+			}
+
+			for _, f := range test.Files {
+				fn := filepath.Join(targetFolder, f.Name)
+				stat, err := os.Lstat(fn)
+				if err != nil {
+					t.Errorf("expected %s but it does not exist", f.Name)
+					continue
+				}
+				if stat.Mode() != f.Mode {
+					t.Errorf("mode of %s is %v but expected %v", f.Name, stat.Mode(), f.Mode)
+				}
+
+				if f.Mode&os.ModeSymlink != 0 {
+					target, err := os.Readlink(fn)
+					if err != nil {
+						t.Errorf("cannot read link %s: %v", f.Name, err)
+					} else if target != f.Content {
+						t.Errorf("link %s points to %s but expected %s", f.Name, target, f.Content)
+					}
+					continue
+				}
+
+				content, err := os.ReadFile(fn)
+				if err != nil {
+					t.Errorf("cannot read %s: %v", f.Name, err)
+				} else if string(content) != f.Content {
+					t.Errorf("content of %s is %q but expected %q", f.Name, string(content), f.Content)
+				}
+
+				if os.Geteuid() != 0 {
+					continue
+				}
+				uid := int(stat.Sys().(*syscall.Stat_t).Uid)
+				if uid != test.ExpectedUID {
+					t.Errorf("owner of %s is %d but expected %d", f.Name, uid, test.ExpectedUID)
+				}
+				if dir := filepath.Dir(f.Name); test.ExpectedUID != 0 && !strings.HasPrefix(dir, ".") {
+					dstat, err := os.Stat(filepath.Join(targetFolder, dir))
+					if err == nil && int(dstat.Sys().(*syscall.Stat_t).Uid) != test.ExpectedUID {
+						t.Errorf("owner of %s is %d but expected %d", dir, dstat.Sys().(*syscall.Stat_t).Uid, test.ExpectedUID)
+					}
+				}
+			}
+		})
+	}
+}
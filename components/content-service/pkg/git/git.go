@@ -95,6 +95,15 @@ type Client struct {
 
 	// if true will run git command as gitpod user (should be executed as root that has access to sudo in this case)
 	RunAsGitpodUser bool
+
+	// PartialCloneFilter omits objects from the clone which are fetched on demand, e.g. blob:none or tree:0
+	PartialCloneFilter string
+
+	// Depth is the history depth of shallow clones and fetches. Defaults to 1.
+	Depth int
+
+	// SparseCheckoutPatterns restricts the working copy to these directories (cone mode)
+	SparseCheckoutPatterns []string
 }
 
 // Status describes the status of a Git repo/working copy akin to "git status"
@@ -338,7 +347,14 @@ func (c *Client) Clone(ctx context.Context) (err error) {
 		log.WithError(err).Error("cannot create clone location")
 	}
 
-	args := []string{"--depth=1", "--shallow-submodules", c.RemoteURI}
+	args := []string{fmt.Sprintf("--depth=%d", c.FetchDepth()), "--shallow-submodules", c.RemoteURI}
+	if c.PartialCloneFilter != "" {
+		args = append(args, "--filter="+c.PartialCloneFilter)
+	}
+	if len(c.SparseCheckoutPatterns) > 0 {
+		// only check out the files in the root directory until SparseCheckout sets the patterns
+		args = append(args, "--sparse")
+	}
 
 	for key, value := range c.Config {
 		args = append(args, "--config")
@@ -356,6 +372,44 @@ func (c *Client) Clone(ctx context.Context) (err error) {
 	return c.Git(ctx, "clone", args...)
 }
 
+// FetchDepth returns the depth used for shallow clones and fetches
+func (c *Client) FetchDepth() int {
+	if c.Depth <= 0 {
+		return 1
+	}
+	return c.Depth
+}
+
+// FetchArgs returns the arguments which make a fetch honour the depth and partial clone filter
+// of the client. Passing the filter explicitly also turns working copies which were cloned
+// without a filter, e.g. those restored from a prebuild, into partial clones.
+func (c *Client) FetchArgs(depth int) []string {
+	if depth < c.FetchDepth() {
+		depth = c.FetchDepth()
+	}
+	res := []string{fmt.Sprintf("--depth=%d", depth)}
+	if c.PartialCloneFilter != "" {
+		res = append(res, "--filter="+c.PartialCloneFilter)
+	}
+	return res
+}
+
+// SparseCheckout restricts the working copy to the sparse checkout patterns of the client.
+// This is a no-op if the client has no sparse checkout patterns.
+func (c *Client) SparseCheckout(ctx context.Context) (err error) {
+	if len(c.SparseCheckoutPatterns) == 0 {
+		return nil
+	}
+
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sparseCheckout")
+	span.SetTag("patterns", strings.Join(c.SparseCheckoutPatterns, ","))
+	defer tracing.FinishSpan(span, &err)
+
+	args := append([]string{"set", "--cone"}, c.SparseCheckoutPatterns...)
+	return c.Git(ctx, "sparse-checkout", args...)
+}
+
 // UpdateRemote performs a git fetch on the upstream remote URI
 func (c *Client) UpdateRemote(ctx context.Context) (err error) {
 	//nolint:staticcheck,ineffassign
@@ -369,7 +423,11 @@ func (c *Client) UpdateRemote(ctx context.Context) (err error) {
 			return err
 		}
 		// fetch
-		if err := c.Git(ctx, "fetch", "upstream"); err != nil {
+		args := []string{"upstream"}
+		if c.PartialCloneFilter != "" {
+			args = append(args, "--filter="+c.PartialCloneFilter)
+		}
+		if err := c.Git(ctx, "fetch", args...); err != nil {
 			return err
 		}
 	}
@@ -6,6 +6,7 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -479,6 +480,101 @@ func TestGitStatusFromFiles(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	tests := []struct {
+		Name                   string
+		PartialCloneFilter     string
+		Depth                  int
+		SparseCheckoutPatterns []string
+		ExpectedFiles          []string
+		ExpectedMissingFiles   []string
+		ExpectedCommits        string
+	}{
+		{
+			Name:            "default",
+			ExpectedFiles:   []string{"top-file", "frontend/file", "backend/file"},
+			ExpectedCommits: "1",
+		},
+		{
+			Name:            "deeper clone",
+			Depth:           2,
+			ExpectedFiles:   []string{"top-file", "frontend/file", "backend/file"},
+			ExpectedCommits: "2",
+		},
+		{
+			Name:               "blobless clone",
+			PartialCloneFilter: "blob:none",
+			ExpectedFiles:      []string{"top-file", "frontend/file", "backend/file"},
+			ExpectedCommits:    "1",
+		},
+		{
+			Name:                   "sparse checkout",
+			PartialCloneFilter:     "tree:0",
+			SparseCheckoutPatterns: []string{"frontend"},
+			ExpectedFiles:          []string{"top-file", "frontend/file"},
+			ExpectedMissingFiles:   []string{"backend/file"},
+			ExpectedCommits:        "1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			remote, err := newRemoteWithDirectories(ctx, []string{"frontend", "backend"}, 3)
+			if err != nil {
+				t.Fatalf("cannot create remote: %v", err)
+			}
+			defer os.RemoveAll(remote)
+
+			client, err := newGitClient(ctx)
+			if err != nil {
+				t.Fatalf("cannot create test Git client: %v", err)
+			}
+			defer os.RemoveAll(client.Location)
+			// partial clones require a transport which supports filters, i.e. not a local path
+			client.RemoteURI = "file://" + remote
+			client.PartialCloneFilter = test.PartialCloneFilter
+			client.Depth = test.Depth
+			client.SparseCheckoutPatterns = test.SparseCheckoutPatterns
+
+			err = client.Clone(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = client.SparseCheckout(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, f := range test.ExpectedFiles {
+				if _, err := os.Stat(filepath.Join(client.Location, f)); err != nil {
+					t.Errorf("expected %s to be checked out: %v", f, err)
+				}
+			}
+			for _, f := range test.ExpectedMissingFiles {
+				if _, err := os.Stat(filepath.Join(client.Location, f)); err == nil {
+					t.Errorf("expected %s not to be checked out", f)
+				}
+			}
+
+			out, err := client.GitWithOutput(ctx, nil, "rev-list", "--count", "HEAD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if commits := strings.TrimSpace(string(out)); commits != test.ExpectedCommits {
+				t.Errorf("unexpected number of commits: is %s but expected %s", commits, test.ExpectedCommits)
+			}
+
+			out, _ = client.GitWithOutput(ctx, nil, "config", "remote.origin.partialclonefilter")
+			if filter := strings.TrimSpace(string(out)); filter != test.PartialCloneFilter {
+				t.Errorf("unexpected partial clone filter: is %q but expected %q", filter, test.PartialCloneFilter)
+			}
+		})
+	}
+}
+
 func newGitClient(ctx context.Context) (*Client, error) {
 	loc, err := os.MkdirTemp("", "gittest")
 	if err != nil {
@@ -531,3 +627,41 @@ func initFromRemote(ctx context.Context, c *Client) error {
 
 	return nil
 }
+
+// newRemoteWithDirectories creates a repository with a file in each directory and the given number of commits
+func newRemoteWithDirectories(ctx context.Context, dirs []string, commits int) (string, error) {
+	remote, err := newGitClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := remote.Git(ctx, "init"); err != nil {
+		return "", err
+	}
+	for k, v := range map[string]string{"user.email": "foo@bar.com", "user.name": "foo bar", "uploadpack.allowFilter": "true"} {
+		if err := remote.Git(ctx, "config", "--local", k, v); err != nil {
+			return "", err
+		}
+	}
+	for i := 0; i < commits; i++ {
+		files := []string{"top-file"}
+		for _, d := range dirs {
+			files = append(files, filepath.Join(d, "file"))
+		}
+		for _, f := range files {
+			fn := filepath.Join(remote.Location, f)
+			if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(fn, []byte(fmt.Sprintf("commit %d", i)), 0644); err != nil {
+				return "", err
+			}
+		}
+		if err := remote.Git(ctx, "add", "."); err != nil {
+			return "", err
+		}
+		if err := remote.Git(ctx, "commit", "-m", fmt.Sprintf("commit %d", i)); err != nil {
+			return "", err
+		}
+	}
+	return remote.Location, nil
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	dockerref "github.com/containerd/containerd/reference/docker"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/credentials"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// dockerHubRegistry is the registry host of Docker Hub references
+	dockerHubRegistry = "registry-1.docker.io"
+	// dockerHubAuthHost is the host under which Docker config files store the credentials of Docker Hub
+	dockerHubAuthHost = "index.docker.io"
+)
+
+var (
+	magicGzip = []byte{0x1f, 0x8b}
+	magicZstd = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicZip  = []byte{'P', 'K', 0x03, 0x04}
+)
+
+// archiveInitializer downloads an archive from a URL or an OCI registry and extracts it into the workspace
+type archiveInitializer struct {
+	// URL is the HTTP(S) location of the archive. Either URL or OCIReference must be set.
+	URL string
+	// OCIReference references an OCI artifact whose single layer is the archive
+	OCIReference string
+	// Digest is the expected digest of the archive. Must be set if URL is set.
+	Digest digest.Digest
+	// Format is the archive format. csapi.ArchiveFormat_ARCHIVE_FORMAT_DETECT detects the format from the content.
+	Format csapi.ArchiveFormat
+	// DockerConfig holds the registry credentials of the pull secret. Registries are accessed anonymously if it is nil.
+	DockerConfig *configfile.ConfigFile
+
+	TargetLocation string
+	HTTPClient     *http.Client
+	RetryTimeout   time.Duration
+}
+
+// Run initializes the workspace
+func (ai *archiveInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, metrics csapi.InitializerMetrics, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ArchiveInitializer.Run")
+	defer tracing.FinishSpan(span, &err)
+	start := time.Now()
+	initialSize, fsErr := getFsUsage()
+	if fsErr != nil {
+		log.WithError(fsErr).Error("could not get disk usage")
+	}
+
+	var (
+		dgst   = ai.Digest
+		format = ai.Format
+		open   = ai.httpGet
+	)
+	if ai.OCIReference != "" {
+		var (
+			fetcher remotes.Fetcher
+			layer   ociv1.Descriptor
+		)
+		fetcher, layer, err = ai.resolveOCILayer(ctx)
+		if err != nil {
+			return src, nil, xerrors.Errorf("cannot resolve %s: %w", ai.OCIReference, err)
+		}
+		if dgst != "" && dgst != layer.Digest {
+			return src, nil, xerrors.Errorf("digest mismatch: %s has digest %s but expected %s", ai.OCIReference, layer.Digest, dgst)
+		}
+		dgst = layer.Digest
+		open = func(ctx context.Context) (io.ReadCloser, error) {
+			return fetcher.Fetch(ctx, layer)
+		}
+		if format == csapi.ArchiveFormat_ARCHIVE_FORMAT_DETECT {
+			format = formatFromMediaType(layer.MediaType)
+		}
+	}
+
+	tmpf, err := os.CreateTemp("", "archive-initializer-*")
+	if err != nil {
+		return src, nil, xerrors.Errorf("cannot create temporary file: %w", err)
+	}
+	defer os.Remove(tmpf.Name())
+	defer tmpf.Close()
+
+	err = ai.download(ctx, open, dgst, tmpf)
+	if err != nil {
+		return src, nil, xerrors.Errorf("cannot download archive: %w", err)
+	}
+
+	err = os.MkdirAll(ai.TargetLocation, 0755)
+	if err != nil {
+		return src, nil, xerrors.Errorf("cannot create target location: %w", err)
+	}
+	err = extractArchive(ctx, tmpf, format, ai.TargetLocation, mappings)
+	if err != nil {
+		return src, nil, err
+	}
+
+	if fsErr == nil {
+		currentSize, fsErr := getFsUsage()
+		if fsErr != nil {
+			log.WithError(fsErr).Error("could not get disk usage")
+		}
+
+		metrics = csapi.InitializerMetrics{csapi.InitializerMetric{
+			Type:     "archive",
+			Duration: time.Since(start),
+			Size:     currentSize - initialSize,
+		}}
+	}
+
+	src = csapi.WorkspaceInitFromOther
+	return
+}
+
+// download fetches the archive into dst and verifies its digest. Failed attempts are retried.
+func (ai *archiveInitializer) download(ctx context.Context, open func(context.Context) (io.ReadCloser, error), dgst digest.Digest, dst *os.File) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
+	defer tracing.FinishSpan(span, &err)
+	span.LogKV("digest", dgst)
+
+	dl := func() (err error) {
+		_, err = dst.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+		err = dst.Truncate(0)
+		if err != nil {
+			return err
+		}
+
+		rc, err := open(ctx)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		verifier := dgst.Verifier()
+		_, err = io.Copy(io.MultiWriter(dst, verifier), rc)
+		if err != nil {
+			return err
+		}
+		if !verifier.Verified() {
+			return xerrors.Errorf("digest mismatch")
+		}
+		return nil
+	}
+	for i := 0; i < otsDownloadAttempts; i++ {
+		span.LogKV("attempt", i)
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(ai.RetryTimeout):
+			}
+		}
+
+		err = dl()
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+		if err == nil {
+			break
+		}
+		log.WithError(err).WithField("attempt", i).Warn("cannot download archive")
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// httpGet opens the archive at the URL
+func (ai *archiveInitializer) httpGet(ctx context.Context) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", ai.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		_ = opentracing.GlobalTracer().Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+	}
+
+	resp, err := ai.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, xerrors.Errorf("non-OK download response: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// extractArchive extracts the archive in src to dst. src must be positioned at the beginning of the archive.
+func extractArchive(ctx context.Context, src *os.File, format csapi.ArchiveFormat, dst string, mappings []archive.IDMapping) (err error) {
+	_, err = src.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	if format == csapi.ArchiveFormat_ARCHIVE_FORMAT_DETECT {
+		format, err = detectArchiveFormat(src)
+		if err != nil {
+			return xerrors.Errorf("cannot detect archive format: %w", err)
+		}
+	}
+
+	opts := []archive.TarOption{
+		archive.WithUIDMapping(mappings),
+		archive.WithGIDMapping(mappings),
+	}
+	switch format {
+	case csapi.ArchiveFormat_ARCHIVE_FORMAT_ZIP:
+		stat, err := src.Stat()
+		if err != nil {
+			return err
+		}
+		return archive.ExtractZip(ctx, src, stat.Size(), dst, append(opts, archive.WithDefaultOwner(GitpodUID, GitpodGID))...)
+	case csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR:
+		return archive.ExtractTarbal(ctx, bufio.NewReader(src), dst, opts...)
+	case csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ:
+		gz, err := gzip.NewReader(bufio.NewReader(src))
+		if err != nil {
+			return xerrors.Errorf("cannot read gzip stream: %w", err)
+		}
+		defer gz.Close()
+		return archive.ExtractTarbal(ctx, gz, dst, opts...)
+	case csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_ZSTD:
+		zr, err := zstd.NewReader(bufio.NewReader(src))
+		if err != nil {
+			return xerrors.Errorf("cannot read zstd stream: %w", err)
+		}
+		defer zr.Close()
+		return archive.ExtractTarbal(ctx, zr, dst, opts...)
+	default:
+		return xerrors.Errorf("unsupported archive format: %v", format)
+	}
+}
+
+// detectArchiveFormat determines the archive format from its magic bytes. Content without known magic bytes is assumed to be a tarball.
+func detectArchiveFormat(src io.ReadSeeker) (csapi.ArchiveFormat, error) {
+	magic := make([]byte, 4)
+	n, err := io.ReadFull(src, magic)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_DETECT, err
+	}
+	_, err = src.Seek(0, io.SeekStart)
+	if err != nil {
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_DETECT, err
+	}
+
+	magic = magic[:n]
+	switch {
+	case bytes.HasPrefix(magic, magicGzip):
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ, nil
+	case bytes.HasPrefix(magic, magicZstd):
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_ZSTD, nil
+	case bytes.HasPrefix(magic, magicZip):
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_ZIP, nil
+	default:
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR, nil
+	}
+}
+
+func formatFromMediaType(mediaType string) csapi.ArchiveFormat {
+	switch mediaType {
+	case ociv1.MediaTypeImageLayer:
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR
+	case ociv1.MediaTypeImageLayerGzip, images.MediaTypeDockerSchema2LayerGzip:
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ
+	case ociv1.MediaTypeImageLayerZstd:
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_ZSTD
+	default:
+		return csapi.ArchiveFormat_ARCHIVE_FORMAT_DETECT
+	}
+}
+
+// resolveOCILayer resolves the OCI reference to its manifest and returns the manifest's single layer,
+// along with the fetcher to download it. Registries are accessed with the credentials of the pull secret.
+func (ai *archiveInitializer) resolveOCILayer(ctx context.Context) (fetcher remotes.Fetcher, layer ociv1.Descriptor, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "resolveOCILayer")
+	defer tracing.FinishSpan(span, &err)
+	span.LogKV("ref", ai.OCIReference)
+
+	ref, err := dockerref.ParseDockerRef(ai.OCIReference)
+	if err != nil {
+		return nil, layer, xerrors.Errorf("invalid OCI reference: %w", err)
+	}
+
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(
+				docker.WithAuthClient(ai.HTTPClient),
+				docker.WithAuthCreds(ai.registryCredentials),
+			)),
+			docker.WithClient(ai.HTTPClient),
+		),
+	})
+	name, desc, err := resolver.Resolve(ctx, ref.String())
+	if err != nil {
+		return nil, layer, err
+	}
+	if desc.MediaType != ociv1.MediaTypeImageManifest && desc.MediaType != images.MediaTypeDockerSchema2Manifest {
+		return nil, layer, xerrors.Errorf("unsupported manifest media type: %s", desc.MediaType)
+	}
+
+	fetcher, err = resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, layer, err
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, layer, xerrors.Errorf("cannot fetch manifest: %w", err)
+	}
+	defer rc.Close()
+	body, err := io.ReadAll(io.LimitReader(rc, desc.Size))
+	if err != nil {
+		return nil, layer, xerrors.Errorf("cannot fetch manifest: %w", err)
+	}
+	if desc.Digest.Algorithm().FromBytes(body) != desc.Digest {
+		return nil, layer, xerrors.Errorf("manifest digest mismatch")
+	}
+
+	var manifest ociv1.Manifest
+	err = json.Unmarshal(body, &manifest)
+	if err != nil {
+		return nil, layer, xerrors.Errorf("cannot unmarshal manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 {
+		return nil, layer, xerrors.Errorf("artifact has %d layers but expected exactly one", len(manifest.Layers))
+	}
+	return fetcher, manifest.Layers[0], nil
+}
+
+// registryCredentials returns the credentials the pull secret holds for a registry host
+func (ai *archiveInitializer) registryCredentials(host string) (user, pass string, err error) {
+	if ai.DockerConfig == nil {
+		return "", "", nil
+	}
+	if host == dockerHubRegistry {
+		host = dockerHubAuthHost
+	}
+	for addr, auth := range ai.DockerConfig.AuthConfigs {
+		if credentials.ConvertToHostname(addr) == host {
+			return auth.Username, auth.Password, nil
+		}
+	}
+	return "", "", nil
+}
@@ -0,0 +1,386 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+)
+
+func TestArchiveInitializer(t *testing.T) {
+	files := map[string]string{
+		"README.md":        "hello world",
+		"data/train.csv":   "a,b\n1,2\n",
+		"data/nested/x.md": "x",
+	}
+	tarball := buildTarArchive(t, files)
+
+	tests := []struct {
+		Name          string
+		Content       []byte
+		Format        csapi.ArchiveFormat
+		Digest        digest.Digest
+		ExpectedError string
+	}{
+		{
+			Name:    "tar",
+			Content: tarball,
+		},
+		{
+			Name:    "tar.gz",
+			Content: gzipArchive(t, tarball),
+		},
+		{
+			Name:    "tar.zst",
+			Content: zstdArchive(t, tarball),
+		},
+		{
+			Name:    "zip",
+			Content: buildZipArchive(t, files),
+		},
+		{
+			Name:    "explicit format",
+			Content: gzipArchive(t, tarball),
+			Format:  csapi.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ,
+		},
+		{
+			Name:          "digest mismatch",
+			Content:       tarball,
+			Digest:        digest.FromString("something else"),
+			ExpectedError: "cannot download archive: digest mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(test.Content)
+			}))
+			defer srv.Close()
+
+			dgst := test.Digest
+			if dgst == "" {
+				dgst = digest.FromBytes(test.Content)
+			}
+			loc := t.TempDir()
+			ai := &archiveInitializer{
+				URL:            srv.URL + "/archive",
+				Digest:         dgst,
+				Format:         test.Format,
+				TargetLocation: filepath.Join(loc, "dataset"),
+				HTTPClient:     srv.Client(),
+			}
+			_, _, err := ai.Run(context.Background(), nil)
+			if test.ExpectedError != "" {
+				if err == nil || err.Error() != test.ExpectedError {
+					t.Fatalf("error is %v but expected %s", err, test.ExpectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertFiles(t, filepath.Join(loc, "dataset"), files)
+		})
+	}
+}
+
+func TestArchiveInitializerOCI(t *testing.T) {
+	files := map[string]string{"dataset/labels.txt": "cat\ndog\n"}
+	layer := gzipArchive(t, buildTarArchive(t, files))
+	layerDigest := digest.FromBytes(layer)
+	manifest, err := json.Marshal(ociv1.Manifest{
+		Config: ociv1.Descriptor{MediaType: ociv1.MediaTypeImageConfig, Digest: digest.FromString("{}"), Size: 2},
+		Layers: []ociv1.Descriptor{
+			{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: layerDigest, Size: int64(len(layer))},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDigest := digest.FromBytes(manifest)
+
+	const token = "anonymous-token"
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:datasets/pets:pull" {
+				http.Error(w, "invalid scope", http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": token})
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/v2/datasets/private/") {
+			if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "secret" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		} else if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:datasets/pets:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		repo := strings.TrimPrefix(r.URL.Path, "/v2/datasets/")
+		repo = repo[:strings.Index(repo, "/")]
+		switch strings.TrimPrefix(r.URL.Path, "/v2/datasets/"+repo) {
+		case "/manifests/v1", "/manifests/" + manifestDigest.String():
+			w.Header().Set("Content-Type", ociv1.MediaTypeImageManifest)
+			_, _ = w.Write(manifest)
+		case "/blobs/" + layerDigest.String():
+			_, _ = w.Write(layer)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	tests := []struct {
+		Name          string
+		Reference     string
+		Digest        digest.Digest
+		PullSecret    string
+		ExpectedError string
+	}{
+		{
+			Name:      "tag",
+			Reference: host + "/datasets/pets:v1",
+		},
+		{
+			Name:      "manifest digest",
+			Reference: host + "/datasets/pets@" + manifestDigest.String(),
+		},
+		{
+			Name:      "matching layer digest",
+			Reference: host + "/datasets/pets:v1",
+			Digest:    layerDigest,
+		},
+		{
+			Name:          "layer digest mismatch",
+			Reference:     host + "/datasets/pets:v1",
+			Digest:        digest.FromString("foobar"),
+			ExpectedError: "digest mismatch",
+		},
+		{
+			Name:          "unknown tag",
+			Reference:     host + "/datasets/pets:v2",
+			ExpectedError: "not found",
+		},
+		{
+			Name:       "pull secret",
+			Reference:  host + "/datasets/private:v1",
+			PullSecret: `{"auths":{"` + host + `":{"username":"alice","password":"secret"}}}`,
+		},
+		{
+			Name:          "missing pull secret",
+			Reference:     host + "/datasets/private:v1",
+			ExpectedError: "401 Unauthorized",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			loc := t.TempDir()
+			ai := &archiveInitializer{
+				OCIReference:   test.Reference,
+				Digest:         test.Digest,
+				TargetLocation: loc,
+				HTTPClient:     srv.Client(),
+			}
+			if test.PullSecret != "" {
+				ai.DockerConfig = configfile.New("")
+				err := ai.DockerConfig.LoadFromReader(strings.NewReader(test.PullSecret))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, _, err := ai.Run(context.Background(), nil)
+			if test.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.ExpectedError) {
+					t.Fatalf("error is %v but expected %s", err, test.ExpectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertFiles(t, loc, files)
+		})
+	}
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	dockerCfg := configfile.New("")
+	err := dockerCfg.LoadFromReader(strings.NewReader(`{"auths": {
+		"https://index.docker.io/v1/": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("hub:hubpass")) + `"},
+		"eu.gcr.io": {"username": "_json_key", "password": "gcrpass"}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Host     string
+		Config   *configfile.ConfigFile
+		User     string
+		Password string
+	}{
+		{Host: dockerHubRegistry, Config: dockerCfg, User: "hub", Password: "hubpass"},
+		{Host: "eu.gcr.io", Config: dockerCfg, User: "_json_key", Password: "gcrpass"},
+		{Host: "registry.example.com", Config: dockerCfg},
+		{Host: "eu.gcr.io"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Host, func(t *testing.T) {
+			ai := &archiveInitializer{DockerConfig: test.Config}
+			user, pass, err := ai.registryCredentials(test.Host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user != test.User || pass != test.Password {
+				t.Errorf("credentials are %s:%s but expected %s:%s", user, pass, test.User, test.Password)
+			}
+		})
+	}
+}
+
+func TestNewArchiveInitializerTargetLocation(t *testing.T) {
+	tests := []struct {
+		TargetLocation string
+		Error          bool
+	}{
+		{TargetLocation: ""},
+		{TargetLocation: "data"},
+		{TargetLocation: "data/../models"},
+		{TargetLocation: "..", Error: true},
+		{TargetLocation: "../other-workspace", Error: true},
+		{TargetLocation: "data/../../etc", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TargetLocation, func(t *testing.T) {
+			_, err := newArchiveInitializer("/workspace", &csapi.ArchiveInitializer{
+				Source:         &csapi.ArchiveInitializer_Url{Url: "https://example.com/archive.tar"},
+				Digest:         digest.FromString("").String(),
+				TargetLocation: test.TargetLocation,
+			})
+			if test.Error && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !test.Error && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func assertFiles(t *testing.T, loc string, files map[string]string) {
+	for name, content := range files {
+		b, err := os.ReadFile(filepath.Join(loc, name))
+		if err != nil {
+			t.Errorf("cannot read %s: %v", name, err)
+			continue
+		}
+		if string(b) != content {
+			t.Errorf("content of %s is %q but expected %q", name, string(b), content)
+		}
+	}
+}
+
+func buildTarArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	dirs := make(map[string]struct{})
+	for name, content := range files {
+		for d := filepath.Dir(name); d != "."; d = filepath.Dir(d) {
+			if _, ok := dirs[d]; ok {
+				continue
+			}
+			dirs[d] = struct{}{}
+			err := tw.WriteHeader(&tar.Header{Name: d + "/", Mode: 0755, Typeflag: tar.TypeDir, Uid: GitpodUID, Gid: GitpodGID})
+			if err != nil {
+				t.Fatalf("cannot prepare archive: %q", err)
+			}
+		}
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg, Uid: GitpodUID, Gid: GitpodGID})
+		if err != nil {
+			t.Fatalf("cannot prepare archive: %q", err)
+		}
+		_, err = tw.Write([]byte(content))
+		if err != nil {
+			t.Fatalf("cannot prepare archive: %q", err)
+		}
+	}
+	err := tw.Close()
+	if err != nil {
+		t.Fatalf("cannot prepare archive: %q", err)
+	}
+	return buf.Bytes()
+}
+
+func buildZipArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("cannot prepare archive: %q", err)
+		}
+		_, err = w.Write([]byte(content))
+		if err != nil {
+			t.Fatalf("cannot prepare archive: %q", err)
+		}
+	}
+	err := zw.Close()
+	if err != nil {
+		t.Fatalf("cannot prepare archive: %q", err)
+	}
+	return buf.Bytes()
+}
+
+func gzipArchive(t *testing.T, content []byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(content)
+	if err != nil {
+		t.Fatalf("cannot prepare archive: %q", err)
+	}
+	err = gw.Close()
+	if err != nil {
+		t.Fatalf("cannot prepare archive: %q", err)
+	}
+	return buf.Bytes()
+}
+
+func zstdArchive(t *testing.T, content []byte) []byte {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("cannot prepare archive: %q", err)
+	}
+	defer enc.Close()
+	return enc.EncodeAll(content, nil)
+}
@@ -110,6 +110,11 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 			return err
 		}
 
+		err = ws.SparseCheckout(ctx)
+		if err != nil {
+			return err
+		}
+
 		err = ws.Git(ctx, "config", "--replace-all", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
 		if err != nil {
 			log.WithError(err).WithField("location", ws.Location).Error("cannot configure fecth behavior")
@@ -184,9 +189,12 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 		}
 
 		stats = csapi.InitializerMetrics{csapi.InitializerMetric{
-			Type:     "git",
-			Duration: time.Since(start),
-			Size:     currentSize - initialSize,
+			Type:                   "git",
+			Duration:               time.Since(start),
+			Size:                   currentSize - initialSize,
+			CloneFilter:            ws.PartialCloneFilter,
+			CloneDepth:             ws.FetchDepth(),
+			SparseCheckoutPatterns: len(ws.SparseCheckoutPatterns),
 		}}
 	}
 	return
@@ -228,7 +236,8 @@ func (ws *GitInitializer) realizeCloneTarget(ctx context.Context) (err error) {
 		//
 		// We don't recurse submodules because callers realizeCloneTarget() are expected to update submodules explicitly,
 		// and deal with any error appropriately (i.e. emit a warning rather than fail).
-		if err := ws.Git(ctx, "fetch", "--depth=1", "origin", "--recurse-submodules=no", ws.CloneTarget); err != nil {
+		args := append(ws.FetchArgs(1), "origin", "--recurse-submodules=no", ws.CloneTarget)
+		if err := ws.Git(ctx, "fetch", args...); err != nil {
 			log.WithError(err).WithField("remoteURI", ws.RemoteURI).WithField("branch", ws.CloneTarget).Error("Cannot fetch remote branch")
 			return err
 		}
@@ -246,7 +255,8 @@ func (ws *GitInitializer) realizeCloneTarget(ctx context.Context) (err error) {
 		// We did a shallow clone before, hence need to fetch the commit we are about to check out.
 		// Because we don't want to make the "git fetch" mechanism in supervisor more complicated,
 		// we'll just fetch the 20 commits right away.
-		if err := ws.Git(ctx, "fetch", "origin", ws.CloneTarget, "--depth=20"); err != nil {
+		args := append([]string{"origin", ws.CloneTarget}, ws.FetchArgs(20)...)
+		if err := ws.Git(ctx, "fetch", args...); err != nil {
 			return err
 		}
 
@@ -18,6 +18,8 @@ import (
 	"syscall"
 	"time"
 
+	dockerref "github.com/containerd/containerd/reference/docker"
+	"github.com/docker/cli/cli/config/configfile"
 	"github.com/opencontainers/go-digest"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
@@ -162,6 +164,11 @@ func NewFromRequest(ctx context.Context, loc string, rs storage.DirectDownloader
 		initializer, err = newFileDownloadInitializer(loc, ir.Download)
 	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Backup); ok {
 		initializer, err = newFromBackupInitializer(loc, rs, ir.Backup)
+	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Archive); ok {
+		if ir.Archive == nil {
+			return nil, status.Error(codes.InvalidArgument, "missing archive initializer spec")
+		}
+		initializer, err = newArchiveInitializer(loc, ir.Archive)
 	} else {
 		initializer = &EmptyInitializer{}
 	}
@@ -194,6 +201,61 @@ func newFileDownloadInitializer(loc string, req *csapi.FileDownloadInitializer)
 	return initializer, nil
 }
 
+// newArchiveInitializer creates an archive initializer for a request
+func newArchiveInitializer(loc string, req *csapi.ArchiveInitializer) (*archiveInitializer, error) {
+	var dgst digest.Digest
+	if req.Digest != "" {
+		var err error
+		dgst, err = digest.Parse(req.Digest)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid digest %s: %w", req.Digest, err)
+		}
+	}
+	switch src := req.Source.(type) {
+	case *csapi.ArchiveInitializer_Url:
+		if src.Url == "" {
+			return nil, xerrors.Errorf("missing archive URL")
+		}
+		if dgst == "" {
+			return nil, xerrors.Errorf("missing digest for archive %s", src.Url)
+		}
+	case *csapi.ArchiveInitializer_OciReference:
+		if src.OciReference == "" {
+			return nil, xerrors.Errorf("missing OCI reference")
+		}
+		if _, err := dockerref.ParseDockerRef(src.OciReference); err != nil {
+			return nil, xerrors.Errorf("invalid OCI reference %s: %w", src.OciReference, err)
+		}
+	default:
+		return nil, xerrors.Errorf("missing archive source")
+	}
+
+	var dockerCfg *configfile.ConfigFile
+	if req.PullSecret != "" {
+		dockerCfg = configfile.New("")
+		err := dockerCfg.LoadFromReader(strings.NewReader(req.PullSecret))
+		if err != nil {
+			return nil, xerrors.Errorf("invalid pull secret: %w", err)
+		}
+	}
+
+	target := filepath.Join(loc, req.TargetLocation)
+	if rel, err := filepath.Rel(loc, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, xerrors.Errorf("target location %s is outside of the workspace", req.TargetLocation)
+	}
+
+	return &archiveInitializer{
+		URL:            req.GetUrl(),
+		OCIReference:   req.GetOciReference(),
+		Digest:         dgst,
+		Format:         req.Format,
+		DockerConfig:   dockerCfg,
+		TargetLocation: target,
+		HTTPClient:     http.DefaultClient,
+		RetryTimeout:   1 * time.Second,
+	}, nil
+}
+
 // newFromBackupInitializer creates a backup restoration initializer for a request
 func newFromBackupInitializer(loc string, rs storage.DirectDownloader, req *csapi.FromBackupInitializer) (*fromBackupInitializer, error) {
 	return &fromBackupInitializer{
@@ -220,7 +282,7 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 		log.WithError(fsErr).Error("could not get disk usage")
 	}
 
-	hasBackup, err := bi.RemoteStorage.Download(ctx, bi.Location, storage.DefaultBackup, mappings)
+	hasBackup, retriedBytes, err := bi.download(ctx, mappings)
 	if !hasBackup {
 		if err != nil {
 			return src, nil, xerrors.Errorf("no backup found, error: %w", err)
@@ -238,15 +300,50 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 		}
 
 		stats = csapi.InitializerMetrics{csapi.InitializerMetric{
-			Type:     "fromBackup",
-			Duration: time.Since(start),
-			Size:     currentSize - initialSize,
+			Type:         "fromBackup",
+			Duration:     time.Since(start),
+			Size:         currentSize - initialSize,
+			RetriedBytes: uint64(retriedBytes),
 		}}
 	}
 
 	return csapi.WorkspaceInitFromBackup, stats, nil
 }
 
+// download restores the backup using a parallel, resumable ranged download if the remote storage can describe it.
+// Incremental backups and backups of unknown size are restored by the remote storage itself.
+func (bi *fromBackupInitializer) download(ctx context.Context, mappings []archive.IDMapping) (found bool, retriedBytes int64, err error) {
+	serial := func() (bool, int64, error) {
+		found, err := storage.DownloadBackup(ctx, bi.RemoteStorage, bi.Location, storage.DefaultBackup, mappings)
+		return found, 0, err
+	}
+
+	dip, ok := bi.RemoteStorage.(storage.DownloadInfoProvider)
+	if !ok {
+		return serial()
+	}
+	if _, err := dip.DownloadInfo(storage.IncrementalManifestName(storage.DefaultBackup)); err == nil {
+		return serial()
+	}
+	info, err := dip.DownloadInfo(storage.DefaultBackup)
+	if err == storage.ErrNotFound {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	if info.Size <= 0 {
+		return serial()
+	}
+
+	retriedBytes, err = restoreRanged(ctx, bi.Location, info, mappings)
+	if errors.Is(err, errRangeNotSupported) {
+		log.WithError(err).Warn("cannot restore backup using ranged download - falling back to serial download")
+		return serial()
+	}
+	return true, retriedBytes, err
+}
+
 // newGitInitializer creates a Git initializer based on the request.
 // Returns gRPC errors.
 func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitializer, forceGitpodUser bool) (*GitInitializer, error) {
@@ -268,6 +365,23 @@ func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitialize
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid target mode: %v", req.TargetMode))
 	}
 
+	var cloneFilter string
+	switch req.Config.PartialCloneFilter {
+	case csapi.PartialCloneFilter_NO_FILTER:
+	case csapi.PartialCloneFilter_BLOB_NONE:
+		cloneFilter = "blob:none"
+	case csapi.PartialCloneFilter_TREE_ZERO:
+		cloneFilter = "tree:0"
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid partial clone filter: %v", req.Config.PartialCloneFilter))
+	}
+
+	for _, p := range req.SparseCheckoutPatterns {
+		if p == "" || strings.HasPrefix(p, "-") {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid sparse checkout pattern: %q", p))
+		}
+	}
+
 	var authMethod = git.BasicAuth
 	if req.Config.Authentication == csapi.GitAuthMethod_NO_AUTH {
 		authMethod = git.NoAuth
@@ -304,6 +418,10 @@ func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitialize
 			AuthMethod:        authMethod,
 			AuthProvider:      authProvider,
 			RunAsGitpodUser:   forceGitpodUser,
+
+			PartialCloneFilter:     cloneFilter,
+			Depth:                  int(req.CloneDepth),
+			SparseCheckoutPatterns: req.SparseCheckoutPatterns,
 		},
 		TargetMode:  targetMode,
 		CloneTarget: req.CloneTaget,
@@ -468,7 +586,7 @@ func InitializeWorkspace(ctx context.Context, location string, remoteStorage sto
 	}
 
 	// Run the initializer
-	hasBackup, err := remoteStorage.Download(ctx, location, storage.DefaultBackup, cfg.mappings)
+	hasBackup, err := storage.DownloadBackup(ctx, remoteStorage, location, storage.DefaultBackup, cfg.mappings)
 	if err != nil {
 		return src, nil, xerrors.Errorf("cannot restore backup: %w", err)
 	}
@@ -163,6 +163,12 @@ func runGitInit(ctx context.Context, gInit *GitInitializer) (commitChanged bool,
 		if err != nil {
 			log.WithError(err).Warn("couldn't run git status - continuing")
 		}
+		// The prebuild may have been cloned with different sparse checkout patterns. Apply ours before
+		// we check out the clone target so that we don't fetch the blobs of directories we don't need.
+		err = gInit.SparseCheckout(ctx)
+		if err != nil {
+			return commitChanged, xerrors.Errorf("prebuild initializer: %w", err)
+		}
 		err = checkGitStatus(gInit.realizeCloneTarget(ctx))
 		if err != nil {
 			return commitChanged, xerrors.Errorf("prebuild initializer: %w", err)
@@ -0,0 +1,373 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+const (
+	rangedDownloadConcurrency  = 8
+	rangedDownloadPartSize     = 64 * 1024 * 1024
+	rangedDownloadAttempts     = 5
+	rangedDownloadRetryTimeout = 2 * time.Second
+
+	// rangedRestorePartialTTL is how long we keep the partial downloads of failed restores around to resume them
+	rangedRestorePartialTTL = 24 * time.Hour
+)
+
+// errRangeNotSupported is returned when the remote end ignores range requests
+var errRangeNotSupported = errors.New("range requests are not supported")
+
+// rangedDownload downloads an object of known size using parallel range requests. A failed range
+// is retried from the last byte that was written successfully, rather than from the start.
+type rangedDownload struct {
+	URL    string
+	Size   int64
+	Digest digest.Digest
+
+	// StateFile records which ranges were downloaded, so that a later download to the same
+	// destination resumes where this one stopped. Downloads without a digest are never resumed.
+	StateFile string
+
+	HTTPClient   *http.Client
+	Concurrency  int
+	PartSize     int64
+	Attempts     int
+	RetryTimeout time.Duration
+
+	mu    sync.Mutex
+	state rangedDownloadState
+}
+
+// rangedDownloadState is the progress of a ranged download
+type rangedDownloadState struct {
+	Size     int64         `json:"size"`
+	Digest   digest.Digest `json:"digest"`
+	PartSize int64         `json:"partSize"`
+
+	// Offsets maps the start of each part to the offset up to which it was downloaded
+	Offsets map[int64]int64 `json:"offsets"`
+}
+
+func newRangedDownload(info *storage.DownloadInfo) (*rangedDownload, error) {
+	var dgst digest.Digest
+	if info.Meta.Digest != "" {
+		dgst = digest.Digest(info.Meta.Digest)
+		err := dgst.Validate()
+		if err != nil {
+			return nil, xerrors.Errorf("invalid digest %s: %w", info.Meta.Digest, err)
+		}
+	}
+
+	return &rangedDownload{
+		URL:          info.URL,
+		Size:         info.Size,
+		Digest:       dgst,
+		HTTPClient:   http.DefaultClient,
+		Concurrency:  rangedDownloadConcurrency,
+		PartSize:     rangedDownloadPartSize,
+		Attempts:     rangedDownloadAttempts,
+		RetryTimeout: rangedDownloadRetryTimeout,
+	}, nil
+}
+
+// Run downloads the object to dst and verifies its digest. Returns the number of bytes which had to be requested again.
+func (d *rangedDownload) Run(ctx context.Context, dst *os.File) (retriedBytes int64, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rangedDownload.Run")
+	span.SetTag("size", d.Size)
+	defer tracing.FinishSpan(span, &err)
+
+	if d.Size <= 0 {
+		return 0, xerrors.Errorf("ranged download requires the object size")
+	}
+	err = dst.Truncate(d.Size)
+	if err != nil {
+		return 0, err
+	}
+	d.loadState()
+
+	var (
+		retried atomic.Int64
+		resumed int64
+	)
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(d.Concurrency)
+	for start := int64(0); start < d.Size; start += d.PartSize {
+		start, end := start, start+d.PartSize
+		if end > d.Size {
+			end = d.Size
+		}
+		offset := start
+		if o, ok := d.state.Offsets[start]; ok && o > start && o <= end {
+			offset = o
+			resumed += o - start
+		}
+		if offset == end {
+			continue
+		}
+		eg.Go(func() error {
+			return d.downloadPart(egctx, dst, start, offset, end, &retried)
+		})
+	}
+	span.LogKV("resumedBytes", resumed)
+	err = eg.Wait()
+	retriedBytes = retried.Load()
+	span.LogKV("retriedBytes", retriedBytes)
+	if err != nil {
+		return retriedBytes, err
+	}
+
+	if d.Digest != "" {
+		dgst, err := d.Digest.Algorithm().FromReader(io.NewSectionReader(dst, 0, d.Size))
+		if err != nil {
+			return retriedBytes, err
+		}
+		if dgst != d.Digest {
+			// the content we'd resume from is broken - the next download must start over
+			if d.StateFile != "" {
+				_ = os.Remove(d.StateFile)
+			}
+			return retriedBytes, xerrors.Errorf("digest mismatch: expected %s, got %s", d.Digest, dgst)
+		}
+	}
+
+	return retriedBytes, nil
+}
+
+// loadState reads the progress of a previous download, unless it was a download of a different object
+func (d *rangedDownload) loadState() {
+	d.state = rangedDownloadState{
+		Size:     d.Size,
+		Digest:   d.Digest,
+		PartSize: d.PartSize,
+		Offsets:  make(map[int64]int64),
+	}
+	if d.StateFile == "" || d.Digest == "" {
+		return
+	}
+
+	fc, err := os.ReadFile(d.StateFile)
+	if err != nil {
+		return
+	}
+	var prev rangedDownloadState
+	err = json.Unmarshal(fc, &prev)
+	if err != nil {
+		log.WithError(err).WithField("stateFile", d.StateFile).Warn("cannot read ranged download state - starting over")
+		return
+	}
+	if prev.Size != d.Size || prev.Digest != d.Digest || prev.PartSize != d.PartSize || prev.Offsets == nil {
+		return
+	}
+	d.state = prev
+}
+
+// saveProgress records up to which offset a part was downloaded. The content must be synced before
+// the state is, lest the state claims content which never made it to disk.
+func (d *rangedDownload) saveProgress(dst *os.File, start, offset int64) {
+	if d.StateFile == "" || d.Digest == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.state.Offsets[start] >= offset {
+		return
+	}
+	err := dst.Sync()
+	if err != nil {
+		log.WithError(err).Warn("cannot sync ranged download")
+		return
+	}
+	d.state.Offsets[start] = offset
+	fc, err := json.Marshal(d.state)
+	if err != nil {
+		return
+	}
+	tmp := d.StateFile + ".tmp"
+	err = os.WriteFile(tmp, fc, 0600)
+	if err == nil {
+		err = os.Rename(tmp, d.StateFile)
+	}
+	if err != nil {
+		log.WithError(err).WithField("stateFile", d.StateFile).Warn("cannot save ranged download state")
+	}
+}
+
+func (d *rangedDownload) downloadPart(ctx context.Context, dst *os.File, start, offset, end int64, retried *atomic.Int64) error {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			retried.Add(end - offset)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.RetryTimeout):
+			}
+		}
+
+		n, err := d.fetchRange(ctx, dst, offset, end)
+		offset += n
+		if n > 0 {
+			d.saveProgress(dst, start, offset)
+		}
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, errRangeNotSupported) || attempt+1 >= d.Attempts {
+			return xerrors.Errorf("cannot download bytes %d-%d: %w", offset, end-1, err)
+		}
+		log.WithError(err).WithField("offset", offset).WithField("attempt", attempt).Warn("cannot download range - resuming")
+	}
+}
+
+// fetchRange downloads the bytes [start, end) and returns how many of them were written to dst
+func (d *rangedDownload) fetchRange(ctx context.Context, dst io.WriterAt, start, end int64) (n int64, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", d.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end-1))
+
+	resp, err := d.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return 0, errRangeNotSupported
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, xerrors.Errorf("non-OK download response: %s", resp.Status)
+	}
+
+	n, err = io.Copy(&offsetWriter{w: dst, offset: start}, io.LimitReader(resp.Body, end-start))
+	if err == nil && n < end-start {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.WriteAt(p, o.offset)
+	o.offset += int64(n)
+	return n, err
+}
+
+// restoreRanged downloads a backup using a ranged download and extracts it to destination
+func restoreRanged(ctx context.Context, destination string, info *storage.DownloadInfo, mappings []archive.IDMapping) (retriedBytes int64, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "restoreRanged")
+	defer tracing.FinishSpan(span, &err)
+
+	dl, err := newRangedDownload(info)
+	if err != nil {
+		return 0, err
+	}
+
+	tmpf, err := openRangedRestoreFile(dl)
+	if err != nil {
+		return 0, xerrors.Errorf("cannot create temporary file: %w", err)
+	}
+	defer tmpf.Close()
+	defer func() {
+		// we keep the partial download of a failed restore, so that the next attempt can resume it
+		if err == nil || dl.StateFile == "" {
+			os.Remove(tmpf.Name())
+			if dl.StateFile != "" {
+				os.Remove(dl.StateFile)
+			}
+		}
+	}()
+
+	retriedBytes, err = dl.Run(ctx, tmpf)
+	if err != nil {
+		return retriedBytes, err
+	}
+
+	var src io.Reader = io.NewSectionReader(tmpf, 0, info.Size)
+	if info.DataKey != nil {
+		src, err = storage.NewDecryptingReader(src, info.DataKey)
+		if err != nil {
+			return retriedBytes, xerrors.Errorf("cannot decrypt backup: %w", err)
+		}
+	}
+
+	err = archive.ExtractTarbal(ctx, src, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	if err != nil {
+		return retriedBytes, xerrors.Errorf("tar %s: %s", destination, err.Error())
+	}
+
+	return retriedBytes, nil
+}
+
+// openRangedRestoreFile opens the file a ranged download is written to. Downloads of an object with a known digest
+// use a file named after the digest, alongside the state of the download, such that restores which fail can be resumed.
+// Partial downloads which were not resumed for a while are removed.
+func openRangedRestoreFile(dl *rangedDownload) (*os.File, error) {
+	if dl.Digest == "" || dl.Digest.Validate() != nil {
+		return os.CreateTemp("", "ranged-restore-*")
+	}
+
+	dir := filepath.Join(os.TempDir(), "ranged-restore")
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, e := range entries {
+			info, err := e.Info()
+			if err == nil && time.Since(info.ModTime()) > rangedRestorePartialTTL {
+				os.Remove(filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+
+	fn := filepath.Join(dir, dl.Digest.Algorithm().String()+"-"+dl.Digest.Encoded())
+	f, err := os.OpenFile(fn, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	// another restore of the same object is in progress - we must not write to its file
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		f.Close()
+		return os.CreateTemp("", "ranged-restore-*")
+	}
+
+	dl.StateFile = fn + ".state"
+	return f, nil
+}
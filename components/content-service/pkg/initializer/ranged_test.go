@@ -0,0 +1,244 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestRangedDownload(t *testing.T) {
+	const (
+		size     = 1024 * 1024
+		partSize = 100 * 1024
+	)
+	content := make([]byte, size)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	tests := []struct {
+		Name            string
+		Digest          digest.Digest
+		FailFirstRanges bool
+		IgnoreRanges    bool
+		ExpectedError   string
+		ExpectRetries   bool
+	}{
+		{
+			Name:   "happy path",
+			Digest: digest.FromBytes(content),
+		},
+		{
+			Name:   "no digest",
+			Digest: "",
+		},
+		{
+			Name:            "flaky connection",
+			Digest:          digest.FromBytes(content),
+			FailFirstRanges: true,
+			ExpectRetries:   true,
+		},
+		{
+			Name:          "digest mismatch",
+			Digest:        digest.FromString("something else"),
+			ExpectedError: "digest mismatch",
+		},
+		{
+			Name:          "ranges not supported",
+			Digest:        digest.FromBytes(content),
+			IgnoreRanges:  true,
+			ExpectedError: errRangeNotSupported.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				failed = make(map[int]bool)
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.IgnoreRanges {
+					_, _ = w.Write(content)
+					return
+				}
+
+				// resumed requests start at a different offset, but end at the same byte
+				var start, end int
+				_, _ = fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+				mu.Lock()
+				fail := test.FailFirstRanges && !failed[end]
+				failed[end] = true
+				mu.Unlock()
+				if fail {
+					// pretend to serve the full range, but break the connection after the first 100 bytes
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write(content[start : start+100])
+					w.(http.Flusher).Flush()
+					panic(http.ErrAbortHandler)
+				}
+
+				http.ServeContent(w, r, "content", time.Time{}, bytes.NewReader(content))
+			}))
+			defer srv.Close()
+
+			dst, err := os.CreateTemp(t.TempDir(), "download")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dst.Close()
+
+			dl := &rangedDownload{
+				URL:          srv.URL,
+				Size:         size,
+				Digest:       test.Digest,
+				HTTPClient:   srv.Client(),
+				Concurrency:  4,
+				PartSize:     partSize,
+				Attempts:     3,
+				RetryTimeout: 10 * time.Millisecond,
+			}
+			retried, err := dl.Run(context.Background(), dst)
+			if test.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.ExpectedError) {
+					t.Fatalf("expected error containing %q, got %v", test.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.ExpectRetries {
+				// every range was resumed after the first 100 bytes
+				parts := (size + partSize - 1) / partSize
+				if expected := int64(size - parts*100); retried != expected {
+					t.Errorf("unexpected retried bytes: is %d but expected %d", retried, expected)
+				}
+			} else if retried != 0 {
+				t.Errorf("unexpected retried bytes: %d", retried)
+			}
+
+			act, err := os.ReadFile(dst.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(act, content) {
+				t.Errorf("downloaded content differs")
+			}
+		})
+	}
+}
+
+func TestRangedDownloadResume(t *testing.T) {
+	const (
+		size     = 1024 * 1024
+		partSize = 100 * 1024
+	)
+	content := make([]byte, size)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	var (
+		mu     sync.Mutex
+		broken = true
+		served int64
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start, end int
+		_, _ = fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+		mu.Lock()
+		fail := broken
+		if !fail {
+			served += int64(end - start + 1)
+		}
+		mu.Unlock()
+		if fail {
+			// break the connection after the first 100 bytes of every range
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(content[start : start+100])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+
+		http.ServeContent(w, r, "content", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	dst, err := os.CreateTemp(t.TempDir(), "download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	newDownload := func() *rangedDownload {
+		return &rangedDownload{
+			URL:          srv.URL,
+			Size:         size,
+			Digest:       digest.FromBytes(content),
+			StateFile:    dst.Name() + ".state",
+			HTTPClient:   srv.Client(),
+			Concurrency:  4,
+			PartSize:     partSize,
+			Attempts:     1,
+			RetryTimeout: 10 * time.Millisecond,
+		}
+	}
+	_, err = newDownload().Run(context.Background(), dst)
+	if err == nil {
+		t.Fatal("expected the download over a broken connection to fail")
+	}
+
+	// ranges which were canceled once the first one failed may not have made any progress
+	var (
+		state      rangedDownloadState
+		downloaded int64
+	)
+	fc, err := os.ReadFile(dst.Name() + ".state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.Unmarshal(fc, &state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for start, offset := range state.Offsets {
+		downloaded += offset - start
+	}
+	if downloaded == 0 {
+		t.Fatal("expected the state to record the progress of the failed download")
+	}
+
+	mu.Lock()
+	broken = false
+	mu.Unlock()
+	_, err = newDownload().Run(context.Background(), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := size - downloaded; served != expected {
+		t.Errorf("unexpected bytes served after resuming: is %d but expected %d", served, expected)
+	}
+	act, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(act, content) {
+		t.Errorf("downloaded content differs")
+	}
+}
@@ -45,7 +45,7 @@ func (s *SnapshotInitializer) Run(ctx context.Context, mappings []archive.IDMapp
 		return src, nil, nil
 	}
 
-	ok, err := s.Storage.DownloadSnapshot(ctx, s.Location, s.Snapshot, mappings)
+	ok, err := storage.DownloadSnapshotBackup(ctx, s.Storage, s.Location, s.Snapshot, mappings)
 	if err != nil {
 		return src, nil, xerrors.Errorf("snapshot initializer: %w", err)
 	}
@@ -212,6 +212,20 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 		}
 		return []Layer{*layer}, nil, nil
 	}
+	if initializer.GetArchive() != nil {
+		span.LogKV("initializer", "Archive")
+
+		cdesc, err := executor.Prepare(initializer, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		layer, err = contentDescriptorToLayer(cdesc)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []Layer{*layer}, nil, nil
+	}
 	if initializer.GetBackup() != nil {
 		// We were asked to restore a backup and have tried above. We've failed to restore the backup,
 		// hance the backup initializer failed.
@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package retention
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "gitpod"
+	metricsSubsystem = "content_service_retention"
+)
+
+// Metrics are the Prometheus metrics of content retention
+type Metrics struct {
+	ReclaimedBytesTotal  *prometheus.CounterVec
+	DeletedObjectsTotal  *prometheus.CounterVec
+	ReclaimableBytes     *prometheus.GaugeVec
+	RunsTotal            *prometheus.CounterVec
+	RunDurationSeconds   prometheus.Histogram
+	OwnerFailuresTotal   prometheus.Counter
+	LastSuccessTimestamp prometheus.Gauge
+}
+
+// NewMetrics creates the content retention metrics
+func NewMetrics() *Metrics {
+	return &Metrics{
+		ReclaimedBytesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reclaimed_bytes_total",
+			Help:      "bytes of remote storage reclaimed by deleting content",
+		}, []string{"kind"}),
+		DeletedObjectsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "deleted_objects_total",
+			Help:      "number of objects deleted from remote storage",
+		}, []string{"kind"}),
+		ReclaimableBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reclaimable_bytes",
+			Help:      "bytes the last run found to violate the retention rules, also reported in dry-run mode",
+		}, []string{"kind"}),
+		RunsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "runs_total",
+			Help:      "number of retention runs",
+		}, []string{"outcome"}),
+		RunDurationSeconds: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "run_duration_seconds",
+			Help:      "duration of a retention run",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+		}),
+		OwnerFailuresTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "owner_failures_total",
+			Help:      "number of owners whose content could not be processed",
+		}),
+		LastSuccessTimestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "time of the last successful retention run",
+		}),
+	}
+}
+
+// Register registers all metrics with the registry
+func (m *Metrics) Register(registry prometheus.Registerer) error {
+	metrics := []prometheus.Collector{
+		m.ReclaimedBytesTotal,
+		m.DeletedObjectsTotal,
+		m.ReclaimableBytes,
+		m.RunsTotal,
+		m.RunDurationSeconds,
+		m.OwnerFailuresTotal,
+		m.LastSuccessTimestamp,
+	}
+
+	for _, metric := range metrics {
+		err := registry.Register(metric)
+		if err != nil {
+			return fmt.Errorf("failed to register metric: %w", err)
+		}
+	}
+	return nil
+}
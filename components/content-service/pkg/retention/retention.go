@@ -0,0 +1,565 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package retention
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+// Kind is the kind of content subject to retention
+type Kind string
+
+const (
+	// KindInstanceBackup is a backup uploaded for a particular workspace instance
+	KindInstanceBackup Kind = "instance-backup"
+	// KindSnapshot is a workspace snapshot
+	KindSnapshot Kind = "snapshot"
+	// KindChunk is a chunk of incremental backups which no backup references anymore
+	KindChunk Kind = "chunk"
+)
+
+// Reason explains why content is deleted
+type Reason string
+
+const (
+	// ReasonKeepInstanceBackups is given to instance backups beyond the configured number of backups to keep
+	ReasonKeepInstanceBackups Reason = "keepInstanceBackups"
+	// ReasonSnapshotMaxAge is given to snapshots older than the configured maximum age
+	ReasonSnapshotMaxAge Reason = "snapshotMaxAge"
+	// ReasonMaxBytesPerUser is given to content deleted to bring a user below the configured size cap
+	ReasonMaxBytesPerUser Reason = "maxBytesPerUser"
+)
+
+// snapshotObjectRegexp matches the names of snapshots and their incremental backup manifests within a workspace's backup prefix
+var snapshotObjectRegexp = regexp.MustCompile(`^snapshot-\d+\.tar(` + regexp.QuoteMeta(storage.IncrementalManifestSuffix) + `)?$`)
+
+// Deletion describes content which violates a retention rule
+type Deletion struct {
+	Owner     string `json:"owner"`
+	Workspace string `json:"workspace"`
+	Kind      Kind   `json:"kind"`
+	// Name is the instance ID of instance backups and the object name of snapshots
+	Name    string    `json:"name"`
+	Objects []string  `json:"objects"`
+	Size    int64     `json:"size"`
+	Updated time.Time `json:"updated"`
+	Reason  Reason    `json:"reason"`
+}
+
+// WorkspaceStorage is the size of the content retained for a workspace
+type WorkspaceStorage struct {
+	Owner     string `json:"owner"`
+	Workspace string `json:"workspace"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// Report summarizes a retention run
+type Report struct {
+	DryRun         bool       `json:"dryRun"`
+	Started        time.Time  `json:"started"`
+	Duration       string     `json:"duration"`
+	Owners         int        `json:"owners"`
+	FailedOwners   []string   `json:"failedOwners,omitempty"`
+	Deletions      []Deletion `json:"deletions"`
+	ReclaimedBytes int64      `json:"reclaimedBytes"`
+	// Storage is the size of the content retained per workspace at the end of the run
+	Storage []WorkspaceStorage `json:"storage"`
+}
+
+// Enforcer deletes workspace content from remote storage according to the retention rules
+type Enforcer struct {
+	Config  config.RetentionConfig
+	Storage storage.PresignedAccess
+	Lister  storage.ContentLister
+	Metrics *Metrics
+
+	// Chunks provides access to the chunk store of an owner. The chunks of incremental backups count towards the
+	// size of the backups which reference them exclusively, and chunks no backup references anymore are deleted.
+	// If nil, chunks are neither accounted for nor deleted.
+	Chunks func(ctx context.Context, owner string) (storage.ChunkAccess, error)
+
+	// ReportStorage charges the storage retained at the end of a run for the last complete retention interval.
+	// Each run reports the interval which ended last, hence an interval can be reported more than once.
+	// If nil, storage is not charged.
+	ReportStorage func(ctx context.Context, from, to time.Time, storage []WorkspaceStorage) error
+
+	now func() time.Time
+}
+
+// NewEnforcer creates a new retention enforcer. The storage must be able to list its content.
+func NewEnforcer(cfg config.RetentionConfig, access storage.PresignedAccess) (*Enforcer, error) {
+	lister, ok := access.(storage.ContentLister)
+	if !ok {
+		return nil, xerrors.Errorf("storage %T does not support listing its content", access)
+	}
+	if cfg.KeepInstanceBackups < 0 {
+		return nil, xerrors.Errorf("keepInstanceBackups must not be negative")
+	}
+	if cfg.MaxBytesPerUser < 0 {
+		return nil, xerrors.Errorf("maxBytesPerUser must not be negative")
+	}
+	if cfg.SnapshotMaxAge < 0 {
+		return nil, xerrors.Errorf("snapshotMaxAge must not be negative")
+	}
+
+	return &Enforcer{
+		Config:  cfg,
+		Storage: access,
+		Lister:  lister,
+		Metrics: NewMetrics(),
+		now:     time.Now,
+	}, nil
+}
+
+// Start enforces the retention rules in the configured interval until the context is canceled
+func (e *Enforcer) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.interval())
+	defer ticker.Stop()
+	for {
+		report, err := e.Enforce(ctx)
+		if err != nil {
+			log.WithError(err).Error("cannot enforce content retention")
+		} else {
+			log.WithField("dryRun", report.DryRun).
+				WithField("deletions", len(report.Deletions)).
+				WithField("reclaimedBytes", report.ReclaimedBytes).
+				WithField("failedOwners", len(report.FailedOwners)).
+				WithField("workspaces", len(report.Storage)).
+				Info("content retention run complete")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Enforcer) interval() time.Duration {
+	interval := time.Duration(e.Config.Interval)
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return interval
+}
+
+// Enforce applies the retention rules to the content of all owners once. In dry-run mode
+// the report lists the content which would be deleted, but nothing is deleted.
+func (e *Enforcer) Enforce(ctx context.Context) (report *Report, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "retention.Enforce")
+	defer tracing.FinishSpan(span, &err)
+
+	report = &Report{
+		DryRun:    e.Config.DryRun,
+		Started:   e.now(),
+		Deletions: []Deletion{},
+		Storage:   []WorkspaceStorage{},
+	}
+	defer func() {
+		outcome := "success"
+		if err != nil {
+			outcome = "error"
+		} else if len(report.FailedOwners) > 0 {
+			outcome = "partial"
+		} else {
+			e.Metrics.LastSuccessTimestamp.SetToCurrentTime()
+		}
+		e.Metrics.RunsTotal.WithLabelValues(outcome).Inc()
+		e.Metrics.RunDurationSeconds.Observe(time.Since(report.Started).Seconds())
+		report.Duration = time.Since(report.Started).String()
+	}()
+
+	owners, err := e.Lister.Owners(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list owners: %w", err)
+	}
+	report.Owners = len(owners)
+
+	reclaimable := make(map[Kind]int64)
+	for _, owner := range owners {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		dels, retained, err := e.EnforceOwner(ctx, owner)
+		if err != nil {
+			log.WithError(err).WithField("owner", owner).Warn("cannot enforce content retention for owner")
+			e.Metrics.OwnerFailuresTotal.Inc()
+			report.FailedOwners = append(report.FailedOwners, owner)
+		}
+		for _, d := range dels {
+			reclaimable[d.Kind] += d.Size
+			report.ReclaimedBytes += d.Size
+		}
+		report.Deletions = append(report.Deletions, dels...)
+		report.Storage = append(report.Storage, retained...)
+	}
+	for _, k := range []Kind{KindInstanceBackup, KindSnapshot} {
+		e.Metrics.ReclaimableBytes.WithLabelValues(string(k)).Set(float64(reclaimable[k]))
+	}
+
+	if e.ReportStorage != nil {
+		// charging whole intervals makes reports of the same interval by several runs or replicas idempotent
+		interval := e.interval()
+		to := report.Started.Truncate(interval)
+		err = e.ReportStorage(ctx, to.Add(-interval), to, report.Storage)
+		if err != nil {
+			return report, xerrors.Errorf("cannot report storage usage: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// EnforceOwner applies the retention rules to the content of a single owner and returns the content it deleted,
+// as well as the size of the content it retained per workspace. In dry-run mode the content which would be deleted is returned.
+func (e *Enforcer) EnforceOwner(ctx context.Context, owner string) (dels []Deletion, retained []WorkspaceStorage, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "retention.EnforceOwner")
+	span.SetTag("owner", owner)
+	defer tracing.FinishSpan(span, &err)
+
+	bucket := e.Storage.Bucket(owner)
+	prefix := e.Lister.BackupPrefix(owner)
+	objs, err := e.Lister.ListBucketObjects(ctx, bucket, prefix)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot list content: %w", err)
+	}
+	if len(objs) == 0 {
+		return nil, nil, nil
+	}
+
+	candidates := groupContent(owner, prefix, objs)
+
+	var (
+		ca   storage.ChunkAccess
+		refs *chunkRefs
+	)
+	if e.Chunks != nil {
+		ca, err = e.Chunks(ctx, owner)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("cannot access chunk store: %w", err)
+		}
+		refs, err = e.readChunkRefs(ctx, bucket, ca, objs)
+		if err != nil {
+			return nil, nil, err
+		}
+		refs.attribute(candidates)
+	}
+
+	dels = e.selectDeletions(candidates)
+
+	if e.Config.MaxBytesPerUser > 0 {
+		usage, err := e.Storage.DiskUsage(ctx, bucket, prefix)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("cannot compute disk usage: %w", err)
+		}
+		if refs != nil {
+			usage += refs.total
+		}
+		dels = e.selectCapDeletions(candidates, dels, usage)
+	}
+	if e.Config.DryRun {
+		return dels, retainedStorage(owner, prefix, objs, refs, nil), nil
+	}
+
+	var deleted []Deletion
+	for _, d := range dels {
+		err = e.delete(ctx, bucket, d)
+		if err != nil {
+			return deleted, nil, err
+		}
+		deleted = append(deleted, d)
+	}
+	retained = retainedStorage(owner, prefix, objs, refs, deleted)
+
+	if ca != nil {
+		// the chunks of the manifests we deleted (or of backups which were replaced) are not referenced anymore
+		n, err := storage.CollectGarbageChunks(ctx, ca, storage.DefaultChunkGCGracePeriod)
+		if err != nil {
+			return deleted, retained, xerrors.Errorf("cannot collect garbage chunks: %w", err)
+		}
+		e.Metrics.DeletedObjectsTotal.WithLabelValues(string(KindChunk)).Add(float64(n))
+	}
+	return deleted, retained, nil
+}
+
+// chunkRefs records which chunks the incremental backups of an owner reference
+type chunkRefs struct {
+	// sizes are the sizes of all chunks in the chunk store
+	sizes map[string]int64
+	// total is the size of all chunks in the chunk store
+	total int64
+	// referenced lists the chunks each incremental backup manifest references, each of them once
+	referenced map[string][]string
+}
+
+// readChunkRefs reads the manifests of all incremental backups among the objects
+func (e *Enforcer) readChunkRefs(ctx context.Context, bucket string, ca storage.ChunkAccess, objs []storage.ObjectInfo) (*chunkRefs, error) {
+	chunks, err := e.Lister.ListBucketObjects(ctx, bucket, ca.ChunkStorePrefix()+"/")
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list chunks: %w", err)
+	}
+	res := &chunkRefs{
+		sizes:      make(map[string]int64, len(chunks)),
+		referenced: make(map[string][]string),
+	}
+	for _, c := range chunks {
+		res.sizes[c.Name] = c.Size
+		res.total += c.Size
+	}
+
+	for _, obj := range objs {
+		if !strings.HasSuffix(obj.Name, storage.IncrementalManifestSuffix) {
+			continue
+		}
+
+		rc, err := ca.ReadObject(ctx, obj.Name)
+		if err == storage.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read manifest %s: %w", obj.Name, err)
+		}
+		manifest, err := storage.ParseIncrementalManifest(rc)
+		rc.Close()
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read manifest %s: %w", obj.Name, err)
+		}
+
+		seen := make(map[string]struct{}, len(manifest.Chunks))
+		for _, c := range manifest.Chunks {
+			chunk := manifest.ChunkObject(c.Digest)
+			if _, ok := seen[chunk]; ok {
+				continue
+			}
+			seen[chunk] = struct{}{}
+			res.referenced[obj.Name] = append(res.referenced[obj.Name], chunk)
+		}
+	}
+	return res, nil
+}
+
+// attribute adds the size of the chunks which only a single incremental backup references to the size of that backup,
+// as deleting it reclaims them. Chunks shared by several backups are not attributed to any of them.
+func (r *chunkRefs) attribute(candidates []Deletion) {
+	refs := make(map[string]int)
+	for _, chunks := range r.referenced {
+		for _, chunk := range chunks {
+			refs[chunk]++
+		}
+	}
+
+	for i := range candidates {
+		for _, obj := range candidates[i].Objects {
+			for _, chunk := range r.referenced[obj] {
+				if refs[chunk] == 1 {
+					candidates[i].Size += r.sizes[chunk]
+				}
+			}
+		}
+	}
+}
+
+// retainedStorage sums up the size of the objects of each workspace which were not deleted. The chunks of incremental backups count
+// towards the workspaces whose remaining backups reference them, split evenly among the backups. Chunks no backup references anymore
+// are garbage and not counted.
+func retainedStorage(owner, prefix string, objs []storage.ObjectInfo, refs *chunkRefs, deleted []Deletion) []WorkspaceStorage {
+	gone := make(map[string]struct{})
+	for _, d := range deleted {
+		for _, obj := range d.Objects {
+			gone[obj] = struct{}{}
+		}
+	}
+
+	var (
+		sizes     = make(map[string]float64)
+		manifests = make(map[string]string)
+		chunkRefs = make(map[string]int)
+	)
+	for _, obj := range objs {
+		if _, ok := gone[obj.Name]; ok {
+			continue
+		}
+		ws, _, found := strings.Cut(strings.TrimPrefix(obj.Name, prefix), "/")
+		if !found || ws == "" {
+			continue
+		}
+		sizes[ws] += float64(obj.Size)
+
+		if refs == nil {
+			continue
+		}
+		if chunks, ok := refs.referenced[obj.Name]; ok {
+			manifests[obj.Name] = ws
+			for _, chunk := range chunks {
+				chunkRefs[chunk]++
+			}
+		}
+	}
+	for manifest, ws := range manifests {
+		for _, chunk := range refs.referenced[manifest] {
+			sizes[ws] += float64(refs.sizes[chunk]) / float64(chunkRefs[chunk])
+		}
+	}
+
+	res := make([]WorkspaceStorage, 0, len(sizes))
+	for ws, size := range sizes {
+		res = append(res, WorkspaceStorage{Owner: owner, Workspace: ws, Bytes: int64(math.Round(size))})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Workspace < res[j].Workspace })
+	return res
+}
+
+func (e *Enforcer) delete(ctx context.Context, bucket string, d Deletion) error {
+	for _, obj := range d.Objects {
+		err := e.Storage.DeleteObject(ctx, bucket, &storage.DeleteObjectQuery{Name: obj})
+		if err != nil && err != storage.ErrNotFound {
+			return xerrors.Errorf("cannot delete %s: %w", obj, err)
+		}
+		e.Metrics.DeletedObjectsTotal.WithLabelValues(string(d.Kind)).Inc()
+	}
+	e.Metrics.ReclaimedBytesTotal.WithLabelValues(string(d.Kind)).Add(float64(d.Size))
+
+	log.WithField("owner", d.Owner).
+		WithField("workspaceId", d.Workspace).
+		WithField("kind", d.Kind).
+		WithField("name", d.Name).
+		WithField("size", d.Size).
+		WithField("reason", d.Reason).
+		Debug("deleted content")
+	return nil
+}
+
+// selectDeletions applies the per-workspace retention rules
+func (e *Enforcer) selectDeletions(candidates []Deletion) (dels []Deletion) {
+	var (
+		keep      = e.Config.KeepInstanceBackups
+		maxAge    = time.Duration(e.Config.SnapshotMaxAge)
+		instances = make(map[string]int)
+	)
+	for _, c := range candidates {
+		switch c.Kind {
+		case KindInstanceBackup:
+			// candidates are sorted newest first per workspace
+			instances[c.Workspace]++
+			if keep > 0 && instances[c.Workspace] > keep {
+				c.Reason = ReasonKeepInstanceBackups
+				dels = append(dels, c)
+			}
+		case KindSnapshot:
+			if maxAge > 0 && e.now().Sub(c.Updated) > maxAge {
+				c.Reason = ReasonSnapshotMaxAge
+				dels = append(dels, c)
+			}
+		}
+	}
+	return dels
+}
+
+// selectCapDeletions deletes the oldest content until the usage is below the cap. The latest instance backup
+// of a workspace is never selected.
+func (e *Enforcer) selectCapDeletions(candidates []Deletion, dels []Deletion, usage int64) []Deletion {
+	selected := make(map[string]struct{}, len(dels))
+	for _, d := range dels {
+		selected[d.Workspace+"/"+d.Name] = struct{}{}
+		usage -= d.Size
+	}
+	if usage <= e.Config.MaxBytesPerUser {
+		return dels
+	}
+
+	var (
+		evictable []Deletion
+		latest    = make(map[string]struct{})
+	)
+	for _, c := range candidates {
+		if _, ok := selected[c.Workspace+"/"+c.Name]; ok {
+			continue
+		}
+		if c.Kind == KindInstanceBackup {
+			if _, ok := latest[c.Workspace]; !ok {
+				latest[c.Workspace] = struct{}{}
+				continue
+			}
+		}
+		evictable = append(evictable, c)
+	}
+	sort.SliceStable(evictable, func(i, j int) bool { return evictable[i].Updated.Before(evictable[j].Updated) })
+
+	for _, c := range evictable {
+		if usage <= e.Config.MaxBytesPerUser {
+			break
+		}
+		c.Reason = ReasonMaxBytesPerUser
+		dels = append(dels, c)
+		usage -= c.Size
+	}
+	return dels
+}
+
+// groupContent groups the objects below an owner's backup prefix into instance backups and snapshots.
+// Other objects, e.g. the latest backup of a workspace, are not subject to retention. The result is sorted
+// by workspace and newest first.
+func groupContent(owner, prefix string, objs []storage.ObjectInfo) []Deletion {
+	idx := make(map[string]int)
+	var res []Deletion
+	for _, obj := range objs {
+		segs := strings.Split(strings.TrimPrefix(obj.Name, prefix), "/")
+		if len(segs) < 2 || segs[0] == "" {
+			continue
+		}
+
+		var (
+			ws   = segs[0]
+			kind Kind
+			name string
+		)
+		switch {
+		case len(segs) >= 4 && segs[1] == "instances":
+			kind, name = KindInstanceBackup, segs[2]
+		case len(segs) == 2 && snapshotObjectRegexp.MatchString(segs[1]):
+			// a snapshot and its incremental backup manifest are deleted together
+			kind, name = KindSnapshot, strings.TrimSuffix(segs[1], storage.IncrementalManifestSuffix)
+		default:
+			continue
+		}
+
+		key := ws + "/" + name
+		i, ok := idx[key]
+		if !ok {
+			i = len(res)
+			idx[key] = i
+			res = append(res, Deletion{Owner: owner, Workspace: ws, Kind: kind, Name: name})
+		}
+		res[i].Objects = append(res[i].Objects, obj.Name)
+		res[i].Size += obj.Size
+		if obj.Updated.After(res[i].Updated) {
+			res[i].Updated = obj.Updated
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Workspace != res[j].Workspace {
+			return res[i].Workspace < res[j].Workspace
+		}
+		return res[i].Updated.After(res[j].Updated)
+	})
+	return res
+}
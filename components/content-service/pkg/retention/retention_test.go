@@ -0,0 +1,366 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+func TestEnforce(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	type object struct {
+		Owner string
+		Name  string
+		Size  int
+		Age   time.Duration
+	}
+	// ws1 has three instance backups, ws2 only one, and there's a young and an old snapshot
+	objects := []object{
+		{"u1", "workspaces/ws1/full.tar", 100, 1 * day},
+		{"u1", "workspaces/ws1/instances/i1/full.tar", 100, 30 * day},
+		{"u1", "workspaces/ws1/instances/i1/wsfull.json", 10, 30 * day},
+		{"u1", "workspaces/ws1/instances/i2/full.tar", 100, 20 * day},
+		{"u1", "workspaces/ws1/instances/i3/full.tar", 100, 10 * day},
+		{"u1", "workspaces/ws1/snapshot-1.tar", 50, 40 * day},
+		{"u1", "workspaces/ws1/snapshot-2.tar", 50, 1 * day},
+		{"u1", "workspaces/ws2/instances/i4/full.tar", 100, 50 * day},
+		{"u2", "workspaces/ws3/instances/i5/full.tar", 100, 60 * day},
+		{"u2", "workspaces/ws3/instances/i6/full.tar", 100, 5 * day},
+	}
+
+	tests := []struct {
+		Name             string
+		Config           config.RetentionConfig
+		ExpectedDeletion []string
+		ExpectedBytes    int64
+	}{
+		{
+			Name:   "no rules",
+			Config: config.RetentionConfig{},
+		},
+		{
+			Name:             "keep instance backups",
+			Config:           config.RetentionConfig{KeepInstanceBackups: 1},
+			ExpectedDeletion: []string{"u1/ws1/i2", "u1/ws1/i1", "u2/ws3/i5"},
+			ExpectedBytes:    310,
+		},
+		{
+			Name:             "snapshot max age",
+			Config:           config.RetentionConfig{SnapshotMaxAge: util.Duration(7 * day)},
+			ExpectedDeletion: []string{"u1/ws1/snapshot-1.tar"},
+			ExpectedBytes:    50,
+		},
+		{
+			Name:             "dry run",
+			Config:           config.RetentionConfig{SnapshotMaxAge: util.Duration(7 * day), DryRun: true},
+			ExpectedDeletion: []string{"u1/ws1/snapshot-1.tar"},
+			ExpectedBytes:    50,
+		},
+		{
+			// u1 uses 610 bytes. The latest instance backups i3 and i4 and the full backup are never deleted.
+			Name:             "max bytes per user",
+			Config:           config.RetentionConfig{MaxBytesPerUser: 500},
+			ExpectedDeletion: []string{"u1/ws1/snapshot-1.tar", "u1/ws1/i1"},
+			ExpectedBytes:    160,
+		},
+		{
+			Name:             "max bytes per user after other rules",
+			Config:           config.RetentionConfig{MaxBytesPerUser: 400, KeepInstanceBackups: 2},
+			ExpectedDeletion: []string{"u1/ws1/i1", "u1/ws1/snapshot-1.tar", "u1/ws1/i2"},
+			ExpectedBytes:    260,
+		},
+		{
+			Name:             "cap cannot be reached",
+			Config:           config.RetentionConfig{MaxBytesPerUser: 1},
+			ExpectedDeletion: []string{"u1/ws1/snapshot-1.tar", "u1/ws1/i1", "u1/ws1/i2", "u1/ws1/snapshot-2.tar", "u2/ws3/i5"},
+			ExpectedBytes:    410,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cfg := &config.FilesystemConfig{
+				Root:       t.TempDir(),
+				BaseURL:    "http://localhost/storage",
+				SigningKey: "test-signing-key",
+			}
+			access, err := storage.NewPresignedFilesystemAccess(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, obj := range objects {
+				fn := filepath.Join(cfg.Root, access.Bucket(obj.Owner), obj.Name)
+				err := os.MkdirAll(filepath.Dir(fn), 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = os.WriteFile(fn, make([]byte, obj.Size), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = os.Chtimes(fn, now.Add(-obj.Age), now.Add(-obj.Age))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			enforcer, err := NewEnforcer(test.Config, access)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enforcer.now = func() time.Time { return now }
+
+			report, err := enforcer.Enforce(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var deleted []string
+			for _, d := range report.Deletions {
+				deleted = append(deleted, d.Owner+"/"+d.Workspace+"/"+d.Name)
+			}
+			if diff := cmp.Diff(test.ExpectedDeletion, deleted); diff != "" {
+				t.Errorf("unexpected deletions (-want +got):\n%s", diff)
+			}
+			if report.ReclaimedBytes != test.ExpectedBytes {
+				t.Errorf("reclaimed bytes are %d but expected %d", report.ReclaimedBytes, test.ExpectedBytes)
+			}
+
+			var remaining []string
+			for _, obj := range objects {
+				_, err := os.Stat(filepath.Join(cfg.Root, access.Bucket(obj.Owner), obj.Name))
+				if err == nil {
+					remaining = append(remaining, obj.Owner+"/"+obj.Name)
+				}
+			}
+			var expectedRemaining []string
+			for _, obj := range objects {
+				if !test.Config.DryRun && contains(test.ExpectedDeletion, contentKey(obj.Owner, obj.Name)) {
+					continue
+				}
+				expectedRemaining = append(expectedRemaining, obj.Owner+"/"+obj.Name)
+			}
+			sort.Strings(remaining)
+			sort.Strings(expectedRemaining)
+			if diff := cmp.Diff(expectedRemaining, remaining); diff != "" {
+				t.Errorf("unexpected remaining objects (-want +got):\n%s", diff)
+			}
+
+			retained := make(map[string]int64)
+			for _, obj := range objects {
+				if contains(expectedRemaining, obj.Owner+"/"+obj.Name) {
+					retained[obj.Owner+"/"+strings.Split(obj.Name, "/")[1]] += int64(obj.Size)
+				}
+			}
+			var expectedStorage []WorkspaceStorage
+			for _, owner := range []string{"u1", "u2"} {
+				for _, ws := range []string{"ws1", "ws2", "ws3"} {
+					if size, ok := retained[owner+"/"+ws]; ok {
+						expectedStorage = append(expectedStorage, WorkspaceStorage{Owner: owner, Workspace: ws, Bytes: size})
+					}
+				}
+			}
+			if diff := cmp.Diff(expectedStorage, report.Storage); diff != "" {
+				t.Errorf("unexpected retained storage (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnforceIncremental(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	day := 24 * time.Hour
+
+	storageCfg := config.StorageConfig{
+		Stage: config.StageProduction,
+		Kind:  config.FilesystemStorage,
+		FilesystemConfig: &config.FilesystemConfig{
+			Root:       t.TempDir(),
+			BaseURL:    "http://localhost/storage",
+			SigningKey: "test-signing-key",
+		},
+	}
+	access, err := storage.NewPresignedFilesystemAccess(storageCfg.FilesystemConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := func(ctx context.Context, owner string) (storage.ChunkAccess, error) {
+		da, err := storage.NewDirectAccess(&storageCfg)
+		if err != nil {
+			return nil, err
+		}
+		err = da.Init(ctx, owner, "retention", "")
+		if err != nil {
+			return nil, err
+		}
+		return da.(storage.ChunkAccess), nil
+	}
+	ca, err := chunks(ctx, "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	age := func(obj string, age time.Duration) {
+		fn := filepath.Join(storageCfg.FilesystemConfig.Root, access.Bucket("u1"), obj)
+		err := os.Chtimes(fn, now.Add(-age), now.Add(-age))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	upload := func(obj string, content []byte, a time.Duration) {
+		err := ca.UploadObject(ctx, obj, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		age(obj, a)
+	}
+
+	// the old snapshot shares chunk b with the latest backup, but references chunk a exclusively
+	content := map[string][]byte{
+		"a": make([]byte, 1000),
+		"b": make([]byte, 2000),
+		"c": make([]byte, 3000),
+	}
+	content["a"][0], content["b"][0], content["c"][0] = 'a', 'b', 'c'
+	chunkObject := func(name string) string {
+		m := storage.IncrementalManifest{ChunkPrefix: ca.ChunkStorePrefix()}
+		return m.ChunkObject(digest.FromBytes(content[name]))
+	}
+	manifest := func(names ...string) []byte {
+		m := storage.IncrementalManifest{Version: 1, ChunkPrefix: ca.ChunkStorePrefix()}
+		for _, n := range names {
+			m.Chunks = append(m.Chunks, storage.IncrementalChunk{Digest: digest.FromBytes(content[n]), Size: int64(len(content[n]))})
+			m.Size += int64(len(content[n]))
+		}
+		fc, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fc
+	}
+	for name, c := range content {
+		upload(chunkObject(name), c, 30*day)
+	}
+	snapshotManifest := manifest("a", "b")
+	upload("workspaces/ws1/full.tar.chunks.json", manifest("b", "c"), 1*day)
+	upload("workspaces/ws1/snapshot-1.tar.chunks.json", snapshotManifest, 40*day)
+
+	enforcer, err := NewEnforcer(config.RetentionConfig{SnapshotMaxAge: util.Duration(7 * day)}, access)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enforcer.Chunks = chunks
+	var (
+		reportedFrom, reportedTo time.Time
+		reportedStorage          []WorkspaceStorage
+	)
+	enforcer.ReportStorage = func(ctx context.Context, from, to time.Time, storage []WorkspaceStorage) error {
+		reportedFrom, reportedTo, reportedStorage = from, to, storage
+		return nil
+	}
+
+	report, err := enforcer.Enforce(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var deleted []string
+	for _, d := range report.Deletions {
+		deleted = append(deleted, d.Owner+"/"+d.Workspace+"/"+d.Name)
+	}
+	if diff := cmp.Diff([]string{"u1/ws1/snapshot-1.tar"}, deleted); diff != "" {
+		t.Errorf("unexpected deletions (-want +got):\n%s", diff)
+	}
+	if expected := int64(len(snapshotManifest) + 1000); report.ReclaimedBytes != expected {
+		t.Errorf("reclaimed bytes are %d but expected %d", report.ReclaimedBytes, expected)
+	}
+
+	// the remaining backup references chunks b and c, a is garbage
+	expectedStorage := []WorkspaceStorage{{Owner: "u1", Workspace: "ws1", Bytes: int64(len(manifest("b", "c")) + 5000)}}
+	if diff := cmp.Diff(expectedStorage, report.Storage); diff != "" {
+		t.Errorf("unexpected retained storage (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedStorage, reportedStorage); diff != "" {
+		t.Errorf("unexpected reported storage (-want +got):\n%s", diff)
+	}
+	if expected := report.Started.Truncate(day); !reportedTo.Equal(expected) || !reportedFrom.Equal(expected.Add(-day)) {
+		t.Errorf("reported storage for %s - %s, expected the day before %s", reportedFrom, reportedTo, expected)
+	}
+
+	for name, expectExists := range map[string]bool{"a": false, "b": true, "c": true} {
+		_, err := ca.ObjectUpdated(ctx, chunkObject(name))
+		if exists := err == nil; exists != expectExists {
+			t.Errorf("chunk %s exists: %v, expected: %v (%v)", name, exists, expectExists, err)
+		}
+	}
+}
+
+func TestRetainedStorage(t *testing.T) {
+	prefix := "workspaces/"
+	objs := []storage.ObjectInfo{
+		{Name: "workspaces/ws1/full.tar.chunks.json", Size: 10},
+		{Name: "workspaces/ws2/full.tar.chunks.json", Size: 20},
+		{Name: "workspaces/ws2/snapshot-1.tar.chunks.json", Size: 5},
+	}
+	refs := &chunkRefs{
+		sizes: map[string]int64{"chunks/c1": 100, "chunks/c2": 50, "chunks/c3": 1000},
+		referenced: map[string][]string{
+			"workspaces/ws1/full.tar.chunks.json":       {"chunks/c1"},
+			"workspaces/ws2/full.tar.chunks.json":       {"chunks/c1", "chunks/c2"},
+			"workspaces/ws2/snapshot-1.tar.chunks.json": {"chunks/c3"},
+		},
+	}
+	deleted := []Deletion{{Workspace: "ws2", Kind: KindSnapshot, Objects: []string{"workspaces/ws2/snapshot-1.tar.chunks.json"}}}
+
+	// c1 is split between both workspaces, c3 is garbage once the snapshot is deleted
+	expected := []WorkspaceStorage{
+		{Owner: "u1", Workspace: "ws1", Bytes: 60},
+		{Owner: "u1", Workspace: "ws2", Bytes: 120},
+	}
+	if diff := cmp.Diff(expected, retainedStorage("u1", prefix, objs, refs, deleted)); diff != "" {
+		t.Errorf("unexpected retained storage (-want +got):\n%s", diff)
+	}
+}
+
+// contentKey mirrors the owner/workspace/name notation of the expected deletions
+func contentKey(owner, obj string) string {
+	segs := strings.Split(obj, "/")
+	if len(segs) >= 4 && segs[2] == "instances" {
+		return owner + "/" + segs[1] + "/" + segs[3]
+	}
+	return owner + "/" + segs[1] + "/" + segs[len(segs)-1]
+}
+
+func contains(s []string, e string) bool {
+	for _, x := range s {
+		if x == e {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewEnforcer(t *testing.T) {
+	_, err := NewEnforcer(config.RetentionConfig{}, &storage.PresignedNoopStorage{})
+	if err == nil {
+		t.Error("expected an error for a storage which cannot list its content")
+	}
+}
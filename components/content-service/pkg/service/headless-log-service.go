@@ -6,7 +6,17 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
@@ -21,12 +31,19 @@ import (
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 )
 
+// headlessLogURLExpiry is how long the URLs to decrypted headless logs remain valid
+const headlessLogURLExpiry = 30 * time.Minute
+
 // HeadlessLogService implements LogServiceServer
 type HeadlessLogService struct {
 	cfg       config.StorageConfig
 	s         storage.PresignedAccess
 	daFactory func(cfg *config.StorageConfig) (storage.DirectAccess, error)
 
+	// signingKey signs the URLs to decrypted headless logs if the storage is encrypted
+	signingKey []byte
+	now        func() time.Time
+
 	api.UnimplementedHeadlessLogServiceServer
 }
 
@@ -39,11 +56,32 @@ func NewHeadlessLogService(cfg config.StorageConfig) (res *HeadlessLogService, e
 	daFactory := func(cfg *config.StorageConfig) (storage.DirectAccess, error) {
 		return storage.NewDirectAccess(cfg)
 	}
-	return &HeadlessLogService{
+	res = &HeadlessLogService{
 		cfg:       cfg,
 		s:         s,
 		daFactory: daFactory,
-	}, nil
+		now:       time.Now,
+	}
+
+	if enc := cfg.Encryption; enc != nil {
+		if enc.HeadlessLogsBaseURL == "" {
+			return nil, xerrors.Errorf("encrypted storage requires the headless logs base URL")
+		}
+		key := enc.SigningKey
+		if enc.SigningKeyFile != "" {
+			fc, err := os.ReadFile(enc.SigningKeyFile)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot read headless log signing key: %w", err)
+			}
+			key = strings.TrimSpace(string(fc))
+		}
+		if key == "" {
+			return nil, xerrors.Errorf("encrypted storage requires a headless log signing key")
+		}
+		res.signingKey = []byte(key)
+	}
+
+	return res, nil
 }
 
 // LogDownloadURL provides a URL from where the content of a workspace log stream can be downloaded from
@@ -68,11 +106,108 @@ func (ls *HeadlessLogService) LogDownloadURL(ctx context.Context, req *api.LogDo
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
+	if ls.signingKey != nil {
+		// encrypted logs cannot be downloaded from the storage directly, hence we serve them ourselves
+		return &api.LogDownloadURLResponse{
+			Url: ls.headlessLogURL(req),
+		}, nil
+	}
+
 	return &api.LogDownloadURLResponse{
 		Url: info.URL,
 	}, nil
 }
 
+func (ls *HeadlessLogService) headlessLogURL(req *api.LogDownloadURLRequest) string {
+	expires := ls.now().Add(headlessLogURLExpiry).Unix()
+	p := strings.Join([]string{req.OwnerId, req.WorkspaceId, req.InstanceId, req.TaskId}, "/")
+
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", ls.sign(p, expires))
+	return strings.TrimSuffix(ls.cfg.Encryption.HeadlessLogsBaseURL, "/") + "/" + p + "?" + q.Encode()
+}
+
+func (ls *HeadlessLogService) sign(path string, expires int64) string {
+	mac := hmac.New(sha256.New, ls.signingKey)
+	fmt.Fprintf(mac, "%s\n%d", path, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeHTTP serves the decrypted headless logs under the URLs produced by LogDownloadURL.
+// The handler expects requests with the base URL path already stripped.
+func (ls *HeadlessLogService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if ls.signingKey == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/")
+	segments := strings.Split(p, "/")
+	if len(segments) != 4 {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	for _, s := range segments {
+		if s == "" || s == "." || s == ".." {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+	}
+
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	sig, serr := hex.DecodeString(r.URL.Query().Get("signature"))
+	expected, _ := hex.DecodeString(ls.sign(p, expires))
+	if err != nil || serr != nil || ls.now().Unix() > expires || !hmac.Equal(sig, expected) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	ownerID, workspaceID, instanceID, taskID := segments[0], segments[1], segments[2], segments[3]
+	log := log.WithFields(log.OWI(ownerID, workspaceID, instanceID)).WithField("taskId", taskID)
+
+	da, err := ls.daFactory(&ls.cfg)
+	if err != nil {
+		log.WithError(err).Error("cannot use configured storage")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	err = da.Init(r.Context(), ownerID, workspaceID, instanceID)
+	if err != nil {
+		log.WithError(err).Error("cannot use configured storage")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	ea, ok := da.(storage.EncryptedAccess)
+	if !ok {
+		log.Error("configured storage does not support encryption")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	rc, err := ea.DecryptedObject(r.Context(), ls.s.Bucket(ownerID), ls.s.InstanceObject(ownerID, workspaceID, instanceID, logs.UploadedHeadlessLogPath(taskID)))
+	if err == storage.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("cannot read headless log")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err = io.Copy(w, rc)
+	if err != nil {
+		log.WithError(err).Warn("cannot serve headless log")
+	}
+}
+
 // ListLogs returns a list of taskIds for the specified workspace instance
 func (ls *HeadlessLogService) ListLogs(ctx context.Context, req *api.ListLogsRequest) (resp *api.ListLogsResponse, err error) {
 	da, err := ls.daFactory(&ls.cfg)
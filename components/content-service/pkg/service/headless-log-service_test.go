@@ -6,7 +6,15 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -87,3 +95,81 @@ func TestListLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestEncryptedLogDownload(t *testing.T) {
+	ctx := context.Background()
+
+	keyringFile := filepath.Join(t.TempDir(), "keyring.json")
+	err := os.WriteFile(keyringFile, []byte(fmt.Sprintf(`{"primary":"key-1","keys":[{"id":"key-1","key":"%s"}]}`, base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.StorageConfig{
+		Stage: config.StageProduction,
+		Kind:  config.FilesystemStorage,
+		FilesystemConfig: &config.FilesystemConfig{
+			Root:       t.TempDir(),
+			BaseURL:    "http://content-service:8080/storage",
+			SigningKey: "storage-secret",
+		},
+		Encryption: &config.EncryptionConfig{
+			KeyringFile:         keyringFile,
+			HeadlessLogsBaseURL: "http://content-service:8080/headless-logs",
+			SigningKey:          "log-secret",
+		},
+	}
+
+	da, err := storage.NewDirectAccess(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = da.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "log")
+	err = os.WriteFile(src, []byte("hello world"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = da.UploadInstance(ctx, src, "logs/task-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, err := NewHeadlessLogService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := svc.LogDownloadURL(ctx, &api.LogDownloadURLRequest{
+		OwnerId:     "owner",
+		WorkspaceId: "workspace",
+		InstanceId:  "instance",
+		TaskId:      "task-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(resp.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(u.Path, "/headless-logs/") {
+		t.Fatalf("expected URL to point to content-service, got %s", resp.Url)
+	}
+	handler := http.StripPrefix("/headless-logs", svc)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	body, _ := io.ReadAll(rec.Body)
+	if rec.Code != http.StatusOK || string(body) != "hello world" {
+		t.Errorf("unexpected response: %d %q", rec.Code, string(body))
+	}
+
+	u.Path = strings.Replace(u.Path, "task-1", "task-2", 1)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected forged URL to be rejected, got %d", rec.Code)
+	}
+}
@@ -129,3 +129,33 @@ func (cs *WorkspaceService) WorkspaceSnapshotExists(ctx context.Context, req *ap
 		Exists: exists,
 	}, nil
 }
+
+// DeleteSnapshot deletes the content of a single snapshot
+func (cs *WorkspaceService) DeleteSnapshot(ctx context.Context, req *api.DeleteSnapshotRequest) (resp *api.DeleteSnapshotResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteSnapshot")
+	span.SetTag("name", req.Name)
+	defer tracing.FinishSpan(span, &err)
+
+	bkt, obj, err := storage.ParseSnapshotName(req.Name)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// snapshots are stored next to the backups of a workspace, see storage.DirectAccess.Qualify
+	if !strings.HasPrefix(obj, "workspaces/") || strings.Contains(obj, "..") {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a snapshot", req.Name)
+	}
+
+	for _, name := range []string{obj, obj + storage.IncrementalManifestSuffix} {
+		err = cs.s.DeleteObject(ctx, bkt, &storage.DeleteObjectQuery{Name: name})
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WithField("name", name).Debug("deleting snapshot: NotFound")
+			continue
+		}
+		if err != nil {
+			log.WithError(err).WithField("name", name).Error("error deleting snapshot")
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+	}
+
+	return &api.DeleteSnapshotResponse{}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	storagemock "github.com/gitpod-io/gitpod/content-service/pkg/storage/mock"
+)
+
+func TestDeleteSnapshot(t *testing.T) {
+	tests := []struct {
+		Name          string
+		SnapshotName  string
+		Deletions     []string
+		ExpectedError codes.Code
+	}{
+		{
+			Name:         "snapshot",
+			SnapshotName: "workspaces/amber-baboon-cij4wozf/snapshot-1676454523.tar@gitpod-user-1234",
+			Deletions: []string{
+				"workspaces/amber-baboon-cij4wozf/snapshot-1676454523.tar",
+				"workspaces/amber-baboon-cij4wozf/snapshot-1676454523.tar" + storage.IncrementalManifestSuffix,
+			},
+			ExpectedError: codes.OK,
+		},
+		{
+			Name:          "not qualified",
+			SnapshotName:  "workspaces/amber-baboon-cij4wozf/snapshot-1676454523.tar",
+			ExpectedError: codes.InvalidArgument,
+		},
+		{
+			Name:          "blob",
+			SnapshotName:  "blobs/some-blob@gitpod-user-1234",
+			ExpectedError: codes.InvalidArgument,
+		},
+		{
+			Name:          "escapes the workspaces",
+			SnapshotName:  "workspaces/../blobs/some-blob@gitpod-user-1234",
+			ExpectedError: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := storagemock.NewMockPresignedAccess(ctrl)
+			for i, obj := range test.Deletions {
+				var err error
+				if i > 0 {
+					// snapshots have no incremental manifest
+					err = storage.ErrNotFound
+				}
+				s.EXPECT().DeleteObject(gomock.Any(), "gitpod-user-1234", &storage.DeleteObjectQuery{Name: obj}).Return(err)
+			}
+			svc := WorkspaceService{s: s}
+
+			_, err := svc.DeleteSnapshot(context.Background(), &api.DeleteSnapshotRequest{Name: test.SnapshotName})
+			if code := status.Code(err); code != test.ExpectedError {
+				t.Errorf("unexpected error code %v, expected %v: %v", code, test.ExpectedError, err)
+			}
+		})
+	}
+}
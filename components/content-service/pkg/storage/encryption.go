@@ -0,0 +1,725 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// ObjectAnnotationEncryptionKeyID is the ID of the key-encryption key which wrapped the data key of an encrypted object
+	ObjectAnnotationEncryptionKeyID = "gitpod-encryption-keyid"
+
+	// ObjectAnnotationEncryptionDataKey is the base64 encoded, wrapped data key of an encrypted object
+	ObjectAnnotationEncryptionDataKey = "gitpod-encryption-datakey"
+
+	// ObjectAnnotationEncryptionAlgorithm is the algorithm an encrypted object was encrypted with
+	ObjectAnnotationEncryptionAlgorithm = "gitpod-encryption-algorithm"
+
+	// EncryptionAlgorithm is AES-256-GCM applied to fixed size segments of the object
+	EncryptionAlgorithm = "AES256-GCM-SEGMENTED-V1"
+
+	// DataKeyObjectName is the name of the object which holds the wrapped data key of a workspace
+	DataKeyObjectName = "datakey.json"
+
+	dataKeySize = 32
+
+	encryptionSegmentSize = 64 * 1024
+	encryptionNoncePrefix = 7
+)
+
+var _ DirectAccess = &EncryptedDirectStorage{}
+var _ EncryptedAccess = &EncryptedDirectStorage{}
+var _ ChunkAccess = &EncryptedDirectStorage{}
+
+// Keyring holds the key-encryption keys which wrap the data keys of encrypted objects
+type Keyring struct {
+	// Primary is the ID of the key used to wrap new data keys
+	Primary string
+	keys    map[string]cipher.AEAD
+}
+
+type keyringFile struct {
+	Primary string `json:"primary"`
+	Keys    []struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"keys"`
+}
+
+// LoadKeyring reads a keyring from a JSON file
+func LoadKeyring(fn string) (*Keyring, error) {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read keyring: %w", err)
+	}
+
+	var kf keyringFile
+	err = json.Unmarshal(fc, &kf)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal keyring: %w", err)
+	}
+
+	res := &Keyring{
+		Primary: kf.Primary,
+		keys:    make(map[string]cipher.AEAD, len(kf.Keys)),
+	}
+	for _, k := range kf.Keys {
+		if k.ID == "" {
+			return nil, xerrors.Errorf("keyring contains key without ID")
+		}
+		if _, exists := res.keys[k.ID]; exists {
+			return nil, xerrors.Errorf("keyring contains key %s more than once", k.ID)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(k.Key))
+		if err != nil {
+			return nil, xerrors.Errorf("cannot decode key %s: %w", k.ID, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, xerrors.Errorf("key %s must be %d bytes long", k.ID, dataKeySize)
+		}
+		res.keys[k.ID], err = newAEAD(key)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid key %s: %w", k.ID, err)
+		}
+	}
+	if _, ok := res.keys[res.Primary]; !ok {
+		return nil, xerrors.Errorf("primary key %s is not part of the keyring", res.Primary)
+	}
+
+	return res, nil
+}
+
+// WrapDataKey encrypts a data key using the primary key-encryption key
+func (k *Keyring) WrapDataKey(dataKey []byte) (keyID string, wrapped []byte, err error) {
+	aead := k.keys[k.Primary]
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return k.Primary, aead.Seal(nonce, nonce, dataKey, []byte(k.Primary)), nil
+}
+
+// UnwrapDataKey decrypts a data key which was wrapped by the key-encryption key with the given ID
+func (k *Keyring) UnwrapDataKey(keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, xerrors.Errorf("unknown key-encryption key %s", keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, xerrors.Errorf("wrapped data key is too short")
+	}
+
+	dataKey, err := aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unwrap data key using key %s: %w", keyID, err)
+	}
+	return dataKey, nil
+}
+
+// UnwrapObjectDataKey unwraps the data key recorded in the annotations of an object.
+// Returns nil if the object is not encrypted.
+func (k *Keyring) UnwrapObjectDataKey(annotations map[string]string) ([]byte, error) {
+	alg := getAnnotation(annotations, ObjectAnnotationEncryptionAlgorithm)
+	if alg == "" {
+		return nil, nil
+	}
+	if alg != EncryptionAlgorithm {
+		return nil, xerrors.Errorf("unsupported encryption algorithm %s", alg)
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(getAnnotation(annotations, ObjectAnnotationEncryptionDataKey))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot decode data key: %w", err)
+	}
+	return k.UnwrapDataKey(getAnnotation(annotations, ObjectAnnotationEncryptionKeyID), wrapped)
+}
+
+// getAnnotation looks up an annotation regardless of its case, as some storage backends canonicalize metadata keys
+func getAnnotation(annotations map[string]string, key string) string {
+	if v, ok := annotations[key]; ok {
+		return v
+	}
+	for k, v := range annotations {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewEncryptingWriter encrypts everything written to it using the data key. The content is split into segments
+// which are sealed individually, so that it can be decrypted as a stream. Close must be called to write the final segment.
+func NewEncryptingWriter(dst io.Writer, dataKey []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, encryptionNoncePrefix)
+	_, err = rand.Read(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptingWriter{
+		dst:    dst,
+		aead:   aead,
+		prefix: prefix,
+		buf:    make([]byte, 0, encryptionSegmentSize),
+	}, nil
+}
+
+type encryptingWriter struct {
+	dst     io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	out     []byte
+	closed  bool
+}
+
+func (w *encryptingWriter) Write(p []byte) (n int, err error) {
+	if w.closed {
+		return 0, xerrors.Errorf("write to closed encrypting writer")
+	}
+
+	for len(p) > 0 {
+		// we only seal a full segment once we know that it's not the last one
+		if len(w.buf) == encryptionSegmentSize {
+			err = w.seal(false)
+			if err != nil {
+				return n, err
+			}
+		}
+
+		c := copy(w.buf[len(w.buf):encryptionSegmentSize], p)
+		w.buf = w.buf[:len(w.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (w *encryptingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.seal(true)
+}
+
+func (w *encryptingWriter) seal(last bool) error {
+	if w.counter == 0 {
+		_, err := w.dst.Write(w.prefix)
+		if err != nil {
+			return err
+		}
+	}
+	if w.counter == math.MaxUint32 {
+		return xerrors.Errorf("content is too large to be encrypted")
+	}
+
+	w.out = w.aead.Seal(w.out[:0], segmentNonce(w.prefix, w.counter, last), w.buf, nil)
+	_, err := w.dst.Write(w.out)
+	if err != nil {
+		return err
+	}
+
+	w.counter++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// NewDecryptingReader decrypts content produced by an encrypting writer. The reader fails if the content
+// was tampered with, truncated or reordered.
+func NewDecryptingReader(src io.Reader, dataKey []byte) (io.Reader, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(src)
+	prefix := make([]byte, encryptionNoncePrefix)
+	_, err = io.ReadFull(br, prefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+
+	return &decryptingReader{
+		src:    br,
+		aead:   aead,
+		prefix: prefix,
+		in:     make([]byte, encryptionSegmentSize+aead.Overhead()),
+	}, nil
+}
+
+type decryptingReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	in      []byte
+	out     []byte
+	pending []byte
+	done    bool
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		err := r.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *decryptingReader) open() error {
+	n, err := io.ReadFull(r.src, r.in)
+	var last bool
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		last = true
+	} else if err != nil {
+		return err
+	} else {
+		_, err = r.src.Peek(1)
+		if err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	r.out, err = r.aead.Open(r.out[:0], segmentNonce(r.prefix, r.counter, last), r.in[:n], nil)
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt segment %d: %w", r.counter, err)
+	}
+
+	r.counter++
+	r.pending = r.out
+	r.done = last
+	return nil
+}
+
+func segmentNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, encryptionNoncePrefix+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encryptionNoncePrefix:], counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// newEncryptedDirectAccess wraps a storage backend so that backups and snapshots are encrypted client-side
+func newEncryptedDirectAccess(delegate DirectAccess, cfg *config.EncryptionConfig) (*EncryptedDirectStorage, error) {
+	objects, ok := delegate.(encryptableAccess)
+	if !ok {
+		return nil, xerrors.Errorf("storage does not support encryption")
+	}
+	keyring, err := LoadKeyring(cfg.KeyringFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedDirectStorage{
+		DirectAccess: delegate,
+		Keyring:      keyring,
+		objects:      objects,
+	}, nil
+}
+
+type encryptableAccess interface {
+	ChunkAccess
+	ObjectReader
+}
+
+// EncryptedDirectStorage encrypts backups and snapshots using a per-workspace data key, which in turn is wrapped
+// by a key-encryption key of the keyring. Each object carries the wrapped data key and the ID of the key-encryption
+// key in its annotations, so that key-encryption keys can be rotated as long as old keys remain in the keyring.
+//
+// Objects stored using UploadObject are encrypted with the data key of the workspace as well. Incremental backups
+// are not supported though: their chunks are shared among all workspaces of the owner, while data keys are not.
+//
+// Objects uploaded using UploadInstance (e.g. headless logs) are encrypted as well, hence they cannot be served
+// to users using presigned URLs but must be read using DecryptedObject.
+type EncryptedDirectStorage struct {
+	DirectAccess
+	Keyring *Keyring
+
+	objects encryptableAccess
+
+	mu      sync.Mutex
+	dataKey []byte
+	keyID   string
+	wrapped []byte
+}
+
+type dataKeyObject struct {
+	KeyID   string `json:"keyId"`
+	DataKey []byte `json:"dataKey"`
+}
+
+// Init initializes the remote storage - call this before calling anything else on the interface
+func (rs *EncryptedDirectStorage) Init(ctx context.Context, owner, workspace, instance string) error {
+	rs.mu.Lock()
+	rs.dataKey, rs.keyID, rs.wrapped = nil, "", nil
+	rs.mu.Unlock()
+
+	return rs.DirectAccess.Init(ctx, owner, workspace, instance)
+}
+
+// workspaceDataKey loads the data key of the workspace, or creates one if the workspace has none yet.
+// Data keys wrapped by a key-encryption key other than the primary one are re-wrapped.
+func (rs *EncryptedDirectStorage) workspaceDataKey(ctx context.Context) (dataKey []byte, keyID string, wrapped []byte, err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.dataKey != nil {
+		return rs.dataKey, rs.keyID, rs.wrapped, nil
+	}
+
+	obj := rs.BackupObject(DataKeyObjectName)
+	rc, err := rs.objects.ReadObject(ctx, obj)
+	if err == nil {
+		var dko dataKeyObject
+		err = json.NewDecoder(rc).Decode(&dko)
+		rc.Close()
+		if err != nil {
+			return nil, "", nil, xerrors.Errorf("cannot read workspace data key: %w", err)
+		}
+		dataKey, err = rs.Keyring.UnwrapDataKey(dko.KeyID, dko.DataKey)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		keyID, wrapped = dko.KeyID, dko.DataKey
+	} else if err == ErrNotFound {
+		dataKey = make([]byte, dataKeySize)
+		_, err = rand.Read(dataKey)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	} else {
+		return nil, "", nil, xerrors.Errorf("cannot read workspace data key: %w", err)
+	}
+
+	if keyID != rs.Keyring.Primary {
+		keyID, wrapped, err = rs.Keyring.WrapDataKey(dataKey)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		fc, err := json.Marshal(dataKeyObject{KeyID: keyID, DataKey: wrapped})
+		if err != nil {
+			return nil, "", nil, err
+		}
+		err = rs.objects.UploadObject(ctx, obj, fc)
+		if err != nil {
+			return nil, "", nil, xerrors.Errorf("cannot store workspace data key: %w", err)
+		}
+	}
+
+	rs.dataKey, rs.keyID, rs.wrapped = dataKey, keyID, wrapped
+	return dataKey, keyID, wrapped, nil
+}
+
+// Upload encrypts the file and uploads it to the remote storage
+func (rs *EncryptedDirectStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EncryptedDirectStorage.Upload")
+	defer tracing.FinishSpan(span, &err)
+
+	return rs.upload(ctx, span, source, opts, func(encrypted string, opts []UploadOption) (string, string, error) {
+		return rs.DirectAccess.Upload(ctx, encrypted, name, opts...)
+	})
+}
+
+// UploadInstance encrypts the file and uploads it to the per-instance remote storage
+func (rs *EncryptedDirectStorage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EncryptedDirectStorage.UploadInstance")
+	defer tracing.FinishSpan(span, &err)
+
+	return rs.upload(ctx, span, source, opts, func(encrypted string, opts []UploadOption) (string, string, error) {
+		return rs.DirectAccess.UploadInstance(ctx, encrypted, name, opts...)
+	})
+}
+
+// upload encrypts the file to a temporary file which it passes to the upload function, together with the encryption annotations
+func (rs *EncryptedDirectStorage) upload(ctx context.Context, span opentracing.Span, source string, opts []UploadOption, upload func(encrypted string, opts []UploadOption) (bucket, obj string, err error)) (bucket, obj string, err error) {
+	dataKey, keyID, wrapped, err := rs.workspaceDataKey(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	span.LogKV("keyID", keyID)
+
+	tmpf, err := os.CreateTemp("", "encrypted-upload-*")
+	if err != nil {
+		return "", "", xerrors.Errorf("cannot create temporary file: %w", err)
+	}
+	defer os.Remove(tmpf.Name())
+	defer tmpf.Close()
+
+	dgst, err := encryptFile(tmpf, source, dataKey)
+	if err != nil {
+		return "", "", xerrors.Errorf("cannot encrypt %s: %w", source, err)
+	}
+
+	// the digest must describe the object as it's stored, i.e. the ciphertext
+	opts = append(opts, withAddedAnnotations(map[string]string{
+		ObjectAnnotationDigest:              dgst.String(),
+		ObjectAnnotationEncryptionAlgorithm: EncryptionAlgorithm,
+		ObjectAnnotationEncryptionKeyID:     keyID,
+		ObjectAnnotationEncryptionDataKey:   base64.StdEncoding.EncodeToString(wrapped),
+	}))
+	return upload(tmpf.Name(), opts)
+}
+
+func encryptFile(dst io.Writer, source string, dataKey []byte) (digest.Digest, error) {
+	src, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	digester := digest.Canonical.Digester()
+	bw := bufio.NewWriterSize(io.MultiWriter(dst, digester.Hash()), 1*megabytes)
+	w, err := NewEncryptingWriter(bw, dataKey)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(w, src)
+	if err != nil {
+		return "", err
+	}
+	err = w.Close()
+	if err != nil {
+		return "", err
+	}
+	err = bw.Flush()
+	if err != nil {
+		return "", err
+	}
+	return digester.Digest(), nil
+}
+
+// withAddedAnnotations adds annotations to those already configured, rather than replacing them
+func withAddedAnnotations(md map[string]string) UploadOption {
+	return func(opts *UploadOptions) error {
+		res := make(map[string]string, len(opts.Annotations)+len(md))
+		for k, v := range opts.Annotations {
+			res[k] = v
+		}
+		for k, v := range md {
+			res[k] = v
+		}
+		opts.Annotations = res
+		return nil
+	}
+}
+
+// Download takes the latest state from the remote storage and downloads it to a local path
+func (rs *EncryptedDirectStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(rs.Qualify(name))
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings, func() (bool, error) {
+		return rs.DirectAccess.Download(ctx, destination, name, mappings)
+	})
+}
+
+// DownloadSnapshot downloads a snapshot. The snapshot name is expected to be one produced by Qualify
+func (rs *EncryptedDirectStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings, func() (bool, error) {
+		return rs.DirectAccess.DownloadSnapshot(ctx, destination, name, mappings)
+	})
+}
+
+// download decrypts and extracts an object. Objects which are not encrypted, e.g. because they were
+// uploaded before encryption was enabled, are downloaded using the plaintext download function.
+func (rs *EncryptedDirectStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping, plaintext func() (bool, error)) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EncryptedDirectStorage.download")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	dataKey, err := rs.ObjectDataKey(ctx, bkt, obj)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if dataKey == nil {
+		span.LogKV("encrypted", false)
+		return plaintext()
+	}
+
+	rc, err := rs.objects.ReadBucketObject(ctx, bkt, obj)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	src, err := NewDecryptingReader(rc, dataKey)
+	if err != nil {
+		return true, err
+	}
+	err = extractTarbal(ctx, destination, src, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// ChunkStorePrefix returns the object prefix under which the owner's chunks are stored
+func (rs *EncryptedDirectStorage) ChunkStorePrefix() string {
+	return rs.objects.ChunkStorePrefix()
+}
+
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (rs *EncryptedDirectStorage) BackupPrefix() string {
+	return rs.objects.BackupPrefix()
+}
+
+// UploadObject encrypts content using the data key of the workspace and stores it in an object
+func (rs *EncryptedDirectStorage) UploadObject(ctx context.Context, obj string, content []byte) error {
+	dataKey, _, _, err := rs.workspaceDataKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w, err := NewEncryptingWriter(&buf, dataKey)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return rs.objects.UploadObject(ctx, obj, buf.Bytes())
+}
+
+// ReadObject reads an object stored using UploadObject and decrypts it - if the object is not found, ErrNotFound is returned
+func (rs *EncryptedDirectStorage) ReadObject(ctx context.Context, obj string) (io.ReadCloser, error) {
+	dataKey, _, _, err := rs.workspaceDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := rs.objects.ReadObject(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	src, err := NewDecryptingReader(rc, dataKey)
+	if err != nil {
+		rc.Close()
+		return nil, xerrors.Errorf("cannot decrypt %s: %w", obj, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{src, rc}, nil
+}
+
+// ObjectUpdated returns the time an object was last modified - if the object is not found, ErrNotFound is returned
+func (rs *EncryptedDirectStorage) ObjectUpdated(ctx context.Context, obj string) (time.Time, error) {
+	return rs.objects.ObjectUpdated(ctx, obj)
+}
+
+// DeleteObject deletes an object
+func (rs *EncryptedDirectStorage) DeleteObject(ctx context.Context, obj string) error {
+	return rs.objects.DeleteObject(ctx, obj)
+}
+
+// DecryptedObject reads an object and decrypts it, unless it is not encrypted - if the object is not found, ErrNotFound is returned
+func (rs *EncryptedDirectStorage) DecryptedObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	dataKey, err := rs.ObjectDataKey(ctx, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := rs.objects.ReadBucketObject(ctx, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	if dataKey == nil {
+		return rc, nil
+	}
+
+	src, err := NewDecryptingReader(rc, dataKey)
+	if err != nil {
+		rc.Close()
+		return nil, xerrors.Errorf("cannot decrypt %s: %w", obj, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{src, rc}, nil
+}
+
+// ObjectDataKey returns the unwrapped data key of an object, or nil if the object is not encrypted
+func (rs *EncryptedDirectStorage) ObjectDataKey(ctx context.Context, bkt, obj string) ([]byte, error) {
+	annotations, err := rs.objects.ObjectAnnotations(ctx, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	return rs.Keyring.UnwrapObjectDataKey(annotations)
+}
@@ -0,0 +1,351 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	dataKey := make([]byte, dataKeySize)
+	_, _ = rand.Read(dataKey)
+
+	for _, size := range []int{0, 1, encryptionSegmentSize - 1, encryptionSegmentSize, encryptionSegmentSize + 1, 3*encryptionSegmentSize + 42} {
+		t.Run(fmt.Sprintf("size %d", size), func(t *testing.T) {
+			content := make([]byte, size)
+			_, _ = rand.Read(content)
+
+			var buf bytes.Buffer
+			w, err := NewEncryptingWriter(&buf, dataKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = w.Write(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size > 16 && bytes.Contains(buf.Bytes(), content[:16]) {
+				t.Fatal("ciphertext contains plaintext")
+			}
+
+			r, err := NewDecryptingReader(bytes.NewReader(buf.Bytes()), dataKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			act, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(act, content) {
+				t.Errorf("decrypted content differs from original content")
+			}
+		})
+	}
+}
+
+func TestEncryptionTampering(t *testing.T) {
+	dataKey := make([]byte, dataKeySize)
+	_, _ = rand.Read(dataKey)
+
+	content := make([]byte, 2*encryptionSegmentSize+100)
+	var buf bytes.Buffer
+	w, err := NewEncryptingWriter(&buf, dataKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write(content)
+	_ = w.Close()
+	ciphertext := buf.Bytes()
+	segment := encryptionSegmentSize + 16
+
+	otherKey := make([]byte, dataKeySize)
+	_, _ = rand.Read(otherKey)
+
+	tests := []struct {
+		Name       string
+		Ciphertext []byte
+		Key        []byte
+	}{
+		{
+			Name:       "wrong key",
+			Ciphertext: ciphertext,
+			Key:        otherKey,
+		},
+		{
+			Name:       "truncated at segment boundary",
+			Ciphertext: ciphertext[:encryptionNoncePrefix+segment],
+			Key:        dataKey,
+		},
+		{
+			Name:       "truncated within segment",
+			Ciphertext: ciphertext[:len(ciphertext)-1],
+			Key:        dataKey,
+		},
+		{
+			Name: "reordered segments",
+			Ciphertext: func() []byte {
+				res := append([]byte{}, ciphertext[:encryptionNoncePrefix]...)
+				res = append(res, ciphertext[encryptionNoncePrefix+segment:encryptionNoncePrefix+2*segment]...)
+				res = append(res, ciphertext[encryptionNoncePrefix:encryptionNoncePrefix+segment]...)
+				return append(res, ciphertext[encryptionNoncePrefix+2*segment:]...)
+			}(),
+			Key: dataKey,
+		},
+		{
+			Name: "flipped bit",
+			Ciphertext: func() []byte {
+				res := append([]byte{}, ciphertext...)
+				res[encryptionNoncePrefix+10] ^= 1
+				return res
+			}(),
+			Key: dataKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			r, err := NewDecryptingReader(bytes.NewReader(test.Ciphertext), test.Key)
+			if err != nil {
+				return
+			}
+			_, err = io.ReadAll(r)
+			if err == nil {
+				t.Errorf("expected decryption to fail")
+			}
+		})
+	}
+}
+
+func TestEncryptedDirectStorage(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	keyringFile := filepath.Join(t.TempDir(), "keyring.json")
+	writeTestKeyring(t, keyringFile, "key-1", "key-1")
+
+	newStorage := func() *EncryptedDirectStorage {
+		fs, err := newDirectFilesystemAccess(&config.FilesystemConfig{Root: root})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs, err := newEncryptedDirectAccess(fs, &config.EncryptionConfig{KeyringFile: keyringFile})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = rs.Init(ctx, "owner", "workspace", "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs
+	}
+	rs := newStorage()
+	fs := rs.DirectAccess.(*DirectFilesystemStorage)
+
+	src := filepath.Join(t.TempDir(), "backup.tar")
+	writeEncryptionTestTarbal(t, src, "hello world")
+
+	// backups uploaded before encryption was enabled must remain readable
+	_, _, err := fs.Upload(ctx, src, "plaintext.tar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectDownload(t, rs, "plaintext.tar", "hello world")
+
+	_, obj, err := rs.Upload(ctx, src, DefaultBackup, WithAnnotations(map[string]string{"foo": "bar"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err := fs.ObjectAnnotations(ctx, fs.bucketName(), obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations["foo"] != "bar" {
+		t.Errorf("existing annotations were not retained: %v", annotations)
+	}
+	if annotations[ObjectAnnotationEncryptionKeyID] != "key-1" {
+		t.Errorf("unexpected key ID: %s", annotations[ObjectAnnotationEncryptionKeyID])
+	}
+	raw, err := fs.ReadObject(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawContent, _ := io.ReadAll(raw)
+	raw.Close()
+	if bytes.Contains(rawContent, []byte("hello world")) {
+		t.Errorf("backup was stored in plaintext")
+	}
+	if dgst := digest.FromBytes(rawContent).String(); annotations[ObjectAnnotationDigest] != dgst {
+		t.Errorf("unexpected digest annotation: is %s but expected %s", annotations[ObjectAnnotationDigest], dgst)
+	}
+	expectDownload(t, rs, DefaultBackup, "hello world")
+
+	// rotate the key-encryption key: old backups must remain readable, new ones use the new key
+	writeTestKeyring(t, keyringFile, "key-2", "key-1", "key-2")
+	rs = newStorage()
+	expectDownload(t, rs, DefaultBackup, "hello world")
+
+	writeEncryptionTestTarbal(t, src, "hello rotated world")
+	_, obj, err = rs.Upload(ctx, src, "snapshot.tar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err = fs.ObjectAnnotations(ctx, fs.bucketName(), obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations[ObjectAnnotationEncryptionKeyID] != "key-2" {
+		t.Errorf("unexpected key ID after rotation: %s", annotations[ObjectAnnotationEncryptionKeyID])
+	}
+	dst := t.TempDir()
+	found, err := rs.DownloadSnapshot(ctx, dst, rs.Qualify("snapshot.tar"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("snapshot not found")
+	}
+	expectFileContent(t, filepath.Join(dst, "README.md"), "hello rotated world")
+
+	found, err = rs.Download(ctx, t.TempDir(), "does-not-exist.tar", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Errorf("expected non-existent backup not to be found")
+	}
+}
+
+func TestEncryptedDirectStorageObjects(t *testing.T) {
+	ctx := context.Background()
+
+	keyringFile := filepath.Join(t.TempDir(), "keyring.json")
+	writeTestKeyring(t, keyringFile, "key-1", "key-1")
+	fs, err := newDirectFilesystemAccess(&config.FilesystemConfig{Root: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := newEncryptedDirectAccess(fs, &config.EncryptionConfig{KeyringFile: keyringFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := rs.BackupObject(IncrementalManifestName(DefaultBackup))
+	err = rs.UploadObject(ctx, obj, []byte("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := fs.ReadObject(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawContent, _ := io.ReadAll(raw)
+	raw.Close()
+	if bytes.Contains(rawContent, []byte("hello world")) {
+		t.Errorf("object was stored in plaintext")
+	}
+
+	rc, err := rs.ReadObject(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "hello world" {
+		t.Errorf("unexpected content: %q", string(content))
+	}
+
+	err = rs.DeleteObject(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = rs.ObjectUpdated(ctx, obj)
+	if err != ErrNotFound {
+		t.Errorf("expected deleted object not to be found, got %v", err)
+	}
+}
+
+func expectDownload(t *testing.T, rs DirectAccess, name, content string) {
+	dst := t.TempDir()
+	found, err := rs.Download(context.Background(), dst, name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatalf("backup %s not found", name)
+	}
+	expectFileContent(t, filepath.Join(dst, "README.md"), content)
+}
+
+func expectFileContent(t *testing.T, fn, content string) {
+	act, err := os.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(act) != content {
+		t.Errorf("unexpected content of %s: is %q but expected %q", fn, string(act), content)
+	}
+}
+
+func writeTestKeyring(t *testing.T, fn string, primary string, ids ...string) {
+	var kf keyringFile
+	kf.Primary = primary
+	for _, id := range ids {
+		// derive the key from its ID so that rewriting the keyring retains existing keys
+		key := bytes.Repeat([]byte(id), dataKeySize)[:dataKeySize]
+		kf.Keys = append(kf.Keys, struct {
+			ID  string `json:"id"`
+			Key string `json:"key"`
+		}{ID: id, Key: base64.StdEncoding.EncodeToString(key)})
+	}
+	fc, err := json.Marshal(kf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(fn, fc, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeEncryptionTestTarbal(t *testing.T, fn string, content string) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: int64(len(content))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = tw.Write([]byte(content))
+	_ = tw.Close()
+
+	err = os.WriteFile(fn, buf.Bytes(), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,912 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// filesystemMetaSuffix is appended to an object's path to store its metadata next to it
+	filesystemMetaSuffix = ".gitpod-meta"
+
+	// filesystemTempInfix marks files which are still being written
+	filesystemTempInfix = ".tmp-"
+
+	// filesystemSignedURLExpiry is the time signed URLs remain valid for
+	filesystemSignedURLExpiry = 30 * time.Minute
+)
+
+var _ DirectAccess = &DirectFilesystemStorage{}
+var _ ChunkAccess = &DirectFilesystemStorage{}
+var _ ObjectReader = &DirectFilesystemStorage{}
+var _ PresignedAccess = &PresignedFilesystemStorage{}
+var _ ContentLister = &PresignedFilesystemStorage{}
+
+// ValidateFilesystemConfig checks if the filesystem storage config is valid
+func ValidateFilesystemConfig(c *config.FilesystemConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Root, validation.Required),
+	)
+}
+
+// validatePresignedFilesystemConfig checks if the filesystem storage config is valid for producing signed URLs
+func validatePresignedFilesystemConfig(c *config.FilesystemConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Root, validation.Required),
+		validation.Field(&c.BaseURL, validation.Required),
+		validation.Field(&c.SigningKey, validation.Required),
+	)
+}
+
+// addFilesystemParamsFromMounts allows for the signing key to be read from a file
+func addFilesystemParamsFromMounts(c *config.FilesystemConfig) error {
+	if c.SigningKeyFile != "" {
+		value, err := os.ReadFile(c.SigningKeyFile)
+		if err != nil {
+			return err
+		}
+		c.SigningKey = strings.TrimSpace(string(value))
+	}
+	return nil
+}
+
+// filesystemObjectMeta is the metadata we store alongside each object
+type filesystemObjectMeta struct {
+	ContentType string            `json:"contentType,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func filesystemBucketName(ownerID string) string {
+	return fmt.Sprintf("gitpod-user-%s", ownerID)
+}
+
+func filesystemWorkspaceBackupObjectName(workspaceID, name string) string {
+	return filepath.Join("workspaces", workspaceID, name)
+}
+
+// filesystemObjectPath computes the location of an object on disk and makes sure it does not escape its bucket
+func filesystemObjectPath(root, bucket, obj string) (string, error) {
+	if bucket == "" || bucket == "." || bucket == ".." || strings.ContainsRune(bucket, '/') {
+		return "", xerrors.Errorf("invalid bucket name: %s", bucket)
+	}
+
+	bkt := filepath.Join(root, bucket)
+	if obj == "" {
+		return bkt, nil
+	}
+
+	p := filepath.Join(bkt, obj)
+	if !strings.HasPrefix(p, bkt+string(filepath.Separator)) {
+		return "", xerrors.Errorf("object %s is outside of bucket %s", obj, bucket)
+	}
+	if strings.HasSuffix(p, filesystemMetaSuffix) {
+		return "", xerrors.Errorf("object name must not end in %s", filesystemMetaSuffix)
+	}
+	return p, nil
+}
+
+func readFilesystemObjectMeta(path string) (*filesystemObjectMeta, error) {
+	var res filesystemObjectMeta
+	fc, err := os.ReadFile(path + filesystemMetaSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(fc, &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal object metadata: %w", err)
+	}
+	return &res, nil
+}
+
+// writeFilesystemObject atomically writes an object and its metadata to disk
+func writeFilesystemObject(path string, src io.Reader, meta *filesystemObjectMeta) (err error) {
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	if meta != nil {
+		fc, err := json.Marshal(meta)
+		if err != nil {
+			return err
+		}
+		err = writeFileAtomic(path+filesystemMetaSuffix, bytes.NewReader(fc))
+		if err != nil {
+			return xerrors.Errorf("cannot write object metadata: %w", err)
+		}
+	}
+
+	return writeFileAtomic(path, src)
+}
+
+func writeFileAtomic(path string, src io.Reader) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+filesystemTempInfix+"*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	_, err = io.Copy(f, src)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+func isFilesystemTempFile(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.Contains(name, filesystemTempInfix)
+}
+
+// walkFilesystemObjects calls fn for each object in a bucket whose name has the given prefix.
+// Returns no error if the bucket does not exist.
+func walkFilesystemObjects(root, bucket, prefix string, fn func(obj string, info fs.FileInfo) error) error {
+	bkt, err := filesystemObjectPath(root, bucket, "")
+	if err != nil {
+		return err
+	}
+
+	err = filepath.Walk(bkt, func(path string, info fs.FileInfo, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, filesystemMetaSuffix) || isFilesystemTempFile(info.Name()) {
+			return nil
+		}
+
+		obj, err := filepath.Rel(bkt, path)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(obj, prefix) {
+			return nil
+		}
+		return fn(obj, info)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// newDirectFilesystemAccess provides direct access to the remote storage system
+func newDirectFilesystemAccess(cfg *config.FilesystemConfig) (*DirectFilesystemStorage, error) {
+	if cfg == nil {
+		return nil, xerrors.Errorf("missing filesystem storage config")
+	}
+	if err := ValidateFilesystemConfig(cfg); err != nil {
+		return nil, err
+	}
+	return &DirectFilesystemStorage{FilesystemConfig: *cfg}, nil
+}
+
+// DirectFilesystemStorage implements a locally mounted directory as remote storage backend
+type DirectFilesystemStorage struct {
+	Username         string
+	WorkspaceName    string
+	InstanceID       string
+	FilesystemConfig config.FilesystemConfig
+}
+
+// Validate checks if the filesystem storage is configured properly
+func (rs *DirectFilesystemStorage) Validate() error {
+	err := ValidateFilesystemConfig(&rs.FilesystemConfig)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(rs,
+		validation.Field(&rs.Username, validation.Required),
+		validation.Field(&rs.WorkspaceName, validation.Required),
+	)
+}
+
+// Init initializes the remote storage - call this before calling anything else on the interface
+func (rs *DirectFilesystemStorage) Init(ctx context.Context, owner, workspace, instance string) (err error) {
+	rs.Username = owner
+	rs.WorkspaceName = workspace
+	rs.InstanceID = instance
+
+	return rs.Validate()
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (rs *DirectFilesystemStorage) EnsureExists(ctx context.Context) error {
+	bkt, err := filesystemObjectPath(rs.FilesystemConfig.Root, rs.bucketName(), "")
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(bkt, 0755)
+}
+
+func (rs *DirectFilesystemStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, bkt, obj)
+	if err != nil {
+		return false, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	err = extractTarbal(ctx, destination, f, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Download takes the latest state from the remote storage and downloads it to a local path
+func (rs *DirectFilesystemStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	return rs.download(ctx, destination, rs.bucketName(), rs.objectName(name), mappings)
+}
+
+// DownloadSnapshot downloads a snapshot. The snapshot name is expected to be one produced by Qualify
+func (rs *DirectFilesystemStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings)
+}
+
+// ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
+func (rs *DirectFilesystemStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
+	err = walkFilesystemObjects(rs.FilesystemConfig.Root, rs.bucketName(), prefix, func(obj string, info fs.FileInfo) error {
+		objects = append(objects, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+	return objects, nil
+}
+
+// Qualify fully qualifies a snapshot name so that it can be downloaded using DownloadSnapshot
+func (rs *DirectFilesystemStorage) Qualify(name string) string {
+	return fmt.Sprintf("%s@%s", rs.objectName(name), rs.bucketName())
+}
+
+// UploadInstance takes all files from a local location and uploads it to the per-instance remote storage
+func (rs *DirectFilesystemStorage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, object string, err error) {
+	if rs.InstanceID == "" {
+		return "", "", xerrors.Errorf("instanceID is required to comput object name")
+	}
+	return rs.Upload(ctx, source, InstanceObjectName(rs.InstanceID, name), opts...)
+}
+
+// Upload takes all files from a local location and uploads it to the remote storage
+func (rs *DirectFilesystemStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUpload")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, bucket, obj)
+	if err != nil {
+		return
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		err = xerrors.Errorf("cannot read backup file: %w", err)
+		return
+	}
+	defer f.Close()
+
+	err = writeFilesystemObject(path, f, &filesystemObjectMeta{
+		ContentType: options.ContentType,
+		Annotations: options.Annotations,
+	})
+	if err != nil {
+		err = xerrors.Errorf("cannot write %s: %w", obj, err)
+		return
+	}
+
+	return
+}
+
+// ChunkStorePrefix returns the object prefix under which the owner's chunks are stored
+func (rs *DirectFilesystemStorage) ChunkStorePrefix() string {
+	return "chunks"
+}
+
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (rs *DirectFilesystemStorage) BackupPrefix() string {
+	return "workspaces"
+}
+
+// UploadObject stores content in an object
+func (rs *DirectFilesystemStorage) UploadObject(ctx context.Context, obj string, content []byte) error {
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, rs.bucketName(), obj)
+	if err != nil {
+		return err
+	}
+	return writeFilesystemObject(path, bytes.NewReader(content), nil)
+}
+
+// ReadObject reads an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectFilesystemStorage) ReadObject(ctx context.Context, obj string) (io.ReadCloser, error) {
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, rs.bucketName(), obj)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ObjectAnnotations returns the annotations of an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectFilesystemStorage) ObjectAnnotations(ctx context.Context, bkt, obj string) (map[string]string, error) {
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := readFilesystemObjectMeta(path)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Annotations, nil
+}
+
+// ReadBucketObject reads the raw content of an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectFilesystemStorage) ReadBucketObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ObjectUpdated returns the time an object was last modified - if the object is not found, ErrNotFound is returned
+func (rs *DirectFilesystemStorage) ObjectUpdated(ctx context.Context, obj string) (time.Time, error) {
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, rs.bucketName(), obj)
+	if err != nil {
+		return time.Time{}, err
+	}
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, ErrNotFound
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return stat.ModTime(), nil
+}
+
+// DeleteObject deletes an object
+func (rs *DirectFilesystemStorage) DeleteObject(ctx context.Context, obj string) error {
+	path, err := filesystemObjectPath(rs.FilesystemConfig.Root, rs.bucketName(), obj)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path + filesystemMetaSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Bucket provides the bucket name for a particular user
+func (rs *DirectFilesystemStorage) Bucket(ownerID string) string {
+	return filesystemBucketName(ownerID)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (rs *DirectFilesystemStorage) BackupObject(name string) string {
+	return rs.objectName(name)
+}
+
+func (rs *DirectFilesystemStorage) bucketName() string {
+	return filesystemBucketName(rs.Username)
+}
+
+func (rs *DirectFilesystemStorage) objectName(name string) string {
+	return filesystemWorkspaceBackupObjectName(rs.WorkspaceName, name)
+}
+
+// NewPresignedFilesystemAccess provides presigned URLs for a locally mounted directory
+func NewPresignedFilesystemAccess(cfg *config.FilesystemConfig) (*PresignedFilesystemStorage, error) {
+	if cfg == nil {
+		return nil, xerrors.Errorf("missing filesystem storage config")
+	}
+
+	c := *cfg
+	err := addFilesystemParamsFromMounts(&c)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePresignedFilesystemConfig(&c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedFilesystemStorage{
+		FilesystemConfig: c,
+		signer:           &filesystemURLSigner{key: []byte(c.SigningKey)},
+		now:              time.Now,
+	}, nil
+}
+
+// PresignedFilesystemStorage provides presigned URLs for a locally mounted directory.
+// The URLs are served by the handler produced by NewFilesystemHandler.
+type PresignedFilesystemStorage struct {
+	FilesystemConfig config.FilesystemConfig
+
+	signer *filesystemURLSigner
+	now    func() time.Time
+}
+
+// Bucket provides the bucket name for a particular user
+func (s *PresignedFilesystemStorage) Bucket(ownerID string) string {
+	return filesystemBucketName(ownerID)
+}
+
+// BlobObject returns a blob's object name
+func (s *PresignedFilesystemStorage) BlobObject(userID, name string) (string, error) {
+	return blobObjectName(name)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (s *PresignedFilesystemStorage) BackupObject(ownerID string, workspaceID string, name string) string {
+	return filesystemWorkspaceBackupObjectName(workspaceID, name)
+}
+
+// InstanceObject returns a instance's object name that a direct downloader would download
+func (s *PresignedFilesystemStorage) InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string {
+	return s.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (s *PresignedFilesystemStorage) EnsureExists(ctx context.Context, bucket string) error {
+	bkt, err := filesystemObjectPath(s.FilesystemConfig.Root, bucket, "")
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(bkt, 0755)
+}
+
+// DiskUsage gives the total objects size of objects that have the given prefix
+func (s *PresignedFilesystemStorage) DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error) {
+	//nolint:ineffassign,staticcheck
+	span, ctx := opentracing.StartSpanFromContext(ctx, "filesystem.DiskUsage")
+	defer tracing.FinishSpan(span, &err)
+
+	err = walkFilesystemObjects(s.FilesystemConfig.Root, bucket, prefix, func(obj string, info fs.FileInfo) error {
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// Owners returns the IDs of all owners which have a bucket below the root directory
+func (s *PresignedFilesystemStorage) Owners(ctx context.Context) (owners []string, err error) {
+	entries, err := os.ReadDir(s.FilesystemConfig.Root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filesystemBucketName("")
+	for _, e := range entries {
+		if e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			owners = append(owners, strings.TrimPrefix(e.Name(), prefix))
+		}
+	}
+	return owners, nil
+}
+
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (s *PresignedFilesystemStorage) BackupPrefix(ownerID string) string {
+	return "workspaces/"
+}
+
+// ListBucketObjects returns all objects in the bucket whose name has the given prefix
+func (s *PresignedFilesystemStorage) ListBucketObjects(ctx context.Context, bkt, prefix string) (objects []ObjectInfo, err error) {
+	err = walkFilesystemObjects(s.FilesystemConfig.Root, bkt, prefix, func(obj string, info fs.FileInfo) error {
+		objects = append(objects, ObjectInfo{
+			Name:    obj,
+			Size:    info.Size(),
+			Updated: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
+func (s *PresignedFilesystemStorage) SignDownload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *DownloadInfo, err error) {
+	//nolint:ineffassign,staticcheck
+	span, ctx := opentracing.StartSpanFromContext(ctx, "filesystem.SignDownload")
+	defer func() {
+		if err == ErrNotFound {
+			span.LogKV("found", false)
+			tracing.FinishSpan(span, nil)
+			return
+		}
+
+		tracing.FinishSpan(span, &err)
+	}()
+
+	path, err := filesystemObjectPath(s.FilesystemConfig.Root, bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		return nil, ErrNotFound
+	}
+	meta, err := readFilesystemObjectMeta(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadInfo{
+		Meta: ObjectMeta{
+			ContentType:        meta.ContentType,
+			OCIMediaType:       meta.Annotations[ObjectAnnotationOCIContentType],
+			Digest:             meta.Annotations[ObjectAnnotationDigest],
+			UncompressedDigest: meta.Annotations[ObjectAnnotationUncompressedDigest],
+		},
+		Size: stat.Size(),
+		URL:  s.signedURL(http.MethodGet, bucket, obj),
+	}, nil
+}
+
+// SignUpload describes an object for upload
+func (s *PresignedFilesystemStorage) SignUpload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *UploadInfo, err error) {
+	_, err = filesystemObjectPath(s.FilesystemConfig.Root, bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadInfo{URL: s.signedURL(http.MethodPut, bucket, obj)}, nil
+}
+
+func (s *PresignedFilesystemStorage) signedURL(method, bucket, obj string) string {
+	expires := s.now().Add(filesystemSignedURLExpiry).Unix()
+
+	segments := strings.Split(filepath.ToSlash(obj), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", s.signer.Sign(method, bucket, obj, expires))
+
+	return fmt.Sprintf("%s/%s/%s?%s", strings.TrimSuffix(s.FilesystemConfig.BaseURL, "/"), url.PathEscape(bucket), strings.Join(segments, "/"), q.Encode())
+}
+
+// DeleteObject deletes objects in the given bucket specified by the given query
+func (s *PresignedFilesystemStorage) DeleteObject(ctx context.Context, bucket string, query *DeleteObjectQuery) (err error) {
+	//nolint:ineffassign,staticcheck
+	span, ctx := opentracing.StartSpanFromContext(ctx, "filesystem.DeleteObject")
+	defer tracing.FinishSpan(span, &err)
+
+	var objs []string
+	switch {
+	case query.Name != "":
+		objs = []string{query.Name}
+	case query.Prefix != "":
+		err = walkFilesystemObjects(s.FilesystemConfig.Root, bucket, query.Prefix, func(obj string, info fs.FileInfo) error {
+			objs = append(objs, obj)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, obj := range objs {
+		path, err := filesystemObjectPath(s.FilesystemConfig.Root, bucket, obj)
+		if err != nil {
+			return err
+		}
+		err = os.Remove(path)
+		if errors.Is(err, fs.ErrNotExist) && query.Name != "" {
+			return ErrNotFound
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.WithField("bucket", bucket).WithField("object", obj).Error(err)
+			return err
+		}
+		err = os.Remove(path + filesystemMetaSuffix)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteBucket deletes a bucket
+func (s *PresignedFilesystemStorage) DeleteBucket(ctx context.Context, userID, bucket string) error {
+	bkt, err := filesystemObjectPath(s.FilesystemConfig.Root, bucket, "")
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(bkt)
+}
+
+// ObjectHash gets a hash value of an object
+func (s *PresignedFilesystemStorage) ObjectHash(ctx context.Context, bucket string, obj string) (hash string, err error) {
+	//nolint:ineffassign,staticcheck
+	span, ctx := opentracing.StartSpanFromContext(ctx, "filesystem.ObjectHash")
+	defer tracing.FinishSpan(span, &err)
+
+	path, err := filesystemObjectPath(s.FilesystemConfig.Root, bucket, obj)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ObjectExists tells whether the given object exists or not
+func (s *PresignedFilesystemStorage) ObjectExists(ctx context.Context, bucket string, obj string) (bool, error) {
+	path, err := filesystemObjectPath(s.FilesystemConfig.Root, bucket, obj)
+	if err != nil {
+		return false, err
+	}
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !stat.IsDir(), nil
+}
+
+// filesystemURLSigner produces and verifies HMAC signatures of filesystem storage URLs
+type filesystemURLSigner struct {
+	key []byte
+}
+
+// Sign computes the signature of a request against an object
+func (s *filesystemURLSigner) Sign(method, bucket, obj string, expires int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%d", method, bucket, filepath.ToSlash(obj), expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a request against an object
+func (s *filesystemURLSigner) Verify(method, bucket, obj string, expires int64, signature string) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(s.Sign(method, bucket, obj, expires))
+	return hmac.Equal(sig, expected)
+}
+
+// NewFilesystemHandler produces an HTTP handler which serves the signed URLs
+// produced by the filesystem presigned access. The handler expects requests
+// with the base URL path already stripped.
+func NewFilesystemHandler(cfg *config.FilesystemConfig) (http.Handler, error) {
+	ps, err := NewPresignedFilesystemAccess(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &filesystemHandler{
+		Root:   ps.FilesystemConfig.Root,
+		signer: ps.signer,
+		now:    time.Now,
+	}, nil
+}
+
+type filesystemHandler struct {
+	Root string
+
+	signer *filesystemURLSigner
+	now    func() time.Time
+}
+
+// ServeHTTP serves signed up- and downloads
+func (h *filesystemHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodPut {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	bucket, obj, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok || obj == "" {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil || h.now().Unix() > expires || !h.signer.Verify(method, bucket, obj, expires, r.URL.Query().Get("signature")) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	path, err := filesystemObjectPath(h.Root, bucket, obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		h.serveDownload(w, r, path)
+	case http.MethodPut:
+		h.serveUpload(w, r, path)
+	}
+}
+
+func (h *filesystemHandler) serveDownload(w http.ResponseWriter, r *http.Request, path string) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("path", path).Error("cannot open object")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil || stat.IsDir() {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	meta, err := readFilesystemObjectMeta(path)
+	if err != nil {
+		log.WithError(err).WithField("path", path).Warn("cannot read object metadata")
+	} else if meta.ContentType != "" {
+		w.Header().Set("Content-Type", meta.ContentType)
+	}
+
+	// ServeContent handles range requests for us
+	http.ServeContent(w, r, "", stat.ModTime(), f)
+}
+
+func (h *filesystemHandler) serveUpload(w http.ResponseWriter, r *http.Request, path string) {
+	defer r.Body.Close()
+
+	err := writeFilesystemObject(path, r.Body, &filesystemObjectMeta{
+		ContentType: r.Header.Get("Content-Type"),
+	})
+	if err != nil {
+		log.WithError(err).WithField("path", path).Error("cannot write object")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
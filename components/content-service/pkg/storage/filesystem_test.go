@@ -0,0 +1,206 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+type testableFilesystemPresignedAccess struct {
+	*storage.PresignedFilesystemStorage
+}
+
+func (f testableFilesystemPresignedAccess) ForTestCreateObj(ctx context.Context, bucket, path, content string) error {
+	fn := filepath.Join(f.FilesystemConfig.Root, bucket, path)
+	err := os.MkdirAll(filepath.Dir(fn), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fn, []byte(content), 0644)
+}
+
+func (f testableFilesystemPresignedAccess) ForTestReset(ctx context.Context) error {
+	entries, err := os.ReadDir(f.FilesystemConfig.Root)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		err = os.RemoveAll(filepath.Join(f.FilesystemConfig.Root, e.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestFilesystemConfig(t *testing.T) *config.FilesystemConfig {
+	return &config.FilesystemConfig{
+		Root:       t.TempDir(),
+		BaseURL:    "http://localhost/storage",
+		SigningKey: "test-signing-key",
+	}
+}
+
+func TestFilesystemPresignedHappyPath(t *testing.T) {
+	dut, err := storage.NewPresignedFilesystemAccess(newTestFilesystemConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SuiteTestPresignedAccess(t, testableFilesystemPresignedAccess{dut})
+}
+
+func TestFilesystemDeleteObject(t *testing.T) {
+	ctx := context.Background()
+	dut, err := storage.NewPresignedFilesystemAccess(newTestFilesystemConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := testableFilesystemPresignedAccess{dut}
+
+	const bucket = "test-bucket"
+	for _, p := range []string{"workspaces/a/full.tar", "workspaces/a/instances/b/full.tar", "workspaces/c/full.tar"} {
+		failOnErr(t, ps.ForTestCreateObj(ctx, bucket, p, "hello world"))
+	}
+
+	failOnErr(t, ps.DeleteObject(ctx, bucket, &storage.DeleteObjectQuery{Prefix: "workspaces/a/"}))
+
+	for p, expected := range map[string]bool{
+		"workspaces/a/full.tar":             false,
+		"workspaces/a/instances/b/full.tar": false,
+		"workspaces/c/full.tar":             true,
+	} {
+		exists, err := ps.ObjectExists(ctx, bucket, p)
+		failOnErr(t, err)
+		if exists != expected {
+			t.Errorf("unexpected existence of %s: is %v but expected %v", p, exists, expected)
+		}
+	}
+
+	err = ps.DeleteObject(ctx, bucket, &storage.DeleteObjectQuery{Name: "workspaces/a/full.tar"})
+	if err != storage.ErrNotFound {
+		t.Errorf("unexpected error deleting a non-existent object: %v", err)
+	}
+}
+
+func TestFilesystemObjectPathEscape(t *testing.T) {
+	ctx := context.Background()
+	dut, err := storage.NewPresignedFilesystemAccess(newTestFilesystemConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dut.SignUpload(ctx, "test-bucket", "../other-bucket/foo", &storage.SignedURLOptions{})
+	if err == nil {
+		t.Errorf("expected an error when escaping the bucket")
+	}
+	_, err = dut.SignUpload(ctx, "../", "foo", &storage.SignedURLOptions{})
+	if err == nil {
+		t.Errorf("expected an error for an invalid bucket name")
+	}
+}
+
+func TestFilesystemHandler(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestFilesystemConfig(t)
+
+	handler, err := storage.NewFilesystemHandler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.StripPrefix("/storage", handler))
+	defer srv.Close()
+	cfg.BaseURL = srv.URL + "/storage"
+
+	dut, err := storage.NewPresignedFilesystemAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		bucket  = "test-bucket"
+		obj     = "workspaces/foo/full.tar"
+		content = "hello world"
+	)
+
+	ul, err := dut.SignUpload(ctx, bucket, obj, &storage.SignedURLOptions{})
+	failOnErr(t, err)
+	req, err := http.NewRequest(http.MethodPut, ul.URL, strings.NewReader(content))
+	failOnErr(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	failOnErr(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected upload status: %d", resp.StatusCode)
+	}
+
+	dl, err := dut.SignDownload(ctx, bucket, obj, &storage.SignedURLOptions{})
+	failOnErr(t, err)
+	if dl.Size != int64(len(content)) {
+		t.Errorf("unexpected object size: is %d but expected %d", dl.Size, len(content))
+	}
+	resp, err = http.Get(dl.URL)
+	failOnErr(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	failOnErr(t, err)
+	if string(body) != content {
+		t.Errorf("unexpected download content: is %q but expected %q", string(body), content)
+	}
+
+	tests := []struct {
+		Name           string
+		Method         string
+		URL            string
+		ExpectedStatus int
+	}{
+		{
+			Name:           "download with upload signature",
+			Method:         http.MethodGet,
+			URL:            ul.URL,
+			ExpectedStatus: http.StatusForbidden,
+		},
+		{
+			Name:           "tampered object",
+			Method:         http.MethodGet,
+			URL:            strings.Replace(dl.URL, "foo", "bar", 1),
+			ExpectedStatus: http.StatusForbidden,
+		},
+		{
+			Name:           "missing signature",
+			Method:         http.MethodGet,
+			URL:            strings.Split(dl.URL, "?")[0],
+			ExpectedStatus: http.StatusForbidden,
+		},
+		{
+			Name:           "unsupported method",
+			Method:         http.MethodDelete,
+			URL:            dl.URL,
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req, err := http.NewRequest(test.Method, test.URL, nil)
+			failOnErr(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			failOnErr(t, err)
+			resp.Body.Close()
+			if resp.StatusCode != test.ExpectedStatus {
+				t.Errorf("unexpected status: is %d but expected %d", resp.StatusCode, test.ExpectedStatus)
+			}
+		})
+	}
+}
@@ -35,6 +35,9 @@ import (
 )
 
 var _ DirectAccess = &DirectGCPStorage{}
+var _ ChunkAccess = &DirectGCPStorage{}
+var _ ObjectReader = &DirectGCPStorage{}
+var _ ContentLister = &PresignedGCPStorage{}
 
 var validateExistsInFilesystem = validation.By(func(o interface{}) error {
 	s, ok := o.(string)
@@ -358,6 +361,12 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 	defer tracing.FinishSpan(span, &err)
 	log := log.WithFields(log.OWI(rs.Username, rs.WorkspaceName, ""))
 
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
 	if rs.client == nil {
 		err = xerrors.Errorf("no gcloud client available - did you call Init()?")
 		return
@@ -384,6 +393,7 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 	uploadSpan := opentracing.StartSpan("remote-upload", opentracing.ChildOf(span.Context()))
 	uploadSpan.SetTag("bucket", bucket)
 	uploadSpan.SetTag("obj", object)
+	defer uploadSpan.Finish()
 
 	err = gcpEnsureExists(ctx, rs.client, bucket, rs.GCPConfig)
 	if err != nil {
@@ -391,42 +401,129 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 		return
 	}
 
-	var wg sync.WaitGroup
+	// the annotations must be part of the write, lest a reader finds the object without them
+	w := rs.client.Bucket(bucket).Object(object).NewWriter(ctx)
+	w.Metadata = options.Annotations
+	if options.ContentType != "" {
+		w.ContentType = options.ContentType
+	}
+	_, err = io.Copy(w, sfn)
+	if err != nil {
+		w.Close()
+		log.WithError(err).Error("unexpected error uploading file to GCS")
+		err = xerrors.Errorf("unexpected error uploading backup: %w", err)
+		return
+	}
+	err = w.Close()
+	if err != nil {
+		log.WithError(err).Error("unexpected error uploading file to GCS")
+		err = xerrors.Errorf("unexpected error uploading backup: %w", err)
+		return
+	}
 
-	wg.Add(1)
+	return
+}
 
-	go func() {
-		defer wg.Done()
+// ChunkStorePrefix returns the object prefix under which the owner's chunks are stored
+func (rs *DirectGCPStorage) ChunkStorePrefix() string {
+	return "chunks"
+}
 
-		sa := ""
-		if rs.GCPConfig.CredentialsFile != "" {
-			sa = fmt.Sprintf(`-o "Credentials:gs_service_key_file=%v"`, rs.GCPConfig.CredentialsFile)
-		}
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (rs *DirectGCPStorage) BackupPrefix() string {
+	return "workspaces"
+}
 
-		args := fmt.Sprintf(`gsutil -q -m %v\
-		  -o "GSUtil:parallel_composite_upload_threshold=150M" \
-		  -o "GSUtil:parallel_process_count=3" \
-		  -o "GSUtil:parallel_thread_count=6" \
-		  cp %s gs://%s`, sa, source, filepath.Join(bucket, object))
+// UploadObject stores content in an object
+func (rs *DirectGCPStorage) UploadObject(ctx context.Context, obj string, content []byte) (err error) {
+	if rs.client == nil {
+		return xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
 
-		log.WithField("flags", args).Debug("gsutil flags")
+	w := rs.client.Bucket(rs.bucketName()).Object(obj).NewWriter(ctx)
+	_, err = w.Write(content)
+	if err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
 
-		cmd := exec.Command("/bin/bash", []string{"-c", args}...)
-		var out []byte
-		out, err = cmd.CombinedOutput()
-		if err != nil {
-			log.WithError(err).WithField("out", string(out)).Error("unexpected error uploading file to GCS using gsutil")
-			err = xerrors.Errorf("unexpected error uploading backup")
-			return
-		}
-	}()
+// ReadObject reads an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectGCPStorage) ReadObject(ctx context.Context, obj string) (io.ReadCloser, error) {
+	if rs.client == nil {
+		return nil, xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
 
-	wg.Wait()
+	rc, err := rs.client.Bucket(rs.bucketName()).Object(obj).NewReader(ctx)
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) || errors.Is(err, gcpstorage.ErrBucketNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
 
-	uploadSpan.Finish()
+// ObjectAnnotations returns the annotations of an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectGCPStorage) ObjectAnnotations(ctx context.Context, bkt, obj string) (map[string]string, error) {
+	if rs.client == nil {
+		return nil, xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
 
-	err = nil
-	return
+	attrs, err := rs.client.Bucket(bkt).Object(obj).Attrs(ctx)
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) || errors.Is(err, gcpstorage.ErrBucketNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attrs.Metadata, nil
+}
+
+// ReadBucketObject reads the raw content of an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectGCPStorage) ReadBucketObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	if rs.client == nil {
+		return nil, xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
+
+	rc, err := rs.client.Bucket(bkt).Object(obj).NewReader(ctx)
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) || errors.Is(err, gcpstorage.ErrBucketNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
+// ObjectUpdated returns the time an object was last modified - if the object is not found, ErrNotFound is returned
+func (rs *DirectGCPStorage) ObjectUpdated(ctx context.Context, obj string) (time.Time, error) {
+	if rs.client == nil {
+		return time.Time{}, xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
+
+	attrs, err := rs.client.Bucket(rs.bucketName()).Object(obj).Attrs(ctx)
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) || errors.Is(err, gcpstorage.ErrBucketNotExist) {
+		return time.Time{}, ErrNotFound
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return attrs.Updated, nil
+}
+
+// DeleteObject deletes an object
+func (rs *DirectGCPStorage) DeleteObject(ctx context.Context, obj string) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
+
+	err := rs.client.Bucket(rs.bucketName()).Object(obj).Delete(ctx)
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	return err
 }
 
 func (rs *DirectGCPStorage) bucketName() string {
@@ -805,3 +902,63 @@ func (p *PresignedGCPStorage) BackupObject(ownerID string, workspaceID string, n
 func (p *PresignedGCPStorage) InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string {
 	return p.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
 }
+
+// Owners returns the IDs of all owners which have a bucket in the project
+func (p *PresignedGCPStorage) Owners(ctx context.Context) (owners []string, err error) {
+	client, err := newGCPClient(ctx, p.config)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:staticcheck
+	defer client.Close()
+
+	prefix := gcpBucketName(p.stage, "")
+	it := client.Buckets(ctx, p.config.Project)
+	it.Prefix = prefix
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, strings.TrimPrefix(attrs.Name, prefix))
+	}
+	return owners, nil
+}
+
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (p *PresignedGCPStorage) BackupPrefix(ownerID string) string {
+	return "workspaces/"
+}
+
+// ListBucketObjects returns all objects in the bucket whose name has the given prefix
+func (p *PresignedGCPStorage) ListBucketObjects(ctx context.Context, bkt, prefix string) (objects []ObjectInfo, err error) {
+	client, err := newGCPClient(ctx, p.config)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:staticcheck
+	defer client.Close()
+
+	it := client.Bucket(bkt).Objects(ctx, &gcpstorage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if errors.Is(err, gcpstorage.ErrBucketNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, ObjectInfo{
+			Name:    attrs.Name,
+			Size:    attrs.Size,
+			Updated: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
@@ -0,0 +1,546 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// IncrementalManifestSuffix is appended to a backup's name to produce the name of its incremental backup manifest
+	IncrementalManifestSuffix = ".chunks.json"
+
+	// DefaultChunkGCGracePeriod is the minimum age of an unreferenced chunk before it is garbage collected.
+	// Chunks are uploaded before the manifest referencing them, hence we must not delete young chunks.
+	DefaultChunkGCGracePeriod = 24 * time.Hour
+
+	incrementalManifestVersion = 1
+
+	// chunk boundaries are content-defined, so that unchanged content produces the same chunks
+	// even if data was inserted or removed before it.
+	minChunkSize  = 1 * megabytes
+	maxChunkSize  = 16 * megabytes
+	chunkMaskBits = 22 // ~4 MiB average chunk size
+
+	chunkUploadConcurrency   = 4
+	chunkDownloadConcurrency = 4
+
+	// chunkGCLeaseTimeout is the time after which a garbage collection lease is considered abandoned.
+	// Garbage collection stops deleting chunks after half of it, so that uploads never wait on a collection in progress for long.
+	chunkGCLeaseTimeout = 1 * time.Hour
+	// chunkLeasePollInterval is how often uploads check whether garbage collection has released its lease
+	chunkLeasePollInterval = 5 * time.Second
+)
+
+// IncrementalManifest describes an incremental backup. The backup is a tarbal split into
+// content-addressed chunks, which are stored in the chunk store of the backup owner.
+type IncrementalManifest struct {
+	Version int `json:"version"`
+
+	// ChunkPrefix is the prefix of the chunk objects in the bucket the manifest is stored in
+	ChunkPrefix string `json:"chunkPrefix"`
+
+	// Size is the total size of the tarbal
+	Size int64 `json:"size"`
+
+	// Chunks are the chunks the tarbal consists of, in order
+	Chunks []IncrementalChunk `json:"chunks"`
+}
+
+// IncrementalChunk is a single chunk of an incremental backup
+type IncrementalChunk struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+}
+
+// ChunkObject returns the name of the object a chunk is stored in
+func (m *IncrementalManifest) ChunkObject(dgst digest.Digest) string {
+	return chunkObjectName(m.ChunkPrefix, dgst)
+}
+
+func chunkObjectName(prefix string, dgst digest.Digest) string {
+	return path.Join(prefix, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// IncrementalManifestName returns the name of the incremental backup manifest for a backup name.
+// Snapshot names produced by Qualify remain qualified.
+func IncrementalManifestName(name string) string {
+	if obj, bkt, ok := strings.Cut(name, "@"); ok {
+		return fmt.Sprintf("%s%s@%s", obj, IncrementalManifestSuffix, bkt)
+	}
+	return name + IncrementalManifestSuffix
+}
+
+// ParseIncrementalManifest reads an incremental backup manifest
+func ParseIncrementalManifest(r io.Reader) (*IncrementalManifest, error) {
+	var res IncrementalManifest
+	err := json.NewDecoder(r).Decode(&res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse incremental backup manifest: %w", err)
+	}
+	if res.Version != incrementalManifestVersion {
+		return nil, xerrors.Errorf("unsupported incremental backup manifest version %d", res.Version)
+	}
+	for _, c := range res.Chunks {
+		err = c.Digest.Validate()
+		if err != nil {
+			return nil, xerrors.Errorf("invalid chunk digest %s: %w", c.Digest, err)
+		}
+	}
+	return &res, nil
+}
+
+// UploadIncremental splits a tarbal into chunks, uploads all chunks which are not in the chunk store yet,
+// and finally uploads the manifest describing the backup. Returns the object name of the manifest.
+func UploadIncremental(ctx context.Context, ca ChunkAccess, source string, name string) (obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "UploadIncremental")
+	span.SetTag("name", name)
+	defer tracing.FinishSpan(span, &err)
+
+	// We must hold the upload lease until the manifest is written, and must not pick the chunks to reuse
+	// while garbage collection may still delete them.
+	release, err := acquireUploadLease(ctx, ca)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	err = awaitGCLease(ctx, ca)
+	if err != nil {
+		return "", err
+	}
+
+	existing := make(map[string]struct{})
+	objs, err := ca.ListObjects(ctx, ca.ChunkStorePrefix()+"/")
+	if err != nil {
+		return "", xerrors.Errorf("cannot list chunks: %w", err)
+	}
+	for _, o := range objs {
+		existing[o] = struct{}{}
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		return "", xerrors.Errorf("cannot read backup file: %w", err)
+	}
+	defer f.Close()
+
+	manifest := IncrementalManifest{
+		Version:     incrementalManifestVersion,
+		ChunkPrefix: ca.ChunkStorePrefix(),
+	}
+
+	var (
+		eg, egctx = errgroup.WithContext(ctx)
+		uploaded  int
+	)
+	eg.SetLimit(chunkUploadConcurrency)
+	err = splitChunks(f, func(chunk []byte) error {
+		dgst := digest.FromBytes(chunk)
+		manifest.Chunks = append(manifest.Chunks, IncrementalChunk{Digest: dgst, Size: int64(len(chunk))})
+		manifest.Size += int64(len(chunk))
+
+		chunkObj := manifest.ChunkObject(dgst)
+		if _, exists := existing[chunkObj]; exists {
+			return nil
+		}
+		existing[chunkObj] = struct{}{}
+		uploaded++
+
+		// splitChunks reuses its buffer, hence we must copy the chunk before uploading it asynchronously
+		content := make([]byte, len(chunk))
+		copy(content, chunk)
+		eg.Go(func() error {
+			return ca.UploadObject(egctx, chunkObj, content)
+		})
+		return egctx.Err()
+	})
+	if err != nil {
+		_ = eg.Wait()
+		return "", xerrors.Errorf("cannot chunk backup: %w", err)
+	}
+	err = eg.Wait()
+	if err != nil {
+		return "", xerrors.Errorf("cannot upload chunks: %w", err)
+	}
+	span.LogKV("chunks", len(manifest.Chunks), "uploadedChunks", uploaded)
+
+	fc, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	obj = ca.BackupObject(IncrementalManifestName(name))
+	err = ca.UploadObject(ctx, obj, fc)
+	if err != nil {
+		return "", xerrors.Errorf("cannot upload incremental backup manifest: %w", err)
+	}
+
+	return obj, nil
+}
+
+// RestoreIncremental reassembles the tarbal of an incremental backup from its chunks and extracts it to destination.
+// Chunks are fetched ahead of extraction and verified against their digest.
+func RestoreIncremental(ctx context.Context, destination string, manifest *IncrementalManifest, openChunk func(ctx context.Context, chunk IncrementalChunk) (io.ReadCloser, error), mappings []archive.IDMapping) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RestoreIncremental")
+	span.SetTag("chunks", len(manifest.Chunks))
+	defer tracing.FinishSpan(span, &err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		content []byte
+		err     error
+	}
+	var (
+		results = make([]chan result, len(manifest.Chunks))
+		tokens  = make(chan struct{}, chunkDownloadConcurrency)
+	)
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+	go func() {
+		for i, c := range manifest.Chunks {
+			select {
+			case tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(c IncrementalChunk, res chan<- result) {
+				content, err := fetchChunk(ctx, c, openChunk)
+				res <- result{content, err}
+			}(c, results[i])
+		}
+	}()
+
+	pr, pw := io.Pipe()
+	go func() {
+		for i := range manifest.Chunks {
+			var res result
+			select {
+			case res = <-results[i]:
+			case <-ctx.Done():
+				pw.CloseWithError(ctx.Err())
+				return
+			}
+			<-tokens
+
+			if res.err != nil {
+				pw.CloseWithError(res.err)
+				return
+			}
+			_, err := pw.Write(res.content)
+			if err != nil {
+				return
+			}
+		}
+		pw.Close()
+	}()
+
+	err = extractTarbal(ctx, destination, pr, mappings)
+	pr.Close()
+	return err
+}
+
+func fetchChunk(ctx context.Context, c IncrementalChunk, openChunk func(ctx context.Context, chunk IncrementalChunk) (io.ReadCloser, error)) ([]byte, error) {
+	rc, err := openChunk(ctx, c)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download chunk %s: %w", c.Digest, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, c.Size+1))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download chunk %s: %w", c.Digest, err)
+	}
+	if int64(len(content)) != c.Size || c.Digest.Algorithm().FromBytes(content) != c.Digest {
+		return nil, xerrors.Errorf("chunk %s is corrupt", c.Digest)
+	}
+	return content, nil
+}
+
+// CollectGarbageChunks deletes all chunks from the chunk store which are not referenced by any incremental backup
+// manifest and are older than the grace period. Returns the number of deleted chunks.
+// While an upload which started within the grace period is in progress, no chunks are deleted at all.
+func CollectGarbageChunks(ctx context.Context, ca ChunkAccess, gracePeriod time.Duration) (deleted int, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CollectGarbageChunks")
+	defer tracing.FinishSpan(span, &err)
+
+	leaseAcquired := time.Now()
+	release, err := acquireGCLease(ctx, ca)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	uploading, err := hasActiveUploadLease(ctx, ca, gracePeriod)
+	if err != nil {
+		return 0, err
+	}
+	if uploading {
+		log.Debug("skipping chunk garbage collection while an upload is in progress")
+		return 0, nil
+	}
+
+	// We must list the chunks before the manifests. Otherwise a backup completing in between would
+	// produce chunks we consider unreferenced. The grace period guards against backups in progress.
+	chunks, err := ca.ListObjects(ctx, ca.ChunkStorePrefix()+"/")
+	if err != nil {
+		return 0, xerrors.Errorf("cannot list chunks: %w", err)
+	}
+	objs, err := ca.ListObjects(ctx, ca.BackupPrefix()+"/")
+	if err != nil {
+		return 0, xerrors.Errorf("cannot list backups: %w", err)
+	}
+
+	referenced := make(map[string]struct{})
+	for _, obj := range objs {
+		if !strings.HasSuffix(obj, IncrementalManifestSuffix) {
+			continue
+		}
+
+		rc, err := ca.ReadObject(ctx, obj)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return 0, xerrors.Errorf("cannot read manifest %s: %w", obj, err)
+		}
+		manifest, err := ParseIncrementalManifest(rc)
+		rc.Close()
+		if err != nil {
+			// we cannot tell which chunks this manifest references - better not delete anything
+			return 0, xerrors.Errorf("cannot read manifest %s: %w", obj, err)
+		}
+		for _, c := range manifest.Chunks {
+			referenced[manifest.ChunkObject(c.Digest)] = struct{}{}
+		}
+	}
+
+	for _, chunk := range chunks {
+		if _, ok := referenced[chunk]; ok {
+			continue
+		}
+
+		updated, err := ca.ObjectUpdated(ctx, chunk)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return deleted, err
+		}
+		if time.Since(updated) < gracePeriod {
+			continue
+		}
+		if time.Since(leaseAcquired) > chunkGCLeaseTimeout/2 {
+			// uploads consider our lease abandoned soon - we'll continue with the next collection
+			break
+		}
+
+		err = ca.DeleteObject(ctx, chunk)
+		if err != nil && err != ErrNotFound {
+			return deleted, xerrors.Errorf("cannot delete chunk %s: %w", chunk, err)
+		}
+		deleted++
+	}
+	log.WithField("deleted", deleted).WithField("referenced", len(referenced)).Debug("collected garbage chunks")
+
+	return deleted, nil
+}
+
+// Uploads reuse chunks which are already in the chunk store, hence garbage collection must not delete chunks
+// an upload picked for reuse before the upload wrote its manifest. Uploads and garbage collection announce themselves
+// with lease objects next to the chunk store: garbage collection skips the chunk store while an upload holds a lease,
+// and uploads wait for garbage collection to release its lease before they pick the chunks to reuse.
+// Both write their own lease before they check for the other's, hence at least one of them sees the other.
+
+func chunkLeasePrefix(ca ChunkAccess) string {
+	return ca.ChunkStorePrefix() + "-leases"
+}
+
+func gcLeaseObject(ca ChunkAccess) string {
+	return path.Join(chunkLeasePrefix(ca), "gc")
+}
+
+func uploadLeasePrefix(ca ChunkAccess) string {
+	return path.Join(chunkLeasePrefix(ca), "uploads")
+}
+
+func acquireUploadLease(ctx context.Context, ca ChunkAccess) (release func(), err error) {
+	var id [16]byte
+	_, err = rand.Read(id[:])
+	if err != nil {
+		return nil, err
+	}
+	return acquireLease(ctx, ca, path.Join(uploadLeasePrefix(ca), hex.EncodeToString(id[:])))
+}
+
+func acquireGCLease(ctx context.Context, ca ChunkAccess) (release func(), err error) {
+	return acquireLease(ctx, ca, gcLeaseObject(ca))
+}
+
+func acquireLease(ctx context.Context, ca ChunkAccess, obj string) (release func(), err error) {
+	err = ca.UploadObject(ctx, obj, []byte(time.Now().UTC().Format(time.RFC3339)))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot acquire chunk store lease %s: %w", obj, err)
+	}
+	return func() {
+		// the lease must be released even if the operation holding it was canceled
+		err := ca.DeleteObject(context.Background(), obj)
+		if err != nil && err != ErrNotFound {
+			log.WithError(err).WithField("lease", obj).Warn("cannot release chunk store lease")
+		}
+	}, nil
+}
+
+// hasActiveUploadLease returns true if an upload which started within the grace period holds a lease.
+// Older leases were abandoned by uploads which did not finish, and are removed.
+func hasActiveUploadLease(ctx context.Context, ca ChunkAccess, gracePeriod time.Duration) (bool, error) {
+	leases, err := ca.ListObjects(ctx, uploadLeasePrefix(ca)+"/")
+	if err != nil {
+		return false, xerrors.Errorf("cannot list upload leases: %w", err)
+	}
+	var active bool
+	for _, lease := range leases {
+		acquired, err := ca.ObjectUpdated(ctx, lease)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if time.Since(acquired) < gracePeriod {
+			active = true
+			continue
+		}
+		err = ca.DeleteObject(ctx, lease)
+		if err != nil && err != ErrNotFound {
+			return false, xerrors.Errorf("cannot remove abandoned upload lease %s: %w", lease, err)
+		}
+	}
+	return active, nil
+}
+
+// awaitGCLease waits until no garbage collection holds a lease on the chunk store
+func awaitGCLease(ctx context.Context, ca ChunkAccess) error {
+	for {
+		acquired, err := ca.ObjectUpdated(ctx, gcLeaseObject(ca))
+		if err == ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot check garbage collection lease: %w", err)
+		}
+		if time.Since(acquired) > chunkGCLeaseTimeout {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return xerrors.Errorf("garbage collection of the chunk store is in progress: %w", ctx.Err())
+		case <-time.After(chunkLeasePollInterval):
+		}
+	}
+}
+
+// DownloadBackup restores a backup, preferring an incremental backup over the tarbal if the downloader supports it
+func DownloadBackup(ctx context.Context, rs DirectDownloader, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	if inc, ok := rs.(IncrementalDownloader); ok {
+		found, err = inc.DownloadIncremental(ctx, destination, name, mappings)
+		if found || err != nil {
+			return found, err
+		}
+	}
+	return rs.Download(ctx, destination, name, mappings)
+}
+
+// DownloadSnapshotBackup restores a snapshot, preferring an incremental backup over the tarbal if the downloader supports it
+func DownloadSnapshotBackup(ctx context.Context, rs DirectDownloader, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	if inc, ok := rs.(IncrementalDownloader); ok {
+		found, err = inc.DownloadIncremental(ctx, destination, name, mappings)
+		if found || err != nil {
+			return found, err
+		}
+	}
+	return rs.DownloadSnapshot(ctx, destination, name, mappings)
+}
+
+var chunkGear = func() (res [256]uint64) {
+	// splitmix64 with a fixed seed - the table must never change, or chunk boundaries
+	// (and hence deduplication against existing chunks) would change with it.
+	x := uint64(0x6769747061642d63)
+	for i := range res {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		res[i] = z ^ (z >> 31)
+	}
+	return
+}()
+
+// splitChunks splits the content of r into content-defined chunks using a gear hash.
+// The chunk passed to fn is only valid until fn returns.
+func splitChunks(r io.Reader, fn func(chunk []byte) error) error {
+	const mask = (uint64(1) << chunkMaskBits) - 1
+
+	var (
+		br  = bufio.NewReaderSize(r, 1*megabytes)
+		buf = bytes.NewBuffer(make([]byte, 0, maxChunkSize))
+		h   uint64
+	)
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		buf.WriteByte(b)
+		h = (h << 1) + chunkGear[b]
+		if buf.Len() < minChunkSize {
+			continue
+		}
+		if h&mask != 0 && buf.Len() < maxChunkSize {
+			continue
+		}
+
+		err = fn(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		buf.Reset()
+		h = 0
+	}
+
+	if buf.Len() > 0 {
+		return fn(buf.Bytes())
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+func TestSplitChunks(t *testing.T) {
+	content := make([]byte, 40*megabytes)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	split := func(content []byte) (res []string) {
+		err := splitChunks(bytes.NewReader(content), func(chunk []byte) error {
+			if len(chunk) > maxChunkSize {
+				t.Errorf("chunk exceeds max chunk size: %d", len(chunk))
+			}
+			res = append(res, string(chunk))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	chunks := split(content)
+	if len(chunks) < 2 {
+		t.Fatalf("expected content to be split into multiple chunks, got %d", len(chunks))
+	}
+	if joined := strings.Join(chunks, ""); joined != string(content) {
+		t.Fatalf("chunks do not reassemble to the original content")
+	}
+
+	// inserting data at the beginning must only affect the first chunk(s)
+	modified := append([]byte("some prefix which shifts all content"), content...)
+	known := make(map[string]struct{}, len(chunks))
+	for _, c := range chunks {
+		known[c] = struct{}{}
+	}
+	var reused int
+	for _, c := range split(modified) {
+		if _, ok := known[c]; ok {
+			reused++
+		}
+	}
+	if reused < len(chunks)-1 {
+		t.Errorf("expected at least %d chunks to be reused, got %d", len(chunks)-1, reused)
+	}
+}
+
+func TestIncrementalManifestName(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation string
+	}{
+		{Name: "backup", Input: DefaultBackup, Expectation: "full.tar.chunks.json"},
+		{Name: "qualified snapshot", Input: "workspaces/foo/snapshot-1.tar@gitpod-user-bar", Expectation: "workspaces/foo/snapshot-1.tar.chunks.json@gitpod-user-bar"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if act := IncrementalManifestName(test.Input); act != test.Expectation {
+				t.Errorf("unexpected manifest name: is %s but expected %s", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestIncrementalBackup(t *testing.T) {
+	if _, err := os.Stat("/usr/bin/tar"); err != nil {
+		t.Skip("tar is not available")
+	}
+
+	ctx := context.Background()
+	rs, err := newDirectFilesystemAccess(&config.FilesystemConfig{Root: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	large := make([]byte, 24*megabytes)
+	rand.New(rand.NewSource(42)).Read(large)
+	files := map[string][]byte{
+		"README.md": []byte("hello world"),
+		"large.bin": large,
+	}
+
+	upload := func(files map[string][]byte) *IncrementalManifest {
+		src := filepath.Join(t.TempDir(), "backup.tar")
+		writeTestTarbal(t, src, files)
+
+		obj, err := UploadIncremental(ctx, rs, src, DefaultBackup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc, err := rs.ReadObject(ctx, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		manifest, err := ParseIncrementalManifest(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return manifest
+	}
+
+	first := upload(files)
+	chunks, err := rs.ListObjects(ctx, rs.ChunkStorePrefix()+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != len(first.Chunks) {
+		t.Errorf("expected %d chunks in the chunk store, found %d", len(first.Chunks), len(chunks))
+	}
+
+	files["README.md"] = []byte("hello incremental world")
+	second := upload(files)
+	var shared int
+	for _, c := range second.Chunks {
+		for _, p := range first.Chunks {
+			if c.Digest == p.Digest {
+				shared++
+				break
+			}
+		}
+	}
+	if shared == 0 {
+		t.Errorf("expected the second backup to share chunks with the first one")
+	}
+
+	dst := t.TempDir()
+	found, err := DownloadBackup(ctx, &incrementalTestDownloader{rs}, dst, DefaultBackup, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("expected to find the incremental backup")
+	}
+	for name, content := range files {
+		act, err := os.ReadFile(filepath.Join(dst, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(act, content) {
+			t.Errorf("restored content of %s differs", name)
+		}
+	}
+
+	// all chunks of the first backup which aren't part of the second one are garbage - but still within the grace period
+	deleted, err := CollectGarbageChunks(ctx, rs, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 0 {
+		t.Errorf("expected no chunks to be deleted within the grace period, got %d", deleted)
+	}
+	deleted, err = CollectGarbageChunks(ctx, rs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != len(first.Chunks)-shared {
+		t.Errorf("unexpected number of deleted chunks: is %d but expected %d", deleted, len(first.Chunks)-shared)
+	}
+
+	remaining, err := rs.ListObjects(ctx, rs.ChunkStorePrefix()+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected []string
+	for _, c := range second.Chunks {
+		expected = append(expected, second.ChunkObject(c.Digest))
+	}
+	if diff := cmp.Diff(dedupStrings(expected), dedupStrings(remaining)); diff != "" {
+		t.Errorf("unexpected remaining chunks (-want +got):\n%s", diff)
+	}
+}
+
+func TestChunkStoreLeases(t *testing.T) {
+	ctx := context.Background()
+	rs, err := newDirectFilesystemAccess(&config.FilesystemConfig{Root: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	garbage := rs.ChunkStorePrefix() + "/sha256/garbage"
+	err = rs.UploadObject(ctx, garbage, []byte("garbage"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("garbage collection skips chunks while uploads are in progress", func(t *testing.T) {
+		release, err := acquireUploadLease(ctx, rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deleted, err := CollectGarbageChunks(ctx, rs, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 0 {
+			t.Errorf("expected no chunks to be deleted during an upload, got %d", deleted)
+		}
+		if _, err := rs.ObjectUpdated(ctx, garbage); err != nil {
+			t.Errorf("expected garbage chunk to still exist: %v", err)
+		}
+
+		release()
+		leases, err := rs.ListObjects(ctx, uploadLeasePrefix(rs)+"/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(leases) != 0 {
+			t.Errorf("expected upload lease to be released, found %v", leases)
+		}
+	})
+
+	t.Run("uploads wait for garbage collection", func(t *testing.T) {
+		release, err := acquireGCLease(ctx, rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err = awaitGCLease(waitCtx, rs)
+		if err == nil {
+			t.Fatal("expected upload to wait for garbage collection")
+		}
+
+		release()
+		err = awaitGCLease(ctx, rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("abandoned upload leases do not block garbage collection", func(t *testing.T) {
+		_, err := acquireUploadLease(ctx, rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deleted, err := CollectGarbageChunks(ctx, rs, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 1 {
+			t.Errorf("expected garbage chunk to be deleted, got %d deleted chunks", deleted)
+		}
+		leases, err := rs.ListObjects(ctx, uploadLeasePrefix(rs)+"/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(leases) != 0 {
+			t.Errorf("expected abandoned upload lease to be removed, found %v", leases)
+		}
+	})
+}
+
+// incrementalTestDownloader restores incremental backups straight from the chunk store
+type incrementalTestDownloader struct {
+	*DirectFilesystemStorage
+}
+
+func (d *incrementalTestDownloader) DownloadIncremental(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	rc, err := d.ReadObject(ctx, d.BackupObject(IncrementalManifestName(name)))
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	manifest, err := ParseIncrementalManifest(rc)
+	rc.Close()
+	if err != nil {
+		return true, err
+	}
+
+	return true, RestoreIncremental(ctx, destination, manifest, func(ctx context.Context, chunk IncrementalChunk) (io.ReadCloser, error) {
+		return d.ReadObject(ctx, manifest.ChunkObject(chunk.Digest))
+	}, mappings)
+}
+
+func writeTestTarbal(t *testing.T, dst string, files map[string][]byte) {
+	f, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	for _, name := range []string{"large.bin", "README.md"} {
+		content := files[name]
+		err = tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: time.Unix(0, 0),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func dedupStrings(s []string) []string {
+	m := make(map[string]struct{}, len(s))
+	var res []string
+	for _, e := range s {
+		if _, ok := m[e]; ok {
+			continue
+		}
+		m[e] = struct{}{}
+		res = append(res, e)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -5,6 +5,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -27,6 +28,9 @@ import (
 )
 
 var _ DirectAccess = &DirectMinIOStorage{}
+var _ ChunkAccess = &DirectMinIOStorage{}
+var _ ObjectReader = &DirectMinIOStorage{}
+var _ ContentLister = &presignedMinIOStorage{}
 
 // Validate checks if the GCloud storage MinIOconfig is valid
 func ValidateMinIOConfig(c *config.MinIOConfig) error {
@@ -312,6 +316,71 @@ func (rs *DirectMinIOStorage) Upload(ctx context.Context, source string, name st
 	return
 }
 
+// ChunkStorePrefix returns the object prefix under which the owner's chunks are stored
+func (rs *DirectMinIOStorage) ChunkStorePrefix() string {
+	return filepath.Join(rs.ownerPrefix(), "chunks")
+}
+
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (rs *DirectMinIOStorage) BackupPrefix() string {
+	return filepath.Join(rs.ownerPrefix(), "workspaces")
+}
+
+// UploadObject stores content in an object
+func (rs *DirectMinIOStorage) UploadObject(ctx context.Context, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	_, err := rs.client.PutObject(ctx, rs.bucketName(), obj, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	return translateMinioError(err)
+}
+
+// ReadObject reads an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectMinIOStorage) ReadObject(ctx context.Context, obj string) (io.ReadCloser, error) {
+	return rs.ObjectAccess(ctx, rs.bucketName(), obj)
+}
+
+// ObjectAnnotations returns the annotations of an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectMinIOStorage) ObjectAnnotations(ctx context.Context, bkt, obj string) (map[string]string, error) {
+	if rs.client == nil {
+		return nil, xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	info, err := rs.client.StatObject(ctx, bkt, obj, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, translateMinioError(err)
+	}
+	return info.UserMetadata, nil
+}
+
+// ReadBucketObject reads the raw content of an object - if the object is not found, ErrNotFound is returned
+func (rs *DirectMinIOStorage) ReadBucketObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	return rs.ObjectAccess(ctx, bkt, obj)
+}
+
+// ObjectUpdated returns the time an object was last modified - if the object is not found, ErrNotFound is returned
+func (rs *DirectMinIOStorage) ObjectUpdated(ctx context.Context, obj string) (time.Time, error) {
+	if rs.client == nil {
+		return time.Time{}, xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	info, err := rs.client.StatObject(ctx, rs.bucketName(), obj, minio.StatObjectOptions{})
+	if err != nil {
+		return time.Time{}, translateMinioError(err)
+	}
+	return info.LastModified, nil
+}
+
+// DeleteObject deletes an object
+func (rs *DirectMinIOStorage) DeleteObject(ctx context.Context, obj string) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	return translateMinioError(rs.client.RemoveObject(ctx, rs.bucketName(), obj, minio.RemoveObjectOptions{}))
+}
+
 func minioBucketName(ownerID, bucketName string) string {
 	if bucketName != "" {
 		return bucketName
@@ -339,11 +408,15 @@ func (rs *DirectMinIOStorage) bucketName() string {
 }
 
 func (rs *DirectMinIOStorage) objectName(name string) string {
-	var username string
+	return minioWorkspaceBackupObjectName(rs.ownerPrefix(), rs.WorkspaceName, name)
+}
+
+// ownerPrefix is the prefix of all objects of the owner if the bucket is shared between owners
+func (rs *DirectMinIOStorage) ownerPrefix() string {
 	if rs.MinIOConfig.BucketName != "" {
-		username = rs.Username
+		return rs.Username
 	}
-	return minioWorkspaceBackupObjectName(username, rs.WorkspaceName, name)
+	return ""
 }
 
 func newPresignedMinIOAccess(cfg config.MinIOConfig) (*presignedMinIOStorage, error) {
@@ -549,6 +622,70 @@ func (s *presignedMinIOStorage) InstanceObject(ownerID string, workspaceID strin
 	return s.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
 }
 
+// Owners returns the IDs of all owners which have content in the storage
+func (s *presignedMinIOStorage) Owners(ctx context.Context) (owners []string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.Owners")
+	defer tracing.FinishSpan(span, &err)
+
+	if s.MinIOConfig.BucketName == "" {
+		prefix := minioBucketName("", "")
+		buckets, err := s.client.ListBuckets(ctx)
+		if err != nil {
+			return nil, translateMinioError(err)
+		}
+		for _, b := range buckets {
+			if strings.HasPrefix(b.Name, prefix) {
+				owners = append(owners, strings.TrimPrefix(b.Name, prefix))
+			}
+		}
+		return owners, nil
+	}
+
+	// all owners share a bucket and have their own top-level prefix
+	for object := range s.client.ListObjects(ctx, s.MinIOConfig.BucketName, minio.ListObjectsOptions{}) {
+		if object.Err != nil {
+			return nil, translateMinioError(object.Err)
+		}
+		if strings.HasSuffix(object.Key, "/") {
+			owners = append(owners, strings.TrimSuffix(object.Key, "/"))
+		}
+	}
+	return owners, nil
+}
+
+// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+func (s *presignedMinIOStorage) BackupPrefix(ownerID string) string {
+	var username string
+	if s.MinIOConfig.BucketName != "" {
+		username = ownerID
+	}
+	return filepath.Join(username, "workspaces") + "/"
+}
+
+// ListBucketObjects returns all objects in the bucket whose name has the given prefix
+func (s *presignedMinIOStorage) ListBucketObjects(ctx context.Context, bkt, prefix string) (objects []ObjectInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.ListBucketObjects")
+	defer tracing.FinishSpan(span, &err)
+
+	for object := range s.client.ListObjects(ctx, bkt, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			err = translateMinioError(object.Err)
+			if err == ErrNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+		objects = append(objects, ObjectInfo{
+			Name:    object.Key,
+			Size:    object.Size,
+			Updated: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
 func translateMinioError(err error) error {
 	if err == nil {
 		return nil
@@ -5,12 +5,15 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
@@ -30,7 +33,10 @@ const (
 )
 
 var _ DirectAccess = &s3Storage{}
+var _ ChunkAccess = &s3Storage{}
+var _ ObjectReader = &s3Storage{}
 var _ PresignedAccess = &PresignedS3Storage{}
+var _ ContentLister = &PresignedS3Storage{}
 
 type S3Config struct {
 	Bucket string
@@ -143,16 +149,13 @@ func (rs *PresignedS3Storage) DeleteObject(ctx context.Context, bucket string, q
 
 // DiskUsage implements PresignedAccess
 func (rs *PresignedS3Storage) DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error) {
-	resp, err := rs.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
-		Bucket: &rs.Config.Bucket,
-		Prefix: aws.String(prefix),
-	})
+	objects, err := rs.ListBucketObjects(ctx, bucket, prefix)
 	if err != nil {
 		return 0, err
 	}
 
-	for _, r := range resp.Contents {
-		size += int64(r.Size)
+	for _, obj := range objects {
+		size += obj.Size
 	}
 	return
 }
@@ -167,6 +170,64 @@ func (rs *PresignedS3Storage) InstanceObject(ownerID string, workspaceID string,
 	return rs.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
 }
 
+// Owners implements ContentLister
+func (rs *PresignedS3Storage) Owners(ctx context.Context) (owners []string, err error) {
+	// all owners share the bucket and have their own top-level prefix
+	var token *string
+	for {
+		resp, err := rs.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            &rs.Config.Bucket,
+			Delimiter:         aws.String("/"),
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range resp.CommonPrefixes {
+			owners = append(owners, strings.TrimSuffix(aws.ToString(p.Prefix), "/"))
+		}
+		if !resp.IsTruncated {
+			return owners, nil
+		}
+		token = resp.NextContinuationToken
+	}
+}
+
+// BackupPrefix implements ContentLister
+func (rs *PresignedS3Storage) BackupPrefix(ownerID string) string {
+	return filepath.Join(ownerID, "workspaces") + "/"
+}
+
+// ListBucketObjects implements ContentLister
+func (rs *PresignedS3Storage) ListBucketObjects(ctx context.Context, bkt, prefix string) (objects []ObjectInfo, err error) {
+	var token *string
+	for {
+		resp, err := rs.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            &rs.Config.Bucket,
+			Prefix:            aws.String(prefix),
+			ContinuationToken: token,
+		})
+		var nsb *types.NoSuchBucket
+		if errors.As(err, &nsb) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Contents {
+			objects = append(objects, ObjectInfo{
+				Name:    aws.ToString(r.Key),
+				Size:    int64(r.Size),
+				Updated: aws.ToTime(r.LastModified),
+			})
+		}
+		if !resp.IsTruncated {
+			return objects, nil
+		}
+		token = resp.NextContinuationToken
+	}
+}
+
 // ObjectExists implements PresignedAccess
 func (rs *PresignedS3Storage) ObjectExists(ctx context.Context, bucket string, path string) (bool, error) {
 	_, err := rs.client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
@@ -379,6 +440,102 @@ func s3WorkspaceBackupObjectName(ownerID, workspaceID, name string) string {
 	return filepath.Join(ownerID, "workspaces", workspaceID, name)
 }
 
+// ChunkStorePrefix implements ChunkAccess
+func (s3st *s3Storage) ChunkStorePrefix() string {
+	return filepath.Join(s3st.OwnerID, "chunks")
+}
+
+// BackupPrefix implements ChunkAccess
+func (s3st *s3Storage) BackupPrefix() string {
+	return filepath.Join(s3st.OwnerID, "workspaces")
+}
+
+// UploadObject implements ChunkAccess
+func (s3st *s3Storage) UploadObject(ctx context.Context, obj string, content []byte) error {
+	s3c, ok := s3st.client.(*s3.Client)
+	if !ok {
+		return xerrors.Errorf("Can only upload with actual S3 client")
+	}
+
+	_, err := s3manager.NewUploader(s3c).Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s3st.Config.Bucket),
+		Key:    aws.String(obj),
+		Body:   bytes.NewReader(content),
+	})
+	return err
+}
+
+// ReadObject implements ChunkAccess
+func (s3st *s3Storage) ReadObject(ctx context.Context, obj string) (io.ReadCloser, error) {
+	return s3st.ReadBucketObject(ctx, s3st.Config.Bucket, obj)
+}
+
+// ObjectAnnotations implements ObjectReader
+func (s3st *s3Storage) ObjectAnnotations(ctx context.Context, bkt, obj string) (map[string]string, error) {
+	resp, err := s3st.getObject(ctx, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp.Metadata, nil
+}
+
+// ReadBucketObject implements ObjectReader
+func (s3st *s3Storage) ReadBucketObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	resp, err := s3st.getObject(ctx, bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s3st *s3Storage) getObject(ctx context.Context, bkt, obj string) (*s3.GetObjectOutput, error) {
+	resp, err := s3st.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bkt),
+		Key:    aws.String(obj),
+	})
+	var nsk *types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ObjectUpdated implements ChunkAccess
+func (s3st *s3Storage) ObjectUpdated(ctx context.Context, obj string) (time.Time, error) {
+	resp, err := s3st.client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+		Bucket:           aws.String(s3st.Config.Bucket),
+		Key:              aws.String(obj),
+		ObjectAttributes: []types.ObjectAttributes{types.ObjectAttributesEtag},
+	})
+	var nsk *types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return time.Time{}, ErrNotFound
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if resp.LastModified == nil {
+		return time.Time{}, xerrors.Errorf("no modification time for %s", obj)
+	}
+	return *resp.LastModified, nil
+}
+
+// DeleteObject implements ChunkAccess
+func (s3st *s3Storage) DeleteObject(ctx context.Context, obj string) error {
+	_, err := s3st.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(s3st.Config.Bucket),
+		Delete: &types.Delete{
+			Objects: []types.ObjectIdentifier{{Key: aws.String(obj)}},
+			Quiet:   true,
+		},
+	})
+	return err
+}
+
 // Upload implements DirectAccess
 func (s3st *s3Storage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket string, obj string, err error) {
 	options, err := GetUploadOptions(opts)
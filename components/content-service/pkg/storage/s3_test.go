@@ -61,3 +61,34 @@ func TestS3PresignedHappyPath(t *testing.T) {
 
 	SuiteTestPresignedAccess(t, ps)
 }
+
+func TestS3DiskUsagePaginates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s3c := mock.NewMockS3Client(ctrl)
+	gomock.InOrder(
+		s3c.EXPECT().ListObjectsV2(gomock.Any(), gomock.Any()).Return(&s3.ListObjectsV2Output{
+			Contents:              []types.Object{{Key: aws.String("a"), Size: 100}, {Key: aws.String("b"), Size: 200}},
+			IsTruncated:           true,
+			NextContinuationToken: aws.String("page-2"),
+		}, nil),
+		s3c.EXPECT().ListObjectsV2(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			if aws.ToString(params.ContinuationToken) != "page-2" {
+				t.Errorf("expected the second page to be requested, got token %q", aws.ToString(params.ContinuationToken))
+			}
+			return &s3.ListObjectsV2Output{
+				Contents: []types.Object{{Key: aws.String("c"), Size: 300}},
+			}, nil
+		}),
+	)
+
+	dut := storage.NewPresignedS3Access(s3c, storage.S3Config{Bucket: "test-bucket"})
+	size, err := dut.DiskUsage(context.Background(), "test-bucket", "owner/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 600 {
+		t.Errorf("disk usage is %d but expected 600", size)
+	}
+}
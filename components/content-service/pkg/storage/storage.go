@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"time"
 
 	"golang.org/x/xerrors"
 
@@ -28,6 +29,9 @@ const (
 
 	// DefaultBackupManifest is the name of the manifest of the regular default backup we upload
 	DefaultBackupManifest = "wsfull.json"
+
+	// DefaultCheckpoint is the name of the process checkpoint we upload next to the regular backup when a workspace hibernates
+	DefaultCheckpoint = "checkpoint.tar"
 )
 
 var (
@@ -108,6 +112,9 @@ type DownloadInfo struct {
 	Meta ObjectMeta
 	URL  string
 	Size int64
+
+	// DataKey is the unwrapped data key of a client-side encrypted object
+	DataKey []byte
 }
 
 // UploadInfo describes an object for upload
@@ -163,6 +170,80 @@ type DirectAccess interface {
 	UploadInstance(ctx context.Context, source string, name string, options ...UploadOption) (bucket, obj string, err error)
 }
 
+// ChunkAccess provides access to the chunk store of incremental backups. The chunk store is shared
+// between all workspaces of an owner, so that chunks are deduplicated across backups.
+type ChunkAccess interface {
+	DirectAccess
+
+	// ChunkStorePrefix returns the object prefix under which the owner's chunks are stored
+	ChunkStorePrefix() string
+
+	// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+	BackupPrefix() string
+
+	// UploadObject stores content in an object
+	UploadObject(ctx context.Context, obj string, content []byte) error
+
+	// ReadObject reads an object - if the object is not found, ErrNotFound is returned
+	ReadObject(ctx context.Context, obj string) (io.ReadCloser, error)
+
+	// ObjectUpdated returns the time an object was last modified - if the object is not found, ErrNotFound is returned
+	ObjectUpdated(ctx context.Context, obj string) (time.Time, error)
+
+	// DeleteObject deletes an object
+	DeleteObject(ctx context.Context, obj string) error
+}
+
+// IncrementalDownloader restores incremental backups
+type IncrementalDownloader interface {
+	// DownloadIncremental restores the incremental backup of the given name. Returns found == false if there is no such backup.
+	DownloadIncremental(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error)
+}
+
+// DownloadInfoProvider describes the objects a direct downloader would download, so that they can be downloaded by other means
+type DownloadInfoProvider interface {
+	// DownloadInfo describes the object of the given name - if the object is not found, ErrNotFound is returned
+	DownloadInfo(name string) (*DownloadInfo, error)
+}
+
+// ObjectReader provides raw access to objects in arbitrary buckets
+type ObjectReader interface {
+	// ObjectAnnotations returns the annotations of an object - if the object is not found, ErrNotFound is returned
+	ObjectAnnotations(ctx context.Context, bkt, obj string) (map[string]string, error)
+
+	// ReadBucketObject reads the raw content of an object - if the object is not found, ErrNotFound is returned
+	ReadBucketObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error)
+}
+
+// EncryptedAccess provides access to the data keys of client-side encrypted objects
+type EncryptedAccess interface {
+	// ObjectDataKey returns the unwrapped data key of an object, or nil if the object is not encrypted.
+	// If the object is not found, ErrNotFound is returned.
+	ObjectDataKey(ctx context.Context, bkt, obj string) ([]byte, error)
+
+	// DecryptedObject reads an object and decrypts it, unless it is not encrypted - if the object is not found, ErrNotFound is returned
+	DecryptedObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error)
+}
+
+// ObjectInfo describes an object in remote storage
+type ObjectInfo struct {
+	Name    string
+	Size    int64
+	Updated time.Time
+}
+
+// ContentLister enumerates the content of all owners, e.g. to enforce retention rules
+type ContentLister interface {
+	// Owners returns the IDs of all owners which have content in the remote storage
+	Owners(ctx context.Context) ([]string, error)
+
+	// BackupPrefix returns the object prefix under which the backups of all workspaces of the owner are stored
+	BackupPrefix(ownerID string) string
+
+	// ListBucketObjects returns all objects in the bucket whose name has the given prefix. Returns an empty list if the bucket does not exist.
+	ListBucketObjects(ctx context.Context, bkt, prefix string) ([]ObjectInfo, error)
+}
+
 // UploadOptions configure remote storage upload
 type UploadOptions struct {
 	// Annotations are generic metadata atteched to a storage object
@@ -220,6 +301,17 @@ func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 		return nil, xerrors.Errorf("missing storage stage")
 	}
 
+	rs, err := newDirectAccess(c, stage)
+	if err != nil {
+		return nil, err
+	}
+	if c.Encryption != nil {
+		return newEncryptedDirectAccess(rs, c.Encryption)
+	}
+	return rs, nil
+}
+
+func newDirectAccess(c *config.StorageConfig, stage config.Stage) (DirectAccess, error) {
 	switch c.Kind {
 	case config.GCloudStorage:
 		return newDirectGCPAccess(c.GCloudConfig, stage)
@@ -234,6 +326,8 @@ func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 		return newDirectS3Access(s3.NewFromConfig(*cfg), S3Config{
 			Bucket: c.S3Config.Bucket,
 		}), nil
+	case config.FilesystemStorage:
+		return newDirectFilesystemAccess(c.FilesystemConfig)
 	default:
 		return &DirectNoopStorage{}, nil
 	}
@@ -260,6 +354,8 @@ func NewPresignedAccess(c *config.StorageConfig) (PresignedAccess, error) {
 		return NewPresignedS3Access(s3.NewFromConfig(*cfg), S3Config{
 			Bucket: c.S3Config.Bucket,
 		}), nil
+	case config.FilesystemStorage:
+		return NewPresignedFilesystemAccess(c.FilesystemConfig)
 	default:
 		log.Warnf("falling back to noop presigned storage access. Is this intentional? (storage kind: %s)", c.Kind)
 		return &PresignedNoopStorage{}, nil
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
@@ -44,7 +45,7 @@ var listTasksCmd = &cobra.Command{
 		}
 
 		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Terminal ID", "Name", "State"})
+		table.SetHeader([]string{"Terminal ID", "Name", "State", "Health", "Restarts"})
 		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 		table.SetCenterSeparator("|")
 
@@ -52,6 +53,15 @@ var listTasksCmd = &cobra.Command{
 			0: tablewriter.FgHiGreenColor,
 			1: tablewriter.FgHiGreenColor,
 			2: tablewriter.FgHiBlackColor,
+			3: tablewriter.FgHiYellowColor,
+		}
+
+		mapHealthToColor := map[string]int{
+			"ready":     tablewriter.FgHiGreenColor,
+			"healthy":   tablewriter.FgHiGreenColor,
+			"starting":  tablewriter.FgHiYellowColor,
+			"not ready": tablewriter.FgHiRedColor,
+			"unhealthy": tablewriter.FgHiRedColor,
 		}
 
 		mapCurrentToColor := map[bool]int{
@@ -78,10 +88,10 @@ var listTasksCmd = &cobra.Command{
 			}
 
 			if !noColor && utils.ColorsEnabled() {
-				colors = []tablewriter.Colors{{mapCurrentToColor[isCurrent]}, {}, {mapStatusToColor[task.State]}}
+				colors = []tablewriter.Colors{{mapCurrentToColor[isCurrent]}, {}, {mapStatusToColor[task.State]}, {mapHealthToColor[taskHealth(task)]}, {}}
 			}
 
-			table.Rich([]string{task.Terminal, task.Presentation.Name, task.State.String()}, colors)
+			table.Rich([]string{task.Terminal, task.Presentation.Name, task.State.String(), taskHealth(task), strconv.FormatUint(uint64(task.RestartCount), 10)}, colors)
 		}
 
 		table.Render()
@@ -93,3 +103,25 @@ func init() {
 	listTasksCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Disable output colorization")
 	tasksCmd.AddCommand(listTasksCmd)
 }
+
+// taskHealth summarizes the probe states of a task
+func taskHealth(task *api.TaskStatus) string {
+	if task.State != api.TaskState_running {
+		return "-"
+	}
+	switch {
+	case task.Liveness == api.TaskProbeState_probe_failing:
+		return "unhealthy"
+	case task.Readiness == api.TaskProbeState_probe_failing:
+		return "not ready"
+	case task.Readiness == api.TaskProbeState_probe_pending:
+		return "starting"
+	case task.Readiness == api.TaskProbeState_probe_passing:
+		return "ready"
+	case task.Liveness == api.TaskProbeState_probe_passing:
+		return "healthy"
+	case task.Liveness == api.TaskProbeState_probe_pending:
+		return "starting"
+	}
+	return "-"
+}
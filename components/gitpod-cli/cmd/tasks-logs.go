@@ -0,0 +1,171 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var logsTaskCmdOpts struct {
+	Since      string
+	Until      string
+	Grep       string
+	Limit      int
+	Timestamps bool
+}
+
+// logsTaskCmd represents the logs task command
+var logsTaskCmd = &cobra.Command{
+	Use:   "logs <id>",
+	Short: "Print the recorded output of a workspace task",
+	Long: `Print the recorded output of a workspace task, including output from before a restart of the workspace.
+Requires the task's scrollback to be persisted in the workspace.`,
+	Example: `  gp tasks logs --since 10m
+  gp tasks logs <id> --since 2023-05-01T10:00:00Z --grep "error|warn"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		since, err := parseLogsTime(logsTaskCmdOpts.Since, now)
+		if err != nil {
+			return xerrors.Errorf("invalid --since: %w", err)
+		}
+		until, err := parseLogsTime(logsTaskCmdOpts.Until, now)
+		if err != nil {
+			return xerrors.Errorf("invalid --until: %w", err)
+		}
+
+		client, err := supervisor.New(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		var terminalAlias string
+		if len(args) > 0 {
+			terminalAlias = args[0]
+		} else {
+			tasks, err := client.GetTasksList(cmd.Context())
+			if err != nil {
+				return xerrors.Errorf("cannot get task list: %w", err)
+			}
+
+			var started []*api.TaskStatus
+			for _, task := range tasks {
+				if task.Terminal != "" {
+					started = append(started, task)
+				}
+			}
+			if len(started) == 0 {
+				fmt.Println("There are no started tasks")
+				return nil
+			}
+
+			var taskIndex int
+			if len(started) > 1 {
+				var taskNames []string
+				for _, task := range started {
+					taskNames = append(taskNames, task.Presentation.Name)
+				}
+
+				prompt := promptui.Select{
+					Label: "What task do you want to see the logs of?",
+					Items: taskNames,
+				}
+
+				selectedIndex, selectedValue, err := prompt.Run()
+				if selectedValue == "" {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				taskIndex = selectedIndex
+			}
+			terminalAlias = started[taskIndex].Terminal
+		}
+
+		req := &api.TerminalHistoryRequest{
+			Alias: terminalAlias,
+			Grep:  logsTaskCmdOpts.Grep,
+		}
+		if !since.IsZero() {
+			req.Since = timestamppb.New(since)
+		}
+		if !until.IsZero() {
+			req.Until = timestamppb.New(until)
+		}
+
+		var printed int
+		for {
+			if logsTaskCmdOpts.Limit > 0 {
+				req.PageSize = uint32(logsTaskCmdOpts.Limit - printed)
+			}
+			resp, err := client.Terminal.History(cmd.Context(), req)
+			if err != nil {
+				if e, ok := status.FromError(err); ok {
+					switch e.Code() {
+					case codes.NotFound:
+						fmt.Println("Terminal not found:", terminalAlias)
+						return nil
+					case codes.FailedPrecondition:
+						fmt.Println("The output of this task is not persisted")
+						return nil
+					}
+				}
+				return err
+			}
+
+			for _, line := range resp.Lines {
+				if logsTaskCmdOpts.Timestamps {
+					fmt.Fprint(os.Stdout, line.Time.AsTime().Local().Format(time.RFC3339), " ")
+				}
+				_, _ = os.Stdout.Write(line.Data)
+				fmt.Fprintln(os.Stdout)
+			}
+			printed += len(resp.Lines)
+
+			if resp.NextPageToken == "" || (logsTaskCmdOpts.Limit > 0 && printed >= logsTaskCmdOpts.Limit) {
+				return nil
+			}
+			req.PageToken = resp.NextPageToken
+		}
+	},
+}
+
+// parseLogsTime parses either a duration relative to now or an RFC3339 timestamp.
+func parseLogsTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("%q is neither a duration (e.g. 10m) nor an RFC3339 timestamp", value)
+	}
+	return t, nil
+}
+
+func init() {
+	tasksCmd.AddCommand(logsTaskCmd)
+
+	logsTaskCmd.Flags().StringVar(&logsTaskCmdOpts.Since, "since", "", "only show output since a duration ago (e.g. 10m) or an RFC3339 timestamp")
+	logsTaskCmd.Flags().StringVar(&logsTaskCmdOpts.Until, "until", "", "only show output until a duration ago (e.g. 1m) or an RFC3339 timestamp")
+	logsTaskCmd.Flags().StringVarP(&logsTaskCmdOpts.Grep, "grep", "g", "", "only show lines matching a regular expression")
+	logsTaskCmd.Flags().IntVarP(&logsTaskCmdOpts.Limit, "limit", "n", 0, "maximum number of lines to show, 0 for all")
+	logsTaskCmd.Flags().BoolVarP(&logsTaskCmdOpts.Timestamps, "timestamps", "t", false, "prefix each line with the time it was written")
+}
@@ -33,7 +33,7 @@ type topData struct {
 
 var topCmd = &cobra.Command{
 	Use:   "top",
-	Short: "Display usage of workspace resources (CPU and memory)",
+	Short: "Display usage of workspace resources (CPU, memory and disk)",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
 		defer cancel()
@@ -108,6 +108,18 @@ func outputTable(workspaceResources *api.ResourcesStatusResponse, workspaceClass
 	table.Rich([]string{"CPU (millicores)", cpu}, cpuColors)
 	table.Rich([]string{"Memory (bytes)", memory}, memoryColors)
 
+	// storage is only reported if the workspace has a storage quota
+	if storage := workspaceResources.Storage; storage != nil && storage.Limit > 0 {
+		storageFraction := int64((float64(storage.Used) / float64(storage.Limit)) * 100)
+		disk := fmt.Sprintf("%dMi/%dMi (%d%%)", storage.Used/(1024*1024), storage.Limit/(1024*1024), storageFraction)
+
+		var storageColors []tablewriter.Colors
+		if !noColor && utils.ColorsEnabled() {
+			storageColors = []tablewriter.Colors{nil, {getColor(storage.Severity)}}
+		}
+		table.Rich([]string{"Disk (bytes)", disk}, storageColors)
+	}
+
 	table.Render()
 }
 
@@ -6,6 +6,8 @@ package db
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -34,6 +36,7 @@ type CostCenter struct {
 	BillingStrategy   BillingStrategy `gorm:"column:billingStrategy;type:varchar;size:255;" json:"billingStrategy"`
 	BillingCycleStart VarcharTime     `gorm:"column:billingCycleStart;type:varchar;size:255;" json:"billingCycleStart"`
 	NextBillingTime   VarcharTime     `gorm:"column:nextBillingTime;type:varchar;size:255;" json:"nextBillingTime"`
+	AlertThresholds   AlertThresholds `gorm:"column:alertThresholds;type:varchar;size:255;" json:"alertThresholds"`
 	LastModified      time.Time       `gorm:"->;column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
 }
 
@@ -42,6 +45,45 @@ func (c *CostCenter) TableName() string {
 	return "d_b_cost_center"
 }
 
+// AlertThresholds are the percentages of the spending limit at which budget alerts fire.
+// Cost centers without thresholds (nil) use the default thresholds, an empty list disables alerts.
+type AlertThresholds []int32
+
+// Scan() and Value() store the thresholds as JSON list, and the absence of thresholds as NULL
+func (t *AlertThresholds) Scan(src any) error {
+	var fc []byte
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		fc = v
+	case string:
+		fc = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into alert thresholds", src)
+	}
+
+	res := AlertThresholds{}
+	err := json.Unmarshal(fc, &res)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal alert thresholds: %w", err)
+	}
+	*t = res
+	return nil
+}
+
+func (t AlertThresholds) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	fc, err := json.Marshal([]int32(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(fc), nil
+}
+
 func (c *CostCenter) IsExpired() bool {
 	if !c.NextBillingTime.IsSet() {
 		return false
@@ -220,6 +262,7 @@ func (c *CostCenterManager) IncrementBillingCycle(ctx context.Context, attributi
 		BillingStrategy:   cc.BillingStrategy,
 		BillingCycleStart: billingCycleStart,
 		NextBillingTime:   NewVarCharTime(billingCycleStart.Time().AddDate(0, 1, 0)),
+		AlertThresholds:   cc.AlertThresholds,
 		CreationTime:      NewVarCharTime(now),
 	}
 	err = c.conn.Save(&newCostCenter).Error
@@ -248,6 +291,8 @@ func (c *CostCenterManager) UpdateCostCenter(ctx context.Context, newCC CostCent
 	// we don't allow setting billingCycleStart or nextBillingTime from outside
 	newCC.BillingCycleStart = existingCC.BillingCycleStart
 	newCC.NextBillingTime = existingCC.NextBillingTime
+	// alert thresholds are set through SetAlertThresholds
+	newCC.AlertThresholds = existingCC.AlertThresholds
 
 	// Transitioning into free plan
 	if existingCC.BillingStrategy != CostCenter_Other && newCC.BillingStrategy == CostCenter_Other {
@@ -280,6 +325,29 @@ func (c *CostCenterManager) UpdateCostCenter(ctx context.Context, newCC CostCent
 	return newCC, nil
 }
 
+// SetAlertThresholds sets the budget alert thresholds of a cost center. Passing nil resets the cost center to the default thresholds.
+func (c *CostCenterManager) SetAlertThresholds(ctx context.Context, attributionID AttributionID, thresholds AlertThresholds) (CostCenter, error) {
+	for _, t := range thresholds {
+		if t <= 0 {
+			return CostCenter{}, status.Errorf(codes.InvalidArgument, "Alert thresholds must be positive percentages.")
+		}
+	}
+
+	cc, err := c.GetOrCreateCostCenter(ctx, attributionID)
+	if err != nil {
+		return CostCenter{}, err
+	}
+
+	// cost centers are versioned by their creationTime, hence we store a new version rather than updating the existing one
+	cc.AlertThresholds = thresholds
+	cc.CreationTime = NewVarCharTime(time.Now())
+	err = c.conn.WithContext(ctx).Save(&cc).Error
+	if err != nil {
+		return CostCenter{}, fmt.Errorf("failed to save alert thresholds for attributionID %s: %w", attributionID, err)
+	}
+	return cc, nil
+}
+
 func (c *CostCenterManager) getPreviousSpendingLimit(attributionID AttributionID) (int32, error) {
 	var previousCostCenter CostCenter
 	// find the youngest cost center with billingStrategy='other'
@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CostCenterAlert records that a sink delivered the alert about usage of a cost center crossing a threshold during a billing cycle
+type CostCenterAlert struct {
+	AttributionID     AttributionID `gorm:"primary_key;column:attributionId;type:varchar;size:255;" json:"attributionId"`
+	BillingCycleStart VarcharTime   `gorm:"primary_key;column:billingCycleStart;type:varchar;size:30;" json:"billingCycleStart"`
+	// Threshold is the percentage of the spending limit which was crossed
+	Threshold int32 `gorm:"primary_key;column:threshold;type:int;" json:"threshold"`
+	// Sink is the name of the sink which delivered the alert. Every sink delivers each alert once.
+	Sink    string      `gorm:"primary_key;column:sink;type:varchar;size:255;" json:"sink"`
+	FiredAt VarcharTime `gorm:"column:firedAt;type:varchar;size:30;" json:"firedAt"`
+
+	LastModified time.Time `gorm:"->;column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (a *CostCenterAlert) TableName() string {
+	return "d_b_cost_center_alert"
+}
+
+// RecordCostCenterAlerts stores alerts which have been delivered. Alerts which have been recorded before are ignored.
+func RecordCostCenterAlerts(ctx context.Context, conn *gorm.DB, alerts ...CostCenterAlert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	for i := range alerts {
+		if !alerts[i].FiredAt.IsSet() {
+			alerts[i].FiredAt = NewVarCharTime(time.Now())
+		}
+	}
+
+	tx := conn.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(alerts, 100)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to record cost center alerts: %w", tx.Error)
+	}
+	return nil
+}
+
+// ListCostCenterAlerts returns the alerts which have been delivered for an attribution ID in the billing cycle, by any sink
+func ListCostCenterAlerts(ctx context.Context, conn *gorm.DB, attributionID AttributionID, billingCycleStart VarcharTime) ([]CostCenterAlert, error) {
+	var alerts []CostCenterAlert
+	tx := conn.WithContext(ctx).
+		Where("attributionId = ?", string(attributionID)).
+		Where("billingCycleStart = ?", billingCycleStart.String()).
+		Order("threshold, sink").
+		Find(&alerts)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list cost center alerts for %s: %w", attributionID, tx.Error)
+	}
+	return alerts, nil
+}
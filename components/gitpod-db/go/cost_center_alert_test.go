@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordCostCenterAlerts(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	cycle := db.NewVarCharTime(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	nextCycle := db.NewVarCharTime(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC))
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where("attributionId = ?", string(attributionID)).Delete(&db.CostCenterAlert{}).Error)
+	})
+
+	require.NoError(t, db.RecordCostCenterAlerts(ctx, conn,
+		db.CostCenterAlert{AttributionID: attributionID, BillingCycleStart: cycle, Threshold: 80, Sink: "log"},
+		db.CostCenterAlert{AttributionID: attributionID, BillingCycleStart: cycle, Threshold: 50, Sink: "log"},
+		db.CostCenterAlert{AttributionID: attributionID, BillingCycleStart: cycle, Threshold: 50, Sink: "webhook"},
+	))
+	// recording an alert a second time is a no-op
+	require.NoError(t, db.RecordCostCenterAlerts(ctx, conn,
+		db.CostCenterAlert{AttributionID: attributionID, BillingCycleStart: cycle, Threshold: 80, Sink: "log"},
+		db.CostCenterAlert{AttributionID: attributionID, BillingCycleStart: nextCycle, Threshold: 50, Sink: "log"},
+	))
+
+	alerts, err := db.ListCostCenterAlerts(ctx, conn, attributionID, cycle)
+	require.NoError(t, err)
+	require.Len(t, alerts, 3)
+	require.Equal(t, int32(50), alerts[0].Threshold)
+	require.Equal(t, "log", alerts[0].Sink)
+	require.Equal(t, int32(50), alerts[1].Threshold)
+	require.Equal(t, "webhook", alerts[1].Sink)
+	require.Equal(t, int32(80), alerts[2].Threshold)
+	require.True(t, alerts[0].FiredAt.IsSet())
+
+	alerts, err = db.ListCostCenterAlerts(ctx, conn, attributionID, nextCycle)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+}
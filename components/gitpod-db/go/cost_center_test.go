@@ -494,3 +494,48 @@ func TestCostCenterManager_ResetUsage(t *testing.T) {
 	})
 
 }
+
+func TestCostCenterManager_SetAlertThresholds(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	mnr := db.NewCostCenterManager(conn, db.DefaultSpendingLimit{})
+	team := db.NewTeamAttributionID(uuid.New().String())
+	cleanUp(t, conn, team)
+	ctx := context.Background()
+
+	cc, err := mnr.GetOrCreateCostCenter(ctx, team)
+	require.NoError(t, err)
+	require.Nil(t, cc.AlertThresholds, "new cost centers use the default thresholds")
+
+	_, err = mnr.SetAlertThresholds(ctx, team, db.AlertThresholds{0})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = mnr.SetAlertThresholds(ctx, team, db.AlertThresholds{50, 90})
+	require.NoError(t, err)
+	cc, err = mnr.GetOrCreateCostCenter(ctx, team)
+	require.NoError(t, err)
+	require.Equal(t, db.AlertThresholds{50, 90}, cc.AlertThresholds)
+
+	// updating the cost center keeps its thresholds
+	cc, err = mnr.UpdateCostCenter(ctx, db.CostCenter{ID: team, SpendingLimit: 200, BillingStrategy: db.CostCenter_Other})
+	require.NoError(t, err)
+	require.Equal(t, db.AlertThresholds{50, 90}, cc.AlertThresholds)
+
+	// an empty list disables alerts, which is different from the default thresholds
+	_, err = mnr.SetAlertThresholds(ctx, team, db.AlertThresholds{})
+	require.NoError(t, err)
+	cc, err = mnr.GetOrCreateCostCenter(ctx, team)
+	require.NoError(t, err)
+	require.NotNil(t, cc.AlertThresholds)
+	require.Empty(t, cc.AlertThresholds)
+}
+
+func TestAlertThresholds_ScanValue(t *testing.T) {
+	for _, thresholds := range []db.AlertThresholds{nil, {}, {50, 80, 100}} {
+		value, err := thresholds.Value()
+		require.NoError(t, err)
+
+		var scanned db.AlertThresholds
+		require.NoError(t, scanned.Scan(value))
+		require.Equal(t, thresholds, scanned)
+	}
+}
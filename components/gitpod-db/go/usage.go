@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +24,7 @@ const (
 	WorkspaceInstanceUsageKind UsageKind = "workspaceinstance"
 	InvoiceUsageKind           UsageKind = "invoice"
 	CreditNoteKind             UsageKind = "creditnote"
+	StorageUsageKind           UsageKind = "storage"
 )
 
 func NewCreditCents(n float64) CreditCents {
@@ -58,6 +60,16 @@ func (u *Usage) SetMetadataWithWorkspaceInstance(data WorkspaceInstanceUsageData
 	return nil
 }
 
+func (u *Usage) SetMetadataWithStorage(data StorageUsageData) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to serialize storage usage data into json: %w", err)
+	}
+
+	u.Metadata = b
+	return nil
+}
+
 func (u *Usage) SetCreditNoteMetaData(data CreditNoteMetaData) error {
 	b, err := json.Marshal(data)
 	if err != nil {
@@ -90,6 +102,25 @@ type WorkspaceInstanceUsageData struct {
 	UserID         uuid.UUID     `json:"userId"`
 	UserName       string        `json:"userName"`
 	UserAvatarURL  string        `json:"userAvatarURL"`
+
+	// PricingVersion, PricingRule and PricingDiscounts identify the pricing rule which produced the charge
+	PricingVersion   string   `json:"pricingVersion,omitempty"`
+	PricingRule      string   `json:"pricingRule,omitempty"`
+	PricingDiscounts []string `json:"pricingDiscounts,omitempty"`
+}
+
+// StorageUsageData represents the shape of metadata for usage entries of kind "storage"
+// the equivalent TypeScript definition is maintained in `components/gitpod-protocol/src/usage.ts“
+type StorageUsageData struct {
+	WorkspaceId string  `json:"workspaceId"`
+	Bytes       int64   `json:"bytes"`
+	GBHours     float64 `json:"gbHours"`
+	StartTime   string  `json:"startTime"`
+	EndTime     string  `json:"endTime"`
+
+	// PricingVersion and PricingRule identify the pricing rule which produced the charge
+	PricingVersion string `json:"pricingVersion,omitempty"`
+	PricingRule    string `json:"pricingRule,omitempty"`
 }
 
 type CreditNoteMetaData struct {
@@ -206,6 +237,162 @@ func GetUsageSummary(ctx context.Context, conn *gorm.DB, params GetUsageSummaryP
 	return result, nil
 }
 
+// UsageDimension is a property of workspace instance usage which usage can be aggregated by
+type UsageDimension string
+
+const (
+	AttributionDimension    UsageDimension = "attributionId"
+	ProjectDimension        UsageDimension = "projectId"
+	WorkspaceClassDimension UsageDimension = "workspaceClass"
+	UserDimension           UsageDimension = "userId"
+)
+
+// usageDimensionColumns maps dimensions to the SQL expressions computing them from d_b_usage (u) and d_b_workspace (w)
+var usageDimensionColumns = map[UsageDimension]string{
+	AttributionDimension:    "u.attributionId",
+	ProjectDimension:        "COALESCE(w.projectId, '')",
+	WorkspaceClassDimension: "COALESCE(JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.workspaceClass')), '')",
+	UserDimension:           "COALESCE(JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.userId')), '')",
+}
+
+// TimeBucket is the period usage is aggregated over
+type TimeBucket string
+
+const (
+	NoTimeBucket    TimeBucket = ""
+	DayTimeBucket   TimeBucket = "day"
+	WeekTimeBucket  TimeBucket = "week"
+	MonthTimeBucket TimeBucket = "month"
+)
+
+// timeBucketColumns maps time buckets to the SQL expressions computing the first day of the bucket.
+// effectiveTime is stored as ISO 8601 in UTC, hence the date is its first 10 characters.
+var timeBucketColumns = map[TimeBucket]string{
+	NoTimeBucket:    "''",
+	DayTimeBucket:   "LEFT(u.effectiveTime, 10)",
+	WeekTimeBucket:  "DATE_FORMAT(DATE_SUB(LEFT(u.effectiveTime, 10), INTERVAL WEEKDAY(LEFT(u.effectiveTime, 10)) DAY), '%Y-%m-%d')",
+	MonthTimeBucket: "CONCAT(LEFT(u.effectiveTime, 7), '-01')",
+}
+
+// usageRuntimeSecondsColumn computes the runtime of the workspace instance a usage record was charged for.
+// Instances which are still running have no end time yet and were charged up to the effective time of their usage.
+const usageRuntimeSecondsColumn = `CASE WHEN COALESCE(JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.startTime')), '') = '' THEN 0 ELSE TIMESTAMPDIFF(SECOND,
+	STR_TO_DATE(JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.startTime')), '%Y-%m-%dT%H:%i:%s.%fZ'),
+	STR_TO_DATE(COALESCE(NULLIF(JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.endTime')), ''), u.effectiveTime), '%Y-%m-%dT%H:%i:%s.%fZ')) END`
+
+type AggregateUsageParams struct {
+	// AttributionID limits the aggregation to a single attribution. All attributions are aggregated when empty.
+	AttributionID AttributionID
+	From, To      time.Time
+	GroupBy       []UsageDimension
+	Bucket        TimeBucket
+}
+
+type UsageAggregate struct {
+	// BucketStart is the first day of the time bucket formatted as YYYY-MM-DD, or empty if no time bucket was requested
+	BucketStart string `gorm:"column:bucketStart"`
+
+	// Dimensions are empty unless usage is grouped by them
+	AttributionID  AttributionID `gorm:"column:attributionId"`
+	ProjectID      string        `gorm:"column:projectId"`
+	WorkspaceClass string        `gorm:"column:workspaceClass"`
+	UserID         string        `gorm:"column:userId"`
+
+	CreditCents CreditCents `gorm:"column:creditCents"`
+	// WorkspaceMinutes is the runtime of the workspace instances the credits were charged for
+	WorkspaceMinutes float64 `gorm:"column:workspaceMinutes"`
+	UsageEntries     int64   `gorm:"column:usageEntries"`
+}
+
+// AggregateUsage sums up workspace instance usage in the given time range, grouped by dimensions and time bucket.
+// Aggregates are ordered by their time bucket and dimensions.
+func AggregateUsage(ctx context.Context, conn *gorm.DB, params AggregateUsageParams) ([]UsageAggregate, error) {
+	db, err := aggregateUsageQuery(ctx, conn, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []UsageAggregate
+	err = db.Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate usage: %w", err)
+	}
+	return result, nil
+}
+
+// ForEachUsageAggregate aggregates usage like AggregateUsage, but hands the aggregates to fn one by one as they
+// are read from the database, such that they never have to be held in memory all at once.
+func ForEachUsageAggregate(ctx context.Context, conn *gorm.DB, params AggregateUsageParams, fn func(UsageAggregate) error) error {
+	db, err := aggregateUsageQuery(ctx, conn, params)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Rows()
+	if err != nil {
+		return fmt.Errorf("failed to aggregate usage: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var aggregate UsageAggregate
+		err = conn.ScanRows(rows, &aggregate)
+		if err != nil {
+			return fmt.Errorf("failed to scan usage aggregate: %w", err)
+		}
+		err = fn(aggregate)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func aggregateUsageQuery(ctx context.Context, conn *gorm.DB, params AggregateUsageParams) (*gorm.DB, error) {
+	bucket, ok := timeBucketColumns[params.Bucket]
+	if !ok {
+		return nil, fmt.Errorf("unknown time bucket %q", params.Bucket)
+	}
+	grouped := make(map[UsageDimension]bool, len(params.GroupBy))
+	for _, d := range params.GroupBy {
+		if _, ok := usageDimensionColumns[d]; !ok {
+			return nil, fmt.Errorf("unknown usage dimension %q", d)
+		}
+		grouped[d] = true
+	}
+
+	selects := []string{bucket + " AS bucketStart"}
+	groups := []string{bucket}
+	for _, d := range []UsageDimension{AttributionDimension, ProjectDimension, WorkspaceClassDimension, UserDimension} {
+		if !grouped[d] {
+			selects = append(selects, fmt.Sprintf("'' AS %s", d))
+			continue
+		}
+		selects = append(selects, fmt.Sprintf("%s AS %s", usageDimensionColumns[d], d))
+		groups = append(groups, usageDimensionColumns[d])
+	}
+	selects = append(selects,
+		"SUM(u.creditCents) AS creditCents",
+		fmt.Sprintf("COALESCE(SUM(%s), 0) / 60 AS workspaceMinutes", usageRuntimeSecondsColumn),
+		"COUNT(*) AS usageEntries",
+	)
+
+	db := conn.WithContext(ctx).
+		Table(fmt.Sprintf("%s AS u", (&Usage{}).TableName())).
+		Select(strings.Join(selects, ", ")).
+		Where("u.effectiveTime >= ? AND u.effectiveTime < ?", TimeToISO8601(params.From), TimeToISO8601(params.To)).
+		Where("u.kind = ?", WorkspaceInstanceUsageKind)
+	if params.AttributionID != "" {
+		db = db.Where("u.attributionId = ?", params.AttributionID)
+	}
+	if grouped[ProjectDimension] {
+		db = db.Joins(fmt.Sprintf("LEFT JOIN %s AS w ON w.id = JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.workspaceId'))", (&Workspace{}).TableName()))
+	}
+
+	return db.Group(strings.Join(groups, ", ")).
+		Order(strings.Join(groups, ", ")), nil
+}
+
 type Balance struct {
 	AttributionID AttributionID `gorm:"column:attributionId;type:varchar;size:255;" json:"attributionId"`
 	CreditCents   CreditCents   `gorm:"column:creditCents;type:bigint;" json:"creditCents"`
@@ -6,6 +6,7 @@ package db_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -373,3 +374,124 @@ func TestGetBalance(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, 0, int(noUsageBalance))
 }
+
+func TestAggregateUsage(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	// a Wednesday
+	start := time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	userID := uuid.New()
+	projectID := uuid.New().String()
+
+	workspaces := dbtest.CreateWorkspaces(t, conn, dbtest.NewWorkspace(t, db.Workspace{
+		ProjectID: sql.NullString{String: projectID, Valid: true},
+	}))
+
+	newUsage := func(effectiveTime time.Time, workspaceClass string, creditCents db.CreditCents, runtime time.Duration, running bool) db.Usage {
+		endTime := db.TimeToISO8601(effectiveTime)
+		if running {
+			endTime = ""
+		}
+		usage := dbtest.NewUsage(t, db.Usage{
+			AttributionID: attributionID,
+			EffectiveTime: db.NewVarCharTime(effectiveTime),
+			CreditCents:   creditCents,
+		})
+		require.NoError(t, usage.SetMetadataWithWorkspaceInstance(db.WorkspaceInstanceUsageData{
+			WorkspaceId:    workspaces[0].ID,
+			WorkspaceClass: workspaceClass,
+			StartTime:      db.TimeToISO8601(effectiveTime.Add(-runtime)),
+			EndTime:        endTime,
+			UserID:         userID,
+		}))
+		return usage
+	}
+
+	dbtest.CreateUsageRecords(t, conn,
+		newUsage(start.Add(2*time.Hour), "g1-standard", 100, 10*time.Minute, false),
+		newUsage(start.Add(26*time.Hour), "g1-standard", 200, 20*time.Minute, true),
+		newUsage(start.Add(8*24*time.Hour), "g1-large", 400, 40*time.Minute, false),
+		newUsage(end.Add(2*time.Hour), "g1-large", 800, 80*time.Minute, false),
+		dbtest.NewUsage(t, db.Usage{
+			AttributionID: attributionID,
+			Kind:          db.InvoiceUsageKind,
+			EffectiveTime: db.NewVarCharTime(start.Add(2 * time.Hour)),
+			CreditCents:   -300,
+		}),
+	)
+
+	tests := []struct {
+		name     string
+		groupBy  []db.UsageDimension
+		bucket   db.TimeBucket
+		expected []db.UsageAggregate
+	}{
+		{
+			name: "total",
+			expected: []db.UsageAggregate{
+				{CreditCents: 700, WorkspaceMinutes: 70, UsageEntries: 3},
+			},
+		},
+		{
+			name:    "by workspace class",
+			groupBy: []db.UsageDimension{db.WorkspaceClassDimension},
+			expected: []db.UsageAggregate{
+				{WorkspaceClass: "g1-large", CreditCents: 400, WorkspaceMinutes: 40, UsageEntries: 1},
+				{WorkspaceClass: "g1-standard", CreditCents: 300, WorkspaceMinutes: 30, UsageEntries: 2},
+			},
+		},
+		{
+			name:    "by attribution, project and user per day",
+			groupBy: []db.UsageDimension{db.AttributionDimension, db.ProjectDimension, db.UserDimension},
+			bucket:  db.DayTimeBucket,
+			expected: []db.UsageAggregate{
+				{BucketStart: "2023-05-03", AttributionID: attributionID, ProjectID: projectID, UserID: userID.String(), CreditCents: 100, WorkspaceMinutes: 10, UsageEntries: 1},
+				{BucketStart: "2023-05-04", AttributionID: attributionID, ProjectID: projectID, UserID: userID.String(), CreditCents: 200, WorkspaceMinutes: 20, UsageEntries: 1},
+				{BucketStart: "2023-05-11", AttributionID: attributionID, ProjectID: projectID, UserID: userID.String(), CreditCents: 400, WorkspaceMinutes: 40, UsageEntries: 1},
+			},
+		},
+		{
+			name:   "per week",
+			bucket: db.WeekTimeBucket,
+			expected: []db.UsageAggregate{
+				{BucketStart: "2023-05-01", CreditCents: 300, WorkspaceMinutes: 30, UsageEntries: 2},
+				{BucketStart: "2023-05-08", CreditCents: 400, WorkspaceMinutes: 40, UsageEntries: 1},
+			},
+		},
+		{
+			name:   "per month",
+			bucket: db.MonthTimeBucket,
+			expected: []db.UsageAggregate{
+				{BucketStart: "2023-05-01", CreditCents: 700, WorkspaceMinutes: 70, UsageEntries: 3},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := db.AggregateUsageParams{
+				AttributionID: attributionID,
+				From:          start,
+				To:            end,
+				GroupBy:       test.groupBy,
+				Bucket:        test.bucket,
+			}
+			aggregates, err := db.AggregateUsage(context.Background(), conn, params)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, aggregates)
+
+			var streamed []db.UsageAggregate
+			err = db.ForEachUsageAggregate(context.Background(), conn, params, func(aggregate db.UsageAggregate) error {
+				streamed = append(streamed, aggregate)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, streamed)
+		})
+	}
+
+	_, err := db.AggregateUsage(context.Background(), conn, db.AggregateUsageParams{GroupBy: []db.UsageDimension{"foo"}})
+	require.Error(t, err)
+}
@@ -71,3 +71,27 @@ func GetUser(ctx context.Context, conn *gorm.DB, id uuid.UUID) (User, error) {
 
 	return user, nil
 }
+
+// Built-in users are created by Gitpod itself, hence do not count as registered users.
+// The equivalent TypeScript definitions are maintained in `components/gitpod-db/src/user-db.ts`.
+const (
+	BuiltinWorkspaceProbeUserID    = "builtin-user-workspace-probe-0000000"
+	BuiltinAgentSmithUserID        = "builtin-user-agent-smith-0000000"
+	BuiltinInstallationAdminUserID = "f071bb8e-b5d1-46cf-a436-da03ae63bcd2"
+)
+
+// CountRegisteredUsers counts all users which have not been deleted, excluding built-in users
+func CountRegisteredUsers(ctx context.Context, conn *gorm.DB) (int64, error) {
+	var count int64
+	tx := conn.
+		WithContext(ctx).
+		Model(&User{}).
+		Where("markedDeleted != ?", 1).
+		Where("id NOT IN ?", []string{BuiltinWorkspaceProbeUserID, BuiltinAgentSmithUserID, BuiltinInstallationAdminUserID}).
+		Count(&count)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("failed to count registered users: %w", tx.Error)
+	}
+
+	return count, nil
+}
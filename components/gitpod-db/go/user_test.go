@@ -21,3 +21,16 @@ func TestGetUser(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, user, retrived)
 }
+
+func TestCountRegisteredUsers(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	before, err := db.CountRegisteredUsers(context.Background(), conn)
+	require.NoError(t, err)
+
+	dbtest.CreatUsers(t, conn, db.User{}, db.User{MarkedDeleted: true})
+
+	after, err := db.CountRegisteredUsers(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, before+1, after)
+}
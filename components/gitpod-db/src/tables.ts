@@ -204,6 +204,11 @@ export class GitpodTableDescriptionProvider implements TableDescriptionProvider
             deletionColumn: "deleted",
             timeColumn: "_lastModified",
         },
+        {
+            name: "d_b_cost_center_alert",
+            primaryKeys: ["attributionId", "billingCycleStart", "threshold", "sink"],
+            timeColumn: "_lastModified",
+        },
         {
             name: "d_b_usage",
             primaryKeys: ["id"],
@@ -0,0 +1,28 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+
+export class CostCenterAlert1687421483952 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        await queryRunner.query(
+            `
+            CREATE TABLE IF NOT EXISTS d_b_cost_center_alert (
+                attributionId varchar(255) NOT NULL,
+                billingCycleStart varchar(30) NOT NULL,
+                threshold int(11) NOT NULL,
+                sink varchar(255) NOT NULL,
+                firedAt varchar(30) NOT NULL,
+                _lastModified timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+                PRIMARY KEY (attributionId, billingCycleStart, threshold, sink),
+                KEY ind_dbsync (_lastModified)
+            ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+            `,
+        );
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {}
+}
@@ -31,6 +31,11 @@ type CoreDump struct {
 type Env struct {
 }
 
+// Exec Succeeds if the shell command exits with status code 0.
+type Exec struct {
+	Command string `yaml:"command" json:"command"`
+}
+
 // Github Configures Gitpod's GitHub app
 type Github struct {
 
@@ -81,6 +86,12 @@ type GitpodConfig struct {
 	WorkspaceLocation string `yaml:"workspaceLocation,omitempty" json:"workspaceLocation,omitempty"`
 }
 
+// Http Succeeds if a GET request returns a 2xx or 3xx status code.
+type Http struct {
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	Port int    `yaml:"port" json:"port"`
+}
+
 // Image_object The Docker image to run your workspace in.
 type Image_object struct {
 
@@ -189,6 +200,31 @@ type Prebuilds_object struct {
 	PullRequestsFromForks bool `yaml:"pullRequestsFromForks,omitempty" json:"pullRequestsFromForks,omitempty"`
 }
 
+// TaskProbe
+type TaskProbe struct {
+
+	// Succeeds if the shell command exits with status code 0.
+	Exec *Exec `yaml:"exec,omitempty" json:"exec,omitempty"`
+
+	// Number of consecutive failures after which the probe is failing. Default is 3.
+	FailureThreshold int `yaml:"failureThreshold,omitempty" json:"failureThreshold,omitempty"`
+
+	// Succeeds if a GET request returns a 2xx or 3xx status code.
+	Http *Http `yaml:"http,omitempty" json:"http,omitempty"`
+
+	// Seconds to wait after the task started before probing. Default is 0.
+	InitialDelaySeconds int `yaml:"initialDelaySeconds,omitempty" json:"initialDelaySeconds,omitempty"`
+
+	// Seconds between two probes. Default is 10.
+	PeriodSeconds int `yaml:"periodSeconds,omitempty" json:"periodSeconds,omitempty"`
+
+	// Succeeds if the port accepts connections.
+	Tcp *Tcp `yaml:"tcp,omitempty" json:"tcp,omitempty"`
+
+	// Seconds after which a probe is considered failed. Default is 1.
+	TimeoutSeconds int `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+}
+
 // TasksItems
 type TasksItems struct {
 
@@ -198,12 +234,21 @@ type TasksItems struct {
 	// The main shell command to run after `before` and `init`. This command is executed last on every start and doesn't have to terminate.
 	Command string `yaml:"command,omitempty" json:"command,omitempty"`
 
+	// Names of the tasks whose commands have to finish before this task starts. In prebuilds this task is skipped if any of them fails.
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
 	// Environment variables to set.
 	Env *Env `yaml:"env,omitempty" json:"env,omitempty"`
 
 	// A shell command to run between `before` and the main `command`. This command is executed only on after initializing a workspace with a fresh clone, but not on restarts and snapshots. This command is expected to terminate. If it fails, the `command` property will not be executed.
 	Init string `yaml:"init,omitempty" json:"init,omitempty"`
 
+	// Determines whether the task is alive. The task is terminated, and restarted according to its `restartPolicy`, if the probe fails.
+	LivenessProbe *TaskProbe `yaml:"livenessProbe,omitempty" json:"livenessProbe,omitempty"`
+
+	// How often the `command` is restarted at most. Default is 5.
+	MaxRestarts int `yaml:"maxRestarts,omitempty" json:"maxRestarts,omitempty"`
+
 	// Name of the task. Shown on the tab of the opened terminal.
 	Name string `yaml:"name,omitempty" json:"name,omitempty"`
 
@@ -215,6 +260,23 @@ type TasksItems struct {
 
 	// A shell command to run after `before`. This command is executed only on during workspace prebuilds. This command is expected to terminate. If it fails, the workspace build fails.
 	Prebuild string `yaml:"prebuild,omitempty" json:"prebuild,omitempty"`
+
+	// Determines whether the task is ready.
+	ReadinessProbe *TaskProbe `yaml:"readinessProbe,omitempty" json:"readinessProbe,omitempty"`
+
+	// Whether the `command` is restarted when it exits. Default is 'never'. Has no effect in prebuilds.
+	RestartPolicy string `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
+
+	// Files which have to exist before this task starts. Relative paths are resolved against the workspace location.
+	WaitForFile []string `yaml:"waitForFile,omitempty" json:"waitForFile,omitempty"`
+
+	// Ports which have to accept connections before this task starts.
+	WaitForPort []int `yaml:"waitForPort,omitempty" json:"waitForPort,omitempty"`
+}
+
+// Tcp Succeeds if the port accepts connections.
+type Tcp struct {
+	Port int `yaml:"port" json:"port"`
 }
 
 // Vscode Configure VS Code integration
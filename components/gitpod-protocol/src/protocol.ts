@@ -1125,6 +1125,23 @@ export interface TaskConfig {
     env?: { [env: string]: any };
     openIn?: "bottom" | "main" | "left" | "right";
     openMode?: "split-top" | "split-left" | "split-right" | "split-bottom" | "tab-before" | "tab-after";
+    dependsOn?: string[];
+    waitForPort?: number[];
+    waitForFile?: string[];
+    restartPolicy?: "never" | "on-failure" | "always";
+    maxRestarts?: number;
+    readinessProbe?: TaskProbe;
+    livenessProbe?: TaskProbe;
+}
+
+export interface TaskProbe {
+    http?: { port: number; path?: string };
+    tcp?: { port: number };
+    exec?: { command: string };
+    initialDelaySeconds?: number;
+    periodSeconds?: number;
+    timeoutSeconds?: number;
+    failureThreshold?: number;
 }
 
 export namespace TaskConfig {
@@ -38,7 +38,7 @@ export interface PaginationResponse {
     page: number;
 }
 
-export type UsageKind = "workspaceinstance" | "invoice";
+export type UsageKind = "workspaceinstance" | "invoice" | "storage";
 export interface Usage {
     id: string;
     attributionId: string;
@@ -48,7 +48,7 @@ export interface Usage {
     kind: UsageKind;
     workspaceInstanceId: string;
     draft: boolean;
-    metadata: WorkspaceInstanceUsageData | InvoiceUsageData;
+    metadata: WorkspaceInstanceUsageData | InvoiceUsageData | StorageUsageData;
 }
 
 // the equivalent golang shape is maintained in `/workspace/gitpod/`components/usage/pkg/db/usage.go`
@@ -62,6 +62,9 @@ export interface WorkspaceInstanceUsageData {
     userId: string;
     userName: string;
     userAvatarURL: string;
+    pricingVersion?: string;
+    pricingRule?: string;
+    pricingDiscounts?: string[];
 }
 
 export interface InvoiceUsageData {
@@ -70,6 +73,17 @@ export interface InvoiceUsageData {
     endDate: string;
 }
 
+// the equivalent golang shape is maintained in `components/gitpod-db/go/usage.go`
+export interface StorageUsageData {
+    workspaceId: string;
+    bytes: number;
+    gbHours: number;
+    startTime: string;
+    endTime: string;
+    pricingVersion?: string;
+    pricingRule?: string;
+}
+
 export interface CostCenterJSON {
     attributionId: string;
     spendingLimit: number;
@@ -21,4 +21,8 @@ export interface InitializerMetric {
     type: string;
     duration: number;
     size: number;
+    retriedBytes?: number;
+    cloneFilter?: string;
+    cloneDepth?: number;
+    sparseCheckoutPatterns?: number;
 }
@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"fmt"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"gorm.io/gorm"
+)
+
+func NewStatsService(connPool proxy.ServerConnectionPool, dbConn *gorm.DB) *StatsService {
+	return &StatsService{
+		connectionPool: connPool,
+		dbConn:         dbConn,
+	}
+}
+
+type StatsService struct {
+	connectionPool proxy.ServerConnectionPool
+	dbConn         *gorm.DB
+
+	v1connect.UnimplementedStatsServiceHandler
+}
+
+func (s *StatsService) GetUserStats(ctx context.Context, req *connect.Request[v1.GetUserStatsRequest]) (*connect.Response[v1.GetUserStatsResponse], error) {
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stats are only available to authenticated users
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+	log.AddFields(ctx, log.UserID(user.ID))
+
+	// The server API does not expose user counts, hence we query them ourselves
+	registeredUsers, err := db.CountRegisteredUsers(ctx, s.dbConn)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to count registered users.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to retrieve user stats."))
+	}
+
+	return connect.NewResponse(&v1.GetUserStatsResponse{
+		RegisteredUsers: uint32(registeredUsers),
+	}), nil
+}
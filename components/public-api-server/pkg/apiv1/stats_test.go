@@ -0,0 +1,92 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/gitpod-io/gitpod/components/public-api/go/config"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/jws"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/jws/jwstest"
+	"github.com/golang/mock/gomock"
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestStatsService_GetUserStats(t *testing.T) {
+	user := newUser(&protocol.User{})
+
+	t.Run("unauthenticated when server rejects the token", func(t *testing.T) {
+		serverMock, _, client := setupStatsService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(nil, &jsonrpc2.Error{
+			Code:    401,
+			Message: "unauthenticated",
+		})
+
+		_, err := client.GetUserStats(context.Background(), connect.NewRequest(&v1.GetUserStatsRequest{}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("counts registered users", func(t *testing.T) {
+		serverMock, dbConn, client := setupStatsService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(2)
+
+		before, err := client.GetUserStats(context.Background(), connect.NewRequest(&v1.GetUserStatsRequest{}))
+		require.NoError(t, err)
+
+		dbtest.CreatUsers(t, dbConn, db.User{}, db.User{MarkedDeleted: true})
+
+		after, err := client.GetUserStats(context.Background(), connect.NewRequest(&v1.GetUserStatsRequest{}))
+		require.NoError(t, err)
+		require.Equal(t, before.Msg.GetRegisteredUsers()+1, after.Msg.GetRegisteredUsers())
+	})
+}
+
+func setupStatsService(t *testing.T) (*protocol.MockAPIInterface, *gorm.DB, v1connect.StatsServiceClient) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+
+	svc := NewStatsService(&FakeServerConnPool{api: serverMock}, dbConn)
+
+	keyset := jwstest.GenerateKeySet(t)
+	rsa256, err := jws.NewRSA256(keyset)
+	require.NoError(t, err)
+
+	_, handler := v1connect.NewStatsServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor(config.SessionConfig{
+		Issuer: "unitetest.com",
+		Cookie: config.CookieConfig{
+			Name: "cookie_jwt",
+		},
+	}, rsa256)))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewStatsServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, dbConn, client
+}
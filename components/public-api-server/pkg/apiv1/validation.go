@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	connect "github.com/bufbuild/connect-go"
@@ -60,6 +61,19 @@ func validateWorkspaceID(ctx context.Context, id string) (string, error) {
 	return id, nil
 }
 
+func validateContextURL(contextURL string) (string, error) {
+	trimmed := strings.TrimSpace(contextURL)
+	if trimmed == "" {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Empty context URL specified."))
+	}
+
+	if _, err := url.Parse(trimmed); err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Context URL must be a valid URL."))
+	}
+
+	return trimmed, nil
+}
+
 func validateProjectID(ctx context.Context, id string) (uuid.UUID, error) {
 	log.AddFields(ctx, log.ProjectID(id))
 	projectID, err := validateUUID(id)
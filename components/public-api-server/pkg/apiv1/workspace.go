@@ -206,6 +206,51 @@ func (s *WorkspaceService) UpdatePort(ctx context.Context, req *connect.Request[
 	), nil
 }
 
+func (s *WorkspaceService) CreateAndStartWorkspace(ctx context.Context, req *connect.Request[v1.CreateAndStartWorkspaceRequest]) (*connect.Response[v1.CreateAndStartWorkspaceResponse], error) {
+	if req.Msg.GetIdempotencyToken() != "" {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Idempotency tokens are not supported yet."))
+	}
+
+	var contextURL string
+	switch req.Msg.GetSource().(type) {
+	case *v1.CreateAndStartWorkspaceRequest_ContextUrl:
+		var err error
+		contextURL, err = validateContextURL(req.Msg.GetContextUrl())
+		if err != nil {
+			return nil, err
+		}
+	case *v1.CreateAndStartWorkspaceRequest_PrebuildId:
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Creating workspaces from a prebuild ID is not supported yet, please specify a context URL."))
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Either a context URL or a prebuild ID must be specified."))
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := conn.CreateWorkspace(ctx, &protocol.CreateWorkspaceOptions{
+		ContextURL: contextURL,
+		// Callers of the API ask for a new workspace, hence we must not hand them running workspaces or prebuilds instead
+		IgnoreRunningWorkspaceOnSameCommit: true,
+		IgnoreRunningPrebuild:              true,
+	})
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to create workspace.")
+		return nil, proxy.ConvertError(err)
+	}
+	if result.CreatedWorkspaceID == "" {
+		log.Extract(ctx).Error("Server did not create a workspace.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to create workspace."))
+	}
+	log.AddFields(ctx, log.WorkspaceID(result.CreatedWorkspaceID))
+
+	return connect.NewResponse(&v1.CreateAndStartWorkspaceResponse{
+		WorkspaceId: result.CreatedWorkspaceID,
+	}), nil
+}
+
 func (s *WorkspaceService) StartWorkspace(ctx context.Context, req *connect.Request[v1.StartWorkspaceRequest]) (*connect.Response[v1.StartWorkspaceResponse], error) {
 	workspaceID, err := validateWorkspaceID(ctx, req.Msg.GetWorkspaceId())
 	if err != nil {
@@ -83,6 +83,109 @@ func TestWorkspaceService_GetWorkspace(t *testing.T) {
 	})
 }
 
+func TestWorkspaceService_CreateAndStartWorkspace(t *testing.T) {
+
+	workspaceID := workspaceTestData[0].Protocol.Workspace.ID
+	contextURL := "https://github.com/gitpod-io/gitpod"
+
+	t.Run("invalid argument when no source is specified", func(t *testing.T) {
+		_, client := setupWorkspacesService(t)
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when context URL is empty", func(t *testing.T) {
+		_, client := setupWorkspacesService(t)
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			Source: &v1.CreateAndStartWorkspaceRequest_ContextUrl{ContextUrl: "  "},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when context URL does not validate", func(t *testing.T) {
+		_, client := setupWorkspacesService(t)
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			Source: &v1.CreateAndStartWorkspaceRequest_ContextUrl{ContextUrl: "https://github.com/%zz"},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("unimplemented when prebuild ID is specified", func(t *testing.T) {
+		_, client := setupWorkspacesService(t)
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			Source: &v1.CreateAndStartWorkspaceRequest_PrebuildId{PrebuildId: "some-prebuild"},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	})
+
+	t.Run("unimplemented when idempotency token is specified", func(t *testing.T) {
+		_, client := setupWorkspacesService(t)
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			IdempotencyToken: "some-token",
+			Source:           &v1.CreateAndStartWorkspaceRequest_ContextUrl{ContextUrl: contextURL},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when server denies creation", func(t *testing.T) {
+		serverMock, client := setupWorkspacesService(t)
+
+		serverMock.EXPECT().CreateWorkspace(gomock.Any(), gomock.Any()).Return(nil, &jsonrpc2.Error{
+			Code:    403,
+			Message: "permission denied",
+		})
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			Source: &v1.CreateAndStartWorkspaceRequest_ContextUrl{ContextUrl: contextURL},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("internal error when server does not create a workspace", func(t *testing.T) {
+		serverMock, client := setupWorkspacesService(t)
+
+		serverMock.EXPECT().CreateWorkspace(gomock.Any(), gomock.Any()).Return(&protocol.WorkspaceCreationResult{
+			ExistingWorkspaces: []*protocol.WorkspaceInfo{&workspaceTestData[0].Protocol},
+		}, nil)
+
+		_, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			Source: &v1.CreateAndStartWorkspaceRequest_ContextUrl{ContextUrl: contextURL},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	})
+
+	t.Run("delegates to server", func(t *testing.T) {
+		serverMock, client := setupWorkspacesService(t)
+
+		serverMock.EXPECT().CreateWorkspace(gomock.Any(), &protocol.CreateWorkspaceOptions{
+			ContextURL:                         contextURL,
+			IgnoreRunningWorkspaceOnSameCommit: true,
+			IgnoreRunningPrebuild:              true,
+		}).Return(&protocol.WorkspaceCreationResult{
+			CreatedWorkspaceID: workspaceID,
+			WorkspaceURL:       workspaceTestData[0].Protocol.LatestInstance.IdeURL,
+		}, nil)
+
+		resp, err := client.CreateAndStartWorkspace(context.Background(), connect.NewRequest(&v1.CreateAndStartWorkspaceRequest{
+			Source: &v1.CreateAndStartWorkspaceRequest_ContextUrl{ContextUrl: " " + contextURL + " "},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, workspaceID, resp.Msg.GetWorkspaceId())
+	})
+}
+
 func TestWorkspaceService_StartWorkspace(t *testing.T) {
 
 	workspaceID := workspaceTestData[0].Protocol.Workspace.ID
@@ -38,6 +38,12 @@ func GetConfig(fn string) (*ServiceConfig, error) {
 			return nil, xerrors.Errorf("IPFS cache requires Redis")
 		}
 	}
+	if cfg.Registry.LazyPull != nil && cfg.Registry.LazyPull.Enabled {
+		// converted layers are as large as the original ones and must not end up in Redis
+		if cfg.Registry.IPFSCache == nil || !cfg.Registry.IPFSCache.Enabled {
+			return nil, xerrors.Errorf("lazy pulling requires the IPFS cache")
+		}
+	}
 
 	if cfg.Registry.RedisCache != nil {
 		rd := cfg.Registry.RedisCache
@@ -73,6 +79,8 @@ type Config struct {
 	IPFSCache *IPFSCacheConfig `json:"ipfs,omitempty"`
 
 	RedisCache *RedisCacheConfig `json:"redis,omitempty"`
+
+	LazyPull *LazyPullConfig `json:"lazyPull,omitempty"`
 }
 
 type RedisCacheConfig struct {
@@ -92,6 +100,14 @@ type IPFSCacheConfig struct {
 	IPFSAddr string `json:"ipfsAddr"`
 }
 
+// LazyPullConfig configures serving of lazy-pullable (eStargz) layers. Lazy pulling requires the IPFS cache.
+type LazyPullConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Images are converted to eStargz by the convert command
+	Images []string `json:"images,omitempty"`
+}
+
 // StaticLayerCfg configure statically added layer
 type StaticLayerCfg struct {
 	Ref  string `json:"ref"`
@@ -0,0 +1,100 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+	"github.com/gitpod-io/gitpod/registry-facade/pkg/registry"
+)
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert <config.json> [ref...]",
+	Short: "Converts the layers of images to eStargz, such that the registry facade serves them for lazy pulling",
+	Long: `Converts the layers of the images configured in lazyPull.images, and those passed as arguments, to eStargz.
+The converted layers are cached in the registry facade's IPFS store, from where the registry facade
+serves them in place of the original layers.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		configPath := args[0]
+		cfg, err := config.GetConfig(configPath)
+		if err != nil {
+			log.WithError(err).WithField("filename", configPath).Fatal("cannot load config")
+		}
+		if cfg.Registry.LazyPull == nil || !cfg.Registry.LazyPull.Enabled {
+			log.Fatal("lazy pulling is not enabled")
+		}
+
+		var refs []string
+		refs = append(refs, cfg.Registry.LazyPull.Images...)
+		refs = append(refs, args[1:]...)
+		if len(refs) == 0 {
+			log.Info("no images to convert")
+			return
+		}
+
+		var dockerCfg *configfile.ConfigFile
+		if cfg.AuthCfg != "" {
+			dockerCfg = loadDockerCfg(cfg.AuthCfg)
+		}
+		resolverProvider := func() remotes.Resolver {
+			client := registry.NewRetryableHTTPClient()
+			client.Transport = newDefaultTransport()
+
+			resolverOpts := docker.ResolverOptions{
+				Client: client,
+			}
+			if dockerCfg != nil {
+				resolverOpts.Hosts = docker.ConfigureDefaultRegistries(
+					docker.WithAuthorizer(authorizerFromDockerConfig(dockerCfg)),
+					docker.WithClient(client),
+				)
+			}
+
+			return docker.NewResolver(resolverOpts)
+		}
+
+		// The converter neither serves workspace images nor adds static layers
+		cfg.Registry.RemoteSpecProvider = nil
+		cfg.Registry.FixedSpecProvider = ""
+		cfg.Registry.StaticLayer = nil
+
+		reg, err := registry.NewRegistry(cfg.Registry, resolverProvider, prometheus.NewRegistry())
+		if err != nil {
+			log.WithError(err).Fatal("cannot create registry")
+		}
+		converter, err := reg.LazyLayerConverter()
+		if err != nil {
+			log.WithError(err).Fatal("cannot create layer converter")
+		}
+
+		var failed int
+		for _, ref := range refs {
+			err := converter.ConvertImage(context.Background(), ref)
+			if err != nil {
+				log.WithError(err).WithField("ref", ref).Error("cannot convert image")
+				failed++
+				continue
+			}
+			log.WithField("ref", ref).Info("image is lazy-pullable")
+		}
+		if failed > 0 {
+			log.Fatalf("cannot convert %d of %d images", failed, len(refs))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+}
@@ -75,6 +75,7 @@ func (reg *Registry) handleBlob(ctx context.Context, r *http.Request) http.Handl
 			reg.LayerSource,
 		},
 		ConfigModifier: reg.ConfigModifier,
+		LazyLayers:     reg.LazyLayers,
 
 		Metrics: reg.metrics,
 	}
@@ -102,6 +103,7 @@ type blobHandler struct {
 	IPFS              *IPFSBlobCache
 	AdditionalSources []BlobSource
 	ConfigModifier    ConfigModifier
+	LazyLayers        LazyLayerIndex
 
 	Metrics *metrics
 }
@@ -146,7 +148,7 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 		// 3. upstream registry
 		srcs = append(srcs, proxyingBlobSource{Fetcher: fetcher, Blobs: manifest.Layers})
 
-		srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier})
+		srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier, LazyLayers: bh.LazyLayers})
 		srcs = append(srcs, bh.AdditionalSources...)
 
 		w.Header().Set("Etag", bh.Digest.String())
@@ -227,6 +229,17 @@ func (bh *blobHandler) retrieveFromSource(ctx context.Context, src BlobSource, w
 
 	w.Header().Set("Content-Type", mediaType)
 
+	// Lazy-pulling snapshotters fetch individual files of eStargz layers using ranged reads.
+	// We serve those from seekable sources only, and never cache as result of a partial read.
+	// Sources which cannot seek (e.g. because their size is unknown) serve the whole blob instead.
+	if rs, ok := rc.(io.ReadSeeker); ok && r.Header.Get("Range") != "" && isSeekable(rs) {
+		http.ServeContent(w, r, "", time.Time{}, rs)
+		if bh.Metrics != nil {
+			bh.Metrics.BlobDownloadCounter.WithLabelValues(src.Name(), "true").Inc()
+		}
+		return true, true, nil
+	}
+
 	bp := bufPool.Get().(*[]byte)
 	defer bufPool.Put(bp)
 
@@ -260,6 +273,14 @@ func (bh *blobHandler) retrieveFromSource(ctx context.Context, src BlobSource, w
 	return true, dontCache, nil
 }
 
+func isSeekable(rs io.Seeker) bool {
+	if _, err := rs.Seek(0, io.SeekEnd); err != nil {
+		return false
+	}
+	_, err := rs.Seek(0, io.SeekStart)
+	return err == nil
+}
+
 func (bh *blobHandler) downloadManifest(ctx context.Context, ref string) (res *ociv1.Manifest, fetcher remotes.Fetcher, err error) {
 	_, desc, err := bh.Resolver.Resolve(ctx, ref)
 	if err != nil {
@@ -287,6 +308,23 @@ func (r *reader) Read(b []byte) (n int, err error) {
 	return
 }
 
+func (r *reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += r.Size()
+	default:
+		return 0, xerrors.Errorf("invalid whence: %d", whence)
+	}
+	if offset < 0 {
+		return 0, xerrors.Errorf("negative offset: %d", offset)
+	}
+	r.off = offset
+	return offset, nil
+}
+
 // BlobSource can provide blobs for download
 type BlobSource interface {
 	// HasBlob checks if a digest can be served by this blob source
@@ -370,6 +408,7 @@ type configBlobSource struct {
 	Spec           *api.ImageSpec
 	Manifest       *ociv1.Manifest
 	ConfigModifier ConfigModifier
+	LazyLayers     LazyLayerIndex
 }
 
 func (sbs configBlobSource) Name() string {
@@ -409,6 +448,9 @@ func (pbs *configBlobSource) getConfig(ctx context.Context) (rawCfg []byte, err
 		return
 	}
 
+	// the diffIDs must match those of the manifest, which serves converted layers where available
+	_ = substituteLazyLayers(ctx, pbs.LazyLayers, manifest.Layers, cfg)
+
 	_, err = pbs.ConfigModifier(ctx, pbs.Spec, cfg)
 	if err != nil {
 		return
@@ -122,6 +122,9 @@ var _ content.ReaderAt = stringReader("")
 func (r stringReader) Size() int64  { return int64(len(r)) }
 func (r stringReader) Close() error { return nil }
 func (r stringReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off >= int64(len(r)) {
+		return 0, io.EOF
+	}
 	n = copy(p, r[off:])
 	if n < len(p) {
 		return n, io.EOF
@@ -0,0 +1,269 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	redis "github.com/redis/go-redis/v9"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// ConvertedLayer is a lazy-pullable (eStargz) replacement for an image layer
+type ConvertedLayer struct {
+	Descriptor ociv1.Descriptor `json:"descriptor"`
+	DiffID     digest.Digest    `json:"diffID"`
+}
+
+// LazyLayerIndex maps image layers to their lazy-pullable replacement
+type LazyLayerIndex interface {
+	// Get returns the converted layer for the original layer digest, or nil if the layer has not been converted.
+	Get(ctx context.Context, original digest.Digest) (*ConvertedLayer, error)
+
+	// Put registers a converted layer for the original layer digest.
+	Put(ctx context.Context, original digest.Digest, layer *ConvertedLayer) error
+}
+
+// RedisLazyLayerIndex stores the lazy layer index in Redis
+type RedisLazyLayerIndex struct {
+	Client *redis.Client
+}
+
+var _ LazyLayerIndex = &RedisLazyLayerIndex{}
+
+func lazyLayerKey(dgst digest.Digest) string {
+	return "lazy." + dgst.String()
+}
+
+// Get returns the converted layer for the original layer digest, or nil if the layer has not been converted.
+func (idx *RedisLazyLayerIndex) Get(ctx context.Context, original digest.Digest) (*ConvertedLayer, error) {
+	res, err := idx.Client.Get(ctx, lazyLayerKey(original)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var layer ConvertedLayer
+	err = json.Unmarshal([]byte(res), &layer)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal converted layer: %w", err)
+	}
+	return &layer, nil
+}
+
+// Put registers a converted layer for the original layer digest.
+func (idx *RedisLazyLayerIndex) Put(ctx context.Context, original digest.Digest, layer *ConvertedLayer) error {
+	fc, err := json.Marshal(layer)
+	if err != nil {
+		return err
+	}
+	return idx.Client.Set(ctx, lazyLayerKey(original), string(fc), 0).Err()
+}
+
+// substituteLazyLayers replaces the layers which have been converted to eStargz with their converted counterpart.
+// The layers and the config's diffIDs must be replaced together, so that the image remains consistent.
+// We never modify the layers in place because manifests are shared through caches.
+func substituteLazyLayers(ctx context.Context, idx LazyLayerIndex, layers []ociv1.Descriptor, cfg *ociv1.Image) []ociv1.Descriptor {
+	if idx == nil || len(layers) != len(cfg.RootFS.DiffIDs) {
+		return layers
+	}
+
+	res := make([]ociv1.Descriptor, len(layers))
+	copy(res, layers)
+	for i, l := range layers {
+		if !isGzipLayer(l) {
+			continue
+		}
+
+		converted, err := idx.Get(ctx, l.Digest)
+		if err != nil {
+			// lazy pulling is an optimisation - we'd rather serve the original layer than fail
+			log.WithError(err).WithField("digest", l.Digest).Warn("cannot look up converted layer")
+			continue
+		}
+		if converted == nil {
+			continue
+		}
+
+		res[i] = converted.Descriptor
+		cfg.RootFS.DiffIDs[i] = converted.DiffID
+	}
+	return res
+}
+
+func isGzipLayer(desc ociv1.Descriptor) bool {
+	return desc.MediaType == ociv1.MediaTypeImageLayerGzip || desc.MediaType == images.MediaTypeDockerSchema2LayerGzip
+}
+
+// LazyLayerConverter converts image layers to eStargz and caches them, such that the registry
+// can serve them instead of the original layers. Converted layers are as large as the original ones,
+// hence we store them in IPFS rather than in Redis.
+type LazyLayerConverter struct {
+	Resolver ResolverProvider
+	IPFS     *IPFSBlobCache
+	Index    LazyLayerIndex
+}
+
+// LazyLayerConverter produces a converter which caches converted layers in the registry's stores
+func (reg *Registry) LazyLayerConverter() (*LazyLayerConverter, error) {
+	if reg.LazyLayers == nil {
+		return nil, xerrors.Errorf("lazy pulling is not enabled")
+	}
+	if reg.IPFS == nil {
+		return nil, xerrors.Errorf("lazy pulling requires the IPFS cache")
+	}
+	return &LazyLayerConverter{
+		Resolver: reg.Resolver,
+		IPFS:     reg.IPFS,
+		Index:    reg.LazyLayers,
+	}, nil
+}
+
+// ConvertImage converts all gzip layers of an image which haven't been converted yet
+func (c *LazyLayerConverter) ConvertImage(ctx context.Context, ref string) error {
+	resolver := c.Resolver()
+	_, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return xerrors.Errorf("cannot resolve %s: %w", ref, err)
+	}
+	fetcher, err := resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return xerrors.Errorf("cannot get fetcher for %s: %w", ref, err)
+	}
+	manifest, _, err := DownloadManifest(ctx, AsFetcherFunc(fetcher), desc)
+	if err != nil {
+		return xerrors.Errorf("cannot download manifest of %s: %w", ref, err)
+	}
+
+	for _, l := range manifest.Layers {
+		log := log.WithField("ref", ref).WithField("digest", l.Digest)
+		if !isGzipLayer(l) {
+			log.WithField("mediaType", l.MediaType).Debug("skipping layer with unsupported media type")
+			continue
+		}
+		if _, ok := l.Annotations[estargz.TOCJSONDigestAnnotation]; ok {
+			log.Debug("layer is lazy-pullable already")
+			continue
+		}
+		if converted, err := c.Index.Get(ctx, l.Digest); err != nil {
+			return err
+		} else if converted != nil {
+			log.Debug("layer has been converted before")
+			continue
+		}
+
+		rc, err := fetcher.Fetch(ctx, l)
+		if err != nil {
+			return xerrors.Errorf("cannot fetch layer %s: %w", l.Digest, err)
+		}
+		converted, err := c.convertLayer(ctx, l, rc)
+		rc.Close()
+		if err != nil {
+			return xerrors.Errorf("cannot convert layer %s: %w", l.Digest, err)
+		}
+
+		err = c.Index.Put(ctx, l.Digest, converted)
+		if err != nil {
+			return xerrors.Errorf("cannot index converted layer %s: %w", l.Digest, err)
+		}
+		log.WithField("converted", converted.Descriptor.Digest).WithField("size", converted.Descriptor.Size).Info("converted layer")
+	}
+
+	return nil
+}
+
+func (c *LazyLayerConverter) convertLayer(ctx context.Context, desc ociv1.Descriptor, rc io.Reader) (*ConvertedLayer, error) {
+	// layers can be large, hence we buffer the converted layer on disk rather than in memory
+	f, err := os.CreateTemp("", "estargz-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	converted, err := buildLazyLayer(ctx, desc, rc, f)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	err = c.IPFS.Store(ctx, converted.Descriptor.Digest, f, desc.MediaType)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot store converted layer in IPFS: %w", err)
+	}
+	return converted, nil
+}
+
+// buildLazyLayer converts the gzip layer read from rc to eStargz and writes the result to dst
+func buildLazyLayer(ctx context.Context, desc ociv1.Descriptor, rc io.Reader, dst *os.File) (*ConvertedLayer, error) {
+	// estargz.Build needs random access to the original layer
+	org, err := os.CreateTemp("", "layer-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(org.Name())
+	defer org.Close()
+	orgSize, err := io.Copy(org, rc)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download layer: %w", err)
+	}
+
+	blob, err := estargz.Build(io.NewSectionReader(org, 0, orgSize), estargz.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+
+	dgst := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(dst, dgst.Hash()), blob)
+	if err != nil {
+		return nil, err
+	}
+	// the DiffID is only valid once the blob is closed
+	err = blob.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// snapshotters need the uncompressed size of the layer to pull it lazily
+	gz, err := gzip.NewReader(io.NewSectionReader(dst, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	uncompressedSize, err := io.Copy(io.Discard, gz)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot decompress converted layer: %w", err)
+	}
+
+	return &ConvertedLayer{
+		Descriptor: ociv1.Descriptor{
+			MediaType: desc.MediaType,
+			Digest:    dgst.Digest(),
+			Size:      size,
+			Annotations: map[string]string{
+				estargz.TOCJSONDigestAnnotation:         blob.TOCDigest().String(),
+				estargz.StoreUncompressedSizeAnnotation: strconv.FormatInt(uncompressedSize, 10),
+			},
+		},
+		DiffID: blob.DiffID(),
+	}, nil
+}
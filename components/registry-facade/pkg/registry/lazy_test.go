@@ -0,0 +1,265 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	redis "github.com/redis/go-redis/v9"
+
+	rfapi "github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+func TestSubstituteLazyLayers(t *testing.T) {
+	ctx := context.Background()
+	redisServer, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("cannot run mini redis server: %v", err)
+	}
+	defer redisServer.Close()
+	idx := &RedisLazyLayerIndex{Client: redis.NewClient(&redis.Options{Addr: redisServer.Addr()})}
+
+	converted := &ConvertedLayer{
+		Descriptor: ociv1.Descriptor{
+			MediaType:   images.MediaTypeDockerSchema2LayerGzip,
+			Digest:      digest.FromString("converted"),
+			Size:        42,
+			Annotations: map[string]string{estargz.TOCJSONDigestAnnotation: digest.FromString("toc").String()},
+		},
+		DiffID: digest.FromString("converted-diff"),
+	}
+	err = idx.Put(ctx, digest.FromString("base"), converted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newImage := func() ([]ociv1.Descriptor, *ociv1.Image) {
+		return []ociv1.Descriptor{
+			{MediaType: images.MediaTypeDockerSchema2LayerGzip, Digest: digest.FromString("base"), Size: 100},
+			{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString("other"), Size: 100},
+		}, &ociv1.Image{
+			RootFS: ociv1.RootFS{DiffIDs: []digest.Digest{digest.FromString("base-diff"), digest.FromString("other-diff")}},
+		}
+	}
+
+	tests := []struct {
+		Name            string
+		Index           LazyLayerIndex
+		Modify          func(layers []ociv1.Descriptor, cfg *ociv1.Image) []ociv1.Descriptor
+		ExpectConverted bool
+	}{
+		{Name: "no index"},
+		{Name: "converted layer", Index: idx, ExpectConverted: true},
+		{
+			Name:  "diffIDs don't match layers",
+			Index: idx,
+			Modify: func(layers []ociv1.Descriptor, cfg *ociv1.Image) []ociv1.Descriptor {
+				cfg.RootFS.DiffIDs = cfg.RootFS.DiffIDs[:1]
+				return layers
+			},
+		},
+		{
+			Name:  "unsupported media type",
+			Index: idx,
+			Modify: func(layers []ociv1.Descriptor, cfg *ociv1.Image) []ociv1.Descriptor {
+				layers[0].MediaType = "application/vnd.oci.image.layer.v1.tar+zstd"
+				return layers
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			layers, cfg := newImage()
+			if test.Modify != nil {
+				layers = test.Modify(layers, cfg)
+			}
+			origLayers := append([]ociv1.Descriptor{}, layers...)
+			origDiffIDs := append([]digest.Digest{}, cfg.RootFS.DiffIDs...)
+
+			act := substituteLazyLayers(ctx, test.Index, layers, cfg)
+
+			if diff := cmp.Diff(origLayers, layers); diff != "" {
+				t.Errorf("original layers were modified (-want +got):\n%s", diff)
+			}
+
+			expLayers, expDiffIDs := origLayers, origDiffIDs
+			if test.ExpectConverted {
+				expLayers = []ociv1.Descriptor{converted.Descriptor, origLayers[1]}
+				expDiffIDs = []digest.Digest{converted.DiffID, origDiffIDs[1]}
+			}
+			if diff := cmp.Diff(expLayers, act); diff != "" {
+				t.Errorf("unexpected layers (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(expDiffIDs, cfg.RootFS.DiffIDs); diff != "" {
+				t.Errorf("unexpected diffIDs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildLazyLayer(t *testing.T) {
+	files := map[string]string{
+		"etc/hello":     "hello world",
+		"usr/bin/large": string(bytes.Repeat([]byte("0123456789"), 1<<20)),
+	}
+
+	var layer bytes.Buffer
+	gzw := gzip.NewWriter(&layer)
+	tw := tar.NewWriter(gzw)
+	for _, name := range []string{"etc/hello", "usr/bin/large"} {
+		err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(files[name]))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(files[name]))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := os.Create(filepath.Join(t.TempDir(), "layer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	desc := ociv1.Descriptor{MediaType: images.MediaTypeDockerSchema2LayerGzip, Digest: digest.FromBytes(layer.Bytes()), Size: int64(layer.Len())}
+	converted, err := buildLazyLayer(context.Background(), desc, bytes.NewReader(layer.Bytes()), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted.Descriptor.MediaType != desc.MediaType {
+		t.Errorf("unexpected media type: %s", converted.Descriptor.MediaType)
+	}
+	if converted.Descriptor.Size != int64(len(blob)) || converted.Descriptor.Digest != digest.FromBytes(blob) {
+		t.Errorf("descriptor does not match the converted blob")
+	}
+
+	gzr, err := gzip.NewReader(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed, err := io.ReadAll(gzr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted.DiffID != digest.FromBytes(uncompressed) {
+		t.Errorf("unexpected diffID: expected %s, got %s", digest.FromBytes(uncompressed), converted.DiffID)
+	}
+	if act := converted.Descriptor.Annotations[estargz.StoreUncompressedSizeAnnotation]; act != strconv.Itoa(len(uncompressed)) {
+		t.Errorf("unexpected uncompressed size annotation: expected %d, got %s", len(uncompressed), act)
+	}
+
+	r, err := estargz.Open(io.NewSectionReader(bytes.NewReader(blob), 0, int64(len(blob))))
+	if err != nil {
+		t.Fatalf("converted layer is not eStargz: %v", err)
+	}
+	if act := converted.Descriptor.Annotations[estargz.TOCJSONDigestAnnotation]; act != r.TOCDigest().String() {
+		t.Errorf("unexpected TOC digest annotation: expected %s, got %s", r.TOCDigest(), act)
+	}
+	for name, content := range files {
+		sr, err := r.OpenFile(name)
+		if err != nil {
+			t.Fatalf("cannot open %s: %v", name, err)
+		}
+		act, err := io.ReadAll(io.NewSectionReader(sr, 0, sr.Size()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(act) != content {
+			t.Errorf("unexpected content of %s", name)
+		}
+	}
+}
+
+type testBlobSource struct {
+	Content string
+}
+
+func (testBlobSource) Name() string { return "test" }
+
+func (testBlobSource) HasBlob(ctx context.Context, spec *rfapi.ImageSpec, dgst digest.Digest) bool {
+	return true
+}
+
+func (s testBlobSource) GetBlob(ctx context.Context, spec *rfapi.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	return false, images.MediaTypeDockerSchema2LayerGzip, "", &reader{ReaderAt: stringReader(s.Content)}, nil
+}
+
+func TestRetrieveRangedBlob(t *testing.T) {
+	src := testBlobSource{Content: "0123456789"}
+	bh := &blobHandler{
+		Spec:   &rfapi.ImageSpec{},
+		Digest: digest.FromString(src.Content),
+	}
+
+	tests := []struct {
+		Name            string
+		Range           string
+		ExpectStatus    int
+		ExpectBody      string
+		ExpectDontCache bool
+	}{
+		{Name: "full blob", ExpectStatus: http.StatusOK, ExpectBody: "0123456789"},
+		{Name: "range", Range: "bytes=2-5", ExpectStatus: http.StatusPartialContent, ExpectBody: "2345", ExpectDontCache: true},
+		{Name: "suffix range", Range: "bytes=-3", ExpectStatus: http.StatusPartialContent, ExpectBody: "789", ExpectDontCache: true},
+		{Name: "unsatisfiable range", Range: "bytes=20-30", ExpectStatus: http.StatusRequestedRangeNotSatisfiable, ExpectDontCache: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v2/foo/blobs/"+bh.Digest.String(), nil)
+			if test.Range != "" {
+				req.Header.Set("Range", test.Range)
+			}
+			rec := httptest.NewRecorder()
+
+			handled, dontCache, err := bh.retrieveFromSource(context.Background(), src, rec, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !handled {
+				t.Fatal("blob was not handled")
+			}
+			if dontCache != test.ExpectDontCache {
+				t.Errorf("unexpected dontCache: expected %v, got %v", test.ExpectDontCache, dontCache)
+			}
+			if rec.Code != test.ExpectStatus {
+				t.Errorf("unexpected status: expected %d, got %d", test.ExpectStatus, rec.Code)
+			}
+			if test.ExpectBody != "" && rec.Body.String() != test.ExpectBody {
+				t.Errorf("unexpected body: expected %q, got %q", test.ExpectBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -70,6 +70,7 @@ func (reg *Registry) handleManifest(ctx context.Context, r *http.Request) http.H
 		Resolver:       reg.Resolver(),
 		Store:          reg.Store,
 		ConfigModifier: reg.ConfigModifier,
+		LazyLayers:     reg.LazyLayers,
 	}
 	reference := getReference(ctx)
 	dgst, err := digest.Parse(reference)
@@ -102,6 +103,7 @@ type manifestHandler struct {
 	Resolver       remotes.Resolver
 	Store          BlobStore
 	ConfigModifier ConfigModifier
+	LazyLayers     LazyLayerIndex
 
 	Name   string
 	Tag    string
@@ -185,6 +187,9 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 				return err
 			}
 
+			// serve lazy-pullable (eStargz) layers where we have converted them
+			manifest.Layers = substituteLazyLayers(ctx, mh.LazyLayers, manifest.Layers, cfg)
+
 			// modify config
 			addonLayer, err := mh.ConfigModifier(ctx, mh.Spec, cfg)
 			if err != nil {
@@ -75,6 +75,7 @@ type Registry struct {
 	IPFS           *IPFSBlobCache
 	LayerSource    LayerSource
 	ConfigModifier ConfigModifier
+	LazyLayers     LazyLayerIndex
 	SpecProvider   map[string]ImageSpecProvider
 
 	staticLayerSource *RevisioningLayerSource
@@ -84,13 +85,22 @@ type Registry struct {
 
 // NewRegistry creates a new registry
 func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus.Registerer) (*Registry, error) {
-	var mfStore BlobStore
+	var (
+		mfStore    BlobStore
+		lazyLayers LazyLayerIndex
+	)
 
 	if cfg.IPFSCache != nil && cfg.IPFSCache.Enabled {
 		if cfg.RedisCache == nil || !cfg.RedisCache.Enabled {
 			return nil, xerrors.Errorf("IPFS cache requires Redis")
 		}
 	}
+	if cfg.LazyPull != nil && cfg.LazyPull.Enabled {
+		// converted layers are as large as the original ones and must not end up in Redis
+		if cfg.IPFSCache == nil || !cfg.IPFSCache.Enabled {
+			return nil, xerrors.Errorf("lazy pulling requires the IPFS cache")
+		}
+	}
 
 	if cfg.RedisCache != nil && cfg.RedisCache.Enabled {
 		rdc, err := getRedisClient(cfg.RedisCache)
@@ -99,6 +109,10 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 		}
 
 		mfStore = &RedisBlobStore{Client: rdc}
+		if cfg.LazyPull != nil && cfg.LazyPull.Enabled {
+			lazyLayers = &RedisLazyLayerIndex{Client: rdc}
+			log.Info("serving lazy-pullable layers")
+		}
 		log.Info("using redis to cache manifests and config")
 
 		resolverFactory := &RedisCachedResolver{
@@ -239,6 +253,7 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 		LayerSource:       layerSource,
 		staticLayerSource: staticLayer,
 		ConfigModifier:    NewConfigModifierFromLayerSource(layerSource),
+		LazyLayers:        lazyLayers,
 		metrics:           metrics,
 	}, nil
 }
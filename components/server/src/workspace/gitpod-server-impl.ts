@@ -155,7 +155,7 @@ import {
     WorkspaceTimeoutSetting,
 } from "@gitpod/gitpod-protocol/lib/protocol";
 import { Deferred } from "@gitpod/gitpod-protocol/lib/util/deferred";
-import { ListUsageRequest, ListUsageResponse } from "@gitpod/gitpod-protocol/lib/usage";
+import { ListUsageRequest, ListUsageResponse, UsageKind } from "@gitpod/gitpod-protocol/lib/usage";
 import { VerificationService } from "../auth/verification-service";
 import { BillingMode } from "@gitpod/gitpod-protocol/lib/billing-mode";
 import { EntitlementService, MayStartWorkspaceResult } from "../billing/entitlement-service";
@@ -221,6 +221,17 @@ export function censor<T>(obj: T, k: keyof T): T {
     return r;
 }
 
+function toUsageKind(kind: Usage_Kind): UsageKind {
+    switch (kind) {
+        case Usage_Kind.KIND_WORKSPACE_INSTANCE:
+            return "workspaceinstance";
+        case Usage_Kind.KIND_STORAGE:
+            return "storage";
+        default:
+            return "invoice";
+    }
+}
+
 export type GitpodServerWithTracing = InterfaceWithTraceContext<GitpodServer>;
 
 @injectable()
@@ -4472,7 +4483,7 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
                     description: u.description,
                     draft: u.draft,
                     workspaceInstanceId: u.workspaceInstanceId,
-                    kind: u.kind === Usage_Kind.KIND_WORKSPACE_INSTANCE ? "workspaceinstance" : "invoice",
+                    kind: toUsageKind(u.kind),
                     metadata: !!u.metadata ? JSON.parse(u.metadata) : undefined,
                 };
             }),
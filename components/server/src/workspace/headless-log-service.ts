@@ -174,8 +174,8 @@ export class HeadlessLogService {
         for (const task of tasks) {
             const taskId = task.getId();
             const terminalId = task.getTerminal();
-            if (task.getState() === TaskState.OPENING) {
-                // this might be the case when there is no terminal for this task, yet.
+            if (task.getState() === TaskState.OPENING || task.getState() === TaskState.BLOCKED) {
+                // this might be the case when there is no terminal for this task, yet, e.g. because it waits for other tasks.
                 // if we find any such case, we deem the workspace not ready yet, and try to reconnect later,
                 // to be sure to get hold of all terminals created.
                 throw new Error(`instance's ${instanceId} task ${task.getId()} has no terminal yet`);
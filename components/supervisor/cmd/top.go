@@ -52,12 +52,18 @@ var topCmd = &cobra.Command{
 			tw := tabwriter.NewWriter(os.Stdout, 6, 4, 3, ' ', 0)
 			defer tw.Flush()
 
-			fmt.Fprintf(tw, "CPU(millicores)\tMEMORY(bytes)\n")
-
 			cpuFraction := int64((float64(status.Cpu.Used) / float64(status.Cpu.Limit)) * 100)
 			memFraction := int64((float64(status.Memory.Used) / float64(status.Memory.Limit)) * 100)
 
-			fmt.Fprintf(tw, "%dm/%dm (%d%%)\t%dMi/%dMi (%d%%)\n", status.Cpu.Used, status.Cpu.Limit, cpuFraction, status.Memory.Used/(1024*1024), status.Memory.Limit/(1024*1024), memFraction)
+			if status.Storage == nil || status.Storage.Limit == 0 {
+				fmt.Fprintf(tw, "CPU(millicores)\tMEMORY(bytes)\n")
+				fmt.Fprintf(tw, "%dm/%dm (%d%%)\t%dMi/%dMi (%d%%)\n", status.Cpu.Used, status.Cpu.Limit, cpuFraction, status.Memory.Used/(1024*1024), status.Memory.Limit/(1024*1024), memFraction)
+				return
+			}
+
+			storageFraction := int64((float64(status.Storage.Used) / float64(status.Storage.Limit)) * 100)
+			fmt.Fprintf(tw, "CPU(millicores)\tMEMORY(bytes)\tDISK(bytes)\n")
+			fmt.Fprintf(tw, "%dm/%dm (%d%%)\t%dMi/%dMi (%d%%)\t%dMi/%dMi (%d%%)\n", status.Cpu.Used, status.Cpu.Limit, cpuFraction, status.Memory.Used/(1024*1024), status.Memory.Limit/(1024*1024), memFraction, status.Storage.Used/(1024*1024), status.Storage.Limit/(1024*1024), storageFraction)
 		}
 	},
 }
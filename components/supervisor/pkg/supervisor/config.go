@@ -13,6 +13,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	env "github.com/Netflix/go-env"
@@ -301,6 +304,14 @@ type WorkspaceConfig struct {
 
 	// ConfigcatEnabled controls whether configcat is enabled
 	ConfigcatEnabled bool `env:"GITPOD_CONFIGCAT_ENABLED"`
+
+	// TerminalScrollbackLimit is the max number of compressed bytes of task terminal output persisted in the workspace.
+	// Scrollback is kept in memory only if this is zero.
+	TerminalScrollbackLimit int64 `env:"SUPERVISOR_TERMINAL_SCROLLBACK_LIMIT"`
+
+	// StorageWarningThresholds is a comma-separated list of storage quota fill levels in percent
+	// at which the user is warned, e.g. "80,95". Defaults to 80 and 95 percent. Set to "off" to disable warnings.
+	StorageWarningThresholds string `env:"SUPERVISOR_STORAGE_WARNING_THRESHOLDS"`
 }
 
 // WorkspaceGitpodToken is a list of tokens that should be added to supervisor's token service.
@@ -319,6 +330,212 @@ type TaskConfig struct {
 	Env      *map[string]interface{} `json:"env,omitempty"`
 	OpenIn   *string                 `json:"openIn,omitempty"`
 	OpenMode *string                 `json:"openMode,omitempty"`
+
+	// DependsOn lists the names of the tasks whose commands have to finish before this task starts.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// WaitForPort lists the ports which have to accept connections before this task starts.
+	WaitForPort []int `json:"waitForPort,omitempty"`
+	// WaitForFile lists the files which have to exist before this task starts.
+	WaitForFile []string `json:"waitForFile,omitempty"`
+
+	// RestartPolicy is one of never (default), on-failure or always and controls whether the
+	// command of the task is restarted when it exits. It has no effect in headless workspaces.
+	RestartPolicy *TaskRestartPolicy `json:"restartPolicy,omitempty"`
+	// MaxRestarts limits how often the task is restarted, defaults to defaultTaskMaxRestarts.
+	MaxRestarts *int `json:"maxRestarts,omitempty"`
+	// ReadinessProbe determines whether the task is ready to serve.
+	ReadinessProbe *TaskProbe `json:"readinessProbe,omitempty"`
+	// LivenessProbe determines whether the task is alive. If it fails the task is terminated.
+	LivenessProbe *TaskProbe `json:"livenessProbe,omitempty"`
+}
+
+// TaskRestartPolicy determines when a task is restarted.
+type TaskRestartPolicy string
+
+const (
+	// TaskRestartNever never restarts a task
+	TaskRestartNever TaskRestartPolicy = "never"
+	// TaskRestartOnFailure restarts a task if its command fails
+	TaskRestartOnFailure TaskRestartPolicy = "on-failure"
+	// TaskRestartAlways restarts a task whenever its command exits
+	TaskRestartAlways TaskRestartPolicy = "always"
+)
+
+const defaultTaskMaxRestarts = 5
+
+// TaskProbe checks the health of a task. Exactly one of HTTP, TCP or Exec must be set.
+type TaskProbe struct {
+	HTTP *TaskHTTPProbe `json:"http,omitempty"`
+	TCP  *TaskTCPProbe  `json:"tcp,omitempty"`
+	Exec *TaskExecProbe `json:"exec,omitempty"`
+
+	// InitialDelaySeconds is the time to wait after the task started before probing
+	InitialDelaySeconds int `json:"initialDelaySeconds,omitempty"`
+	// PeriodSeconds is the interval of the probe, defaults to 10 seconds
+	PeriodSeconds int `json:"periodSeconds,omitempty"`
+	// TimeoutSeconds is the time after which a single probe is considered failed, defaults to 1 second
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the probe is failing, defaults to 3
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+// TaskHTTPProbe succeeds if a GET request to the port and path returns a 2xx or 3xx status code.
+type TaskHTTPProbe struct {
+	Port int    `json:"port"`
+	Path string `json:"path,omitempty"`
+}
+
+// TaskTCPProbe succeeds if the port accepts connections.
+type TaskTCPProbe struct {
+	Port int `json:"port"`
+}
+
+// TaskExecProbe succeeds if the command exits with status code 0.
+type TaskExecProbe struct {
+	Command string `json:"command"`
+}
+
+func (p *TaskProbe) validate() error {
+	var n int
+	if p.HTTP != nil {
+		n++
+		if !(0 < p.HTTP.Port && p.HTTP.Port <= math.MaxUint16) {
+			return xerrors.Errorf("http port must be between 1 and %d", math.MaxUint16)
+		}
+	}
+	if p.TCP != nil {
+		n++
+		if !(0 < p.TCP.Port && p.TCP.Port <= math.MaxUint16) {
+			return xerrors.Errorf("tcp port must be between 1 and %d", math.MaxUint16)
+		}
+	}
+	if p.Exec != nil {
+		n++
+		if strings.TrimSpace(p.Exec.Command) == "" {
+			return xerrors.Errorf("exec command must not be empty")
+		}
+	}
+	if n != 1 {
+		return xerrors.Errorf("exactly one of http, tcp or exec is required")
+	}
+	if p.InitialDelaySeconds < 0 || p.PeriodSeconds < 0 || p.TimeoutSeconds < 0 || p.FailureThreshold < 0 {
+		return xerrors.Errorf("initialDelaySeconds, periodSeconds, timeoutSeconds and failureThreshold must be >= 0")
+	}
+	return nil
+}
+
+// validateHealthChecks validates the restart policy and the probes of a task.
+func (c TaskConfig) validateHealthChecks() error {
+	if c.RestartPolicy != nil {
+		switch *c.RestartPolicy {
+		case TaskRestartNever, TaskRestartOnFailure, TaskRestartAlways:
+		default:
+			return xerrors.Errorf("unknown restart policy %q", *c.RestartPolicy)
+		}
+	}
+	if c.MaxRestarts != nil && *c.MaxRestarts < 0 {
+		return xerrors.Errorf("maxRestarts must be >= 0")
+	}
+	if c.ReadinessProbe != nil {
+		if err := c.ReadinessProbe.validate(); err != nil {
+			return xerrors.Errorf("invalid readiness probe: %w", err)
+		}
+	}
+	if c.LivenessProbe != nil {
+		if err := c.LivenessProbe.validate(); err != nil {
+			return xerrors.Errorf("invalid liveness probe: %w", err)
+		}
+	}
+	return nil
+}
+
+// taskName returns the name of the i-th task as it's presented to the user.
+func taskName(config TaskConfig, i int) string {
+	if config.Name != nil {
+		return *config.Name
+	}
+	return "Gitpod Task " + strconv.Itoa(i+1)
+}
+
+// resolveTaskDependencies maps the dependencies of each task to the indices of the tasks they refer to.
+// It fails if a dependency cannot be resolved unambiguously, if a wait condition is invalid or if the
+// dependencies contain a cycle.
+func resolveTaskDependencies(tasks []TaskConfig) ([][]int, error) {
+	names := make(map[string][]int, len(tasks))
+	for i, t := range tasks {
+		name := taskName(t, i)
+		names[name] = append(names[name], i)
+	}
+
+	deps := make([][]int, len(tasks))
+	for i, t := range tasks {
+		for _, dep := range t.DependsOn {
+			idx, ok := names[dep]
+			if !ok {
+				return nil, xerrors.Errorf("task %q depends on unknown task %q", taskName(t, i), dep)
+			}
+			if len(idx) > 1 {
+				return nil, xerrors.Errorf("task %q depends on %q which is the name of %d tasks", taskName(t, i), dep, len(idx))
+			}
+			deps[i] = append(deps[i], idx[0])
+		}
+		for _, port := range t.WaitForPort {
+			if !(0 < port && port <= math.MaxUint16) {
+				return nil, xerrors.Errorf("task %q waits for port %d which is not between 1 and %d", taskName(t, i), port, math.MaxUint16)
+			}
+		}
+		for _, fn := range t.WaitForFile {
+			if strings.TrimSpace(fn) == "" {
+				return nil, xerrors.Errorf("task %q waits for an empty file name", taskName(t, i))
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make([]int, len(tasks))
+		path  []int
+		visit func(i int) error
+	)
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			var cycle []string
+			for j := len(path) - 1; j >= 0; j-- {
+				cycle = append([]string{taskName(tasks[path[j]], path[j])}, cycle...)
+				if path[j] == i {
+					break
+				}
+			}
+			cycle = append(cycle, taskName(tasks[i], i))
+			return xerrors.Errorf("tasks have a dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[i] = visiting
+		path = append(path, i)
+		for _, dep := range deps[i] {
+			err := visit(dep)
+			if err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range tasks {
+		err := visit(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return deps, nil
 }
 
 // Validate validates this configuration.
@@ -335,6 +552,14 @@ func (c WorkspaceConfig) Validate() error {
 		return xerrors.Errorf("logRateLimit must be >= 0")
 	}
 
+	if c.TerminalScrollbackLimit < 0 {
+		return xerrors.Errorf("SUPERVISOR_TERMINAL_SCROLLBACK_LIMIT must be >= 0")
+	}
+
+	if _, err := c.StorageWarnings(); err != nil {
+		return err
+	}
+
 	if _, err := c.GetTokens(false); err != nil {
 		return err
 	}
@@ -346,6 +571,28 @@ func (c WorkspaceConfig) Validate() error {
 	return nil
 }
 
+// StorageWarnings parses the storage quota fill levels at which the user is warned.
+// The thresholds are returned in ascending order.
+func (c WorkspaceConfig) StorageWarnings() ([]int, error) {
+	switch c.StorageWarningThresholds {
+	case "":
+		return []int{80, 95}, nil
+	case "off":
+		return nil, nil
+	}
+
+	var res []int
+	for _, v := range strings.Split(c.StorageWarningThresholds, ",") {
+		threshold, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || threshold <= 0 || threshold > 100 {
+			return nil, xerrors.Errorf("SUPERVISOR_STORAGE_WARNING_THRESHOLDS must be a list of percentages between 1 and 100: %s", v)
+		}
+		res = append(res, threshold)
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
 // GetTokens parses tokens from GITPOD_TOKENS and possibly downloads OTS.
 func (c WorkspaceConfig) GetTokens(downloadOTS bool) ([]WorkspaceGitpodToken, error) {
 	if c.Tokens == "" {
@@ -437,6 +684,18 @@ func (c WorkspaceConfig) getGitpodTasks() (tasks *[]TaskConfig, err error) {
 	if err != nil {
 		return nil, xerrors.Errorf("cannot parse tasks: %w", err)
 	}
+	if tasks != nil {
+		_, err = resolveTaskDependencies(*tasks)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid tasks: %w", err)
+		}
+		for i, t := range *tasks {
+			err = t.validateHealthChecks()
+			if err != nil {
+				return nil, xerrors.Errorf("invalid task %q: %w", taskName(t, i), err)
+			}
+		}
+	}
 	return
 }
 
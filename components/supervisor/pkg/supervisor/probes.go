@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	defaultProbePeriod           = 10 * time.Second
+	defaultProbeTimeout          = 1 * time.Second
+	defaultProbeFailureThreshold = 3
+)
+
+// watchProbe runs a probe periodically until the context is done and reports every change of its state.
+func (tm *tasksManager) watchProbe(ctx context.Context, probe *TaskProbe, onChange func(state api.TaskProbeState)) {
+	var (
+		period    = defaultProbePeriod
+		timeout   = defaultProbeTimeout
+		threshold = defaultProbeFailureThreshold
+	)
+	if probe.PeriodSeconds > 0 {
+		period = time.Duration(probe.PeriodSeconds) * time.Second
+	}
+	if probe.TimeoutSeconds > 0 {
+		timeout = time.Duration(probe.TimeoutSeconds) * time.Second
+	}
+	if probe.FailureThreshold > 0 {
+		threshold = probe.FailureThreshold
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(time.Duration(probe.InitialDelaySeconds) * time.Second):
+	}
+
+	var (
+		state    = api.TaskProbeState_probe_pending
+		failures int
+	)
+	for {
+		err := tm.runProbe(ctx, probe, timeout)
+		if ctx.Err() != nil {
+			return
+		}
+
+		newState := state
+		if err == nil {
+			failures = 0
+			newState = api.TaskProbeState_probe_passing
+		} else {
+			failures++
+			log.WithError(err).WithField("failures", failures).Debug("task probe failed")
+			if failures >= threshold {
+				newState = api.TaskProbeState_probe_failing
+			}
+		}
+		if newState != state {
+			state = newState
+			onChange(state)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(period):
+		}
+	}
+}
+
+// runProbe runs a probe once and returns an error if it did not succeed.
+func (tm *tasksManager) runProbe(ctx context.Context, probe *TaskProbe, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case probe.HTTP != nil:
+		path := probe.HTTP.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d%s", probe.HTTP.Port, path), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return xerrors.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+
+	case probe.TCP != nil:
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("localhost:%d", probe.TCP.Port))
+		if err != nil {
+			return err
+		}
+		conn.Close()
+		return nil
+
+	case probe.Exec != nil:
+		// run the command like it would run in a task terminal
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", probe.Exec.Command)
+		cmd.Dir = tm.workdir()
+		cmd.Env = tm.terminalService.Env
+		if tm.terminalService.DefaultCreds != nil {
+			cmd.SysProcAttr = &syscall.SysProcAttr{
+				Credential: tm.terminalService.DefaultCreds,
+			}
+		}
+		return cmd.Run()
+	}
+	return xerrors.Errorf("probe has no handler")
+}
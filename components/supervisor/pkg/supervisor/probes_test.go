@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+)
+
+func TestRunProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	tests := []struct {
+		Name    string
+		Probe   TaskProbe
+		Success bool
+	}{
+		{Name: "http", Probe: TaskProbe{HTTP: &TaskHTTPProbe{Port: port, Path: "healthz"}}, Success: true},
+		{Name: "http unavailable", Probe: TaskProbe{HTTP: &TaskHTTPProbe{Port: port, Path: "/"}}},
+		{Name: "http closed", Probe: TaskProbe{HTTP: &TaskHTTPProbe{Port: closedPort}}},
+		{Name: "tcp", Probe: TaskProbe{TCP: &TaskTCPProbe{Port: port}}, Success: true},
+		{Name: "tcp closed", Probe: TaskProbe{TCP: &TaskTCPProbe{Port: closedPort}}},
+		{Name: "exec", Probe: TaskProbe{Exec: &TaskExecProbe{Command: "test -d /"}}, Success: true},
+		{Name: "exec failed", Probe: TaskProbe{Exec: &TaskExecProbe{Command: "exit 1"}}},
+		{Name: "exec timeout", Probe: TaskProbe{Exec: &TaskExecProbe{Command: "sleep 10"}}},
+	}
+
+	tm := &tasksManager{terminalService: terminal.NewMuxTerminalService(terminal.NewMux())}
+	tm.terminalService.DefaultWorkdir = t.TempDir()
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := tm.runProbe(context.Background(), &test.Probe, 500*time.Millisecond)
+			if success := err == nil; success != test.Success {
+				t.Errorf("probe success is %v but expected %v: %v", success, test.Success, err)
+			}
+		})
+	}
+}
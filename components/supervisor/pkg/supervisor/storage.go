@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// storageWarningHysteresis is how many percentage points usage must drop below a threshold
+	// before we warn about that threshold again.
+	storageWarningHysteresis = 5
+
+	// storageWarningTimeout is how long a storage warning stays pending if the user doesn't dismiss it
+	storageWarningTimeout = 30 * time.Minute
+)
+
+// storageWarner decides when the user should be warned about the workspace's storage usage
+type storageWarner struct {
+	// thresholds are the fill levels in percent, in ascending order
+	thresholds []int
+	// warned is the number of thresholds the user has been warned about
+	warned int
+}
+
+// update returns the threshold the user should be warned about, if any. Every threshold produces
+// a single warning, until the usage drops below it again, e.g. because files were deleted or the
+// storage quota was resized.
+func (w *storageWarner) update(used, limit int64) (threshold int, warn bool) {
+	if limit <= 0 {
+		return 0, false
+	}
+
+	percentage := int(used * 100 / limit)
+	for w.warned > 0 && percentage < w.thresholds[w.warned-1]-storageWarningHysteresis {
+		w.warned--
+	}
+
+	var crossed int
+	for i, t := range w.thresholds {
+		if percentage >= t {
+			crossed = i + 1
+		}
+	}
+	if crossed <= w.warned {
+		return 0, false
+	}
+	w.warned = crossed
+	return w.thresholds[crossed-1], true
+}
+
+// warnStorageUsage notifies the user when the workspace's storage usage crosses one of the thresholds
+func warnStorageUsage(ctx context.Context, thresholds []int, topService *TopService, notificationService *NotificationService) {
+	if len(thresholds) == 0 {
+		return
+	}
+
+	warner := &storageWarner{thresholds: thresholds}
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		data := topService.data
+		if data == nil || data.Storage == nil {
+			continue
+		}
+		threshold, warn := warner.update(data.Storage.Used, data.Storage.Limit)
+		if !warn {
+			continue
+		}
+
+		message := fmt.Sprintf("Your workspace has used %d%% of its %.1f GiB of storage. Free up some space to avoid running out of disk.", threshold, float64(data.Storage.Limit)/(1024*1024*1024))
+		log.WithField("threshold", threshold).Info("warning user about storage usage")
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, storageWarningTimeout)
+			defer cancel()
+
+			_, err := notificationService.Notify(ctx, &api.NotifyRequest{
+				Level:   api.NotifyRequest_WARNING,
+				Message: message,
+			})
+			if err != nil && ctx.Err() == nil {
+				log.WithError(err).Warn("cannot warn user about storage usage")
+			}
+		}()
+	}
+}
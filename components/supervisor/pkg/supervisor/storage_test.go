@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStorageWarnerUpdate(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Thresholds  []int
+		Usage       []int64
+		Expectation []int
+	}{
+		{
+			Name:        "below thresholds",
+			Thresholds:  []int{80, 95},
+			Usage:       []int64{10, 50, 79},
+			Expectation: []int{0, 0, 0},
+		},
+		{
+			Name:        "warns once per threshold",
+			Thresholds:  []int{80, 95},
+			Usage:       []int64{80, 85, 96, 99},
+			Expectation: []int{80, 0, 95, 0},
+		},
+		{
+			Name:        "jumps to highest threshold",
+			Thresholds:  []int{80, 95},
+			Usage:       []int64{50, 97, 85},
+			Expectation: []int{0, 95, 0},
+		},
+		{
+			Name:        "flapping around a threshold",
+			Thresholds:  []int{80, 95},
+			Usage:       []int64{81, 79, 81, 74, 81},
+			Expectation: []int{80, 0, 0, 0, 80},
+		},
+		{
+			Name:        "quota resized",
+			Thresholds:  []int{80, 95},
+			Usage:       []int64{96, 40, 85},
+			Expectation: []int{95, 0, 80},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			warner := &storageWarner{thresholds: test.Thresholds}
+
+			var act []int
+			for _, used := range test.Usage {
+				threshold, warn := warner.update(used, 100)
+				if !warn {
+					threshold = 0
+				}
+				act = append(act, threshold)
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected warnings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStorageWarnings(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation []int
+		Error       bool
+	}{
+		{Name: "default", Expectation: []int{80, 95}},
+		{Name: "disabled", Input: "off"},
+		{Name: "custom", Input: "90, 50,75", Expectation: []int{50, 75, 90}},
+		{Name: "invalid", Input: "eighty", Error: true},
+		{Name: "out of range", Input: "80,101", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := WorkspaceConfig{StorageWarningThresholds: test.Input}.StorageWarnings()
+			if (err != nil) != test.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected thresholds (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
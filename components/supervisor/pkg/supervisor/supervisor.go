@@ -306,6 +306,10 @@ func Run(options ...RunOption) {
 	if !cfg.isHeadless() && !opts.RunGP {
 		go analyseConfigChanges(ctx, cfg, telemetry, gitpodConfigService)
 		go analysePerfChanges(ctx, cfg, telemetry, topService)
+
+		// thresholds have been validated with the config
+		storageWarnings, _ := cfg.StorageWarnings()
+		go warnStorageUsage(ctx, storageWarnings, topService, notificationService)
 	}
 
 	supervisorMetrics := metrics.NewMetrics()
@@ -346,7 +350,7 @@ func Run(options ...RunOption) {
 		Gid: gitpodGID,
 	}
 
-	taskManager := newTasksManager(cfg, termMuxSrv, cstate, nil, ideReady, desktopIdeReady)
+	taskManager := newTasksManager(cfg, termMuxSrv, cstate, nil, ideReady, desktopIdeReady, notificationService)
 
 	willShutdownCtx, fireWillShutdown := context.WithCancel(ctx)
 	apiServices := []RegisterableService{
@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -70,6 +72,46 @@ type task struct {
 	successChan chan taskSuccess
 	title       string
 	lastOutput  string
+
+	// dependsOn are the tasks whose commands have to finish before this task starts
+	dependsOn []*task
+	// done is closed once the task terminated, result holds its outcome afterwards
+	done   chan struct{}
+	result taskSuccess
+
+	// commandDone is closed once the command of the task finished, commandResult holds its outcome afterwards.
+	// Interactive task terminals keep running after their command finished, hence tasks other tasks depend on
+	// write the exit code of their command to exitMarker.
+	commandDone   chan struct{}
+	commandResult taskSuccess
+	commandOnce   sync.Once
+	exitMarker    string
+
+	restartPolicy TaskRestartPolicy
+	maxRestarts   int
+}
+
+// finish reports the outcome of the task to the tasks manager and the tasks depending on it.
+// It must be called exactly once per task.
+func (t *task) finish(result taskSuccess) {
+	t.commandFinished(result)
+	t.result = result
+	t.successChan <- result
+	close(t.done)
+}
+
+// commandFinished reports the outcome of the task command to the tasks depending on it.
+// Only the first outcome counts, i.e. restarts of the command don't block dependent tasks again.
+func (t *task) commandFinished(result taskSuccess) {
+	t.commandOnce.Do(func() {
+		t.commandResult = result
+		close(t.commandDone)
+	})
+}
+
+// blocked returns true if the task has to wait for other tasks, ports or files before it can start.
+func (t *task) blocked() bool {
+	return len(t.dependsOn) > 0 || len(t.config.WaitForPort) > 0 || len(t.config.WaitForFile) > 0
 }
 
 type headlessTaskProgressReporter interface {
@@ -109,9 +151,10 @@ type tasksManager struct {
 	reporter        headlessTaskProgressReporter
 	ideReady        *ideReadyState
 	desktopIdeReady *ideReadyState
+	notifications   *NotificationService
 }
 
-func newTasksManager(config *Config, terminalService *terminal.MuxTerminalService, contentState ContentState, reporter headlessTaskProgressReporter, ideReady *ideReadyState, desktopIdeReady *ideReadyState) *tasksManager {
+func newTasksManager(config *Config, terminalService *terminal.MuxTerminalService, contentState ContentState, reporter headlessTaskProgressReporter, ideReady *ideReadyState, desktopIdeReady *ideReadyState, notifications *NotificationService) *tasksManager {
 	return &tasksManager{
 		config:          config,
 		terminalService: terminalService,
@@ -122,6 +165,7 @@ func newTasksManager(config *Config, terminalService *terminal.MuxTerminalServic
 		storeLocation:   logs.TerminalStoreLocation,
 		ideReady:        ideReady,
 		desktopIdeReady: desktopIdeReady,
+		notifications:   notifications,
 	}
 }
 
@@ -173,16 +217,16 @@ func (tm *tasksManager) setTaskState(t *task, newState api.TaskState) {
 	})
 }
 
-func (tm *tasksManager) init(ctx context.Context) {
+func (tm *tasksManager) init(ctx context.Context) error {
 	defer close(tm.ready)
 
 	tasks, err := tm.config.getGitpodTasks()
 	if err != nil {
 		log.WithError(err).Error()
-		return
+		return err
 	}
 	if tasks == nil && tm.config.isHeadless() {
-		return
+		return nil
 	}
 	if tasks == nil {
 		tasks = &[]TaskConfig{{}}
@@ -190,7 +234,7 @@ func (tm *tasksManager) init(ctx context.Context) {
 
 	select {
 	case <-ctx.Done():
-		return
+		return nil
 	case <-tm.contentState.ContentReady():
 	}
 
@@ -202,11 +246,8 @@ func (tm *tasksManager) init(ctx context.Context) {
 
 	for i, config := range *tasks {
 		id := strconv.Itoa(i)
-		presentation := &api.TaskPresentation{}
-		if config.Name != nil {
-			presentation.Name = *config.Name
-		} else {
-			presentation.Name = "Gitpod Task " + strconv.Itoa(i+1)
+		presentation := &api.TaskPresentation{
+			Name: taskName(config, i),
 		}
 		if config.OpenIn != nil {
 			presentation.OpenIn = *config.OpenIn
@@ -222,15 +263,81 @@ func (tm *tasksManager) init(ctx context.Context) {
 			},
 			config:      config,
 			successChan: make(chan taskSuccess, 1),
+			done:        make(chan struct{}),
+			commandDone: make(chan struct{}),
 			title:       presentation.Name,
 		}
+		tm.tasks = append(tm.tasks, task)
+	}
+
+	deps, err := resolveTaskDependencies(*tasks)
+	if err != nil {
+		// getGitpodTasks validated the dependencies already
+		return err
+	}
+	dependedOn := make(map[int]bool)
+	for i, task := range tm.tasks {
+		for _, dep := range deps[i] {
+			task.dependsOn = append(task.dependsOn, tm.tasks[dep])
+			dependedOn[dep] = true
+		}
+	}
+	for i, task := range tm.tasks {
+
 		task.command = getCommand(task, tm.config.isHeadless(), tm.config.isPrebuild(), tm.contentSource, tm.storeLocation)
 		if tm.config.isHeadless() && task.command == "exit" {
 			task.State = api.TaskState_closed
-			task.successChan <- taskSuccessful
+			task.finish(taskSuccessful)
+		} else if task.blocked() {
+			task.State = api.TaskState_blocked
 		}
-		tm.tasks = append(tm.tasks, task)
+
+		if tm.config.isHeadless() {
+			// headless tasks are expected to terminate, there's nothing to restart or probe
+			continue
+		}
+		task.restartPolicy = TaskRestartNever
+		if task.config.RestartPolicy != nil && task.config.Command != nil {
+			task.restartPolicy = *task.config.RestartPolicy
+		}
+		task.maxRestarts = defaultTaskMaxRestarts
+		if task.config.MaxRestarts != nil {
+			task.maxRestarts = *task.config.MaxRestarts
+		}
+		if task.restartPolicy != TaskRestartNever {
+			// the terminal has to exit with the command such that we notice when it needs restarting
+			task.command = exitWithCommand(task.command)
+		} else if dependedOn[i] {
+			// the terminal stays open after the command finished, yet dependent tasks must not wait for it
+			task.exitMarker = getExitMarker(task, tm.storeLocation)
+			_ = os.Remove(task.exitMarker)
+			task.command = writeExitMarker(task.command, task.exitMarker)
+		}
+		if task.config.ReadinessProbe != nil {
+			task.Readiness = api.TaskProbeState_probe_pending
+		}
+		if task.config.LivenessProbe != nil {
+			task.Liveness = api.TaskProbeState_probe_pending
+		}
+	}
+	return nil
+}
+
+// writeExitMarker makes a task command write its exit code to the exit marker once it finished.
+func writeExitMarker(command, exitMarker string) string {
+	write := "echo $? > '" + exitMarker + "'"
+	if strings.TrimSpace(command) == "" {
+		return write
+	}
+	return command + "; " + write
+}
+
+// exitWithCommand makes a task terminal exit with the status of the task command.
+func exitWithCommand(command string) string {
+	if strings.TrimSpace(command) == "" {
+		return "exit"
 	}
+	return command + "; exit"
 }
 
 func (tm *tasksManager) waitForIde(parent context.Context, timeout time.Duration) {
@@ -259,106 +366,367 @@ func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan
 	defer wg.Done()
 	defer log.Debug("tasksManager shutdown")
 
-	tm.init(ctx)
+	var success taskSuccess
+	err := tm.init(ctx)
+	if err != nil {
+		success = taskFailed(err.Error())
+	}
 
 	for _, t := range tm.tasks {
-		if t.State == api.TaskState_closed {
+		switch t.State {
+		case api.TaskState_closed:
 			continue
+		case api.TaskState_blocked:
+			go func(t *task) {
+				if tm.awaitConditions(ctx, t) {
+					tm.startTask(ctx, t)
+				}
+			}(t)
+		default:
+			tm.startTask(ctx, t)
 		}
-		taskLog := log.WithField("command", t.command)
-		taskLog.Info("starting a task terminal...")
-		openRequest := &api.OpenTerminalRequest{}
-		if t.config.Env != nil {
-			openRequest.Env = make(map[string]string, len(*t.config.Env))
-			for key, value := range *t.config.Env {
-				// Required check because a string is considered valid JSON (e.g. "hello")
-				// We don't want to marshall basic strings otherwise we get a double quoted environment variable
-				// See: https://github.com/gitpod-io/gitpod/issues/5887
-				if val, ok := value.(string); ok {
-					openRequest.Env[key] = val
+	}
+
+	for _, task := range tm.tasks {
+		select {
+		case <-ctx.Done():
+			success = taskFailed(ctx.Err().Error())
+		case taskResult := <-task.successChan:
+			if taskResult.Failed() {
+				success = success.Fail(string(taskResult))
+			}
+		}
+	}
+
+	if tm.config.isPrebuild() && tm.reporter != nil {
+		tm.reporter.done(success)
+	}
+	successChan <- success
+}
+
+// startTask opens the terminal of a task and runs its command in it.
+func (tm *tasksManager) startTask(ctx context.Context, t *task) {
+	taskLog := log.WithField("command", t.command)
+	taskLog.Info("starting a task terminal...")
+	openRequest := &api.OpenTerminalRequest{}
+	if t.config.Env != nil {
+		openRequest.Env = make(map[string]string, len(*t.config.Env))
+		for key, value := range *t.config.Env {
+			// Required check because a string is considered valid JSON (e.g. "hello")
+			// We don't want to marshall basic strings otherwise we get a double quoted environment variable
+			// See: https://github.com/gitpod-io/gitpod/issues/5887
+			if val, ok := value.(string); ok {
+				openRequest.Env[key] = val
+			} else {
+				v, err := json.Marshal(value)
+				if err != nil {
+					taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
 				} else {
-					v, err := json.Marshal(value)
-					if err != nil {
-						taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
-					} else {
-						openRequest.Env[key] = string(v)
-					}
+					openRequest.Env[key] = string(v)
 				}
 			}
 		}
-		resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
-			ReadTimeout: 5 * time.Second,
-			Title:       t.title,
-		})
-		if err != nil {
-			taskLog.WithError(err).Error("cannot open new task terminal")
-			t.successChan <- taskFailed("cannot open new task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
-			continue
+	}
+	termOptions := terminal.TermOptions{
+		ReadTimeout: 5 * time.Second,
+		Title:       t.title,
+	}
+	if limit := tm.config.TerminalScrollbackLimit; limit > 0 && !tm.config.isHeadless() {
+		// task IDs are stable, hence a task terminal restores its scrollback after a supervisor restart
+		termOptions.Scrollback = &terminal.ScrollbackOptions{
+			Dir:      getScrollbackDir(t, tm.storeLocation),
+			MaxBytes: limit,
+		}
+	}
+	resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, termOptions)
+	if err != nil {
+		taskLog.WithError(err).Error("cannot open new task terminal")
+		t.finish(taskFailed("cannot open new task terminal"))
+		tm.setTaskState(t, api.TaskState_closed)
+		return
+	}
+
+	taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
+	term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
+	if !ok {
+		taskLog.Error("cannot find a task terminal")
+		t.finish(taskFailed("cannot find a task terminal"))
+		tm.setTaskState(t, api.TaskState_closed)
+		return
+	}
+
+	taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
+	taskLog.Info("task terminal has been started")
+	tm.updateState(func() bool {
+		t.Terminal = resp.Terminal.Alias
+		t.State = api.TaskState_running
+		return true
+	})
+
+	probeCtx, cancelProbes := context.WithCancel(ctx)
+	tm.startProbes(probeCtx, t, resp.Terminal.Alias)
+
+	if t.exitMarker != "" {
+		go tm.awaitExitMarker(ctx, t)
+	}
+
+	go func(t *task, term *terminal.Term) {
+		state, err := term.Wait()
+		cancelProbes()
+
+		var result taskSuccess
+		if state != nil && !state.Success() {
+			result = taskFailed(state.String())
+		} else if state == nil && err == nil {
+			result = taskFailed(fmt.Sprintf("cannot wait for task: %s", t.lastOutput))
 		}
+		taskLog.Info("task terminal has been closed")
 
-		taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
-		term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
-		if !ok {
-			taskLog.Error("cannot find a task terminal")
-			t.successChan <- taskFailed("cannot find a task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
-			continue
+		t.commandFinished(result)
+		if tm.restart(ctx, t, result) {
+			return
 		}
+		t.finish(result)
+		tm.setTaskState(t, api.TaskState_closed)
+	}(t, term)
+
+	tm.watch(t, term)
+
+	if t.command != "" {
+		term.PTY.Write([]byte(t.command + "\n"))
+	}
+}
 
-		taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
-		taskLog.Info("task terminal has been started")
+// awaitExitMarker reports the command of a task in an interactive terminal as finished once it wrote its exit marker.
+func (tm *tasksManager) awaitExitMarker(ctx context.Context, t *task) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		// the task terminal may close before the command finished
+		select {
+		case <-t.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var code string
+	ok := pollCondition(ctx, func() bool {
+		content, err := os.ReadFile(t.exitMarker)
+		code = strings.TrimSpace(string(content))
+		return err == nil && code != ""
+	})
+	if !ok {
+		return
+	}
+
+	result := taskSuccessful
+	if code != "0" {
+		result = taskFailed("exit status " + code)
+	}
+	t.commandFinished(result)
+}
+
+// startProbes runs the readiness and liveness probes of a task until the context is done.
+// A failing liveness probe closes the task terminal.
+func (tm *tasksManager) startProbes(ctx context.Context, t *task, alias string) {
+	if tm.config.isHeadless() {
+		return
+	}
+	if t.config.ReadinessProbe != nil {
 		tm.updateState(func() bool {
-			t.Terminal = resp.Terminal.Alias
-			t.State = api.TaskState_running
+			t.Readiness = api.TaskProbeState_probe_pending
 			return true
 		})
+		go tm.watchProbe(ctx, t.config.ReadinessProbe, func(state api.TaskProbeState) {
+			tm.updateState(func() bool {
+				t.Readiness = state
+				return true
+			})
+		})
+	}
+	if t.config.LivenessProbe != nil {
+		tm.updateState(func() bool {
+			t.Liveness = api.TaskProbeState_probe_pending
+			return true
+		})
+		go tm.watchProbe(ctx, t.config.LivenessProbe, func(state api.TaskProbeState) {
+			tm.updateState(func() bool {
+				t.Liveness = state
+				return true
+			})
+			if state != api.TaskProbeState_probe_failing {
+				return
+			}
 
-		go func(t *task, term *terminal.Term) {
-			state, err := term.Wait()
-			if state != nil {
-				if state.Success() {
-					t.successChan <- taskSuccessful
-				} else {
-					t.successChan <- taskFailed(state.String())
-				}
-			} else if err != nil {
-				t.successChan <- taskSuccessful
-			} else {
-				msg := "cannot wait for task"
-				if err != nil {
-					msg = err.Error()
-				}
-
-				t.successChan <- taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
+			log.WithField("task", t.title).Warn("liveness probe of task failed, terminating it")
+			closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := tm.terminalService.Mux.CloseTerminal(closeCtx, alias)
+			if err != nil {
+				log.WithError(err).WithField("task", t.title).Error("cannot close terminal of unhealthy task")
 			}
-			taskLog.Info("task terminal has been closed")
-			tm.setTaskState(t, api.TaskState_closed)
-		}(t, term)
+		})
+	}
+}
+
+// restart restarts a task which exited with result according to its restart policy.
+// It returns false if the task is not restarted.
+func (tm *tasksManager) restart(ctx context.Context, t *task, result taskSuccess) bool {
+	switch t.restartPolicy {
+	case TaskRestartAlways:
+	case TaskRestartOnFailure:
+		if !result.Failed() {
+			return false
+		}
+	default:
+		return false
+	}
+	if ctx.Err() != nil {
+		// supervisor is shutting down
+		return false
+	}
 
-		tm.watch(t, term)
+	taskLog := log.WithField("task", t.title).WithField("restarts", t.RestartCount)
+	if int(t.RestartCount) >= t.maxRestarts {
+		taskLog.Warn("task exceeded its restarts")
+		tm.notify(api.NotifyRequest_ERROR, fmt.Sprintf("Task %q exited and was not restarted again after %d restarts.", t.title, t.RestartCount))
+		return false
+	}
+
+	backoff := taskRestartBackoff(t.RestartCount)
+	taskLog.WithField("backoff", backoff.String()).Info("restarting task")
+	tm.notify(api.NotifyRequest_WARNING, fmt.Sprintf("Task %q exited, restarting it in %s (%d/%d).", t.title, backoff, t.RestartCount+1, t.maxRestarts))
+	tm.updateState(func() bool {
+		t.RestartCount++
+		t.State = api.TaskState_opening
+		t.Terminal = ""
+		return true
+	})
 
-		if t.command != "" {
-			term.PTY.Write([]byte(t.command + "\n"))
+	// a restart behaves like a workspace restart: init has run already
+	t.command = exitWithCommand(getCommand(t, false, false, csapi.WorkspaceInitFromBackup, tm.storeLocation))
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.finish(taskFailed(ctx.Err().Error()))
+			tm.setTaskState(t, api.TaskState_closed)
+		case <-time.After(backoff):
+			tm.startTask(ctx, t)
 		}
+	}()
+	return true
+}
+
+// taskRestartBackoff returns the exponential backoff before the restart of a task.
+func taskRestartBackoff(restarts uint32) time.Duration {
+	const (
+		initial = 1 * time.Second
+		max     = 1 * time.Minute
+	)
+	if restarts >= 6 {
+		return max
 	}
+	return initial << restarts
+}
 
-	var success taskSuccess
-	for _, task := range tm.tasks {
+// notify publishes a supervisor notification without waiting for the user.
+func (tm *tasksManager) notify(level api.NotifyRequest_Level, message string) {
+	if tm.notifications == nil {
+		return
+	}
+	_, err := tm.notifications.Notify(context.Background(), &api.NotifyRequest{
+		Level:   level,
+		Message: message,
+	})
+	if err != nil {
+		log.WithError(err).Warn("cannot publish task notification")
+	}
+}
+
+// taskConditionPollInterval is the interval in which the ports and files a task waits for are checked
+var taskConditionPollInterval = 500 * time.Millisecond
+
+// awaitConditions blocks until the commands of the tasks a task depends on finished and the ports and files
+// it waits for are available. If the task must not start anymore it is finished and false is returned.
+func (tm *tasksManager) awaitConditions(ctx context.Context, t *task) bool {
+	taskLog := log.WithField("task", t.title)
+	taskLog.Info("task is blocked")
+
+	abort := func(result taskSuccess) bool {
+		t.finish(result)
+		tm.setTaskState(t, api.TaskState_closed)
+		return false
+	}
+
+	for _, dep := range t.dependsOn {
 		select {
 		case <-ctx.Done():
-			success = taskFailed(ctx.Err().Error())
-		case taskResult := <-task.successChan:
-			if taskResult.Failed() {
-				success = success.Fail(string(taskResult))
+			return abort(taskFailed(ctx.Err().Error()))
+		case <-dep.commandDone:
+		}
+		if dep.commandResult.Failed() && tm.config.isHeadless() {
+			// there's no point in running tasks which rely on the outcome of a failed task in a prebuild
+			taskLog.WithField("upstream", dep.title).Info("skipping task because an upstream task failed")
+			return abort(taskFailed(fmt.Sprintf("upstream task %q failed", dep.title)))
+		}
+	}
+
+	for _, port := range t.config.WaitForPort {
+		addr := fmt.Sprintf("localhost:%d", port)
+		ok := pollCondition(ctx, func() bool {
+			conn, err := net.DialTimeout("tcp", addr, time.Second)
+			if err != nil {
+				return false
 			}
+			conn.Close()
+			return true
+		})
+		if !ok {
+			return abort(taskFailed(ctx.Err().Error()))
 		}
 	}
 
-	if tm.config.isPrebuild() && tm.reporter != nil {
-		tm.reporter.done(success)
+	for _, fn := range t.config.WaitForFile {
+		if !filepath.IsAbs(fn) {
+			fn = filepath.Join(tm.workdir(), fn)
+		}
+		ok := pollCondition(ctx, func() bool {
+			_, err := os.Stat(fn)
+			return err == nil
+		})
+		if !ok {
+			return abort(taskFailed(ctx.Err().Error()))
+		}
 	}
-	successChan <- success
+
+	taskLog.Info("task is unblocked")
+	tm.setTaskState(t, api.TaskState_opening)
+	return true
+}
+
+// pollCondition checks cond until it's true or the context is done.
+func pollCondition(ctx context.Context, cond func() bool) bool {
+	for {
+		if cond() {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(taskConditionPollInterval):
+		}
+	}
+}
+
+// workdir returns the directory task terminals are started in.
+func (tm *tasksManager) workdir() string {
+	if tm.terminalService.DefaultWorkdirProvider != nil {
+		if wd := tm.terminalService.DefaultWorkdirProvider(); wd != "" {
+			return wd
+		}
+	}
+	return tm.terminalService.DefaultWorkdir
 }
 
 func getCommand(task *task, isHeadless bool, isPrebuild bool, contentSource csapi.WorkspaceInitSource, storeLocation string) string {
@@ -388,6 +756,14 @@ func getCommand(task *task, isHeadless bool, isPrebuild bool, contentSource csap
 	return histfileCommand + "; " + command
 }
 
+func getExitMarker(task *task, storeLocation string) string {
+	return storeLocation + "/exit-" + task.Id
+}
+
+func getScrollbackDir(task *task, storeLocation string) string {
+	return storeLocation + "/scrollback/task-" + task.Id
+}
+
 func getHistfileCommand(task *task, commands []*string, contentSource csapi.WorkspaceInitSource, storeLocation string) string {
 	histfileCommands := commands
 	if contentSource == csapi.WorkspaceInitFromPrebuild {
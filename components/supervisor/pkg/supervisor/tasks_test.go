@@ -8,9 +8,11 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
@@ -24,6 +26,9 @@ import (
 var (
 	skipCommand = "echo \"skip\""
 	failCommand = "exit 1"
+
+	upstreamTask   = "upstream"
+	downstreamTask = "downstream"
 )
 
 var exampleEnvVarInputs = &map[string]interface{}{
@@ -120,6 +125,48 @@ func TestTaskManager(t *testing.T) {
 				Success: false,
 			},
 		},
+		{
+			Desc:     "headless prebuild should run dependent tasks after successful upstream tasks",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &downstreamTask, Init: &skipCommand, DependsOn: []string{upstreamTask}},
+				{Name: &upstreamTask, Init: &skipCommand},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: true,
+			},
+		},
+		{
+			Desc:     "headless prebuild should fail fast if an upstream task fails",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &upstreamTask, Init: &failCommand},
+				{Name: &downstreamTask, Init: &skipCommand, DependsOn: []string{upstreamTask}},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+		},
+		{
+			Desc:     "headless prebuild should fail with cyclic tasks",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &upstreamTask, Init: &skipCommand, DependsOn: []string{downstreamTask}},
+				{Name: &downstreamTask, Init: &skipCommand, DependsOn: []string{upstreamTask}},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+		},
 		{
 			Desc:        "JSON object converted to plain text object",
 			Headless:    true,
@@ -224,7 +271,7 @@ func TestTaskManager(t *testing.T) {
 						GitpodTasks:    gitpodTasks,
 						GitpodHeadless: strconv.FormatBool(test.Headless),
 					},
-				}, terminalService, contentState, &reporter, nil, nil)
+				}, terminalService, contentState, &reporter, nil, nil, nil)
 			)
 			taskManager.storeLocation = storeLocation
 			contentState.MarkContentReady(test.Source)
@@ -306,6 +353,158 @@ func TestGetTask(t *testing.T) {
 	}
 }
 
+func TestResolveTaskDependencies(t *testing.T) {
+	p := func(v string) *string { return &v }
+	tests := []struct {
+		Name          string
+		Tasks         []TaskConfig
+		Expectation   [][]int
+		ExpectedError string
+	}{
+		{
+			Name:        "no dependencies",
+			Tasks:       []TaskConfig{{}, {}},
+			Expectation: [][]int{nil, nil},
+		},
+		{
+			Name: "dependencies by name",
+			Tasks: []TaskConfig{
+				{Name: p("a")},
+				{Name: p("b"), DependsOn: []string{"a"}},
+				{DependsOn: []string{"a", "b"}},
+				{DependsOn: []string{"Gitpod Task 3"}},
+			},
+			Expectation: [][]int{nil, {0}, {0, 1}, {2}},
+		},
+		{
+			Name:          "unknown dependency",
+			Tasks:         []TaskConfig{{Name: p("a"), DependsOn: []string{"b"}}},
+			ExpectedError: `task "a" depends on unknown task "b"`,
+		},
+		{
+			Name:          "ambiguous dependency",
+			Tasks:         []TaskConfig{{Name: p("a")}, {Name: p("a")}, {Name: p("b"), DependsOn: []string{"a"}}},
+			ExpectedError: `task "b" depends on "a" which is the name of 2 tasks`,
+		},
+		{
+			Name:          "self dependency",
+			Tasks:         []TaskConfig{{Name: p("a"), DependsOn: []string{"a"}}},
+			ExpectedError: "tasks have a dependency cycle: a -> a",
+		},
+		{
+			Name: "cycle",
+			Tasks: []TaskConfig{
+				{Name: p("a"), DependsOn: []string{"c"}},
+				{Name: p("b"), DependsOn: []string{"a"}},
+				{Name: p("c"), DependsOn: []string{"b"}},
+			},
+			ExpectedError: "tasks have a dependency cycle: a -> c -> b -> a",
+		},
+		{
+			Name:          "invalid port",
+			Tasks:         []TaskConfig{{Name: p("a"), WaitForPort: []int{0}}},
+			ExpectedError: `task "a" waits for port 0 which is not between 1 and 65535`,
+		},
+		{
+			Name:          "empty file",
+			Tasks:         []TaskConfig{{Name: p("a"), WaitForFile: []string{" "}}},
+			ExpectedError: `task "a" waits for an empty file name`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			deps, err := resolveTaskDependencies(test.Tasks)
+			if test.ExpectedError != "" {
+				if err == nil || err.Error() != test.ExpectedError {
+					t.Fatalf("error is %v but expected %s", err, test.ExpectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, deps); diff != "" {
+				t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateHealthChecks(t *testing.T) {
+	var (
+		onFailure = TaskRestartOnFailure
+		unknown   = TaskRestartPolicy("sometimes")
+		negative  = -1
+	)
+	tests := []struct {
+		Name          string
+		Task          TaskConfig
+		ExpectedError string
+	}{
+		{
+			Name: "no health checks",
+		},
+		{
+			Name: "valid",
+			Task: TaskConfig{
+				RestartPolicy:  &onFailure,
+				ReadinessProbe: &TaskProbe{HTTP: &TaskHTTPProbe{Port: 3000, Path: "/health"}},
+				LivenessProbe:  &TaskProbe{Exec: &TaskExecProbe{Command: "true"}, PeriodSeconds: 5},
+			},
+		},
+		{
+			Name:          "unknown restart policy",
+			Task:          TaskConfig{RestartPolicy: &unknown},
+			ExpectedError: `unknown restart policy "sometimes"`,
+		},
+		{
+			Name:          "negative max restarts",
+			Task:          TaskConfig{RestartPolicy: &onFailure, MaxRestarts: &negative},
+			ExpectedError: "maxRestarts must be >= 0",
+		},
+		{
+			Name:          "probe without handler",
+			Task:          TaskConfig{ReadinessProbe: &TaskProbe{PeriodSeconds: 5}},
+			ExpectedError: "invalid readiness probe: exactly one of http, tcp or exec is required",
+		},
+		{
+			Name:          "probe with two handlers",
+			Task:          TaskConfig{LivenessProbe: &TaskProbe{TCP: &TaskTCPProbe{Port: 3000}, Exec: &TaskExecProbe{Command: "true"}}},
+			ExpectedError: "invalid liveness probe: exactly one of http, tcp or exec is required",
+		},
+		{
+			Name:          "probe with invalid port",
+			Task:          TaskConfig{LivenessProbe: &TaskProbe{TCP: &TaskTCPProbe{}}},
+			ExpectedError: "invalid liveness probe: tcp port must be between 1 and 65535",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := test.Task.validateHealthChecks()
+			var act string
+			if err != nil {
+				act = err.Error()
+			}
+			if diff := cmp.Diff(test.ExpectedError, act); diff != "" {
+				t.Errorf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTaskRestartBackoff(t *testing.T) {
+	var act []time.Duration
+	for i := uint32(0); i < 8; i++ {
+		act = append(act, taskRestartBackoff(i))
+	}
+	expectation := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second, 1 * time.Minute, 1 * time.Minute}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected backoff (-want +got):\n%s", diff)
+	}
+}
+
 func TestTaskSuccess(t *testing.T) {
 	type Expectation struct {
 		Failed bool
@@ -351,3 +550,39 @@ func TestTaskSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestAwaitExitMarker(t *testing.T) {
+	taskConditionPollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		Name        string
+		ExitCode    string
+		Expectation taskSuccess
+	}{
+		{Name: "command succeeded", ExitCode: "0\n", Expectation: taskSuccessful},
+		{Name: "command failed", ExitCode: "127\n", Expectation: taskFailed("exit status 127")},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			task := &task{
+				done:        make(chan struct{}),
+				commandDone: make(chan struct{}),
+				exitMarker:  filepath.Join(t.TempDir(), "exit-0"),
+			}
+			go (&tasksManager{}).awaitExitMarker(context.Background(), task)
+
+			err := os.WriteFile(task.exitMarker, []byte(test.ExitCode), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-task.commandDone:
+			case <-time.After(5 * time.Second):
+				t.Fatal("command was not reported as finished")
+			}
+			if diff := cmp.Diff(test.Expectation, task.commandResult); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -127,7 +127,7 @@ func Top(ctx context.Context) (*api.ResourcesStatusResponse, error) {
 		cpuPercentage := int64((float64(resp.Resources.Cpu.Used) / float64(resp.Resources.Cpu.Limit)) * 100)
 		memoryPercentage := int64((float64(resp.Resources.Memory.Used) / float64(resp.Resources.Memory.Limit)) * 100)
 
-		return &api.ResourcesStatusResponse{
+		res := &api.ResourcesStatusResponse{
 			Memory: &api.ResourceStatus{
 				Limit:    resp.Resources.Memory.Limit,
 				Used:     resp.Resources.Memory.Used,
@@ -138,7 +138,16 @@ func Top(ctx context.Context) (*api.ResourcesStatusResponse, error) {
 				Used:     resp.Resources.Cpu.Used,
 				Severity: calcSeverity(cpuPercentage),
 			},
-		}, nil
+		}
+		if storage := resp.Resources.Storage; storage != nil && storage.Limit > 0 {
+			storagePercentage := int64((float64(storage.Used) / float64(storage.Limit)) * 100)
+			res.Storage = &api.ResourceStatus{
+				Limit:    storage.Limit,
+				Used:     storage.Used,
+				Severity: calcSeverity(storagePercentage),
+			}
+		}
+		return res, nil
 	}
 }
 
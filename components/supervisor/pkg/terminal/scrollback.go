@@ -0,0 +1,400 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// maxScrollbackSegmentSize is the number of uncompressed bytes after which we start a new segment file.
+	maxScrollbackSegmentSize = 1 << 20
+	// minScrollbackSegmentSize keeps small limits from producing a flood of tiny segment files.
+	minScrollbackSegmentSize = 4 << 10
+	// scrollbackFlushInterval is how often buffered output is flushed to disk.
+	scrollbackFlushInterval = 1 * time.Second
+
+	scrollbackSegmentExt = ".gz"
+	// a record is [8 byte unix nano timestamp][4 byte length][data]
+	scrollbackRecordHeaderSize = 12
+)
+
+// ScrollbackOptions configures the disk-backed scrollback of a terminal.
+type ScrollbackOptions struct {
+	// Dir is the directory the scrollback segments are stored in.
+	// Terminals sharing a directory share (and restore) their scrollback.
+	Dir string
+
+	// MaxBytes caps the compressed size of the scrollback on disk.
+	// Once exceeded the oldest output is discarded.
+	MaxBytes int64
+}
+
+// Scrollback records terminal output to gzip compressed segment files on disk.
+// Each write is stored with a timestamp, so that the output can be searched by time.
+type Scrollback struct {
+	dir         string
+	maxBytes    int64
+	segmentSize int64
+
+	mu         sync.Mutex
+	segment    string
+	file       *os.File
+	gz         *gzip.Writer
+	written    int64
+	dirty      bool
+	closed     bool
+	failed     bool
+	lastSegNum int64
+
+	stop chan struct{}
+}
+
+// OpenScrollback opens the scrollback stored in opts.Dir. Output written to it
+// is appended to a new segment, existing segments remain readable.
+func OpenScrollback(opts ScrollbackOptions) (*Scrollback, error) {
+	if opts.Dir == "" {
+		return nil, xerrors.Errorf("scrollback directory is required")
+	}
+	if opts.MaxBytes <= 0 {
+		return nil, xerrors.Errorf("scrollback size limit must be positive")
+	}
+	err := os.MkdirAll(opts.Dir, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create scrollback directory: %w", err)
+	}
+
+	segmentSize := opts.MaxBytes / 4
+	if segmentSize > maxScrollbackSegmentSize {
+		segmentSize = maxScrollbackSegmentSize
+	}
+	if segmentSize < minScrollbackSegmentSize {
+		segmentSize = minScrollbackSegmentSize
+	}
+	sb := &Scrollback{
+		dir:         opts.Dir,
+		maxBytes:    opts.MaxBytes,
+		segmentSize: segmentSize,
+		stop:        make(chan struct{}),
+	}
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	err = sb.rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	go sb.flushPeriodically()
+	return sb, nil
+}
+
+// Write records p with the current time.
+// Errors are logged once and further output is dropped, so that a full disk never breaks the terminal.
+func (sb *Scrollback) Write(p []byte) (n int, err error) {
+	return sb.writeAt(time.Now(), p)
+}
+
+func (sb *Scrollback) writeAt(ts time.Time, p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if sb.closed || sb.failed {
+		return len(p), nil
+	}
+
+	var hdr [scrollbackRecordHeaderSize]byte
+	binary.BigEndian.PutUint64(hdr[0:8], uint64(ts.UnixNano()))
+	binary.BigEndian.PutUint32(hdr[8:12], uint32(len(p)))
+	_, err = sb.gz.Write(hdr[:])
+	if err == nil {
+		_, err = sb.gz.Write(p)
+	}
+	if err == nil {
+		sb.dirty = true
+		sb.written += int64(len(hdr) + len(p))
+		if sb.written >= sb.segmentSize {
+			err = sb.rotate()
+		}
+	}
+	if err != nil {
+		sb.failed = true
+		log.WithError(err).WithField("dir", sb.dir).Warn("cannot write terminal scrollback - dropping further output")
+	}
+	return len(p), nil
+}
+
+// Read calls fn for every record in the scrollback, oldest first.
+// Read works on closed scrollbacks, too.
+func (sb *Scrollback) Read(fn func(ts time.Time, data []byte) error) error {
+	sb.mu.Lock()
+	if !sb.closed && !sb.failed && sb.dirty {
+		err := sb.flush()
+		if err != nil {
+			sb.mu.Unlock()
+			return err
+		}
+	}
+	sb.mu.Unlock()
+
+	segments, err := sb.segments()
+	if err != nil {
+		return err
+	}
+	for _, seg := range segments {
+		err = readScrollbackSegment(filepath.Join(sb.dir, seg.Name()), fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes the scrollback to disk. Further writes are discarded.
+func (sb *Scrollback) Close() error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if sb.closed {
+		return nil
+	}
+	sb.closed = true
+	close(sb.stop)
+
+	return sb.closeSegment()
+}
+
+func (sb *Scrollback) flushPeriodically() {
+	t := time.NewTicker(scrollbackFlushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-sb.stop:
+			return
+		case <-t.C:
+		}
+
+		sb.mu.Lock()
+		if !sb.closed && !sb.failed && sb.dirty {
+			err := sb.flush()
+			if err != nil {
+				sb.failed = true
+				log.WithError(err).WithField("dir", sb.dir).Warn("cannot flush terminal scrollback - dropping further output")
+			}
+		}
+		sb.mu.Unlock()
+	}
+}
+
+// flush writes all buffered output to disk such that it can be read.
+// Callers are expected to hold mu.
+func (sb *Scrollback) flush() error {
+	err := sb.gz.Flush()
+	if err != nil {
+		return err
+	}
+	sb.dirty = false
+	return nil
+}
+
+// closeSegment completes the current segment file.
+// Callers are expected to hold mu.
+func (sb *Scrollback) closeSegment() error {
+	if sb.file == nil {
+		return nil
+	}
+	gzErr := sb.gz.Close()
+	fileErr := sb.file.Close()
+	sb.file, sb.gz = nil, nil
+	if gzErr != nil {
+		return gzErr
+	}
+	return fileErr
+}
+
+// rotate starts a new segment and enforces the size limit by removing the oldest segments.
+// Callers are expected to hold mu.
+func (sb *Scrollback) rotate() error {
+	err := sb.closeSegment()
+	if err != nil {
+		return err
+	}
+
+	// Segments are named by the time they were started so that terminals which share a directory
+	// (e.g. a restarted task) never write to the same segment.
+	num := time.Now().UnixNano()
+	if num <= sb.lastSegNum {
+		num = sb.lastSegNum + 1
+	}
+	sb.lastSegNum = num
+	name := fmt.Sprintf("%020d%s", num, scrollbackSegmentExt)
+	f, err := os.OpenFile(filepath.Join(sb.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return xerrors.Errorf("cannot create scrollback segment: %w", err)
+	}
+	sb.segment = name
+	sb.file = f
+	sb.gz = gzip.NewWriter(f)
+	sb.written = 0
+	sb.dirty = false
+
+	return sb.enforceLimit()
+}
+
+// enforceLimit removes the oldest segments until the scrollback fits in maxBytes.
+// The current segment is never removed.
+// Callers are expected to hold mu.
+func (sb *Scrollback) enforceLimit() error {
+	segments, err := sb.segments()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, seg := range segments {
+		total += seg.Size()
+	}
+	for _, seg := range segments {
+		if total <= sb.maxBytes || seg.Name() == sb.segment {
+			break
+		}
+		err := os.Remove(filepath.Join(sb.dir, seg.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return xerrors.Errorf("cannot remove scrollback segment: %w", err)
+		}
+		total -= seg.Size()
+	}
+	return nil
+}
+
+// segments lists the segment files in dir, oldest first.
+func (sb *Scrollback) segments() ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(sb.dir)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list scrollback segments: %w", err)
+	}
+	res := make([]os.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), scrollbackSegmentExt) {
+			continue
+		}
+		info, err := e.Info()
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, info)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name() < res[j].Name() })
+	return res, nil
+}
+
+// readScrollbackSegment calls fn for every complete record in a segment file.
+// Segments which are still written to or which were not closed properly (e.g. because supervisor
+// crashed) end without a gzip footer. We read such segments up to the last complete record.
+func readScrollbackSegment(fn string, cb func(ts time.Time, data []byte) error) error {
+	f, err := os.Open(fn)
+	if os.IsNotExist(err) {
+		// the segment was removed in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return endOfScrollbackSegment(fn, err)
+	}
+	defer gz.Close()
+
+	var hdr [scrollbackRecordHeaderSize]byte
+	for {
+		_, err = io.ReadFull(gz, hdr[:])
+		if err != nil {
+			return endOfScrollbackSegment(fn, err)
+		}
+		ts := int64(binary.BigEndian.Uint64(hdr[0:8]))
+		data := make([]byte, binary.BigEndian.Uint32(hdr[8:12]))
+		_, err = io.ReadFull(gz, data)
+		if err != nil {
+			return endOfScrollbackSegment(fn, err)
+		}
+
+		err = cb(time.Unix(0, ts), data)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// endOfScrollbackSegment decides how to continue after reading a segment failed. A damaged segment
+// must not make the remaining scrollback unreadable, hence we skip its rest.
+func endOfScrollbackSegment(fn string, err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	log.WithError(err).WithField("segment", fn).Warn("cannot read terminal scrollback segment - skipping its rest")
+	return nil
+}
+
+// ReadLines calls fn for every line in the scrollback, oldest first. A line is stamped with the
+// time its first byte was written. Line endings are stripped.
+func (sb *Scrollback) ReadLines(fn func(ts time.Time, line []byte) error) error {
+	var (
+		line   []byte
+		lineTS time.Time
+	)
+	emit := func() error {
+		l := bytes.TrimSuffix(line, []byte{'\r'})
+		line = nil
+		return fn(lineTS, l)
+	}
+	err := sb.Read(func(ts time.Time, data []byte) error {
+		for len(data) > 0 {
+			if len(line) == 0 {
+				lineTS = ts
+			}
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				line = append(line, data...)
+				return nil
+			}
+			line = append(line, data[:i]...)
+			data = data[i+1:]
+			err := emit()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(line) > 0 {
+		return emit()
+	}
+	return nil
+}
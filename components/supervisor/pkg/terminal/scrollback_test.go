@@ -0,0 +1,245 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestScrollbackRestore(t *testing.T) {
+	dir := t.TempDir()
+	t0 := time.Unix(1000, 0)
+
+	sb, err := OpenScrollback(ScrollbackOptions{Dir: dir, MaxBytes: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = sb.writeAt(t0, []byte("hello "))
+	_, _ = sb.writeAt(t0.Add(time.Second), []byte("world\r\n"))
+	// reading must work without closing the scrollback
+	if diff := cmp.Diff("hello world\r\n", readScrollback(t, sb)); diff != "" {
+		t.Errorf("unexpected output before close (-want +got):\n%s", diff)
+	}
+	err = sb.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = sb.writeAt(t0.Add(2*time.Second), []byte("dropped"))
+
+	// a new scrollback in the same directory, e.g. after a supervisor restart, continues where the previous one stopped
+	sb, err = OpenScrollback(ScrollbackOptions{Dir: dir, MaxBytes: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb.Close()
+	_, _ = sb.writeAt(t0.Add(3*time.Second), []byte("again"))
+	if diff := cmp.Diff("hello world\r\nagain", readScrollback(t, sb)); diff != "" {
+		t.Errorf("unexpected output after restore (-want +got):\n%s", diff)
+	}
+}
+
+func TestScrollbackLimit(t *testing.T) {
+	dir := t.TempDir()
+	const limit = 64 << 10
+	sb, err := OpenScrollback(ScrollbackOptions{Dir: dir, MaxBytes: limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb.Close()
+
+	// pseudo-random output which does not compress well
+	rnd := rand.New(rand.NewSource(42))
+	for i := 0; i < 1000; i++ {
+		chunk := make([]byte, 1024)
+		_, _ = rnd.Read(chunk)
+		_, _ = sb.writeAt(time.Unix(int64(i), 0), chunk)
+	}
+
+	var total int64
+	segments, err := sb.segments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, seg := range segments {
+		total += seg.Size()
+	}
+	// the current segment may grow beyond the limit until it's rotated
+	if total > limit+sb.segmentSize {
+		t.Errorf("scrollback is %d bytes, expected at most %d", total, limit+sb.segmentSize)
+	}
+
+	var first, last time.Time
+	err = sb.Read(func(ts time.Time, data []byte) error {
+		if first.IsZero() {
+			first = ts
+		}
+		last = ts
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.After(time.Unix(0, 0)) {
+		t.Errorf("expected the oldest output to be dropped, but it starts at %v", first.Unix())
+	}
+	if last != time.Unix(999, 0) {
+		t.Errorf("expected the latest output to be kept, but it ends at %v", last.Unix())
+	}
+}
+
+func TestScrollbackTruncatedSegment(t *testing.T) {
+	dir := t.TempDir()
+	sb, err := OpenScrollback(ScrollbackOptions{Dir: dir, MaxBytes: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = sb.writeAt(time.Unix(1, 0), []byte("complete"))
+	_ = readScrollback(t, sb)
+	fn := filepath.Join(dir, sb.segment)
+	stat, err := os.Stat(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// simulate a crash: the segment is never closed and the next record is written only partially
+	_, _ = sb.writeAt(time.Unix(2, 0), []byte("incomplete"))
+	_ = readScrollback(t, sb)
+	err = os.Truncate(fn, stat.Size()+2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff("complete", readScrollback(t, sb)); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	records := []struct {
+		Offset time.Duration
+		Data   string
+	}{
+		{0, "npm run build\r\n"},
+		{1 * time.Second, "building\r\n\x1b[31mer"},
+		{2 * time.Second, "ror\x1b[0m: oops\r\n"},
+		{3 * time.Second, "warning: slow\r\n"},
+		{4 * time.Second, "done"},
+	}
+
+	tests := []struct {
+		Desc        string
+		Req         *api.TerminalHistoryRequest
+		Expectation []string
+		NextPage    string
+		Code        codes.Code
+	}{
+		{
+			Desc:        "all",
+			Req:         &api.TerminalHistoryRequest{},
+			Expectation: []string{"1000 npm run build", "1001 building", "1001 \x1b[31merror\x1b[0m: oops", "1003 warning: slow", "1004 done"},
+		},
+		{
+			Desc:        "time range",
+			Req:         &api.TerminalHistoryRequest{Since: timestamppb.New(t0.Add(time.Second)), Until: timestamppb.New(t0.Add(4 * time.Second))},
+			Expectation: []string{"1001 building", "1001 \x1b[31merror\x1b[0m: oops", "1003 warning: slow"},
+		},
+		{
+			Desc:        "grep ignores escape sequences",
+			Req:         &api.TerminalHistoryRequest{Grep: "^(error|warning):"},
+			Expectation: []string{"1001 \x1b[31merror\x1b[0m: oops", "1003 warning: slow"},
+		},
+		{
+			Desc:        "first page",
+			Req:         &api.TerminalHistoryRequest{PageSize: 2},
+			Expectation: []string{"1000 npm run build", "1001 building"},
+			NextPage:    "2",
+		},
+		{
+			Desc:        "last page",
+			Req:         &api.TerminalHistoryRequest{PageSize: 2, PageToken: "4"},
+			Expectation: []string{"1004 done"},
+		},
+		{
+			Desc: "invalid grep",
+			Req:  &api.TerminalHistoryRequest{Grep: "("},
+			Code: codes.InvalidArgument,
+		},
+		{
+			Desc: "invalid page token",
+			Req:  &api.TerminalHistoryRequest{PageToken: "next"},
+			Code: codes.InvalidArgument,
+		},
+		{
+			Desc: "unknown terminal",
+			Req:  &api.TerminalHistoryRequest{Alias: "unknown"},
+			Code: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			sb, err := OpenScrollback(ScrollbackOptions{Dir: t.TempDir(), MaxBytes: 1 << 20})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sb.Close()
+			for _, r := range records {
+				_, _ = sb.writeAt(t0.Add(r.Offset), []byte(r.Data))
+			}
+
+			mux := NewMux()
+			mux.scrollbacks["term"] = sb
+			srv := NewMuxTerminalService(mux)
+
+			if test.Req.Alias == "" {
+				test.Req.Alias = "term"
+			}
+			resp, err := srv.History(context.Background(), test.Req)
+			if diff := cmp.Diff(test.Code, status.Code(err)); diff != "" {
+				t.Fatalf("unexpected status code (-want +got):\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var lines []string
+			for _, l := range resp.Lines {
+				lines = append(lines, fmt.Sprintf("%d %s", l.Time.AsTime().Unix(), l.Data))
+			}
+			if diff := cmp.Diff(test.Expectation, lines); diff != "" {
+				t.Errorf("unexpected lines (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.NextPage, resp.NextPageToken); diff != "" {
+				t.Errorf("unexpected next page token (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func readScrollback(t *testing.T, sb *Scrollback) string {
+	var res bytes.Buffer
+	err := sb.Read(func(ts time.Time, data []byte) error {
+		res.Write(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.String()
+}
@@ -6,11 +6,14 @@ package terminal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/supervisor/api"
@@ -356,3 +360,99 @@ func (srv *MuxTerminalService) UpdateAnnotations(ctx context.Context, req *api.U
 	term.UpdateAnnotations(req.Changed, req.Deleted)
 	return &api.UpdateTerminalAnnotationsResponse{}, nil
 }
+
+const (
+	defaultHistoryPageSize = 1000
+	maxHistoryPageSize     = 10000
+)
+
+// ansiEscapeSequence matches CSI, OSC and other escape sequences which we ignore when searching the history.
+var ansiEscapeSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+var errHistoryPageFull = errors.New("history page is full")
+
+// History returns the recorded output of a terminal with disk-backed scrollback.
+// Page tokens are line offsets within the matching lines. Output dropped due to the size limit
+// in between two calls makes pages shift.
+func (srv *MuxTerminalService) History(ctx context.Context, req *api.TerminalHistoryRequest) (*api.TerminalHistoryResponse, error) {
+	sb, ok := srv.Mux.Scrollback(req.Alias)
+	if !ok {
+		if _, running := srv.Mux.Get(req.Alias); running {
+			return nil, status.Error(codes.FailedPrecondition, "terminal has no scrollback")
+		}
+		return nil, status.Error(codes.NotFound, "terminal not found")
+	}
+
+	var grep *regexp.Regexp
+	if req.Grep != "" {
+		var err error
+		grep, err = regexp.Compile(req.Grep)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid grep expression: %v", err)
+		}
+	}
+	var offset int
+	if req.PageToken != "" {
+		var err error
+		offset, err = strconv.Atoi(req.PageToken)
+		if err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+	var since, until time.Time
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		until = req.Until.AsTime()
+	}
+
+	var (
+		resp    = &api.TerminalHistoryResponse{}
+		matches int
+	)
+	err := sb.ReadLines(func(ts time.Time, line []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !since.IsZero() && ts.Before(since) {
+			return nil
+		}
+		if !until.IsZero() && !ts.Before(until) {
+			return nil
+		}
+		if grep != nil && !grep.Match(ansiEscapeSequence.ReplaceAll(line, nil)) {
+			return nil
+		}
+		matches++
+		if matches <= offset {
+			return nil
+		}
+		if len(resp.Lines) == pageSize {
+			resp.NextPageToken = strconv.Itoa(offset + pageSize)
+			return errHistoryPageFull
+		}
+		resp.Lines = append(resp.Lines, &api.TerminalHistoryLine{
+			Time: timestamppb.New(ts),
+			Data: line,
+		})
+		return nil
+	})
+	if errors.Is(err, errHistoryPageFull) {
+		err = nil
+	}
+	if ctx.Err() != nil {
+		return nil, status.Error(codes.Canceled, ctx.Err().Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
@@ -40,7 +40,8 @@ const (
 // NewMux creates a new terminal mux.
 func NewMux() *Mux {
 	return &Mux{
-		terms: make(map[string]*Term),
+		terms:       make(map[string]*Term),
+		scrollbacks: make(map[string]*Scrollback),
 	}
 }
 
@@ -48,7 +49,9 @@ func NewMux() *Mux {
 type Mux struct {
 	aliases []string
 	terms   map[string]*Term
-	mu      sync.RWMutex
+	// scrollbacks outlive their terminals so that the output of closed terminals remains accessible
+	scrollbacks map[string]*Scrollback
+	mu          sync.RWMutex
 }
 
 // Get returns a terminal for the given alias.
@@ -59,6 +62,14 @@ func (m *Mux) Get(alias string) (*Term, bool) {
 	return term, ok
 }
 
+// Scrollback returns the disk-backed scrollback of a terminal, even if the terminal was closed already.
+func (m *Mux) Scrollback(alias string) (*Scrollback, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sb, ok := m.scrollbacks[alias]
+	return sb, ok
+}
+
 // Start starts a new command in its own pseudo-terminal and returns an alias
 // for that pseudo terminal.
 func (m *Mux) Start(cmd *exec.Cmd, options TermOptions) (alias string, err error) {
@@ -77,6 +88,9 @@ func (m *Mux) Start(cmd *exec.Cmd, options TermOptions) (alias string, err error
 	}
 	m.aliases = append(m.aliases, alias)
 	m.terms[alias] = term
+	if term.Stdout.scrollback != nil {
+		m.scrollbacks[alias] = term.Stdout.scrollback
+	}
 
 	log.WithField("alias", alias).WithField("cmd", cmd.Path).Info("started new terminal")
 
@@ -255,16 +269,36 @@ func newTerm(alias string, cmd *exec.Cmd, options TermOptions) (*Term, error) {
 		return nil, err
 	}
 
+	var scrollback *Scrollback
+	if options.Scrollback != nil {
+		// a broken scrollback must not keep the terminal from working, hence we continue without
+		scrollback, err = OpenScrollback(*options.Scrollback)
+		if err != nil {
+			log.WithError(err).WithField("dir", options.Scrollback.Dir).Warn("cannot open terminal scrollback")
+			scrollback = nil
+		} else {
+			// restore the output of previous terminals, e.g. from before a supervisor restart
+			err = scrollback.Read(func(ts time.Time, data []byte) error {
+				_, err := recorder.Write(data)
+				return err
+			})
+			if err != nil {
+				log.WithError(err).WithField("dir", options.Scrollback.Dir).Warn("cannot restore terminal scrollback")
+			}
+		}
+	}
+
 	res := &Term{
 		PTY:     pty,
 		pts:     pts,
 		Command: cmd,
 		Stdout: &multiWriter{
-			timeout:   timeout,
-			listener:  make(map[*multiWriterListener]struct{}),
-			recorder:  recorder,
-			logStdout: options.LogToStdout,
-			logLabel:  alias,
+			timeout:    timeout,
+			listener:   make(map[*multiWriterListener]struct{}),
+			recorder:   recorder,
+			scrollback: scrollback,
+			logStdout:  options.LogToStdout,
+			logLabel:   alias,
 		},
 		annotations:  annotations,
 		defaultTitle: options.Title,
@@ -298,6 +332,9 @@ type TermOptions struct {
 
 	// LogToStdout forwards the terminal's stdout to supervisor's stdout
 	LogToStdout bool
+
+	// Scrollback enables disk-backed scrollback for the terminal. If nil, output is kept in memory only.
+	Scrollback *ScrollbackOptions
 }
 
 // Term is a pseudo-terminal.
@@ -451,6 +488,8 @@ type multiWriter struct {
 	// ring buffer to record last 256kb of pty output
 	// new listener is initialized with the latest recodring first
 	recorder *RingBuffer
+	// scrollback optionally persists all pty output on disk
+	scrollback *Scrollback
 
 	logStdout bool
 	logLabel  string
@@ -584,6 +623,9 @@ func (mw *multiWriter) Write(p []byte) (n int, err error) {
 	defer mw.mu.Unlock()
 
 	mw.recorder.Write(p)
+	if mw.scrollback != nil {
+		_, _ = mw.scrollback.Write(p)
+	}
 	if mw.logStdout {
 		log.WithFields(logrus.Fields{
 			"terminalOutput": true,
@@ -624,6 +666,12 @@ func (mw *multiWriter) Close() error {
 			err = cerr
 		}
 	}
+	if mw.scrollback != nil {
+		cerr := mw.scrollback.Close()
+		if cerr != nil {
+			err = cerr
+		}
+	}
 	return err
 }
 
@@ -40,6 +40,12 @@ type UsageServiceClient interface {
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
 	// AddUsageCreditNote adds a usage credit note to the given cost center with the effective date of now
 	AddUsageCreditNote(ctx context.Context, in *AddUsageCreditNoteRequest, opts ...grpc.CallOption) (*AddUsageCreditNoteResponse, error)
+	// GetUsageAggregates sums up usage in the given time range, grouped by dimensions and time buckets
+	GetUsageAggregates(ctx context.Context, in *GetUsageAggregatesRequest, opts ...grpc.CallOption) (*GetUsageAggregatesResponse, error)
+	// ExportUsage streams usage aggregates as a CSV or Parquet file
+	ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (UsageService_ExportUsageClient, error)
+	// ReportStorageUsage charges the storage of retained workspace backups measured at the end of the given period
+	ReportStorageUsage(ctx context.Context, in *ReportStorageUsageRequest, opts ...grpc.CallOption) (*ReportStorageUsageResponse, error)
 }
 
 type usageServiceClient struct {
@@ -113,6 +119,56 @@ func (c *usageServiceClient) AddUsageCreditNote(ctx context.Context, in *AddUsag
 	return out, nil
 }
 
+func (c *usageServiceClient) GetUsageAggregates(ctx context.Context, in *GetUsageAggregatesRequest, opts ...grpc.CallOption) (*GetUsageAggregatesResponse, error) {
+	out := new(GetUsageAggregatesResponse)
+	err := c.cc.Invoke(ctx, "/usage.v1.UsageService/GetUsageAggregates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usageServiceClient) ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (UsageService_ExportUsageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UsageService_ServiceDesc.Streams[0], "/usage.v1.UsageService/ExportUsage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &usageServiceExportUsageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UsageService_ExportUsageClient interface {
+	Recv() (*ExportUsageResponse, error)
+	grpc.ClientStream
+}
+
+type usageServiceExportUsageClient struct {
+	grpc.ClientStream
+}
+
+func (x *usageServiceExportUsageClient) Recv() (*ExportUsageResponse, error) {
+	m := new(ExportUsageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *usageServiceClient) ReportStorageUsage(ctx context.Context, in *ReportStorageUsageRequest, opts ...grpc.CallOption) (*ReportStorageUsageResponse, error) {
+	out := new(ReportStorageUsageResponse)
+	err := c.cc.Invoke(ctx, "/usage.v1.UsageService/ReportStorageUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UsageServiceServer is the server API for UsageService service.
 // All implementations must embed UnimplementedUsageServiceServer
 // for forward compatibility
@@ -131,6 +187,12 @@ type UsageServiceServer interface {
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
 	// AddUsageCreditNote adds a usage credit note to the given cost center with the effective date of now
 	AddUsageCreditNote(context.Context, *AddUsageCreditNoteRequest) (*AddUsageCreditNoteResponse, error)
+	// GetUsageAggregates sums up usage in the given time range, grouped by dimensions and time buckets
+	GetUsageAggregates(context.Context, *GetUsageAggregatesRequest) (*GetUsageAggregatesResponse, error)
+	// ExportUsage streams usage aggregates as a CSV or Parquet file
+	ExportUsage(*ExportUsageRequest, UsageService_ExportUsageServer) error
+	// ReportStorageUsage charges the storage of retained workspace backups measured at the end of the given period
+	ReportStorageUsage(context.Context, *ReportStorageUsageRequest) (*ReportStorageUsageResponse, error)
 	mustEmbedUnimplementedUsageServiceServer()
 }
 
@@ -159,6 +221,15 @@ func (UnimplementedUsageServiceServer) GetBalance(context.Context, *GetBalanceRe
 func (UnimplementedUsageServiceServer) AddUsageCreditNote(context.Context, *AddUsageCreditNoteRequest) (*AddUsageCreditNoteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddUsageCreditNote not implemented")
 }
+func (UnimplementedUsageServiceServer) GetUsageAggregates(context.Context, *GetUsageAggregatesRequest) (*GetUsageAggregatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageAggregates not implemented")
+}
+func (UnimplementedUsageServiceServer) ExportUsage(*ExportUsageRequest, UsageService_ExportUsageServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsage not implemented")
+}
+func (UnimplementedUsageServiceServer) ReportStorageUsage(context.Context, *ReportStorageUsageRequest) (*ReportStorageUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportStorageUsage not implemented")
+}
 func (UnimplementedUsageServiceServer) mustEmbedUnimplementedUsageServiceServer() {}
 
 // UnsafeUsageServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -298,6 +369,63 @@ func _UsageService_AddUsageCreditNote_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsageService_GetUsageAggregates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageAggregatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsageServiceServer).GetUsageAggregates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usage.v1.UsageService/GetUsageAggregates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsageServiceServer).GetUsageAggregates(ctx, req.(*GetUsageAggregatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsageService_ExportUsage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UsageServiceServer).ExportUsage(m, &usageServiceExportUsageServer{stream})
+}
+
+type UsageService_ExportUsageServer interface {
+	Send(*ExportUsageResponse) error
+	grpc.ServerStream
+}
+
+type usageServiceExportUsageServer struct {
+	grpc.ServerStream
+}
+
+func (x *usageServiceExportUsageServer) Send(m *ExportUsageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UsageService_ReportStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsageServiceServer).ReportStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usage.v1.UsageService/ReportStorageUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsageServiceServer).ReportStorageUsage(ctx, req.(*ReportStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UsageService_ServiceDesc is the grpc.ServiceDesc for UsageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -333,7 +461,21 @@ var UsageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddUsageCreditNote",
 			Handler:    _UsageService_AddUsageCreditNote_Handler,
 		},
+		{
+			MethodName: "GetUsageAggregates",
+			Handler:    _UsageService_GetUsageAggregates_Handler,
+		},
+		{
+			MethodName: "ReportStorageUsage",
+			Handler:    _UsageService_ReportStorageUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUsage",
+			Handler:       _UsageService_ExportUsage_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "usage/v1/usage.proto",
 }
@@ -12,6 +12,140 @@ import { Timestamp } from "../../google/protobuf/timestamp.pb";
 
 export const protobufPackage = "usage.v1";
 
+export enum UsageDimension {
+  USAGE_DIMENSION_UNSPECIFIED = "USAGE_DIMENSION_UNSPECIFIED",
+  /** the team or user usage is attributed to */
+  USAGE_DIMENSION_ATTRIBUTION = "USAGE_DIMENSION_ATTRIBUTION",
+  USAGE_DIMENSION_PROJECT = "USAGE_DIMENSION_PROJECT",
+  USAGE_DIMENSION_WORKSPACE_CLASS = "USAGE_DIMENSION_WORKSPACE_CLASS",
+  /** the user who ran the workspace */
+  USAGE_DIMENSION_USER = "USAGE_DIMENSION_USER",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function usageDimensionFromJSON(object: any): UsageDimension {
+  switch (object) {
+    case 0:
+    case "USAGE_DIMENSION_UNSPECIFIED":
+      return UsageDimension.USAGE_DIMENSION_UNSPECIFIED;
+    case 1:
+    case "USAGE_DIMENSION_ATTRIBUTION":
+      return UsageDimension.USAGE_DIMENSION_ATTRIBUTION;
+    case 2:
+    case "USAGE_DIMENSION_PROJECT":
+      return UsageDimension.USAGE_DIMENSION_PROJECT;
+    case 3:
+    case "USAGE_DIMENSION_WORKSPACE_CLASS":
+      return UsageDimension.USAGE_DIMENSION_WORKSPACE_CLASS;
+    case 4:
+    case "USAGE_DIMENSION_USER":
+      return UsageDimension.USAGE_DIMENSION_USER;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return UsageDimension.UNRECOGNIZED;
+  }
+}
+
+export function usageDimensionToJSON(object: UsageDimension): string {
+  switch (object) {
+    case UsageDimension.USAGE_DIMENSION_UNSPECIFIED:
+      return "USAGE_DIMENSION_UNSPECIFIED";
+    case UsageDimension.USAGE_DIMENSION_ATTRIBUTION:
+      return "USAGE_DIMENSION_ATTRIBUTION";
+    case UsageDimension.USAGE_DIMENSION_PROJECT:
+      return "USAGE_DIMENSION_PROJECT";
+    case UsageDimension.USAGE_DIMENSION_WORKSPACE_CLASS:
+      return "USAGE_DIMENSION_WORKSPACE_CLASS";
+    case UsageDimension.USAGE_DIMENSION_USER:
+      return "USAGE_DIMENSION_USER";
+    case UsageDimension.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function usageDimensionToNumber(object: UsageDimension): number {
+  switch (object) {
+    case UsageDimension.USAGE_DIMENSION_UNSPECIFIED:
+      return 0;
+    case UsageDimension.USAGE_DIMENSION_ATTRIBUTION:
+      return 1;
+    case UsageDimension.USAGE_DIMENSION_PROJECT:
+      return 2;
+    case UsageDimension.USAGE_DIMENSION_WORKSPACE_CLASS:
+      return 3;
+    case UsageDimension.USAGE_DIMENSION_USER:
+      return 4;
+    case UsageDimension.UNRECOGNIZED:
+    default:
+      return -1;
+  }
+}
+
+export enum TimeBucket {
+  /** aggregates span the entire requested time range */
+  TIME_BUCKET_UNSPECIFIED = "TIME_BUCKET_UNSPECIFIED",
+  TIME_BUCKET_DAY = "TIME_BUCKET_DAY",
+  /** weeks start on Monday */
+  TIME_BUCKET_WEEK = "TIME_BUCKET_WEEK",
+  TIME_BUCKET_MONTH = "TIME_BUCKET_MONTH",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function timeBucketFromJSON(object: any): TimeBucket {
+  switch (object) {
+    case 0:
+    case "TIME_BUCKET_UNSPECIFIED":
+      return TimeBucket.TIME_BUCKET_UNSPECIFIED;
+    case 1:
+    case "TIME_BUCKET_DAY":
+      return TimeBucket.TIME_BUCKET_DAY;
+    case 2:
+    case "TIME_BUCKET_WEEK":
+      return TimeBucket.TIME_BUCKET_WEEK;
+    case 3:
+    case "TIME_BUCKET_MONTH":
+      return TimeBucket.TIME_BUCKET_MONTH;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return TimeBucket.UNRECOGNIZED;
+  }
+}
+
+export function timeBucketToJSON(object: TimeBucket): string {
+  switch (object) {
+    case TimeBucket.TIME_BUCKET_UNSPECIFIED:
+      return "TIME_BUCKET_UNSPECIFIED";
+    case TimeBucket.TIME_BUCKET_DAY:
+      return "TIME_BUCKET_DAY";
+    case TimeBucket.TIME_BUCKET_WEEK:
+      return "TIME_BUCKET_WEEK";
+    case TimeBucket.TIME_BUCKET_MONTH:
+      return "TIME_BUCKET_MONTH";
+    case TimeBucket.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function timeBucketToNumber(object: TimeBucket): number {
+  switch (object) {
+    case TimeBucket.TIME_BUCKET_UNSPECIFIED:
+      return 0;
+    case TimeBucket.TIME_BUCKET_DAY:
+      return 1;
+    case TimeBucket.TIME_BUCKET_WEEK:
+      return 2;
+    case TimeBucket.TIME_BUCKET_MONTH:
+      return 3;
+    case TimeBucket.UNRECOGNIZED:
+    default:
+      return -1;
+  }
+}
+
 export interface ReconcileUsageRequest {
   /** from specifies the starting time range for this request. */
   from:
@@ -24,6 +158,25 @@ export interface ReconcileUsageRequest {
 export interface ReconcileUsageResponse {
 }
 
+export interface ReportStorageUsageRequest {
+  /** from specifies the start of the period the storage is charged for. */
+  from:
+    | Date
+    | undefined;
+  /** to specifies the end of the period, at which the storage was measured. */
+  to: Date | undefined;
+  workspaces: WorkspaceStorageUsage[];
+}
+
+export interface WorkspaceStorageUsage {
+  workspaceId: string;
+  /** bytes is the size of all retained backups and snapshots of the workspace */
+  bytes: number;
+}
+
+export interface ReportStorageUsageResponse {
+}
+
 export interface PaginatedRequest {
   perPage: number;
   page: number;
@@ -123,6 +276,7 @@ export interface Usage {
 export enum Usage_Kind {
   KIND_WORKSPACE_INSTANCE = "KIND_WORKSPACE_INSTANCE",
   KIND_INVOICE = "KIND_INVOICE",
+  KIND_STORAGE = "KIND_STORAGE",
   UNRECOGNIZED = "UNRECOGNIZED",
 }
 
@@ -134,6 +288,9 @@ export function usage_KindFromJSON(object: any): Usage_Kind {
     case 1:
     case "KIND_INVOICE":
       return Usage_Kind.KIND_INVOICE;
+    case 2:
+    case "KIND_STORAGE":
+      return Usage_Kind.KIND_STORAGE;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -147,6 +304,8 @@ export function usage_KindToJSON(object: Usage_Kind): string {
       return "KIND_WORKSPACE_INSTANCE";
     case Usage_Kind.KIND_INVOICE:
       return "KIND_INVOICE";
+    case Usage_Kind.KIND_STORAGE:
+      return "KIND_STORAGE";
     case Usage_Kind.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -159,6 +318,8 @@ export function usage_KindToNumber(object: Usage_Kind): number {
       return 0;
     case Usage_Kind.KIND_INVOICE:
       return 1;
+    case Usage_Kind.KIND_STORAGE:
+      return 2;
     case Usage_Kind.UNRECOGNIZED:
     default:
       return -1;
@@ -262,6 +423,104 @@ export interface AddUsageCreditNoteRequest {
 export interface AddUsageCreditNoteResponse {
 }
 
+export interface GetUsageAggregatesRequest {
+  /** attribution_id limits aggregation to a single team or user. Usage of all attributions is aggregated when empty. */
+  attributionId: string;
+  /** from specifies the starting time range for this request. */
+  from:
+    | Date
+    | undefined;
+  /** to specifies the end time range for this request. */
+  to:
+    | Date
+    | undefined;
+  /** group_by lists the dimensions usage is aggregated by. All usage in a time bucket is summed up when empty. */
+  groupBy: UsageDimension[];
+  bucket: TimeBucket;
+}
+
+export interface GetUsageAggregatesResponse {
+  aggregates: UsageAggregate[];
+}
+
+export interface UsageAggregate {
+  /** bucket_start is the start of the time bucket, or from if no time bucket was requested */
+  bucketStart:
+    | Date
+    | undefined;
+  /** dimensions are only set when usage is grouped by them */
+  attributionId: string;
+  projectId: string;
+  workspaceClass: string;
+  userId: string;
+  credits: number;
+  /** workspace_minutes is the workspace runtime the credits were charged for */
+  workspaceMinutes: number;
+  /** credits_per_minute is the effective price of the workspace class, i.e. credits per workspace minute, only set when grouped by workspace class */
+  creditsPerMinute: number;
+  /** usage_entries is the number of usage entries the aggregate consists of */
+  usageEntries: number;
+}
+
+export interface ExportUsageRequest {
+  attributionId: string;
+  from: Date | undefined;
+  to: Date | undefined;
+  groupBy: UsageDimension[];
+  bucket: TimeBucket;
+  format: ExportUsageRequest_Format;
+}
+
+export enum ExportUsageRequest_Format {
+  FORMAT_CSV = "FORMAT_CSV",
+  FORMAT_PARQUET = "FORMAT_PARQUET",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function exportUsageRequest_FormatFromJSON(object: any): ExportUsageRequest_Format {
+  switch (object) {
+    case 0:
+    case "FORMAT_CSV":
+      return ExportUsageRequest_Format.FORMAT_CSV;
+    case 1:
+    case "FORMAT_PARQUET":
+      return ExportUsageRequest_Format.FORMAT_PARQUET;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return ExportUsageRequest_Format.UNRECOGNIZED;
+  }
+}
+
+export function exportUsageRequest_FormatToJSON(object: ExportUsageRequest_Format): string {
+  switch (object) {
+    case ExportUsageRequest_Format.FORMAT_CSV:
+      return "FORMAT_CSV";
+    case ExportUsageRequest_Format.FORMAT_PARQUET:
+      return "FORMAT_PARQUET";
+    case ExportUsageRequest_Format.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function exportUsageRequest_FormatToNumber(object: ExportUsageRequest_Format): number {
+  switch (object) {
+    case ExportUsageRequest_Format.FORMAT_CSV:
+      return 0;
+    case ExportUsageRequest_Format.FORMAT_PARQUET:
+      return 1;
+    case ExportUsageRequest_Format.UNRECOGNIZED:
+    default:
+      return -1;
+  }
+}
+
+export interface ExportUsageResponse {
+  /** chunk is the next part of the exported file */
+  chunk: Uint8Array;
+}
+
 function createBaseReconcileUsageRequest(): ReconcileUsageRequest {
   return { from: undefined, to: undefined };
 }
@@ -359,6 +618,176 @@ export const ReconcileUsageResponse = {
   },
 };
 
+function createBaseReportStorageUsageRequest(): ReportStorageUsageRequest {
+  return { from: undefined, to: undefined, workspaces: [] };
+}
+
+export const ReportStorageUsageRequest = {
+  encode(message: ReportStorageUsageRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.from !== undefined) {
+      Timestamp.encode(toTimestamp(message.from), writer.uint32(10).fork()).ldelim();
+    }
+    if (message.to !== undefined) {
+      Timestamp.encode(toTimestamp(message.to), writer.uint32(18).fork()).ldelim();
+    }
+    for (const v of message.workspaces) {
+      WorkspaceStorageUsage.encode(v!, writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ReportStorageUsageRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseReportStorageUsageRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.from = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 2:
+          message.to = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 3:
+          message.workspaces.push(WorkspaceStorageUsage.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ReportStorageUsageRequest {
+    return {
+      from: isSet(object.from) ? fromJsonTimestamp(object.from) : undefined,
+      to: isSet(object.to) ? fromJsonTimestamp(object.to) : undefined,
+      workspaces: Array.isArray(object?.workspaces)
+        ? object.workspaces.map((e: any) => WorkspaceStorageUsage.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: ReportStorageUsageRequest): unknown {
+    const obj: any = {};
+    message.from !== undefined && (obj.from = message.from.toISOString());
+    message.to !== undefined && (obj.to = message.to.toISOString());
+    if (message.workspaces) {
+      obj.workspaces = message.workspaces.map((e) => e ? WorkspaceStorageUsage.toJSON(e) : undefined);
+    } else {
+      obj.workspaces = [];
+    }
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<ReportStorageUsageRequest>): ReportStorageUsageRequest {
+    const message = createBaseReportStorageUsageRequest();
+    message.from = object.from ?? undefined;
+    message.to = object.to ?? undefined;
+    message.workspaces = object.workspaces?.map((e) => WorkspaceStorageUsage.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseWorkspaceStorageUsage(): WorkspaceStorageUsage {
+  return { workspaceId: "", bytes: 0 };
+}
+
+export const WorkspaceStorageUsage = {
+  encode(message: WorkspaceStorageUsage, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.workspaceId !== "") {
+      writer.uint32(10).string(message.workspaceId);
+    }
+    if (message.bytes !== 0) {
+      writer.uint32(16).int64(message.bytes);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WorkspaceStorageUsage {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWorkspaceStorageUsage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.workspaceId = reader.string();
+          break;
+        case 2:
+          message.bytes = longToNumber(reader.int64() as Long);
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WorkspaceStorageUsage {
+    return {
+      workspaceId: isSet(object.workspaceId) ? String(object.workspaceId) : "",
+      bytes: isSet(object.bytes) ? Number(object.bytes) : 0,
+    };
+  },
+
+  toJSON(message: WorkspaceStorageUsage): unknown {
+    const obj: any = {};
+    message.workspaceId !== undefined && (obj.workspaceId = message.workspaceId);
+    message.bytes !== undefined && (obj.bytes = Math.round(message.bytes));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<WorkspaceStorageUsage>): WorkspaceStorageUsage {
+    const message = createBaseWorkspaceStorageUsage();
+    message.workspaceId = object.workspaceId ?? "";
+    message.bytes = object.bytes ?? 0;
+    return message;
+  },
+};
+
+function createBaseReportStorageUsageResponse(): ReportStorageUsageResponse {
+  return {};
+}
+
+export const ReportStorageUsageResponse = {
+  encode(_: ReportStorageUsageResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ReportStorageUsageResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseReportStorageUsageResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): ReportStorageUsageResponse {
+    return {};
+  },
+
+  toJSON(_: ReportStorageUsageResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial(_: DeepPartial<ReportStorageUsageResponse>): ReportStorageUsageResponse {
+    const message = createBaseReportStorageUsageResponse();
+    return message;
+  },
+};
+
 function createBasePaginatedRequest(): PaginatedRequest {
   return { perPage: 0, page: 0 };
 }
@@ -1372,30 +1801,484 @@ export const AddUsageCreditNoteResponse = {
   },
 };
 
-export type UsageServiceDefinition = typeof UsageServiceDefinition;
-export const UsageServiceDefinition = {
-  name: "UsageService",
-  fullName: "usage.v1.UsageService",
-  methods: {
-    /** GetCostCenter retrieves the active cost center for the given attributionID */
-    getCostCenter: {
-      name: "GetCostCenter",
-      requestType: GetCostCenterRequest,
-      requestStream: false,
-      responseType: GetCostCenterResponse,
-      responseStream: false,
-      options: {},
-    },
-    /** SetCostCenter stores the given cost center */
-    setCostCenter: {
-      name: "SetCostCenter",
-      requestType: SetCostCenterRequest,
-      requestStream: false,
-      responseType: SetCostCenterResponse,
-      responseStream: false,
-      options: {},
-    },
-    /** Triggers reconciliation of usage. */
+function createBaseGetUsageAggregatesRequest(): GetUsageAggregatesRequest {
+  return {
+    attributionId: "",
+    from: undefined,
+    to: undefined,
+    groupBy: [],
+    bucket: TimeBucket.TIME_BUCKET_UNSPECIFIED,
+  };
+}
+
+export const GetUsageAggregatesRequest = {
+  encode(message: GetUsageAggregatesRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.attributionId !== "") {
+      writer.uint32(10).string(message.attributionId);
+    }
+    if (message.from !== undefined) {
+      Timestamp.encode(toTimestamp(message.from), writer.uint32(18).fork()).ldelim();
+    }
+    if (message.to !== undefined) {
+      Timestamp.encode(toTimestamp(message.to), writer.uint32(26).fork()).ldelim();
+    }
+    writer.uint32(34).fork();
+    for (const v of message.groupBy) {
+      writer.int32(usageDimensionToNumber(v));
+    }
+    writer.ldelim();
+    if (message.bucket !== TimeBucket.TIME_BUCKET_UNSPECIFIED) {
+      writer.uint32(40).int32(timeBucketToNumber(message.bucket));
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetUsageAggregatesRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetUsageAggregatesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.attributionId = reader.string();
+          break;
+        case 2:
+          message.from = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 3:
+          message.to = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 4:
+          if ((tag & 7) === 2) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.groupBy.push(usageDimensionFromJSON(reader.int32()));
+            }
+          } else {
+            message.groupBy.push(usageDimensionFromJSON(reader.int32()));
+          }
+          break;
+        case 5:
+          message.bucket = timeBucketFromJSON(reader.int32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetUsageAggregatesRequest {
+    return {
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      from: isSet(object.from) ? fromJsonTimestamp(object.from) : undefined,
+      to: isSet(object.to) ? fromJsonTimestamp(object.to) : undefined,
+      groupBy: Array.isArray(object?.groupBy) ? object.groupBy.map((e: any) => usageDimensionFromJSON(e)) : [],
+      bucket: isSet(object.bucket) ? timeBucketFromJSON(object.bucket) : TimeBucket.TIME_BUCKET_UNSPECIFIED,
+    };
+  },
+
+  toJSON(message: GetUsageAggregatesRequest): unknown {
+    const obj: any = {};
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.from !== undefined && (obj.from = message.from.toISOString());
+    message.to !== undefined && (obj.to = message.to.toISOString());
+    if (message.groupBy) {
+      obj.groupBy = message.groupBy.map((e) => usageDimensionToJSON(e));
+    } else {
+      obj.groupBy = [];
+    }
+    message.bucket !== undefined && (obj.bucket = timeBucketToJSON(message.bucket));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<GetUsageAggregatesRequest>): GetUsageAggregatesRequest {
+    const message = createBaseGetUsageAggregatesRequest();
+    message.attributionId = object.attributionId ?? "";
+    message.from = object.from ?? undefined;
+    message.to = object.to ?? undefined;
+    message.groupBy = object.groupBy?.map((e) => e) || [];
+    message.bucket = object.bucket ?? TimeBucket.TIME_BUCKET_UNSPECIFIED;
+    return message;
+  },
+};
+
+function createBaseGetUsageAggregatesResponse(): GetUsageAggregatesResponse {
+  return { aggregates: [] };
+}
+
+export const GetUsageAggregatesResponse = {
+  encode(message: GetUsageAggregatesResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.aggregates) {
+      UsageAggregate.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetUsageAggregatesResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetUsageAggregatesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.aggregates.push(UsageAggregate.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetUsageAggregatesResponse {
+    return {
+      aggregates: Array.isArray(object?.aggregates)
+        ? object.aggregates.map((e: any) => UsageAggregate.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetUsageAggregatesResponse): unknown {
+    const obj: any = {};
+    if (message.aggregates) {
+      obj.aggregates = message.aggregates.map((e) => e ? UsageAggregate.toJSON(e) : undefined);
+    } else {
+      obj.aggregates = [];
+    }
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<GetUsageAggregatesResponse>): GetUsageAggregatesResponse {
+    const message = createBaseGetUsageAggregatesResponse();
+    message.aggregates = object.aggregates?.map((e) => UsageAggregate.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseUsageAggregate(): UsageAggregate {
+  return {
+    bucketStart: undefined,
+    attributionId: "",
+    projectId: "",
+    workspaceClass: "",
+    userId: "",
+    credits: 0,
+    workspaceMinutes: 0,
+    creditsPerMinute: 0,
+    usageEntries: 0,
+  };
+}
+
+export const UsageAggregate = {
+  encode(message: UsageAggregate, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.bucketStart !== undefined) {
+      Timestamp.encode(toTimestamp(message.bucketStart), writer.uint32(10).fork()).ldelim();
+    }
+    if (message.attributionId !== "") {
+      writer.uint32(18).string(message.attributionId);
+    }
+    if (message.projectId !== "") {
+      writer.uint32(26).string(message.projectId);
+    }
+    if (message.workspaceClass !== "") {
+      writer.uint32(34).string(message.workspaceClass);
+    }
+    if (message.userId !== "") {
+      writer.uint32(42).string(message.userId);
+    }
+    if (message.credits !== 0) {
+      writer.uint32(49).double(message.credits);
+    }
+    if (message.workspaceMinutes !== 0) {
+      writer.uint32(57).double(message.workspaceMinutes);
+    }
+    if (message.creditsPerMinute !== 0) {
+      writer.uint32(65).double(message.creditsPerMinute);
+    }
+    if (message.usageEntries !== 0) {
+      writer.uint32(72).int64(message.usageEntries);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): UsageAggregate {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseUsageAggregate();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.bucketStart = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 2:
+          message.attributionId = reader.string();
+          break;
+        case 3:
+          message.projectId = reader.string();
+          break;
+        case 4:
+          message.workspaceClass = reader.string();
+          break;
+        case 5:
+          message.userId = reader.string();
+          break;
+        case 6:
+          message.credits = reader.double();
+          break;
+        case 7:
+          message.workspaceMinutes = reader.double();
+          break;
+        case 8:
+          message.creditsPerMinute = reader.double();
+          break;
+        case 9:
+          message.usageEntries = longToNumber(reader.int64() as Long);
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): UsageAggregate {
+    return {
+      bucketStart: isSet(object.bucketStart) ? fromJsonTimestamp(object.bucketStart) : undefined,
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      projectId: isSet(object.projectId) ? String(object.projectId) : "",
+      workspaceClass: isSet(object.workspaceClass) ? String(object.workspaceClass) : "",
+      userId: isSet(object.userId) ? String(object.userId) : "",
+      credits: isSet(object.credits) ? Number(object.credits) : 0,
+      workspaceMinutes: isSet(object.workspaceMinutes) ? Number(object.workspaceMinutes) : 0,
+      creditsPerMinute: isSet(object.creditsPerMinute) ? Number(object.creditsPerMinute) : 0,
+      usageEntries: isSet(object.usageEntries) ? Number(object.usageEntries) : 0,
+    };
+  },
+
+  toJSON(message: UsageAggregate): unknown {
+    const obj: any = {};
+    message.bucketStart !== undefined && (obj.bucketStart = message.bucketStart.toISOString());
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.projectId !== undefined && (obj.projectId = message.projectId);
+    message.workspaceClass !== undefined && (obj.workspaceClass = message.workspaceClass);
+    message.userId !== undefined && (obj.userId = message.userId);
+    message.credits !== undefined && (obj.credits = message.credits);
+    message.workspaceMinutes !== undefined && (obj.workspaceMinutes = message.workspaceMinutes);
+    message.creditsPerMinute !== undefined && (obj.creditsPerMinute = message.creditsPerMinute);
+    message.usageEntries !== undefined && (obj.usageEntries = Math.round(message.usageEntries));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<UsageAggregate>): UsageAggregate {
+    const message = createBaseUsageAggregate();
+    message.bucketStart = object.bucketStart ?? undefined;
+    message.attributionId = object.attributionId ?? "";
+    message.projectId = object.projectId ?? "";
+    message.workspaceClass = object.workspaceClass ?? "";
+    message.userId = object.userId ?? "";
+    message.credits = object.credits ?? 0;
+    message.workspaceMinutes = object.workspaceMinutes ?? 0;
+    message.creditsPerMinute = object.creditsPerMinute ?? 0;
+    message.usageEntries = object.usageEntries ?? 0;
+    return message;
+  },
+};
+
+function createBaseExportUsageRequest(): ExportUsageRequest {
+  return {
+    attributionId: "",
+    from: undefined,
+    to: undefined,
+    groupBy: [],
+    bucket: TimeBucket.TIME_BUCKET_UNSPECIFIED,
+    format: ExportUsageRequest_Format.FORMAT_CSV,
+  };
+}
+
+export const ExportUsageRequest = {
+  encode(message: ExportUsageRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.attributionId !== "") {
+      writer.uint32(10).string(message.attributionId);
+    }
+    if (message.from !== undefined) {
+      Timestamp.encode(toTimestamp(message.from), writer.uint32(18).fork()).ldelim();
+    }
+    if (message.to !== undefined) {
+      Timestamp.encode(toTimestamp(message.to), writer.uint32(26).fork()).ldelim();
+    }
+    writer.uint32(34).fork();
+    for (const v of message.groupBy) {
+      writer.int32(usageDimensionToNumber(v));
+    }
+    writer.ldelim();
+    if (message.bucket !== TimeBucket.TIME_BUCKET_UNSPECIFIED) {
+      writer.uint32(40).int32(timeBucketToNumber(message.bucket));
+    }
+    if (message.format !== ExportUsageRequest_Format.FORMAT_CSV) {
+      writer.uint32(48).int32(exportUsageRequest_FormatToNumber(message.format));
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ExportUsageRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportUsageRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.attributionId = reader.string();
+          break;
+        case 2:
+          message.from = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 3:
+          message.to = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 4:
+          if ((tag & 7) === 2) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.groupBy.push(usageDimensionFromJSON(reader.int32()));
+            }
+          } else {
+            message.groupBy.push(usageDimensionFromJSON(reader.int32()));
+          }
+          break;
+        case 5:
+          message.bucket = timeBucketFromJSON(reader.int32());
+          break;
+        case 6:
+          message.format = exportUsageRequest_FormatFromJSON(reader.int32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportUsageRequest {
+    return {
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      from: isSet(object.from) ? fromJsonTimestamp(object.from) : undefined,
+      to: isSet(object.to) ? fromJsonTimestamp(object.to) : undefined,
+      groupBy: Array.isArray(object?.groupBy) ? object.groupBy.map((e: any) => usageDimensionFromJSON(e)) : [],
+      bucket: isSet(object.bucket) ? timeBucketFromJSON(object.bucket) : TimeBucket.TIME_BUCKET_UNSPECIFIED,
+      format: isSet(object.format)
+        ? exportUsageRequest_FormatFromJSON(object.format)
+        : ExportUsageRequest_Format.FORMAT_CSV,
+    };
+  },
+
+  toJSON(message: ExportUsageRequest): unknown {
+    const obj: any = {};
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.from !== undefined && (obj.from = message.from.toISOString());
+    message.to !== undefined && (obj.to = message.to.toISOString());
+    if (message.groupBy) {
+      obj.groupBy = message.groupBy.map((e) => usageDimensionToJSON(e));
+    } else {
+      obj.groupBy = [];
+    }
+    message.bucket !== undefined && (obj.bucket = timeBucketToJSON(message.bucket));
+    message.format !== undefined && (obj.format = exportUsageRequest_FormatToJSON(message.format));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<ExportUsageRequest>): ExportUsageRequest {
+    const message = createBaseExportUsageRequest();
+    message.attributionId = object.attributionId ?? "";
+    message.from = object.from ?? undefined;
+    message.to = object.to ?? undefined;
+    message.groupBy = object.groupBy?.map((e) => e) || [];
+    message.bucket = object.bucket ?? TimeBucket.TIME_BUCKET_UNSPECIFIED;
+    message.format = object.format ?? ExportUsageRequest_Format.FORMAT_CSV;
+    return message;
+  },
+};
+
+function createBaseExportUsageResponse(): ExportUsageResponse {
+  return { chunk: new Uint8Array() };
+}
+
+export const ExportUsageResponse = {
+  encode(message: ExportUsageResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.chunk.length !== 0) {
+      writer.uint32(10).bytes(message.chunk);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ExportUsageResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportUsageResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.chunk = reader.bytes();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportUsageResponse {
+    return { chunk: isSet(object.chunk) ? bytesFromBase64(object.chunk) : new Uint8Array() };
+  },
+
+  toJSON(message: ExportUsageResponse): unknown {
+    const obj: any = {};
+    message.chunk !== undefined &&
+      (obj.chunk = base64FromBytes(message.chunk !== undefined ? message.chunk : new Uint8Array()));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<ExportUsageResponse>): ExportUsageResponse {
+    const message = createBaseExportUsageResponse();
+    message.chunk = object.chunk ?? new Uint8Array();
+    return message;
+  },
+};
+
+export type UsageServiceDefinition = typeof UsageServiceDefinition;
+export const UsageServiceDefinition = {
+  name: "UsageService",
+  fullName: "usage.v1.UsageService",
+  methods: {
+    /** GetCostCenter retrieves the active cost center for the given attributionID */
+    getCostCenter: {
+      name: "GetCostCenter",
+      requestType: GetCostCenterRequest,
+      requestStream: false,
+      responseType: GetCostCenterResponse,
+      responseStream: false,
+      options: {},
+    },
+    /** SetCostCenter stores the given cost center */
+    setCostCenter: {
+      name: "SetCostCenter",
+      requestType: SetCostCenterRequest,
+      requestStream: false,
+      responseType: SetCostCenterResponse,
+      responseStream: false,
+      options: {},
+    },
+    /** Triggers reconciliation of usage. */
     reconcileUsage: {
       name: "ReconcileUsage",
       requestType: ReconcileUsageRequest,
@@ -1440,6 +2323,33 @@ export const UsageServiceDefinition = {
       responseStream: false,
       options: {},
     },
+    /** GetUsageAggregates sums up usage in the given time range, grouped by dimensions and time buckets */
+    getUsageAggregates: {
+      name: "GetUsageAggregates",
+      requestType: GetUsageAggregatesRequest,
+      requestStream: false,
+      responseType: GetUsageAggregatesResponse,
+      responseStream: false,
+      options: {},
+    },
+    /** ExportUsage streams usage aggregates as a CSV or Parquet file */
+    exportUsage: {
+      name: "ExportUsage",
+      requestType: ExportUsageRequest,
+      requestStream: false,
+      responseType: ExportUsageResponse,
+      responseStream: true,
+      options: {},
+    },
+    /** ReportStorageUsage charges the storage of retained workspace backups measured at the end of the given period */
+    reportStorageUsage: {
+      name: "ReportStorageUsage",
+      requestType: ReportStorageUsageRequest,
+      requestStream: false,
+      responseType: ReportStorageUsageResponse,
+      responseStream: false,
+      options: {},
+    },
   },
 } as const;
 
@@ -1476,6 +2386,21 @@ export interface UsageServiceServiceImplementation<CallContextExt = {}> {
     request: AddUsageCreditNoteRequest,
     context: CallContext & CallContextExt,
   ): Promise<DeepPartial<AddUsageCreditNoteResponse>>;
+  /** GetUsageAggregates sums up usage in the given time range, grouped by dimensions and time buckets */
+  getUsageAggregates(
+    request: GetUsageAggregatesRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<GetUsageAggregatesResponse>>;
+  /** ExportUsage streams usage aggregates as a CSV or Parquet file */
+  exportUsage(
+    request: ExportUsageRequest,
+    context: CallContext & CallContextExt,
+  ): ServerStreamingMethodResult<DeepPartial<ExportUsageResponse>>;
+  /** ReportStorageUsage charges the storage of retained workspace backups measured at the end of the given period */
+  reportStorageUsage(
+    request: ReportStorageUsageRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<ReportStorageUsageResponse>>;
 }
 
 export interface UsageServiceClient<CallOptionsExt = {}> {
@@ -1511,6 +2436,21 @@ export interface UsageServiceClient<CallOptionsExt = {}> {
     request: DeepPartial<AddUsageCreditNoteRequest>,
     options?: CallOptions & CallOptionsExt,
   ): Promise<AddUsageCreditNoteResponse>;
+  /** GetUsageAggregates sums up usage in the given time range, grouped by dimensions and time buckets */
+  getUsageAggregates(
+    request: DeepPartial<GetUsageAggregatesRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<GetUsageAggregatesResponse>;
+  /** ExportUsage streams usage aggregates as a CSV or Parquet file */
+  exportUsage(
+    request: DeepPartial<ExportUsageRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): AsyncIterable<ExportUsageResponse>;
+  /** ReportStorageUsage charges the storage of retained workspace backups measured at the end of the given period */
+  reportStorageUsage(
+    request: DeepPartial<ReportStorageUsageRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<ReportStorageUsageResponse>;
 }
 
 export interface DataLoaderOptions {
@@ -1541,6 +2481,31 @@ var globalThis: any = (() => {
   throw "Unable to locate global object";
 })();
 
+function bytesFromBase64(b64: string): Uint8Array {
+  if (globalThis.Buffer) {
+    return Uint8Array.from(globalThis.Buffer.from(b64, "base64"));
+  } else {
+    const bin = globalThis.atob(b64);
+    const arr = new Uint8Array(bin.length);
+    for (let i = 0; i < bin.length; ++i) {
+      arr[i] = bin.charCodeAt(i);
+    }
+    return arr;
+  }
+}
+
+function base64FromBytes(arr: Uint8Array): string {
+  if (globalThis.Buffer) {
+    return globalThis.Buffer.from(arr).toString("base64");
+  } else {
+    const bin: string[] = [];
+    arr.forEach((byte) => {
+      bin.push(String.fromCharCode(byte));
+    });
+    return globalThis.btoa(bin.join(""));
+  }
+}
+
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 
 export type DeepPartial<T> = T extends Builtin ? T
@@ -1587,3 +2552,5 @@ if (_m0.util.Long !== Long) {
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
+
+export type ServerStreamingMethodResult<Response> = { [Symbol.asyncIterator](): AsyncIterator<Response, void> };
@@ -0,0 +1,239 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"gorm.io/gorm"
+)
+
+// Config configures budget alerts on cost centers
+type Config struct {
+	// Thresholds are the percentages of the spending limit at which alerts fire, e.g. [50, 80, 100].
+	// Cost centers can override them with thresholds of their own (see db.CostCenter.AlertThresholds).
+	Thresholds []int32 `json:"thresholds"`
+
+	// Webhook delivers alerts as signed HTTP requests
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+
+	// SMTP delivers alerts by email
+	SMTP *SMTPConfig `json:"smtp,omitempty"`
+
+	// Log delivers alerts to the log of the usage component
+	Log bool `json:"log,omitempty"`
+}
+
+// Alert is raised when the usage of a cost center crosses a threshold of its spending limit
+type Alert struct {
+	AttributionID     db.AttributionID `json:"attributionId"`
+	Threshold         int32            `json:"threshold"`
+	UsedCredits       float64          `json:"usedCredits"`
+	SpendingLimit     int32            `json:"spendingLimit"`
+	BillingCycleStart time.Time        `json:"billingCycleStart"`
+	NextBillingTime   *time.Time       `json:"nextBillingTime,omitempty"`
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("Usage of %s reached %d%% of its spending limit (%.2f of %d credits)", a.AttributionID, a.Threshold, a.UsedCredits, a.SpendingLimit)
+}
+
+// Sink delivers alerts
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, alert Alert) error
+}
+
+// NewSinks produces the sinks the config asks for
+func NewSinks(cfg Config) ([]Sink, error) {
+	var sinks []Sink
+	if cfg.Webhook != nil {
+		s, err := NewWebhookSink(*cfg.Webhook)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	if cfg.SMTP != nil {
+		s, err := NewSMTPSink(*cfg.SMTP)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	if cfg.Log {
+		sinks = append(sinks, LogSink{})
+	}
+	return sinks, nil
+}
+
+// NewEvaluator creates an evaluator which delivers alerts to all sinks
+func NewEvaluator(conn *gorm.DB, ccManager *db.CostCenterManager, cfg Config, sinks ...Sink) (*Evaluator, error) {
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("budget alerts require at least one sink")
+	}
+	if err := validateThresholds(cfg.Thresholds); err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(sinks))
+	for _, s := range sinks {
+		// we record deliveries by sink name, hence two sinks of the same name would deliver alerts only once
+		if _, exists := names[s.Name()]; exists {
+			return nil, fmt.Errorf("budget alert sinks must have unique names, %s is used twice", s.Name())
+		}
+		names[s.Name()] = struct{}{}
+	}
+
+	return &Evaluator{
+		conn:      conn,
+		ccManager: ccManager,
+		cfg:       cfg,
+		sinks:     sinks,
+	}, nil
+}
+
+func validateThresholds(thresholds []int32) error {
+	for _, t := range thresholds {
+		if t <= 0 {
+			return fmt.Errorf("thresholds must be positive percentages, got %d", t)
+		}
+	}
+	return nil
+}
+
+// Evaluator checks the usage of all cost centers against their alert thresholds
+type Evaluator struct {
+	conn      *gorm.DB
+	ccManager *db.CostCenterManager
+	cfg       Config
+	sinks     []Sink
+}
+
+func (e *Evaluator) thresholds(costCenter db.CostCenter) []int32 {
+	if costCenter.AlertThresholds != nil {
+		return costCenter.AlertThresholds
+	}
+	return e.cfg.Thresholds
+}
+
+// Evaluate fires alerts for all thresholds cost centers crossed in their current billing cycle.
+// Each sink delivers the alert about a threshold once per billing cycle.
+func (e *Evaluator) Evaluate(ctx context.Context) error {
+	balances, err := db.ListBalance(ctx, e.conn)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, balance := range balances {
+		err := e.evaluate(ctx, balance)
+		if err != nil {
+			log.WithError(err).WithField("attribution_id", balance.AttributionID).Error("Failed to evaluate budget alerts.")
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to evaluate budget alerts for %d of %d cost centers", failed, len(balances))
+	}
+	return nil
+}
+
+func (e *Evaluator) evaluate(ctx context.Context, balance db.Balance) error {
+	if balance.CreditCents <= 0 {
+		return nil
+	}
+
+	costCenter, err := e.ccManager.GetOrCreateCostCenter(ctx, balance.AttributionID)
+	if err != nil {
+		return err
+	}
+	thresholds := e.thresholds(costCenter)
+	if len(thresholds) == 0 || costCenter.SpendingLimit <= 0 {
+		return nil
+	}
+
+	used := balance.CreditCents.ToCredits()
+	percentage := used / float64(costCenter.SpendingLimit) * 100
+	var crossed []int32
+	for _, t := range thresholds {
+		if percentage >= float64(t) {
+			crossed = append(crossed, t)
+		}
+	}
+	if len(crossed) == 0 {
+		return nil
+	}
+	sort.Slice(crossed, func(i, j int) bool { return crossed[i] < crossed[j] })
+
+	delivered, err := db.ListCostCenterAlerts(ctx, e.conn, costCenter.ID, costCenter.BillingCycleStart)
+	if err != nil {
+		return err
+	}
+	deliveredBySink := make(map[string]map[int32]struct{})
+	for _, a := range delivered {
+		if deliveredBySink[a.Sink] == nil {
+			deliveredBySink[a.Sink] = make(map[int32]struct{})
+		}
+		deliveredBySink[a.Sink][a.Threshold] = struct{}{}
+	}
+
+	var errs []error
+	for _, s := range e.sinks {
+		var newlyCrossed []int32
+		for _, t := range crossed {
+			if _, ok := deliveredBySink[s.Name()][t]; ok {
+				continue
+			}
+			newlyCrossed = append(newlyCrossed, t)
+		}
+		if len(newlyCrossed) == 0 {
+			continue
+		}
+
+		// When usage jumped across several thresholds at once we only alert about the highest one
+		alert := Alert{
+			AttributionID:     costCenter.ID,
+			Threshold:         newlyCrossed[len(newlyCrossed)-1],
+			UsedCredits:       used,
+			SpendingLimit:     costCenter.SpendingLimit,
+			BillingCycleStart: costCenter.BillingCycleStart.Time(),
+		}
+		if costCenter.NextBillingTime.IsSet() {
+			t := costCenter.NextBillingTime.Time()
+			alert.NextBillingTime = &t
+		}
+
+		err := s.Send(ctx, alert)
+		if err != nil {
+			// we haven't recorded the delivery, so this sink will try again during the next evaluation
+			log.WithError(err).WithField("sink", s.Name()).WithField("attribution_id", alert.AttributionID).Error("Failed to deliver budget alert.")
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+			continue
+		}
+
+		records := make([]db.CostCenterAlert, 0, len(newlyCrossed))
+		for _, t := range newlyCrossed {
+			records = append(records, db.CostCenterAlert{
+				AttributionID:     costCenter.ID,
+				BillingCycleStart: costCenter.BillingCycleStart,
+				Threshold:         t,
+				Sink:              s.Name(),
+			})
+		}
+		err = db.RecordCostCenterAlerts(ctx, e.conn, records...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to deliver budget alert: %v", errs)
+	}
+	return nil
+}
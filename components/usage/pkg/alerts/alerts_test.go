@@ -0,0 +1,202 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	SinkName string
+	Alerts   []Alert
+	Err      error
+}
+
+func (s *recordingSink) Name() string {
+	if s.SinkName == "" {
+		return "recording"
+	}
+	return s.SinkName
+}
+
+func (s *recordingSink) Send(ctx context.Context, alert Alert) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Alerts = append(s.Alerts, alert)
+	return nil
+}
+
+func (s *recordingSink) thresholdsFor(attributionID db.AttributionID) []int32 {
+	var res []int32
+	for _, a := range s.Alerts {
+		if a.AttributionID == attributionID {
+			res = append(res, a.Threshold)
+		}
+	}
+	return res
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	ccManager := db.NewCostCenterManager(conn, db.DefaultSpendingLimit{})
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	cycleStart := db.NewVarCharTime(time.Now().Add(-24 * time.Hour))
+	dbtest.CreateCostCenters(t, conn, dbtest.NewCostCenter(t, db.CostCenter{
+		ID:                attributionID,
+		SpendingLimit:     100,
+		BillingStrategy:   db.CostCenter_Other,
+		BillingCycleStart: cycleStart,
+		NextBillingTime:   db.NewVarCharTime(time.Now().Add(24 * time.Hour)),
+	}))
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where("attributionId = ?", string(attributionID)).Delete(&db.CostCenterAlert{}).Error)
+	})
+
+	addUsage := func(credits float64) {
+		dbtest.CreateUsageRecords(t, conn, dbtest.NewUsage(t, db.Usage{
+			AttributionID: attributionID,
+			CreditCents:   db.NewCreditCents(credits),
+			EffectiveTime: db.NewVarCharTime(time.Now()),
+		}))
+	}
+
+	sink := &recordingSink{}
+	evaluator, err := NewEvaluator(conn, ccManager, Config{Thresholds: []int32{50, 80, 100}}, sink)
+	require.NoError(t, err)
+
+	addUsage(40)
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Empty(t, sink.thresholdsFor(attributionID), "no threshold was crossed")
+
+	addUsage(45)
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Equal(t, []int32{80}, sink.thresholdsFor(attributionID), "only the highest crossed threshold fires")
+
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Equal(t, []int32{80}, sink.thresholdsFor(attributionID), "thresholds fire once per billing cycle")
+
+	alerts, err := db.ListCostCenterAlerts(ctx, conn, attributionID, cycleStart)
+	require.NoError(t, err)
+	require.Len(t, alerts, 2, "all crossed thresholds are recorded")
+
+	addUsage(20)
+	sink.Err = fmt.Errorf("unavailable")
+	require.Error(t, evaluator.Evaluate(ctx))
+	sink.Err = nil
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Equal(t, []int32{80, 100}, sink.thresholdsFor(attributionID), "undelivered alerts are retried")
+}
+
+func TestEvaluator_EvaluateRecordsDeliveryPerSink(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	ccManager := db.NewCostCenterManager(conn, db.DefaultSpendingLimit{})
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	dbtest.CreateCostCenters(t, conn, dbtest.NewCostCenter(t, db.CostCenter{
+		ID:                attributionID,
+		SpendingLimit:     100,
+		BillingStrategy:   db.CostCenter_Other,
+		BillingCycleStart: db.NewVarCharTime(time.Now().Add(-24 * time.Hour)),
+		NextBillingTime:   db.NewVarCharTime(time.Now().Add(24 * time.Hour)),
+	}))
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where("attributionId = ?", string(attributionID)).Delete(&db.CostCenterAlert{}).Error)
+	})
+	dbtest.CreateUsageRecords(t, conn, dbtest.NewUsage(t, db.Usage{
+		AttributionID: attributionID,
+		CreditCents:   db.NewCreditCents(60),
+		EffectiveTime: db.NewVarCharTime(time.Now()),
+	}))
+
+	healthy := &recordingSink{SinkName: "healthy"}
+	flaky := &recordingSink{SinkName: "flaky", Err: fmt.Errorf("unavailable")}
+	evaluator, err := NewEvaluator(conn, ccManager, Config{Thresholds: []int32{50}}, healthy, flaky)
+	require.NoError(t, err)
+
+	require.Error(t, evaluator.Evaluate(ctx))
+	require.Equal(t, []int32{50}, healthy.thresholdsFor(attributionID))
+
+	flaky.Err = nil
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Equal(t, []int32{50}, healthy.thresholdsFor(attributionID), "sinks which delivered an alert do not deliver it again")
+	require.Equal(t, []int32{50}, flaky.thresholdsFor(attributionID), "sinks which failed to deliver an alert retry")
+}
+
+func TestEvaluator_EvaluateCostCenterThresholds(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	ccManager := db.NewCostCenterManager(conn, db.DefaultSpendingLimit{})
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	dbtest.CreateCostCenters(t, conn, dbtest.NewCostCenter(t, db.CostCenter{
+		ID:                attributionID,
+		SpendingLimit:     100,
+		BillingStrategy:   db.CostCenter_Other,
+		BillingCycleStart: db.NewVarCharTime(time.Now().Add(-24 * time.Hour)),
+		NextBillingTime:   db.NewVarCharTime(time.Now().Add(24 * time.Hour)),
+	}))
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where("attributionId = ?", string(attributionID)).Delete(&db.CostCenterAlert{}).Error)
+	})
+	dbtest.CreateUsageRecords(t, conn, dbtest.NewUsage(t, db.Usage{
+		AttributionID: attributionID,
+		CreditCents:   db.NewCreditCents(30),
+		EffectiveTime: db.NewVarCharTime(time.Now()),
+	}))
+
+	sink := &recordingSink{}
+	evaluator, err := NewEvaluator(conn, ccManager, Config{Thresholds: []int32{50}}, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Empty(t, sink.thresholdsFor(attributionID), "the default threshold was not crossed")
+
+	_, err = ccManager.SetAlertThresholds(ctx, attributionID, db.AlertThresholds{25})
+	require.NoError(t, err)
+	require.NoError(t, evaluator.Evaluate(ctx))
+	require.Equal(t, []int32{25}, sink.thresholdsFor(attributionID), "the cost center's thresholds replace the default ones")
+}
+
+func TestNewEvaluator(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Config      Config
+		Sinks       []Sink
+		ExpectError bool
+	}{
+		{Name: "valid", Config: Config{Thresholds: []int32{50, 100}}, Sinks: []Sink{LogSink{}}},
+		{Name: "no sinks", Config: Config{Thresholds: []int32{50}}, ExpectError: true},
+		{Name: "negative threshold", Config: Config{Thresholds: []int32{-5}}, Sinks: []Sink{LogSink{}}, ExpectError: true},
+		{
+			Name:        "duplicate sink names",
+			Config:      Config{Thresholds: []int32{50}},
+			Sinks:       []Sink{LogSink{}, LogSink{}},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := NewEvaluator(nil, nil, test.Config, test.Sinks...)
+			if test.ExpectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
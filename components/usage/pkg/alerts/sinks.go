@@ -0,0 +1,184 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of webhook request bodies
+const SignatureHeader = "X-Gitpod-Signature-256"
+
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// SecretFile contains the secret webhook requests are signed with
+	SecretFile string `json:"secretFile"`
+}
+
+// NewWebhookSink delivers alerts as JSON to a webhook
+func NewWebhookSink(cfg WebhookConfig) (*WebhookSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("webhook URL is required")
+	}
+	if cfg.SecretFile == "" {
+		return nil, fmt.Errorf("webhook secret file is required")
+	}
+	secret, err := os.ReadFile(cfg.SecretFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook secret: %w", err)
+	}
+
+	return &WebhookSink{
+		URL:    cfg.URL,
+		Secret: bytes.TrimSpace(secret),
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// WebhookSink posts alerts to a URL. The body is signed using HMAC-SHA256,
+// such that receivers can verify the request originates from Gitpod.
+type WebhookSink struct {
+	URL    string
+	Secret []byte
+	Client *http.Client
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(s.Secret, body))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign produces the signature of a webhook request body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username,omitempty"`
+	// PasswordFile contains the password to authenticate with, if Username is set
+	PasswordFile string   `json:"passwordFile,omitempty"`
+	From         string   `json:"from"`
+	To           []string `json:"to"`
+}
+
+// NewSMTPSink delivers alerts by email
+func NewSMTPSink(cfg SMTPConfig) (*SMTPSink, error) {
+	if cfg.Host == "" || cfg.Port == 0 {
+		return nil, fmt.Errorf("SMTP host and port are required")
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("SMTP sender and recipients are required")
+	}
+
+	res := &SMTPSink{
+		Addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		From: cfg.From,
+		To:   cfg.To,
+		send: smtp.SendMail,
+	}
+	if cfg.Username != "" {
+		password, err := os.ReadFile(cfg.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SMTP password: %w", err)
+		}
+		res.Auth = smtp.PlainAuth("", cfg.Username, strings.TrimSpace(string(password)), cfg.Host)
+	}
+	return res, nil
+}
+
+// SMTPSink emails alerts to a fixed set of recipients
+type SMTPSink struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (s *SMTPSink) Name() string {
+	return "smtp"
+}
+
+func (s *SMTPSink) Send(ctx context.Context, alert Alert) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: Gitpod budget alert: %s reached %d%% of its spending limit\r\n", alert.AttributionID, alert.Threshold)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&msg, "\r\n")
+	fmt.Fprintf(&msg, "%s.\r\n", alert)
+	fmt.Fprintf(&msg, "The current billing cycle started at %s.\r\n", alert.BillingCycleStart.Format(time.RFC1123))
+	if alert.NextBillingTime != nil {
+		fmt.Fprintf(&msg, "The next billing cycle starts at %s.\r\n", alert.NextBillingTime.Format(time.RFC1123))
+	}
+
+	err := s.send(s.Addr, s.Auth, s.From, s.To, msg.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// LogSink logs alerts
+type LogSink struct{}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Send(ctx context.Context, alert Alert) error {
+	log.WithField("attribution_id", alert.AttributionID).
+		WithField("threshold", alert.Threshold).
+		WithField("used_credits", alert.UsedCredits).
+		WithField("spending_limit", alert.SpendingLimit).
+		Warn(alert.String())
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/stretchr/testify/require"
+)
+
+var testAlert = Alert{
+	AttributionID:     db.NewTeamAttributionID("0b4dc0f8-1ed8-4dc6-a4d2-1d1bfd9a7e9c"),
+	Threshold:         80,
+	UsedCredits:       850,
+	SpendingLimit:     1000,
+	BillingCycleStart: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestWebhookSink(t *testing.T) {
+	secret := []byte("s3cr3t")
+
+	tests := []struct {
+		Name        string
+		Status      int
+		ExpectError bool
+	}{
+		{Name: "delivered", Status: http.StatusNoContent},
+		{Name: "rejected", Status: http.StatusUnauthorized, ExpectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var received Alert
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, Sign(secret, body), r.Header.Get(SignatureHeader))
+				require.NoError(t, json.Unmarshal(body, &received))
+				w.WriteHeader(test.Status)
+			}))
+			defer srv.Close()
+
+			sink := &WebhookSink{URL: srv.URL, Secret: secret, Client: srv.Client()}
+			err := sink.Send(context.Background(), testAlert)
+			if test.ExpectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testAlert, received)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{"threshold":50}' | openssl dgst -sha256 -hmac s3cr3t
+	require.Equal(t, "sha256=54a4e8c7141d7cfd55983cd98569f709a810f5ee35061de6cd1a57b829d07a84", Sign([]byte("s3cr3t"), []byte(`{"threshold":50}`)))
+}
+
+func TestSMTPSink(t *testing.T) {
+	var (
+		to  []string
+		msg string
+	)
+	sink := &SMTPSink{
+		Addr: "localhost:25",
+		From: "gitpod@example.com",
+		To:   []string{"finance@example.com"},
+		send: func(addr string, a smtp.Auth, from string, rcpt []string, m []byte) error {
+			to = rcpt
+			msg = string(m)
+			return nil
+		},
+	}
+
+	require.NoError(t, sink.Send(context.Background(), testAlert))
+	require.Equal(t, []string{"finance@example.com"}, to)
+	require.True(t, strings.Contains(msg, "Subject: Gitpod budget alert: team:0b4dc0f8-1ed8-4dc6-a4d2-1d1bfd9a7e9c reached 80% of its spending limit\r\n"), msg)
+	require.True(t, strings.Contains(msg, "(850.00 of 1000 credits)"), msg)
+}
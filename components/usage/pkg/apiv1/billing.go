@@ -18,6 +18,7 @@ import (
 	experimental_v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
 	"github.com/gitpod-io/gitpod/usage/pkg/stripe"
 	"github.com/google/uuid"
 	stripe_api "github.com/stripe/stripe-go/v72"
@@ -26,8 +27,11 @@ import (
 	"gorm.io/gorm"
 )
 
-func NewBillingService(stripeClient *stripe.Client, conn *gorm.DB, ccManager *db.CostCenterManager, stripePrices stripe.StripePrices, teamsService v1connect.TeamsServiceClient, userService v1connect.UserServiceClient) *BillingService {
+// NewBillingService creates a billing service which charges usage through the provider.
+// The Stripe specific methods are only available when a Stripe client is given.
+func NewBillingService(provider billing.Provider, stripeClient *stripe.Client, conn *gorm.DB, ccManager *db.CostCenterManager, stripePrices stripe.StripePrices, teamsService v1connect.TeamsServiceClient, userService v1connect.UserServiceClient) *BillingService {
 	return &BillingService{
+		provider:     provider,
 		stripeClient: stripeClient,
 		conn:         conn,
 		ccManager:    ccManager,
@@ -40,6 +44,7 @@ func NewBillingService(stripeClient *stripe.Client, conn *gorm.DB, ccManager *db
 
 type BillingService struct {
 	conn         *gorm.DB
+	provider     billing.Provider
 	stripeClient *stripe.Client
 	ccManager    *db.CostCenterManager
 	stripePrices stripe.StripePrices
@@ -50,7 +55,17 @@ type BillingService struct {
 	v1.UnimplementedBillingServiceServer
 }
 
+func (s *BillingService) requireStripe() error {
+	if s.stripeClient == nil {
+		return status.Errorf(codes.Unimplemented, "not supported by %s", s.provider.Name())
+	}
+	return nil
+}
+
 func (s *BillingService) GetStripeCustomer(ctx context.Context, req *v1.GetStripeCustomerRequest) (*v1.GetStripeCustomerResponse, error) {
+	if err := s.requireStripe(); err != nil {
+		return nil, err
+	}
 
 	storeStripeCustomerAndRespond := func(ctx context.Context, cus *stripe_api.Customer, attributionID db.AttributionID) (*v1.GetStripeCustomerResponse, error) {
 		logger := log.WithField("stripe_customer_id", cus.ID).WithField("attribution_id", attributionID)
@@ -151,36 +166,44 @@ func (s *BillingService) CreateStripeCustomer(ctx context.Context, req *v1.Creat
 		return nil, status.Error(codes.InvalidArgument, "Invalid name specified")
 	}
 
-	customer, err := s.stripeClient.CreateCustomer(ctx, stripe.CreateCustomerParams{
-		AttributionID:        string(attributionID),
+	customer, err := s.provider.CreateCustomer(ctx, billing.CreateCustomerParams{
+		AttributionID:        attributionID,
 		Currency:             req.GetCurrency(),
 		Email:                req.GetEmail(),
 		Name:                 req.GetName(),
 		BillingCreatorUserID: req.GetBillingCreatorUserId(),
 	})
 	if err != nil {
-		log.WithError(err).Errorf("Failed to create stripe customer.")
-		return nil, status.Errorf(codes.Internal, "Failed to create stripe customer")
+		log.WithError(err).Errorf("Failed to create customer in %s.", s.provider.Name())
+		return nil, status.Errorf(codes.Internal, "Failed to create customer in %s", s.provider.Name())
 	}
 
-	err = db.CreateStripeCustomer(ctx, s.conn, db.StripeCustomer{
-		StripeCustomerID: customer.ID,
-		AttributionID:    attributionID,
-		CreationTime:     db.NewVarCharTime(time.Unix(customer.Created, 0)),
-		Currency:         req.GetCurrency(),
-	})
-	if err != nil {
-		log.WithField("attribution_id", attributionID).WithField("stripe_customer_id", customer.ID).WithError(err).Error("Failed to store Stripe Customer in the database.")
-		// We do not return an error to the caller here, as we did manage to create the stripe customer in Stripe and we can proceed with other flows
-		// The StripeCustomer will be backfilled in the DB on the next GetStripeCustomer call by doing a search.
+	if s.stripeClient != nil {
+		err = db.CreateStripeCustomer(ctx, s.conn, db.StripeCustomer{
+			StripeCustomerID: customer.ID,
+			AttributionID:    attributionID,
+			CreationTime:     db.NewVarCharTime(customer.CreatedAt),
+			Currency:         req.GetCurrency(),
+		})
+		if err != nil {
+			log.WithField("attribution_id", attributionID).WithField("stripe_customer_id", customer.ID).WithError(err).Error("Failed to store Stripe Customer in the database.")
+			// We do not return an error to the caller here, as we did manage to create the stripe customer in Stripe and we can proceed with other flows
+			// The StripeCustomer will be backfilled in the DB on the next GetStripeCustomer call by doing a search.
+		}
 	}
 
 	return &v1.CreateStripeCustomerResponse{
-		Customer: convertStripeCustomer(customer),
+		Customer: &v1.StripeCustomer{
+			Id:       customer.ID,
+			Currency: customer.Currency,
+		},
 	}, nil
 }
 
 func (s *BillingService) CreateHoldPaymentIntent(ctx context.Context, req *v1.CreateHoldPaymentIntentRequest) (*v1.CreateHoldPaymentIntentResponse, error) {
+	if err := s.requireStripe(); err != nil {
+		return nil, err
+	}
 	attributionID, err := db.ParseAttributionID(req.GetAttributionId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid attribution ID %s", attributionID)
@@ -216,6 +239,9 @@ func (s *BillingService) CreateHoldPaymentIntent(ctx context.Context, req *v1.Cr
 }
 
 func (s *BillingService) CreateStripeSubscription(ctx context.Context, req *v1.CreateStripeSubscriptionRequest) (*v1.CreateStripeSubscriptionResponse, error) {
+	if err := s.requireStripe(); err != nil {
+		return nil, err
+	}
 	attributionID, err := db.ParseAttributionID(req.GetAttributionId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid attribution ID %s", attributionID)
@@ -327,20 +353,20 @@ func (s *BillingService) ReconcileInvoices(ctx context.Context, in *v1.Reconcile
 		return nil, status.Errorf(codes.Internal, "Failed to reconcile invoices.")
 	}
 
-	stripeBalances, err := balancesForStripeCostCenters(ctx, s.ccManager, balances)
+	chargedBalances, err := balancesChargedBy(ctx, s.ccManager, s.provider, balances)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to identify stripe balances.")
+		return nil, status.Errorf(codes.Internal, "Failed to identify balances charged by %s.", s.provider.Name())
 	}
 
 	creditCentsByAttribution := map[db.AttributionID]int64{}
-	for _, balance := range stripeBalances {
+	for _, balance := range chargedBalances {
 		creditCentsByAttribution[balance.AttributionID] = int64(math.Ceil(balance.CreditCents.ToCredits()))
 	}
 
-	err = s.stripeClient.UpdateUsage(ctx, creditCentsByAttribution)
+	err = s.provider.UpdateUsage(ctx, creditCentsByAttribution)
 	if err != nil {
-		log.WithError(err).Errorf("Failed to udpate usage in stripe.")
-		return nil, status.Errorf(codes.Internal, "Failed to update usage in stripe")
+		log.WithError(err).Errorf("Failed to udpate usage in %s.", s.provider.Name())
+		return nil, status.Errorf(codes.Internal, "Failed to update usage in %s", s.provider.Name())
 	}
 
 	return &v1.ReconcileInvoicesResponse{}, nil
@@ -353,12 +379,19 @@ func (s *BillingService) FinalizeInvoice(ctx context.Context, in *v1.FinalizeInv
 		return nil, status.Errorf(codes.InvalidArgument, "Missing InvoiceID")
 	}
 
-	invoice, err := s.stripeClient.GetInvoiceWithCustomer(ctx, in.GetInvoiceId())
-	if err != nil {
-		logger.WithError(err).Error("Failed to retrieve invoice from Stripe.")
+	invoice, err := s.provider.FinalizeInvoice(ctx, in.GetInvoiceId())
+	if errors.Is(err, billing.ErrInvoiceNotFound) {
+		logger.WithError(err).Errorf("Failed to retrieve invoice from %s.", s.provider.Name())
 		return nil, status.Errorf(codes.NotFound, "Failed to get invoice with ID %s: %s", in.GetInvoiceId(), err.Error())
 	}
-	usage, err := InternalComputeInvoiceUsage(ctx, invoice)
+	if errors.Is(err, billing.ErrInvoiceFinalized) {
+		return nil, status.Errorf(codes.AlreadyExists, "Invoice with ID %s has been finalized already", in.GetInvoiceId())
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("Failed to finalize invoice in %s.", s.provider.Name())
+		return nil, status.Errorf(codes.Internal, "Failed to finalize invoice with ID %s: %s", in.GetInvoiceId(), err.Error())
+	}
+	usage, err := InternalComputeInvoiceUsage(ctx, s.provider.Name(), invoice)
 	if err != nil {
 		return nil, err
 	}
@@ -375,54 +408,37 @@ func (s *BillingService) FinalizeInvoice(ctx context.Context, in *v1.FinalizeInv
 		logger.WithError(err).Errorf("Failed to increment billing cycle.")
 	}
 
-	// update stripe with current usage immediately, so that invoices created between now and the next reconcile are correct.
+	// update the provider with current usage immediately, so that invoices created between now and the next reconcile are correct.
 	newBalance, err := db.GetBalance(ctx, s.conn, usage.AttributionID)
 	if err != nil {
 		// we are just logging at this point, so that we don't see the event again as the usage has been recorded.
 		logger.WithError(err).Errorf("Failed to compute new balance.")
 		return &v1.FinalizeInvoiceResponse{}, nil
 	}
-	err = s.stripeClient.UpdateUsage(ctx, map[db.AttributionID]int64{
+	err = s.provider.UpdateUsage(ctx, map[db.AttributionID]int64{
 		usage.AttributionID: int64(math.Ceil(newBalance.ToCredits())),
 	})
 	if err != nil {
 		// we are just logging at this point, so that we don't see the event again as the usage has been recorded.
-		log.WithError(err).Errorf("Failed to udpate usage in stripe after receiving invoive.finalized.")
+		log.WithError(err).Errorf("Failed to udpate usage in %s after the invoice was finalized.", s.provider.Name())
 	}
 	return &v1.FinalizeInvoiceResponse{}, nil
 }
 
-func InternalComputeInvoiceUsage(ctx context.Context, invoice *stripe_api.Invoice) (db.Usage, error) {
-	logger := log.WithField("invoice_id", invoice.ID)
-	attributionID, err := stripe.GetAttributionID(ctx, invoice.Customer)
-	if err != nil {
-		return db.Usage{}, err
-	}
-	logger = logger.WithField("attributionID", attributionID)
-	finalizedAt := time.Unix(invoice.StatusTransitions.FinalizedAt, 0)
-
-	logger = logger.
-		WithField("attribution_id", attributionID).
-		WithField("invoice_finalized_at", finalizedAt)
-
-	if invoice.Lines == nil || len(invoice.Lines.Data) == 0 {
-		logger.Errorf("Invoice %s did not contain any lines  so we cannot extract quantity to reflect it in usage.", invoice.ID)
-		return db.Usage{}, status.Errorf(codes.Internal, "Invoice did not contain any lines.")
-	}
-
-	lines := invoice.Lines.Data
-	var creditsOnInvoice int64
-	for _, line := range lines {
-		creditsOnInvoice += line.Quantity
+// InternalComputeInvoiceUsage produces the usage record which balances out the credits charged for on the invoice.
+func InternalComputeInvoiceUsage(ctx context.Context, providerName string, invoice *billing.Invoice) (db.Usage, error) {
+	if invoice.AttributionID == "" {
+		log.WithField("invoice_id", invoice.ID).Error("Invoice has no attribution ID.")
+		return db.Usage{}, status.Errorf(codes.Internal, "Invoice has no attribution ID.")
 	}
 
 	return db.Usage{
 		ID:            uuid.New(),
-		AttributionID: attributionID,
-		Description:   fmt.Sprintf("Invoice %s finalized in Stripe", invoice.ID),
+		AttributionID: invoice.AttributionID,
+		Description:   fmt.Sprintf("Invoice %s finalized in %s", invoice.ID, providerName),
 		// Apply negative value of credits to reduce accrued credit usage
-		CreditCents:   db.NewCreditCents(float64(-creditsOnInvoice)),
-		EffectiveTime: db.NewVarCharTime(finalizedAt),
+		CreditCents:   db.NewCreditCents(float64(-invoice.Credits)),
+		EffectiveTime: db.NewVarCharTime(invoice.FinalizedAt),
 		Kind:          db.InvoiceUsageKind,
 		Draft:         false,
 		Metadata:      nil,
@@ -430,6 +446,9 @@ func InternalComputeInvoiceUsage(ctx context.Context, invoice *stripe_api.Invoic
 }
 
 func (s *BillingService) CancelSubscription(ctx context.Context, in *v1.CancelSubscriptionRequest) (*v1.CancelSubscriptionResponse, error) {
+	if err := s.requireStripe(); err != nil {
+		return nil, err
+	}
 	logger := log.WithField("subscription_id", in.GetSubscriptionId())
 	logger.Infof("Subscription ended. Setting cost center back to free.")
 	if in.GetSubscriptionId() == "" {
@@ -466,30 +485,14 @@ func (s *BillingService) OnChargeDispute(ctx context.Context, req *v1.OnChargeDi
 
 	logger := log.WithContext(ctx).WithField("disputeId", req.DisputeId)
 
-	dispute, err := s.stripeClient.GetDispute(ctx, req.DisputeId)
+	dispute, err := s.provider.GetDispute(ctx, req.DisputeId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve dispute ID %s from stripe", req.DisputeId)
-	}
-
-	if dispute.PaymentIntent == nil || dispute.PaymentIntent.Customer == nil {
-		return nil, status.Errorf(codes.Internal, "dispute did not contain customer of payment intent in expanded fields")
+		logger.WithError(err).Errorf("Failed to retrieve dispute from %s.", s.provider.Name())
+		return nil, status.Errorf(codes.Internal, "failed to retrieve dispute ID %s from %s", req.DisputeId, s.provider.Name())
 	}
 
-	customer := dispute.PaymentIntent.Customer
-	logger = logger.WithField("customerId", customer.ID)
-
-	attributionIDValue, ok := customer.Metadata[stripe.AttributionIDMetadataKey]
-	if !ok {
-		return nil, status.Errorf(codes.Internal, "Customer %s object did not contain attribution ID in metadata", customer.ID)
-	}
-
-	logger = logger.WithField("attributionId", attributionIDValue)
-
-	attributionID, err := db.ParseAttributionID(attributionIDValue)
-	if err != nil {
-		log.WithError(err).Errorf("Failed to parse attribution ID from customer metadata.")
-		return nil, status.Errorf(codes.Internal, "failed to parse attribution ID from customer metadata")
-	}
+	attributionID := dispute.AttributionID
+	logger = logger.WithField("attributionId", attributionID)
 
 	var userIDsToBlock []string
 	entity, id := attributionID.Values()
@@ -515,7 +518,7 @@ func (s *BillingService) OnChargeDispute(ctx context.Context, req *v1.OnChargeDi
 		}
 
 	default:
-		return nil, status.Errorf(codes.Internal, "unknown attribution entity for %s", attributionIDValue)
+		return nil, status.Errorf(codes.Internal, "unknown attribution entity for %s", attributionID)
 	}
 
 	logger = logger.WithField("teamOwners", userIDsToBlock)
@@ -525,7 +528,7 @@ func (s *BillingService) OnChargeDispute(ctx context.Context, req *v1.OnChargeDi
 	for _, userToBlock := range userIDsToBlock {
 		_, err := s.userService.BlockUser(ctx, connect.NewRequest(&experimental_v1.BlockUserRequest{
 			UserId: userToBlock,
-			Reason: fmt.Sprintf("User has created a Stripe dispute ID: %s", req.GetDisputeId()),
+			Reason: fmt.Sprintf("User has created a %s dispute ID: %s", s.provider.Name(), req.GetDisputeId()),
 		}))
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to block user %s: %w", userToBlock, err))
@@ -580,6 +583,9 @@ func (s *BillingService) getPriceId(ctx context.Context, attributionId string) s
 }
 
 func (s *BillingService) GetPriceInformation(ctx context.Context, req *v1.GetPriceInformationRequest) (*v1.GetPriceInformationResponse, error) {
+	if err := s.requireStripe(); err != nil {
+		return nil, err
+	}
 	_, err := db.ParseAttributionID(req.GetAttributionId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid attribution ID %s", req.GetAttributionId())
@@ -616,17 +622,17 @@ func (s *BillingService) storeStripeCustomer(ctx context.Context, cus *stripe_ap
 	}, nil
 }
 
-func balancesForStripeCostCenters(ctx context.Context, cm *db.CostCenterManager, balances []db.Balance) ([]db.Balance, error) {
+func balancesChargedBy(ctx context.Context, cm *db.CostCenterManager, provider billing.Provider, balances []db.Balance) ([]db.Balance, error) {
 	var result []db.Balance
 	for _, balance := range balances {
-		// filter out balances for non-stripe attribution IDs
+		// filter out balances for attribution IDs the provider does not charge
 		costCenter, err := cm.GetOrCreateCostCenter(ctx, balance.AttributionID)
 		if err != nil {
 			return nil, err
 		}
 
-		// We only update Stripe usage when the AttributionID is billed against Stripe (determined through CostCenter)
-		if costCenter.BillingStrategy != db.CostCenter_Stripe {
+		// We only update usage with the provider when it charges the AttributionID (determined through CostCenter)
+		if !provider.Charges(costCenter) {
 			continue
 		}
 
@@ -35,6 +35,7 @@ func TestBillingService_OnChargeDispute(t *testing.T) {
 
 	stubUserService := &StubUserService{}
 	svc := &BillingService{
+		provider:     stripe.NewBillingProvider(stripeClient),
 		stripeClient: stripeClient,
 		teamsService: &StubTeamsService{},
 		userService:  stubUserService,
@@ -182,16 +183,18 @@ func TestBalancesForStripeCostCenters(t *testing.T) {
 		},
 	}
 
-	stripeBalances, err := balancesForStripeCostCenters(context.Background(), db.NewCostCenterManager(dbconn, db.DefaultSpendingLimit{}), balances)
+	stripeBalances, err := balancesChargedBy(context.Background(), db.NewCostCenterManager(dbconn, db.DefaultSpendingLimit{}), stripe.NewBillingProvider(nil), balances)
 	require.NoError(t, err)
 	require.Len(t, stripeBalances, 1)
 	require.Equal(t, stripeBalances[0].AttributionID, attributionIDForStripe)
 }
 
 func TestFinalizeInvoiceForIndividual(t *testing.T) {
-	invoice := stripe_api.Invoice{}
-	require.NoError(t, json.Unmarshal([]byte(IndiInvoiceTestData), &invoice))
-	usage, err := InternalComputeInvoiceUsage(context.Background(), &invoice)
+	stripeInvoice := stripe_api.Invoice{}
+	require.NoError(t, json.Unmarshal([]byte(IndiInvoiceTestData), &stripeInvoice))
+	invoice, err := stripe.ConvertInvoice(context.Background(), &stripeInvoice)
+	require.NoError(t, err)
+	usage, err := InternalComputeInvoiceUsage(context.Background(), "Stripe", invoice)
 	require.NoError(t, err)
 	require.Equal(t, usage.CreditCents, db.CreditCents(-103100))
 }
@@ -20,16 +20,62 @@ var (
 )
 
 func NewWorkspacePricer(creditMinutesByWorkspaceClass map[string]float64) (*WorkspacePricer, error) {
-	return &WorkspacePricer{creditMinutesByWorkspaceClass: creditMinutesByWorkspaceClass}, nil
+	return NewWorkspacePricerWithRules(creditMinutesByWorkspaceClass, PricingRules{})
+}
+
+// NewWorkspacePricerWithRules creates a pricer which prices usage with the version of the rules in effect when the usage was incurred.
+// The flat credits per minute by workspace class price usage which no rule matches.
+func NewWorkspacePricerWithRules(creditMinutesByWorkspaceClass map[string]float64, rules PricingRules) (*WorkspacePricer, error) {
+	versions, err := compilePricingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkspacePricer{
+		creditMinutesByWorkspaceClass: creditMinutesByWorkspaceClass,
+		versions:                      versions,
+	}, nil
 }
 
 type WorkspacePricer struct {
 	creditMinutesByWorkspaceClass map[string]float64
+	versions                      []pricingVersion
 }
 
 func (p *WorkspacePricer) CreditsUsedByInstance(instance *db.WorkspaceInstanceForUsage, stopTimeIfStillRunning time.Time) float64 {
+	credits, _ := p.PriceInstance(instance, stopTimeIfStillRunning)
+	return credits
+}
+
+// PriceInstance prices the runtime of the instance with the rules in effect when it started, and reports which rule produced the charge
+func (p *WorkspacePricer) PriceInstance(instance *db.WorkspaceInstanceForUsage, stopTimeIfStillRunning time.Time) (float64, Charge) {
 	runtime := instance.WorkspaceRuntimeSeconds(stopTimeIfStillRunning)
-	return p.Credits(instance.WorkspaceClass, runtime)
+	start := instance.StartedTime.Time()
+
+	if version := p.versionAt(start); version != nil {
+		for i := range version.workspaces {
+			rule := &version.workspaces[i]
+			if !rule.matches(instance) {
+				continue
+			}
+			credits, discounts := rule.credits(start, start.Add(time.Duration(runtime)*time.Second))
+			return credits, Charge{Version: version.id, Rule: rule.ID, Discounts: discounts}
+		}
+	}
+
+	price, rule := p.classPrice(instance.WorkspaceClass)
+	return price * float64(runtime) / 60, Charge{Version: defaultPricingVersion, Rule: rule}
+}
+
+// StorageCredits prices the storage of retained workspace backups with the rules in effect at the given time
+func (p *WorkspacePricer) StorageCredits(attributionID db.AttributionID, gbHours float64, at time.Time) (float64, Charge) {
+	if version := p.versionAt(at); version != nil {
+		for _, rule := range version.storage {
+			if rule.matches(attributionID) {
+				return rule.CreditsPerGBHour * gbHours, Charge{Version: version.id, Rule: rule.ID}
+			}
+		}
+	}
+	return 0, Charge{Version: defaultPricingVersion, Rule: fallbackPricingRule}
 }
 
 func (p *WorkspacePricer) Credits(workspaceClass string, runtimeInSeconds int64) float64 {
@@ -37,10 +83,26 @@ func (p *WorkspacePricer) Credits(workspaceClass string, runtimeInSeconds int64)
 	return p.CreditsPerMinuteForClass(workspaceClass) * inMinutes
 }
 
+// CreditsPerMinuteForClass returns the flat price of the workspace class, which prices usage no pricing rule matches
 func (p *WorkspacePricer) CreditsPerMinuteForClass(workspaceClass string) float64 {
+	price, _ := p.classPrice(workspaceClass)
+	return price
+}
+
+func (p *WorkspacePricer) classPrice(workspaceClass string) (float64, string) {
 	if creditsForClass, ok := p.creditMinutesByWorkspaceClass[workspaceClass]; ok {
-		return creditsForClass
+		return creditsForClass, workspaceClass
 	}
 	log.Errorf("No credit minutes configured for workspace class %q - using default price of %v credits per minute", workspaceClass, defaultPrice)
-	return defaultPrice
+	return defaultPrice, fallbackPricingRule
+}
+
+// versionAt returns the version of the pricing rules in effect at the given time, or nil before the first version
+func (p *WorkspacePricer) versionAt(t time.Time) *pricingVersion {
+	for i := len(p.versions) - 1; i >= 0; i-- {
+		if !t.Before(p.versions[i].effectiveFrom) {
+			return &p.versions[i]
+		}
+	}
+	return nil
 }
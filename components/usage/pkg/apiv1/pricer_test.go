@@ -5,12 +5,14 @@
 package apiv1
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -126,3 +128,177 @@ func TestWorkspaceInstanceForUsage_WorkspaceRuntimeSeconds(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspacePricer_PricingRules(t *testing.T) {
+	org := uuid.New().String()
+	pricer, err := NewWorkspacePricerWithRules(map[string]float64{
+		"g1-standard": 1,
+	}, PricingRules{
+		Versions: []PricingRulesVersion{
+			{
+				ID:            "2023-06",
+				EffectiveFrom: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+				Workspaces: []WorkspacePricingRule{
+					{ID: "org-override", OrganizationID: org, CreditsPerMinute: 0.25},
+					{ID: "prebuilds", WorkspaceType: db.WorkspaceType_Prebuild, CreditsPerMinute: 0.5},
+					{ID: "standard", WorkspaceClass: "g1-standard", CreditsPerMinute: 2, Discounts: []TimeOfDayDiscount{
+						{ID: "nights", From: "22:00", To: "06:00", Location: "Europe/Berlin", Percent: 50},
+						{ID: "weekends", From: "00:00", To: "00:00", Weekdays: []string{"Saturday", "Sunday"}, Location: "Europe/Berlin", Percent: 100},
+					}},
+				},
+				Storage: []StoragePricingRule{
+					{ID: "backups", CreditsPerGBHour: 0.01},
+				},
+			},
+			{
+				ID:            "2023-07",
+				EffectiveFrom: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				Workspaces: []WorkspacePricingRule{
+					{ID: "standard", WorkspaceClass: "g1-standard", CreditsPerMinute: 3},
+				},
+			},
+		},
+	})
+	// Europe/Berlin is only available with time zone data
+	if err != nil && strings.Contains(err.Error(), "unknown time zone") {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+
+	instance := func(start time.Time, d time.Duration, mod func(i *db.WorkspaceInstanceForUsage)) *db.WorkspaceInstanceForUsage {
+		i := &db.WorkspaceInstanceForUsage{
+			WorkspaceClass:     "g1-standard",
+			Type:               db.WorkspaceType_Regular,
+			UsageAttributionID: db.NewTeamAttributionID(uuid.New().String()),
+			StartedTime:        db.NewVarCharTime(start),
+			StoppingTime:       db.NewVarCharTime(start.Add(d)),
+		}
+		if mod != nil {
+			mod(i)
+		}
+		return i
+	}
+	// Thursday, 12:00 in Berlin
+	june := time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	for _, s := range []struct {
+		Name            string
+		Instance        *db.WorkspaceInstanceForUsage
+		ExpectedCredits float64
+		ExpectedCharge  Charge
+	}{
+		{
+			Name:            "usage before the first version uses flat prices",
+			Instance:        instance(time.Date(2023, 5, 15, 10, 0, 0, 0, time.UTC), 10*time.Minute, nil),
+			ExpectedCredits: 10,
+			ExpectedCharge:  Charge{Version: defaultPricingVersion, Rule: "g1-standard"},
+		},
+		{
+			Name:            "regular workspace",
+			Instance:        instance(june, 10*time.Minute, nil),
+			ExpectedCredits: 20,
+			ExpectedCharge:  Charge{Version: "2023-06", Rule: "standard"},
+		},
+		{
+			Name:            "prebuild",
+			Instance:        instance(june, 10*time.Minute, func(i *db.WorkspaceInstanceForUsage) { i.Type = db.WorkspaceType_Prebuild }),
+			ExpectedCredits: 5,
+			ExpectedCharge:  Charge{Version: "2023-06", Rule: "prebuilds"},
+		},
+		{
+			Name: "organization override",
+			Instance: instance(june, 10*time.Minute, func(i *db.WorkspaceInstanceForUsage) {
+				i.UsageAttributionID = db.NewTeamAttributionID(org)
+			}),
+			ExpectedCredits: 2.5,
+			ExpectedCharge:  Charge{Version: "2023-06", Rule: "org-override"},
+		},
+		{
+			Name:            "workspace class without rule uses flat price",
+			Instance:        instance(june, 10*time.Minute, func(i *db.WorkspaceInstanceForUsage) { i.WorkspaceClass = "g1-large" }),
+			ExpectedCredits: 10 * defaultPrice,
+			ExpectedCharge:  Charge{Version: defaultPricingVersion, Rule: fallbackPricingRule},
+		},
+		{
+			// 21:30 to 22:30 in Berlin, half of which at night
+			Name:            "discount applies to the runtime within its window",
+			Instance:        instance(time.Date(2023, 6, 15, 19, 30, 0, 0, time.UTC), time.Hour, nil),
+			ExpectedCredits: 30*2 + 30*1,
+			ExpectedCharge:  Charge{Version: "2023-06", Rule: "standard", Discounts: []string{"nights"}},
+		},
+		{
+			// Friday 23:00 to Saturday 01:00 in Berlin, where the weekend trumps the night
+			Name:            "largest discount applies",
+			Instance:        instance(time.Date(2023, 6, 16, 21, 0, 0, 0, time.UTC), 2*time.Hour, nil),
+			ExpectedCredits: 60 * 1,
+			ExpectedCharge:  Charge{Version: "2023-06", Rule: "standard", Discounts: []string{"nights", "weekends"}},
+		},
+		{
+			Name: "usage is priced with the version in effect when it started",
+			Instance: instance(time.Date(2023, 6, 30, 23, 50, 0, 0, time.UTC), 20*time.Minute, func(i *db.WorkspaceInstanceForUsage) {
+				i.Type = db.WorkspaceType_Prebuild
+			}),
+			ExpectedCredits: 10,
+			ExpectedCharge:  Charge{Version: "2023-06", Rule: "prebuilds"},
+		},
+		{
+			Name:            "later version",
+			Instance:        instance(time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC), 10*time.Minute, nil),
+			ExpectedCredits: 30,
+			ExpectedCharge:  Charge{Version: "2023-07", Rule: "standard"},
+		},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			credits, charge := pricer.PriceInstance(s.Instance, time.Time{})
+			require.True(t, cmp.Equal(s.ExpectedCredits, credits, cmpopts.EquateApprox(0, 0.0000001)), "expected %v credits, got %v", s.ExpectedCredits, credits)
+			require.Equal(t, s.ExpectedCharge, charge)
+		})
+	}
+
+	t.Run("storage", func(t *testing.T) {
+		credits, charge := pricer.StorageCredits(db.NewTeamAttributionID(org), 100, june)
+		require.InDelta(t, 1, credits, 0.0000001)
+		require.Equal(t, Charge{Version: "2023-06", Rule: "backups"}, charge)
+
+		credits, charge = pricer.StorageCredits(db.NewTeamAttributionID(org), 100, time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC))
+		require.Zero(t, credits)
+		require.Equal(t, Charge{Version: defaultPricingVersion, Rule: fallbackPricingRule}, charge)
+	})
+
+	// pricing rules depend on the instance, hence only the flat price is available by workspace class
+	require.Equal(t, float64(1), pricer.CreditsPerMinuteForClass("g1-standard"))
+}
+
+func TestNewWorkspacePricerWithRules_Invalid(t *testing.T) {
+	effectiveFrom := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	for name, version := range map[string]PricingRulesVersion{
+		"missing version id":     {EffectiveFrom: effectiveFrom},
+		"reserved version id":    {ID: defaultPricingVersion, EffectiveFrom: effectiveFrom},
+		"missing rule id":        {ID: "v1", EffectiveFrom: effectiveFrom, Workspaces: []WorkspacePricingRule{{CreditsPerMinute: 1}}},
+		"negative price":         {ID: "v1", EffectiveFrom: effectiveFrom, Workspaces: []WorkspacePricingRule{{ID: "r", CreditsPerMinute: -1}}},
+		"unknown workspace type": {ID: "v1", EffectiveFrom: effectiveFrom, Workspaces: []WorkspacePricingRule{{ID: "r", WorkspaceType: "foo"}}},
+		"invalid time of day": {ID: "v1", EffectiveFrom: effectiveFrom, Workspaces: []WorkspacePricingRule{{ID: "r", Discounts: []TimeOfDayDiscount{
+			{ID: "d", From: "25:00", To: "06:00", Percent: 10},
+		}}}},
+		"invalid percent": {ID: "v1", EffectiveFrom: effectiveFrom, Workspaces: []WorkspacePricingRule{{ID: "r", Discounts: []TimeOfDayDiscount{
+			{ID: "d", From: "22:00", To: "06:00", Percent: 110},
+		}}}},
+		"unknown weekday": {ID: "v1", EffectiveFrom: effectiveFrom, Workspaces: []WorkspacePricingRule{{ID: "r", Discounts: []TimeOfDayDiscount{
+			{ID: "d", From: "22:00", To: "06:00", Weekdays: []string{"Caturday"}, Percent: 10},
+		}}}},
+		"duplicate storage rule": {ID: "v1", EffectiveFrom: effectiveFrom, Storage: []StoragePricingRule{{ID: "s"}, {ID: "s"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewWorkspacePricerWithRules(nil, PricingRules{Versions: []PricingRulesVersion{version}})
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("versions out of order", func(t *testing.T) {
+		_, err := NewWorkspacePricerWithRules(nil, PricingRules{Versions: []PricingRulesVersion{
+			{ID: "v2", EffectiveFrom: effectiveFrom},
+			{ID: "v1", EffectiveFrom: effectiveFrom.Add(-time.Hour)},
+		}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,354 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+const (
+	// defaultPricingVersion identifies charges priced with the flat credits per minute by workspace class
+	defaultPricingVersion = "default"
+	// fallbackPricingRule identifies charges for which no price was configured at all
+	fallbackPricingRule = "fallback"
+)
+
+// PricingRules are versions of pricing rules. Usage is always priced with the version which was in effect when it was incurred,
+// such that re-pricing historical usage yields the same charges even after prices changed.
+type PricingRules struct {
+	Versions []PricingRulesVersion `json:"versions"`
+}
+
+// PricingRulesVersion is a set of pricing rules which takes effect at a point in time and stays in effect until the next version does.
+// Usage incurred before the first version is priced with the flat credits per minute by workspace class.
+type PricingRulesVersion struct {
+	// ID identifies the version in the metadata of usage entries, e.g. "2023-07"
+	ID string `json:"id"`
+
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+
+	// Workspaces price workspace instances. The first matching rule applies; instances matching none of them
+	// are priced with the flat credits per minute of their workspace class.
+	Workspaces []WorkspacePricingRule `json:"workspaces"`
+
+	// Storage prices retained workspace backups. The first matching rule applies; storage matching none of them is free.
+	Storage []StoragePricingRule `json:"storage,omitempty"`
+}
+
+// WorkspacePricingRule prices the runtime of workspace instances. Empty matchers match all instances.
+type WorkspacePricingRule struct {
+	// ID identifies the rule within its version in the metadata of usage entries
+	ID string `json:"id"`
+
+	WorkspaceClass string           `json:"workspaceClass,omitempty"`
+	WorkspaceType  db.WorkspaceType `json:"workspaceType,omitempty"`
+	OrganizationID string           `json:"organizationId,omitempty"`
+
+	CreditsPerMinute float64 `json:"creditsPerMinute"`
+
+	// Discounts reduce the price for the runtime within their time windows. Where windows overlap, the largest discount applies.
+	Discounts []TimeOfDayDiscount `json:"discounts,omitempty"`
+}
+
+// TimeOfDayDiscount reduces the price of a rule during a daily time window
+type TimeOfDayDiscount struct {
+	ID string `json:"id"`
+
+	// From and To are the local times of day ("15:04") the window spans. Windows with To not after From end on the next day,
+	// i.e. "00:00" to "00:00" spans the whole day.
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Weekdays restricts the discount to windows starting on these days, e.g. ["Saturday", "Sunday"]. Empty means every day.
+	Weekdays []string `json:"weekdays,omitempty"`
+
+	// Location is the IANA time zone From and To are in. Defaults to UTC.
+	Location string `json:"location,omitempty"`
+
+	// Percent of the price which is waived within the window, between 0 and 100
+	Percent float64 `json:"percent"`
+}
+
+// StoragePricingRule prices the storage of retained workspace backups. An empty matcher matches all storage.
+type StoragePricingRule struct {
+	// ID identifies the rule within its version in the metadata of usage entries
+	ID string `json:"id"`
+
+	OrganizationID string `json:"organizationId,omitempty"`
+
+	CreditsPerGBHour float64 `json:"creditsPerGBHour"`
+}
+
+// Charge records which pricing rule produced a charge
+type Charge struct {
+	Version   string
+	Rule      string
+	Discounts []string
+}
+
+type pricingVersion struct {
+	id            string
+	effectiveFrom time.Time
+	workspaces    []workspacePricingRule
+	storage       []StoragePricingRule
+}
+
+type workspacePricingRule struct {
+	WorkspacePricingRule
+	discounts []timeOfDayDiscount
+}
+
+type timeOfDayDiscount struct {
+	id       string
+	from, to time.Duration
+	weekdays map[time.Weekday]bool
+	location *time.Location
+	percent  float64
+}
+
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+func compilePricingRules(rules PricingRules) ([]pricingVersion, error) {
+	var versions []pricingVersion
+	seen := map[string]bool{defaultPricingVersion: true}
+	for i, v := range rules.Versions {
+		if v.ID == "" {
+			return nil, fmt.Errorf("pricing rules version %d has no id", i)
+		}
+		if seen[v.ID] {
+			return nil, fmt.Errorf("pricing rules version id %q is reserved or not unique", v.ID)
+		}
+		seen[v.ID] = true
+		if i > 0 && !v.EffectiveFrom.After(rules.Versions[i-1].EffectiveFrom) {
+			return nil, fmt.Errorf("pricing rules version %q must take effect after version %q", v.ID, rules.Versions[i-1].ID)
+		}
+
+		version, err := compilePricingVersion(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pricing rules version %q: %w", v.ID, err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func compilePricingVersion(v PricingRulesVersion) (pricingVersion, error) {
+	version := pricingVersion{
+		id:            v.ID,
+		effectiveFrom: v.EffectiveFrom,
+		storage:       v.Storage,
+	}
+
+	ruleIDs := make(map[string]bool)
+	for _, r := range v.Workspaces {
+		if r.ID == "" || ruleIDs[r.ID] {
+			return version, fmt.Errorf("workspace rule id %q is empty or not unique", r.ID)
+		}
+		ruleIDs[r.ID] = true
+		if r.CreditsPerMinute < 0 {
+			return version, fmt.Errorf("workspace rule %q has a negative price", r.ID)
+		}
+		switch r.WorkspaceType {
+		case "", db.WorkspaceType_Regular, db.WorkspaceType_Prebuild, db.WorkspaceType_Probe:
+		default:
+			return version, fmt.Errorf("workspace rule %q has unknown workspace type %q", r.ID, r.WorkspaceType)
+		}
+
+		rule := workspacePricingRule{WorkspacePricingRule: r}
+		for _, d := range r.Discounts {
+			discount, err := compileTimeOfDayDiscount(d)
+			if err != nil {
+				return version, fmt.Errorf("workspace rule %q: %w", r.ID, err)
+			}
+			rule.discounts = append(rule.discounts, discount)
+		}
+		version.workspaces = append(version.workspaces, rule)
+	}
+
+	ruleIDs = make(map[string]bool)
+	for _, r := range v.Storage {
+		if r.ID == "" || ruleIDs[r.ID] {
+			return version, fmt.Errorf("storage rule id %q is empty or not unique", r.ID)
+		}
+		ruleIDs[r.ID] = true
+		if r.CreditsPerGBHour < 0 {
+			return version, fmt.Errorf("storage rule %q has a negative price", r.ID)
+		}
+	}
+
+	return version, nil
+}
+
+func compileTimeOfDayDiscount(d TimeOfDayDiscount) (timeOfDayDiscount, error) {
+	discount := timeOfDayDiscount{
+		id:       d.ID,
+		location: time.UTC,
+		percent:  d.Percent,
+	}
+	if d.ID == "" {
+		return discount, fmt.Errorf("discount has no id")
+	}
+	if d.Percent <= 0 || d.Percent > 100 {
+		return discount, fmt.Errorf("discount %q must be between 0 and 100 percent, got %v", d.ID, d.Percent)
+	}
+
+	var err error
+	discount.from, err = parseTimeOfDay(d.From)
+	if err != nil {
+		return discount, fmt.Errorf("discount %q: %w", d.ID, err)
+	}
+	discount.to, err = parseTimeOfDay(d.To)
+	if err != nil {
+		return discount, fmt.Errorf("discount %q: %w", d.ID, err)
+	}
+
+	if d.Location != "" {
+		discount.location, err = time.LoadLocation(d.Location)
+		if err != nil {
+			return discount, fmt.Errorf("discount %q: %w", d.ID, err)
+		}
+	}
+
+	if len(d.Weekdays) > 0 {
+		discount.weekdays = make(map[time.Weekday]bool, len(d.Weekdays))
+		for _, name := range d.Weekdays {
+			day, ok := weekdays[name]
+			if !ok {
+				return discount, fmt.Errorf("discount %q has unknown weekday %q", d.ID, name)
+			}
+			discount.weekdays[day] = true
+		}
+	}
+
+	return discount, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected format 15:04", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (r *workspacePricingRule) matches(instance *db.WorkspaceInstanceForUsage) bool {
+	if r.WorkspaceClass != "" && r.WorkspaceClass != instance.WorkspaceClass {
+		return false
+	}
+	if r.WorkspaceType != "" && r.WorkspaceType != instance.Type {
+		return false
+	}
+	if r.OrganizationID != "" && db.NewTeamAttributionID(r.OrganizationID) != instance.UsageAttributionID {
+		return false
+	}
+	return true
+}
+
+func (r *StoragePricingRule) matches(attributionID db.AttributionID) bool {
+	return r.OrganizationID == "" || db.NewTeamAttributionID(r.OrganizationID) == attributionID
+}
+
+// credits prices the runtime between start and stop, applying the largest discount in effect at any point in time
+func (r *workspacePricingRule) credits(start, stop time.Time) (float64, []string) {
+	if len(r.discounts) == 0 || !stop.After(start) {
+		return r.CreditsPerMinute * stop.Sub(start).Minutes(), nil
+	}
+
+	type window struct {
+		start, end time.Time
+		discount   *timeOfDayDiscount
+	}
+	var (
+		windows    []window
+		boundaries = []time.Time{start, stop}
+	)
+	for i := range r.discounts {
+		d := &r.discounts[i]
+		for _, w := range d.windows(start, stop) {
+			windows = append(windows, window{start: w[0], end: w[1], discount: d})
+			boundaries = append(boundaries, w[0], w[1])
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var (
+		credits   float64
+		applied   []string
+		isApplied = make(map[string]bool)
+	)
+	for i := 1; i < len(boundaries); i++ {
+		from, to := boundaries[i-1], boundaries[i]
+		if !to.After(from) {
+			continue
+		}
+
+		var best *timeOfDayDiscount
+		for _, w := range windows {
+			if !from.Before(w.start) && from.Before(w.end) && (best == nil || w.discount.percent > best.percent) {
+				best = w.discount
+			}
+		}
+
+		price := r.CreditsPerMinute
+		if best != nil {
+			price *= 1 - best.percent/100
+			if !isApplied[best.id] {
+				isApplied[best.id] = true
+				applied = append(applied, best.id)
+			}
+		}
+		credits += price * to.Sub(from).Minutes()
+	}
+	return credits, applied
+}
+
+// windows returns the time windows of the discount which overlap with start and stop, clipped to both
+func (d *timeOfDayDiscount) windows(start, stop time.Time) [][2]time.Time {
+	var result [][2]time.Time
+
+	// windows spanning midnight may have started the day before
+	first := start.In(d.location).AddDate(0, 0, -1)
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, d.location)
+	for ; day.Before(stop); day = day.AddDate(0, 0, 1) {
+		if d.weekdays != nil && !d.weekdays[day.Weekday()] {
+			continue
+		}
+
+		ws := atTimeOfDay(day, d.from)
+		end := day
+		if d.to <= d.from {
+			end = day.AddDate(0, 0, 1)
+		}
+		we := atTimeOfDay(end, d.to)
+
+		if ws.Before(start) {
+			ws = start
+		}
+		if we.After(stop) {
+			we = stop
+		}
+		if ws.Before(we) {
+			result = append(result, [2]time.Time{ws, we})
+		}
+	}
+	return result
+}
+
+// atTimeOfDay returns the wall clock time of day on the given day, which is robust against daylight saving time changes
+func atTimeOfDay(day time.Time, timeOfDay time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(timeOfDay/time.Hour), int(timeOfDay%time.Hour/time.Minute), 0, 0, day.Location())
+}
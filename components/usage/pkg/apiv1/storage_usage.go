@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bytesPerGB is the size of a GB as object storage providers price it
+const bytesPerGB = 1 << 30
+
+// storageUsageNamespace derives the IDs of storage usage entries from the workspace and the charged period,
+// such that reporting the same period again doesn't charge the storage twice.
+var storageUsageNamespace = uuid.MustParse("5b6a2f0e-7c1d-4c36-9a59-1f4c3e8d2a71")
+
+const usageDescriptionForStorage = "Storage of workspace backups."
+
+func (s *UsageService) ReportStorageUsage(ctx context.Context, req *v1.ReportStorageUsageRequest) (*v1.ReportStorageUsageResponse, error) {
+	from := req.GetFrom().AsTime()
+	to := req.GetTo().AsTime()
+
+	logger := log.
+		WithField("from", from).
+		WithField("to", to)
+
+	if !to.After(from) {
+		return nil, status.Errorf(codes.InvalidArgument, "To must be after From")
+	}
+
+	ids := make([]string, 0, len(req.GetWorkspaces()))
+	for _, ws := range req.GetWorkspaces() {
+		ids = append(ids, ws.GetWorkspaceId())
+	}
+	workspaces, err := db.ListWorkspacesByID(ctx, s.conn, ids)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list workspaces with reported storage.")
+		return nil, status.Errorf(codes.Internal, "failed to list workspaces")
+	}
+
+	inserts, err := newStorageUsage(workspaces, req.GetWorkspaces(), s.pricer, from, to)
+	if err != nil {
+		logger.WithError(err).Error("Failed to construct storage usage records.")
+		return nil, status.Errorf(codes.Internal, "failed to construct storage usage records")
+	}
+
+	if len(inserts) > 0 {
+		err = db.InsertUsage(ctx, s.conn, inserts...)
+		if err != nil {
+			logger.WithError(err).Errorf("Failed to insert %d storage usage records into the database.", len(inserts))
+			return nil, status.Errorf(codes.Internal, "Failed to insert usage records into the database.")
+		}
+	}
+	logger.Infof("Charged the storage of %d out of %d reported workspaces.", len(inserts), len(req.GetWorkspaces()))
+
+	return &v1.ReportStorageUsageResponse{}, nil
+}
+
+// newStorageUsage prices the reported storage for the period between from and to. Storage which no pricing rule matches is free
+// and not recorded, neither is the storage of workspaces which are unknown or don't belong to an organization.
+func newStorageUsage(workspaces []db.Workspace, reported []*v1.WorkspaceStorageUsage, pricer *WorkspacePricer, from, to time.Time) ([]db.Usage, error) {
+	orgs := make(map[string]uuid.UUID, len(workspaces))
+	for _, ws := range workspaces {
+		if ws.OrganizationId != nil {
+			orgs[ws.ID] = *ws.OrganizationId
+		}
+	}
+
+	var res []db.Usage
+	for _, r := range reported {
+		org, ok := orgs[r.GetWorkspaceId()]
+		if !ok || r.GetBytes() <= 0 {
+			continue
+		}
+
+		attributionID := db.NewTeamAttributionID(org.String())
+		gbHours := float64(r.GetBytes()) / bytesPerGB * to.Sub(from).Hours()
+		credits, charge := pricer.StorageCredits(attributionID, gbHours, from)
+		if charge.Rule == fallbackPricingRule {
+			continue
+		}
+
+		usage := db.Usage{
+			ID:            uuid.NewSHA1(storageUsageNamespace, []byte(r.GetWorkspaceId()+"@"+db.TimeToISO8601(from))),
+			AttributionID: attributionID,
+			Description:   usageDescriptionForStorage,
+			CreditCents:   db.NewCreditCents(credits),
+			EffectiveTime: db.NewVarCharTime(to),
+			Kind:          db.StorageUsageKind,
+			Draft:         false,
+		}
+		err := usage.SetMetadataWithStorage(db.StorageUsageData{
+			WorkspaceId:    r.GetWorkspaceId(),
+			Bytes:          r.GetBytes(),
+			GBHours:        gbHours,
+			StartTime:      db.TimeToISO8601(from),
+			EndTime:        db.TimeToISO8601(to),
+			PricingVersion: charge.Version,
+			PricingRule:    charge.Rule,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize storage metadata: %w", err)
+		}
+		res = append(res, usage)
+	}
+	return res, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStorageUsage(t *testing.T) {
+	var (
+		org      = uuid.New()
+		freeOrg  = uuid.New()
+		from     = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+		to       = from.Add(24 * time.Hour)
+		priced   = uuid.New().String()
+		personal = uuid.New().String()
+		free     = uuid.New().String()
+	)
+	pricer, err := NewWorkspacePricerWithRules(nil, PricingRules{
+		Versions: []PricingRulesVersion{{
+			ID:            "2023-06",
+			EffectiveFrom: from,
+			Storage: []StoragePricingRule{
+				{ID: "backups", OrganizationID: org.String(), CreditsPerGBHour: 0.01},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	workspaces := []db.Workspace{
+		{ID: priced, OrganizationId: &org},
+		{ID: personal},
+		{ID: free, OrganizationId: &freeOrg},
+	}
+	reported := []*v1.WorkspaceStorageUsage{
+		{WorkspaceId: priced, Bytes: 5 * bytesPerGB},
+		{WorkspaceId: personal, Bytes: bytesPerGB},
+		{WorkspaceId: free, Bytes: bytesPerGB},
+		{WorkspaceId: uuid.New().String(), Bytes: bytesPerGB},
+	}
+
+	usage, err := newStorageUsage(workspaces, reported, pricer, from, to)
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	require.Equal(t, db.NewTeamAttributionID(org.String()), usage[0].AttributionID)
+	require.Equal(t, db.StorageUsageKind, usage[0].Kind)
+	require.Equal(t, db.NewCreditCents(5*24*0.01), usage[0].CreditCents)
+	require.Equal(t, db.NewVarCharTime(to), usage[0].EffectiveTime)
+	require.False(t, usage[0].Draft)
+	require.JSONEq(t, `{
+		"workspaceId": "`+priced+`",
+		"bytes": 5368709120,
+		"gbHours": 120,
+		"startTime": "2023-06-01T00:00:00.000Z",
+		"endTime": "2023-06-02T00:00:00.000Z",
+		"pricingVersion": "2023-06",
+		"pricingRule": "backups"
+	}`, string(usage[0].Metadata))
+
+	again, err := newStorageUsage(workspaces, reported, pricer, from, to)
+	require.NoError(t, err)
+	require.Equal(t, usage[0].ID, again[0].ID, "reporting the same period again must not charge twice")
+}
@@ -110,8 +110,11 @@ func (s *UsageService) ListUsage(ctx context.Context, in *v1.ListUsageRequest) (
 	var usageData []*v1.Usage
 	for _, usageRecord := range listUsageResult {
 		kind := v1.Usage_KIND_WORKSPACE_INSTANCE
-		if usageRecord.Kind == db.InvoiceUsageKind {
+		switch usageRecord.Kind {
+		case db.InvoiceUsageKind:
 			kind = v1.Usage_KIND_INVOICE
+		case db.StorageUsageKind:
+			kind = v1.Usage_KIND_STORAGE
 		}
 
 		var workspaceInstanceID string
@@ -377,6 +380,8 @@ func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *Workspa
 		stopTime = instance.StoppedTime
 	}
 
+	credits, charge := pricer.PriceInstance(&instance, now)
+
 	draft := true
 	if stopTime.IsSet() {
 		draft = false
@@ -391,7 +396,7 @@ func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *Workspa
 		ID:                  uuid.New(),
 		AttributionID:       instance.UsageAttributionID,
 		Description:         usageDescriptionFromController,
-		CreditCents:         db.NewCreditCents(pricer.CreditsUsedByInstance(&instance, now)),
+		CreditCents:         db.NewCreditCents(credits),
 		EffectiveTime:       db.NewVarCharTime(effectiveTime),
 		Kind:                db.WorkspaceInstanceUsageKind,
 		WorkspaceInstanceID: &instance.ID,
@@ -416,6 +421,10 @@ func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *Workspa
 		UserID:         instance.UserID,
 		UserName:       instance.UserName,
 		UserAvatarURL:  instance.UserAvatarURL,
+
+		PricingVersion:   charge.Version,
+		PricingRule:      charge.Rule,
+		PricingDiscounts: charge.Discounts,
 	})
 	if err != nil {
 		return db.Usage{}, fmt.Errorf("failed to serialize workspace instance metadata: %w", err)
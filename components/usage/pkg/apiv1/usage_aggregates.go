@@ -0,0 +1,282 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"bufio"
+	"context"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/export"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportChunkSize is the maximum size of the chunks exports are streamed in, well below gRPC's message size limit
+const exportChunkSize = 64 * 1024
+
+var usageDimensions = map[v1.UsageDimension]db.UsageDimension{
+	v1.UsageDimension_USAGE_DIMENSION_ATTRIBUTION:     db.AttributionDimension,
+	v1.UsageDimension_USAGE_DIMENSION_PROJECT:         db.ProjectDimension,
+	v1.UsageDimension_USAGE_DIMENSION_WORKSPACE_CLASS: db.WorkspaceClassDimension,
+	v1.UsageDimension_USAGE_DIMENSION_USER:            db.UserDimension,
+}
+
+var timeBuckets = map[v1.TimeBucket]db.TimeBucket{
+	v1.TimeBucket_TIME_BUCKET_UNSPECIFIED: db.NoTimeBucket,
+	v1.TimeBucket_TIME_BUCKET_DAY:         db.DayTimeBucket,
+	v1.TimeBucket_TIME_BUCKET_WEEK:        db.WeekTimeBucket,
+	v1.TimeBucket_TIME_BUCKET_MONTH:       db.MonthTimeBucket,
+}
+
+type usageAggregateQuery struct {
+	AttributionID db.AttributionID
+	From, To      time.Time
+	GroupBy       []db.UsageDimension
+	Bucket        db.TimeBucket
+}
+
+func (s *UsageService) GetUsageAggregates(ctx context.Context, in *v1.GetUsageAggregatesRequest) (*v1.GetUsageAggregatesResponse, error) {
+	query, err := newUsageAggregateQuery(in.AttributionId, in.From, in.To, in.GroupBy, in.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	aggregates, err := s.aggregateUsage(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetUsageAggregatesResponse{
+		Aggregates: aggregates,
+	}, nil
+}
+
+func (s *UsageService) ExportUsage(in *v1.ExportUsageRequest, srv v1.UsageService_ExportUsageServer) error {
+	query, err := newUsageAggregateQuery(in.AttributionId, in.From, in.To, in.GroupBy, in.Bucket)
+	if err != nil {
+		return err
+	}
+	newWriter := export.NewCSVWriter
+	switch in.Format {
+	case v1.ExportUsageRequest_FORMAT_CSV:
+	case v1.ExportUsageRequest_FORMAT_PARQUET:
+		newWriter = export.NewParquetWriter
+	default:
+		return status.Errorf(codes.InvalidArgument, "Unknown export format %s.", in.Format)
+	}
+
+	// aggregates are written as they are read from the database, such that exports are never held in memory as a whole
+	out := bufio.NewWriterSize(&exportStreamWriter{srv: srv}, exportChunkSize)
+	w, err := newWriter(out, exportColumns(query.GroupBy))
+	if err == nil {
+		err = db.ForEachUsageAggregate(srv.Context(), s.conn, query.params(), func(row db.UsageAggregate) error {
+			aggregate, err := convertUsageAggregate(row, query)
+			if err != nil {
+				return err
+			}
+			return w.Write(exportRow(aggregate, query.GroupBy))
+		})
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		log.WithError(err).WithField("attribution_id", in.AttributionId).Error("Failed to export usage.")
+		return status.Error(codes.Internal, "unable to export usage")
+	}
+	return nil
+}
+
+func newUsageAggregateQuery(attributionID string, from, to *timestamppb.Timestamp, groupBy []v1.UsageDimension, bucket v1.TimeBucket) (usageAggregateQuery, error) {
+	var query usageAggregateQuery
+
+	query.To = time.Now()
+	if to != nil {
+		query.To = to.AsTime()
+	}
+	query.From = query.To.Add(-maxQuerySize)
+	if from != nil {
+		query.From = from.AsTime()
+	}
+	if query.From.After(query.To) {
+		return query, status.Errorf(codes.InvalidArgument, "Specified From timestamp is after To. Please ensure From is always before To")
+	}
+	if query.To.Sub(query.From) > maxQuerySize {
+		return query, status.Errorf(codes.InvalidArgument, "Maximum range exceeded. Range specified can be at most %s", maxQuerySize.String())
+	}
+
+	if attributionID != "" {
+		id, err := db.ParseAttributionID(attributionID)
+		if err != nil {
+			return query, status.Errorf(codes.InvalidArgument, "AttributionID '%s' couldn't be parsed (error: %s).", attributionID, err)
+		}
+		query.AttributionID = id
+	}
+
+	seen := make(map[db.UsageDimension]bool, len(groupBy))
+	for _, d := range groupBy {
+		dimension, ok := usageDimensions[d]
+		if !ok {
+			return query, status.Errorf(codes.InvalidArgument, "Cannot group usage by %s.", d)
+		}
+		if seen[dimension] {
+			continue
+		}
+		seen[dimension] = true
+		query.GroupBy = append(query.GroupBy, dimension)
+	}
+
+	var ok bool
+	query.Bucket, ok = timeBuckets[bucket]
+	if !ok {
+		return query, status.Errorf(codes.InvalidArgument, "Unknown time bucket %s.", bucket)
+	}
+
+	return query, nil
+}
+
+func (q usageAggregateQuery) params() db.AggregateUsageParams {
+	return db.AggregateUsageParams{
+		AttributionID: q.AttributionID,
+		From:          q.From,
+		To:            q.To,
+		GroupBy:       q.GroupBy,
+		Bucket:        q.Bucket,
+	}
+}
+
+func (s *UsageService) aggregateUsage(ctx context.Context, query usageAggregateQuery) ([]*v1.UsageAggregate, error) {
+	rows, err := db.AggregateUsage(ctx, s.conn, query.params())
+	if err != nil {
+		log.WithError(err).WithField("attribution_id", query.AttributionID).Error("Failed to aggregate usage.")
+		return nil, status.Error(codes.Internal, "unable to aggregate usage")
+	}
+
+	return convertUsageAggregates(rows, query)
+}
+
+// convertUsageAggregates converts usage aggregates into their API representation
+func convertUsageAggregates(rows []db.UsageAggregate, query usageAggregateQuery) ([]*v1.UsageAggregate, error) {
+	result := make([]*v1.UsageAggregate, 0, len(rows))
+	for _, row := range rows {
+		aggregate, err := convertUsageAggregate(row, query)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, aggregate)
+	}
+	return result, nil
+}
+
+func convertUsageAggregate(row db.UsageAggregate, query usageAggregateQuery) (*v1.UsageAggregate, error) {
+	bucketStart := query.From
+	if row.BucketStart != "" {
+		t, err := time.Parse("2006-01-02", row.BucketStart)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid time bucket %q", row.BucketStart)
+		}
+		bucketStart = t
+	}
+
+	aggregate := &v1.UsageAggregate{
+		BucketStart:      timestamppb.New(bucketStart),
+		AttributionId:    string(row.AttributionID),
+		ProjectId:        row.ProjectID,
+		WorkspaceClass:   row.WorkspaceClass,
+		UserId:           row.UserID,
+		Credits:          row.CreditCents.ToCredits(),
+		WorkspaceMinutes: row.WorkspaceMinutes,
+		UsageEntries:     row.UsageEntries,
+	}
+	// Pricing rules and organization overrides can charge the same workspace class differently, hence the effective price.
+	if containsDimension(query.GroupBy, db.WorkspaceClassDimension) && row.WorkspaceMinutes > 0 {
+		aggregate.CreditsPerMinute = aggregate.Credits / row.WorkspaceMinutes
+	}
+	return aggregate, nil
+}
+
+// exportColumns produces the columns usage exports consist of. Only dimensions usage is grouped by become columns.
+func exportColumns(groupBy []db.UsageDimension) []export.Column {
+	columns := []export.Column{{Name: "bucket_start", Type: export.TimestampColumn}}
+	for _, d := range groupBy {
+		columns = append(columns, export.Column{Name: exportColumnNames[d], Type: export.StringColumn})
+	}
+	columns = append(columns,
+		export.Column{Name: "credits", Type: export.DoubleColumn},
+		export.Column{Name: "workspace_minutes", Type: export.DoubleColumn},
+	)
+	if containsDimension(groupBy, db.WorkspaceClassDimension) {
+		columns = append(columns, export.Column{Name: "credits_per_minute", Type: export.DoubleColumn})
+	}
+	return append(columns, export.Column{Name: "usage_entries", Type: export.Int64Column})
+}
+
+// exportRow produces the values of an aggregate for the columns returned by exportColumns
+func exportRow(a *v1.UsageAggregate, groupBy []db.UsageDimension) []interface{} {
+	row := []interface{}{a.BucketStart.AsTime()}
+	for _, d := range groupBy {
+		switch d {
+		case db.AttributionDimension:
+			row = append(row, a.AttributionId)
+		case db.ProjectDimension:
+			row = append(row, a.ProjectId)
+		case db.WorkspaceClassDimension:
+			row = append(row, a.WorkspaceClass)
+		case db.UserDimension:
+			row = append(row, a.UserId)
+		}
+	}
+	row = append(row, a.Credits, a.WorkspaceMinutes)
+	if containsDimension(groupBy, db.WorkspaceClassDimension) {
+		row = append(row, a.CreditsPerMinute)
+	}
+	return append(row, a.UsageEntries)
+}
+
+var exportColumnNames = map[db.UsageDimension]string{
+	db.AttributionDimension:    "attribution_id",
+	db.ProjectDimension:        "project_id",
+	db.WorkspaceClassDimension: "workspace_class",
+	db.UserDimension:           "user_id",
+}
+
+func containsDimension(dimensions []db.UsageDimension, d db.UsageDimension) bool {
+	for _, dimension := range dimensions {
+		if dimension == d {
+			return true
+		}
+	}
+	return false
+}
+
+// exportStreamWriter sends everything written to it as chunks of an export
+type exportStreamWriter struct {
+	srv v1.UsageService_ExportUsageServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		size := len(p) - n
+		if size > exportChunkSize {
+			size = exportChunkSize
+		}
+		// gRPC may hold on to messages after sending them, hence we must not pass on the caller's buffer
+		chunk := make([]byte, size)
+		copy(chunk, p[n:n+size])
+		err := w.srv.Send(&v1.ExportUsageResponse{Chunk: chunk})
+		if err != nil {
+			return n, err
+		}
+		n += size
+	}
+	return n, nil
+}
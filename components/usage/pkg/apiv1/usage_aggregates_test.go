@@ -0,0 +1,194 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/export"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewUsageAggregateQuery(t *testing.T) {
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := newUsageAggregateQuery("", timestamppb.New(from), timestamppb.New(to), []v1.UsageDimension{
+		v1.UsageDimension_USAGE_DIMENSION_PROJECT,
+		v1.UsageDimension_USAGE_DIMENSION_USER,
+		v1.UsageDimension_USAGE_DIMENSION_PROJECT,
+	}, v1.TimeBucket_TIME_BUCKET_WEEK)
+	require.NoError(t, err)
+	require.Equal(t, usageAggregateQuery{
+		From:    from,
+		To:      to,
+		GroupBy: []db.UsageDimension{db.ProjectDimension, db.UserDimension},
+		Bucket:  db.WeekTimeBucket,
+	}, query)
+
+	for name, test := range map[string]struct {
+		attributionID string
+		from, to      time.Time
+		groupBy       []v1.UsageDimension
+		bucket        v1.TimeBucket
+	}{
+		"from after to":          {from: to, to: from},
+		"range too large":        {from: from.Add(-maxQuerySize), to: to},
+		"invalid attribution id": {attributionID: "foo", from: from, to: to},
+		"unspecified dimension":  {from: from, to: to, groupBy: []v1.UsageDimension{v1.UsageDimension_USAGE_DIMENSION_UNSPECIFIED}},
+		"unknown time bucket":    {from: from, to: to, bucket: v1.TimeBucket(42)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newUsageAggregateQuery(test.attributionID, timestamppb.New(test.from), timestamppb.New(test.to), test.groupBy, test.bucket)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestConvertUsageAggregates(t *testing.T) {
+	from := time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("aggregates carry their time bucket", func(t *testing.T) {
+		aggregates, err := convertUsageAggregates([]db.UsageAggregate{
+			{BucketStart: "2023-05-01", ProjectID: "p1", CreditCents: 500, WorkspaceMinutes: 6, UsageEntries: 3},
+			{BucketStart: "2023-05-08", ProjectID: "p1", CreditCents: 30, WorkspaceMinutes: 0.6, UsageEntries: 1},
+		}, usageAggregateQuery{
+			From:    from,
+			GroupBy: []db.UsageDimension{db.ProjectDimension},
+			Bucket:  db.WeekTimeBucket,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*v1.UsageAggregate{
+			{BucketStart: timestamppb.New(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), ProjectId: "p1", Credits: 5, WorkspaceMinutes: 6, UsageEntries: 3},
+			{BucketStart: timestamppb.New(time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC)), ProjectId: "p1", Credits: 0.3, WorkspaceMinutes: 0.6, UsageEntries: 1},
+		}, aggregates)
+	})
+
+	t.Run("workspace classes carry their effective price", func(t *testing.T) {
+		aggregates, err := convertUsageAggregates([]db.UsageAggregate{
+			{BucketStart: "2023-05-01", WorkspaceClass: "g1-large", CreditCents: 400, WorkspaceMinutes: 4, UsageEntries: 1},
+			{BucketStart: "2023-05-01", WorkspaceClass: "g1-standard", CreditCents: 100, WorkspaceMinutes: 2, UsageEntries: 2},
+			{BucketStart: "2023-05-01", WorkspaceClass: "g1-unpriced", CreditCents: 0, WorkspaceMinutes: 3, UsageEntries: 1},
+			{BucketStart: "2023-05-01", WorkspaceClass: "g1-without-runtime", CreditCents: 100, WorkspaceMinutes: 0, UsageEntries: 1},
+		}, usageAggregateQuery{
+			From:    from,
+			GroupBy: []db.UsageDimension{db.WorkspaceClassDimension},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*v1.UsageAggregate{
+			{BucketStart: timestamppb.New(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), WorkspaceClass: "g1-large", Credits: 4, WorkspaceMinutes: 4, CreditsPerMinute: 1, UsageEntries: 1},
+			{BucketStart: timestamppb.New(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), WorkspaceClass: "g1-standard", Credits: 1, WorkspaceMinutes: 2, CreditsPerMinute: 0.5, UsageEntries: 2},
+			{BucketStart: timestamppb.New(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), WorkspaceClass: "g1-unpriced", Credits: 0, WorkspaceMinutes: 3, CreditsPerMinute: 0, UsageEntries: 1},
+			{BucketStart: timestamppb.New(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), WorkspaceClass: "g1-without-runtime", Credits: 1, WorkspaceMinutes: 0, CreditsPerMinute: 0, UsageEntries: 1},
+		}, aggregates)
+	})
+
+	t.Run("aggregates without time bucket start at from", func(t *testing.T) {
+		aggregates, err := convertUsageAggregates([]db.UsageAggregate{
+			{CreditCents: 100, WorkspaceMinutes: 2, UsageEntries: 1},
+		}, usageAggregateQuery{From: from})
+		require.NoError(t, err)
+		require.Equal(t, []*v1.UsageAggregate{
+			{BucketStart: timestamppb.New(from), Credits: 1, WorkspaceMinutes: 2, UsageEntries: 1},
+		}, aggregates)
+	})
+
+	t.Run("invalid time bucket", func(t *testing.T) {
+		_, err := convertUsageAggregates([]db.UsageAggregate{{BucketStart: "foo"}}, usageAggregateQuery{From: from})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestExportRow(t *testing.T) {
+	bucketStart := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	groupBy := []db.UsageDimension{db.UserDimension, db.WorkspaceClassDimension}
+	columns := exportColumns(groupBy)
+	row := exportRow(&v1.UsageAggregate{
+		BucketStart: timestamppb.New(bucketStart), UserId: "u1", WorkspaceClass: "g1-standard", Credits: 1, WorkspaceMinutes: 10, CreditsPerMinute: 0.1, UsageEntries: 2,
+	}, groupBy)
+
+	var names []string
+	for _, c := range columns {
+		names = append(names, c.Name)
+	}
+	require.Equal(t, []string{"bucket_start", "user_id", "workspace_class", "credits", "workspace_minutes", "credits_per_minute", "usage_entries"}, names)
+	require.Equal(t, []interface{}{bucketStart, "u1", "g1-standard", 1.0, 10.0, 0.1, int64(2)}, row)
+
+	var buf bytes.Buffer
+	w, err := export.NewParquetWriter(&buf, columns)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(row))
+	require.NoError(t, w.Close())
+}
+
+func TestUsageService_ExportUsage(t *testing.T) {
+	dbconn := dbtest.ConnectForTests(t)
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+
+	usage := dbtest.NewUsage(t, db.Usage{
+		AttributionID: attributionID,
+		EffectiveTime: db.NewVarCharTime(from.Add(2 * time.Hour)),
+		CreditCents:   100,
+	})
+	require.NoError(t, usage.SetMetadataWithWorkspaceInstance(db.WorkspaceInstanceUsageData{
+		WorkspaceClass: "default",
+		StartTime:      db.TimeToISO8601(from.Add(2*time.Hour - 6*time.Minute)),
+		EndTime:        db.TimeToISO8601(from.Add(2 * time.Hour)),
+	}))
+	dbtest.CreateUsageRecords(t, dbconn, usage)
+
+	client := newUsageService(t, dbconn)
+
+	aggregates, err := client.GetUsageAggregates(context.Background(), &v1.GetUsageAggregatesRequest{
+		AttributionId: string(attributionID),
+		From:          timestamppb.New(from),
+		To:            timestamppb.New(to),
+		GroupBy:       []v1.UsageDimension{v1.UsageDimension_USAGE_DIMENSION_WORKSPACE_CLASS},
+		Bucket:        v1.TimeBucket_TIME_BUCKET_MONTH,
+	})
+	require.NoError(t, err)
+	require.Len(t, aggregates.Aggregates, 1)
+	require.Equal(t, "default", aggregates.Aggregates[0].WorkspaceClass)
+	require.Equal(t, float64(1), aggregates.Aggregates[0].Credits)
+	require.Equal(t, float64(6), aggregates.Aggregates[0].WorkspaceMinutes)
+	require.Equal(t, float64(1)/6, aggregates.Aggregates[0].CreditsPerMinute)
+	require.EqualValues(t, 1, aggregates.Aggregates[0].UsageEntries)
+
+	stream, err := client.ExportUsage(context.Background(), &v1.ExportUsageRequest{
+		AttributionId: string(attributionID),
+		From:          timestamppb.New(from),
+		To:            timestamppb.New(to),
+		GroupBy:       []v1.UsageDimension{v1.UsageDimension_USAGE_DIMENSION_ATTRIBUTION},
+		Bucket:        v1.TimeBucket_TIME_BUCKET_MONTH,
+		Format:        v1.ExportUsageRequest_FORMAT_CSV,
+	})
+	require.NoError(t, err)
+
+	var csv bytes.Buffer
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		csv.Write(resp.Chunk)
+	}
+	require.Equal(t, "bucket_start,attribution_id,credits,workspace_minutes,usage_entries\n"+
+		"2023-05-01T00:00:00Z,"+string(attributionID)+",1,6,1\n", csv.String())
+}
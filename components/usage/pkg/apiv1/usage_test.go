@@ -161,6 +161,8 @@ func TestReconcile(t *testing.T) {
 			EndTime:        "",
 			UserName:       instance.UserName,
 			UserAvatarURL:  instance.UserAvatarURL,
+			PricingVersion: defaultPricingVersion,
+			PricingRule:    db.WorkspaceClass_Default,
 		}))
 		require.EqualValues(t, expectedUsage, inserts[0])
 	})
@@ -219,6 +221,8 @@ func TestReconcile(t *testing.T) {
 			EndTime:        "",
 			UserName:       instance.UserName,
 			UserAvatarURL:  instance.UserAvatarURL,
+			PricingVersion: defaultPricingVersion,
+			PricingRule:    db.WorkspaceClass_Default,
 		}))
 		require.EqualValues(t, expectedUsage, updates[0])
 	})
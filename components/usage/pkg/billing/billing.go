@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package billing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+var (
+	// ErrInvoiceNotFound is returned by providers when they do not know an invoice
+	ErrInvoiceNotFound = errors.New("invoice not found")
+	// ErrInvoiceFinalized is returned by providers when an invoice has been finalized before
+	ErrInvoiceFinalized = errors.New("invoice has been finalized already")
+)
+
+// Provider charges attribution IDs for the credits they use.
+// Gitpod.io bills through Stripe, while other installations may charge back usage internally.
+type Provider interface {
+	// Name is the human readable name of the provider, e.g. used in the description of usage records.
+	Name() string
+
+	// Charges determines whether the provider charges the cost center for its usage.
+	Charges(costCenter db.CostCenter) bool
+
+	// CreateCustomer registers an attribution ID as customer with the provider.
+	CreateCustomer(ctx context.Context, params CreateCustomerParams) (*Customer, error)
+
+	// UpdateUsage reports the credits each attribution ID has used in its current billing period.
+	UpdateUsage(ctx context.Context, creditsPerAttributionID map[db.AttributionID]int64) error
+
+	// FinalizeInvoice returns a finalized invoice.
+	FinalizeInvoice(ctx context.Context, invoiceID string) (*Invoice, error)
+
+	// GetDispute returns a dispute raised by a customer against a charge.
+	GetDispute(ctx context.Context, disputeID string) (*Dispute, error)
+}
+
+type CreateCustomerParams struct {
+	AttributionID        db.AttributionID
+	Currency             string
+	Email                string
+	Name                 string
+	BillingCreatorUserID string
+}
+
+type Customer struct {
+	ID            string
+	AttributionID db.AttributionID
+	Currency      string
+	CreatedAt     time.Time
+}
+
+// Invoice is a finalized invoice of a billing period
+type Invoice struct {
+	ID            string
+	AttributionID db.AttributionID
+	// Credits is the amount of credits charged for on the invoice
+	Credits     int64
+	FinalizedAt time.Time
+}
+
+// Dispute is raised by a customer who refuses a charge
+type Dispute struct {
+	ID            string
+	AttributionID db.AttributionID
+}
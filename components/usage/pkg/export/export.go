@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ColumnType int
+
+const (
+	// StringColumn holds string values
+	StringColumn ColumnType = iota
+	// Int64Column holds int64 values
+	Int64Column
+	// DoubleColumn holds float64 values
+	DoubleColumn
+	// TimestampColumn holds time.Time values
+	TimestampColumn
+)
+
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// Writer writes an export row by row, such that exports never have to be held in memory as a whole.
+type Writer interface {
+	// Write writes a row, which holds a value of the column's type for every column
+	Write(row []interface{}) error
+	// Close writes whatever the writer still buffers. It does not close the underlying io.Writer.
+	Close() error
+}
+
+// validateRow ensures all values match the type of their column
+func validateRow(columns []Column, row []interface{}) error {
+	if len(row) != len(columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(row), len(columns))
+	}
+	for i, c := range columns {
+		var ok bool
+		switch c.Type {
+		case StringColumn:
+			_, ok = row[i].(string)
+		case Int64Column:
+			_, ok = row[i].(int64)
+		case DoubleColumn:
+			_, ok = row[i].(float64)
+		case TimestampColumn:
+			_, ok = row[i].(time.Time)
+		}
+		if !ok {
+			return fmt.Errorf("value %v of column %s has type %T", row[i], c.Name, row[i])
+		}
+	}
+	return nil
+}
+
+// NewCSVWriter writes CSV including a header row. Timestamps are formatted as RFC 3339.
+func NewCSVWriter(w io.Writer, columns []Column) (Writer, error) {
+	out := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.Name
+	}
+	if err := out.Write(record); err != nil {
+		return nil, err
+	}
+	return &csvWriter{columns: columns, out: out, record: record}, nil
+}
+
+type csvWriter struct {
+	columns []Column
+	out     *csv.Writer
+	record  []string
+}
+
+func (w *csvWriter) Write(row []interface{}) error {
+	if err := validateRow(w.columns, row); err != nil {
+		return err
+	}
+
+	for i, v := range row {
+		switch v := v.(type) {
+		case string:
+			w.record[i] = v
+		case int64:
+			w.record[i] = strconv.FormatInt(v, 10)
+		case float64:
+			w.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case time.Time:
+			w.record[i] = v.UTC().Format(time.RFC3339)
+		}
+	}
+	return w.out.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	w.out.Flush()
+	return w.out.Error()
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testColumns = []Column{
+		{Name: "bucket_start", Type: TimestampColumn},
+		{Name: "workspace_class", Type: StringColumn},
+		{Name: "credits", Type: DoubleColumn},
+		{Name: "usage_entries", Type: Int64Column},
+	}
+	testRows = [][]interface{}{
+		{time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), "g1-standard", 12.5, int64(3)},
+		{time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC), "g1-large, \"gpu\"", 0.1, int64(1)},
+	}
+)
+
+func writeRows(newWriter func(io.Writer, []Column) (Writer, error), columns []Column, rows [][]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := newWriter(&buf, columns)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func TestCSVWriter(t *testing.T) {
+	fc, err := writeRows(NewCSVWriter, testColumns, testRows)
+	require.NoError(t, err)
+	require.Equal(t, "bucket_start,workspace_class,credits,usage_entries\n"+
+		"2023-05-01T00:00:00Z,g1-standard,12.5,3\n"+
+		"2023-05-08T00:00:00Z,\"g1-large, \"\"gpu\"\"\",0.1,1\n", string(fc))
+}
+
+func TestValidateRow(t *testing.T) {
+	columns := []Column{{Name: "credits", Type: DoubleColumn}}
+	require.NoError(t, validateRow(columns, []interface{}{1.0}))
+	require.Error(t, validateRow(columns, []interface{}{int64(1)}))
+	require.Error(t, validateRow(columns, []interface{}{1.0, 2.0}))
+}
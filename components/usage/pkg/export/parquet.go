@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetRowGroupSize is the number of rows after which a new row group is started,
+// such that the writer never buffers more than that many rows
+const parquetRowGroupSize = 10000
+
+var parquetColumnTypes = map[ColumnType]string{
+	StringColumn:    "type=BYTE_ARRAY, convertedtype=UTF8",
+	Int64Column:     "type=INT64",
+	DoubleColumn:    "type=DOUBLE",
+	TimestampColumn: "type=INT64, convertedtype=TIMESTAMP_MILLIS",
+}
+
+// NewParquetWriter writes a Parquet file with a required column for each of the columns.
+// Timestamps are stored with millisecond precision.
+func NewParquetWriter(w io.Writer, columns []Column) (Writer, error) {
+	metadata := make([]string, 0, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		tpe, ok := parquetColumnTypes[c.Type]
+		if !ok {
+			return nil, fmt.Errorf("column %s has unknown type %d", c.Name, c.Type)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("column %s is not unique", c.Name)
+		}
+		seen[c.Name] = true
+		metadata = append(metadata, fmt.Sprintf("name=%s, %s, repetitiontype=REQUIRED", c.Name, tpe))
+	}
+
+	out, err := writer.NewCSVWriterFromWriter(metadata, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetWriter{columns: columns, out: out}, nil
+}
+
+type parquetWriter struct {
+	columns []Column
+	out     *writer.CSVWriter
+	rows    int
+}
+
+func (w *parquetWriter) Write(row []interface{}) error {
+	if err := validateRow(w.columns, row); err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(row))
+	for i, v := range row {
+		if t, ok := v.(time.Time); ok {
+			v = t.UnixMilli()
+		}
+		values[i] = v
+	}
+	if err := w.out.Write(values); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%parquetRowGroupSize == 0 {
+		return w.out.Flush(true)
+	}
+	return nil
+}
+
+func (w *parquetWriter) Close() error {
+	return w.out.WriteStop()
+}
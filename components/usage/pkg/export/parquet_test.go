@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package export
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestParquetWriter(t *testing.T) {
+	var wide []Column
+	for i := 0; i < 16; i++ {
+		wide = append(wide, Column{Name: fmt.Sprintf("c%d", i), Type: ColumnType(i % 4)})
+	}
+	wideRows := func(n int) [][]interface{} {
+		rows := make([][]interface{}, n)
+		for r := range rows {
+			for _, c := range wide {
+				var v interface{}
+				switch c.Type {
+				case StringColumn:
+					v = fmt.Sprintf("%s-%d", c.Name, r)
+				case Int64Column:
+					v = int64(-r)
+				case DoubleColumn:
+					v = float64(r) / 3
+				case TimestampColumn:
+					v = time.Date(2023, 5, 1, 0, 0, r%60, 1000000, time.UTC)
+				}
+				rows[r] = append(rows[r], v)
+			}
+		}
+		return rows
+	}
+
+	tests := []struct {
+		Name      string
+		Columns   []Column
+		Rows      [][]interface{}
+		RowGroups int
+	}{
+		{Name: "rows", Columns: testColumns, Rows: testRows, RowGroups: 1},
+		{Name: "empty", Columns: testColumns},
+		{Name: "wide", Columns: wide, Rows: wideRows(20), RowGroups: 1},
+		{Name: "row groups", Columns: wide, Rows: wideRows(2*parquetRowGroupSize + 1), RowGroups: 3},
+		{Name: "full row groups", Columns: wide, Rows: wideRows(2 * parquetRowGroupSize), RowGroups: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			fc, err := writeRows(NewParquetWriter, test.Columns, test.Rows)
+			require.NoError(t, err)
+
+			columns, rows, rowGroups, err := readParquet(fc)
+			require.NoError(t, err)
+			require.Equal(t, test.Columns, columns)
+			require.Equal(t, test.RowGroups, rowGroups)
+			require.Equal(t, len(test.Rows), len(rows))
+			for i := range test.Rows {
+				require.Equal(t, test.Rows[i], rows[i], "row %d", i)
+			}
+		})
+	}
+}
+
+func TestParquetWriter_Invalid(t *testing.T) {
+	_, err := writeRows(NewParquetWriter, testColumns, [][]interface{}{{"2023-05-01", "g1-standard", 12.5, int64(3)}})
+	require.Error(t, err)
+
+	_, err = writeRows(NewParquetWriter, []Column{{Name: "a"}, {Name: "a"}}, nil)
+	require.Error(t, err)
+}
+
+// readParquet reads a Parquet file with the Parquet library, and maps its schema back to columns
+func readParquet(fc []byte) (columns []Column, rows [][]interface{}, rowGroups int, err error) {
+	f, err := buffer.NewBufferFile(fc)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	pr, err := reader.NewParquetColumnReader(f, 1)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer pr.ReadStop()
+
+	for i, element := range pr.Footer.Schema[1:] {
+		// the reader renames columns to their Go field names, the file holds their external names
+		name := pr.SchemaHandler.Infos[i+1].ExName
+		if element.GetRepetitionType() != parquet.FieldRepetitionType_REQUIRED {
+			return nil, nil, 0, fmt.Errorf("column %s is not required", name)
+		}
+		var tpe ColumnType
+		switch {
+		case element.GetType() == parquet.Type_BYTE_ARRAY && element.GetConvertedType() == parquet.ConvertedType_UTF8:
+			tpe = StringColumn
+		case element.GetType() == parquet.Type_INT64 && element.ConvertedType == nil:
+			tpe = Int64Column
+		case element.GetType() == parquet.Type_DOUBLE:
+			tpe = DoubleColumn
+		case element.GetType() == parquet.Type_INT64 && element.GetConvertedType() == parquet.ConvertedType_TIMESTAMP_MILLIS:
+			tpe = TimestampColumn
+		default:
+			return nil, nil, 0, fmt.Errorf("column %s has unexpected type %s", name, element.GetType())
+		}
+		columns = append(columns, Column{Name: name, Type: tpe})
+	}
+
+	numRows := pr.GetNumRows()
+	rows = make([][]interface{}, numRows)
+	for i, c := range columns {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), numRows)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if int64(len(values)) != numRows {
+			return nil, nil, 0, fmt.Errorf("column %s has %d values, expected %d", c.Name, len(values), numRows)
+		}
+		for r, v := range values {
+			if c.Type == TimestampColumn {
+				v = time.UnixMilli(v.(int64)).UTC()
+			}
+			rows[r] = append(rows[r], v)
+		}
+	}
+	return columns, rows, len(pr.Footer.RowGroups), nil
+}
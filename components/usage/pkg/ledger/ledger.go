@@ -0,0 +1,524 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package ledger
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"gorm.io/gorm"
+)
+
+const (
+	periodLayout = "2006-01"
+	lockFilename = ".lock"
+)
+
+// Config configures the internal ledger
+type Config struct {
+	// Directory is where the ledger keeps its state and writes the chargeback statements to.
+	// It must be a persistent volume which all usage replicas mount, because the ledger state
+	// must neither get lost when a pod is rescheduled, nor diverge between replicas.
+	Directory string `json:"directory"`
+}
+
+// Ledger is a billing provider which does not collect any payments, but produces monthly chargeback statements
+// for each attribution ID. Organisations can use those to charge back usage to their internal cost centers.
+//
+// The ledger keeps its state in a directory:
+//
+//	customers.json                        customers by attribution ID
+//	invoices/<invoiceID>.json             invoices, one per attribution ID and month
+//	disputes/<disputeID>.json             disputes filed against an invoice
+//	statements/<YYYY-MM>/<invoiceID>.json statements of finalized invoices
+//	statements/<YYYY-MM>/<invoiceID>.csv
+//
+// All replicas serialize their access to the directory through a file lock (see lock).
+type Ledger struct {
+	dir  string
+	conn *gorm.DB
+	now  func() time.Time
+}
+
+var _ billing.Provider = &Ledger{}
+
+// New creates a ledger. If conn is not nil, statements list the workspace usage they charge for.
+func New(cfg Config, conn *gorm.DB) (*Ledger, error) {
+	if cfg.Directory == "" {
+		return nil, fmt.Errorf("ledger directory is required")
+	}
+	// The ledger state lives in the pod's filesystem unless the directory is a volume, in which case
+	// it would be lost with the pod and every replica would charge its own share of the usage.
+	mounted, err := isMountPoint(cfg.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check ledger directory %s: %w", cfg.Directory, err)
+	}
+	if !mounted {
+		return nil, fmt.Errorf("ledger directory %s must be a persistent volume shared by all usage replicas", cfg.Directory)
+	}
+
+	return newLedger(cfg.Directory, conn)
+}
+
+func newLedger(dir string, conn *gorm.DB) (*Ledger, error) {
+	for _, d := range []string{"invoices", "disputes", "statements"} {
+		err := os.MkdirAll(filepath.Join(dir, d), 0755)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ledger directory: %w", err)
+		}
+	}
+
+	return &Ledger{
+		dir:  dir,
+		conn: conn,
+		now:  time.Now,
+	}, nil
+}
+
+// isMountPoint determines whether dir is the mount point of a filesystem
+func isMountPoint(dir string) (bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false, err
+	}
+
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// the mount point is the fifth field, see proc(5)
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		if unescapeMountInfo(fields[4]) == dir {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// unescapeMountInfo replaces the octal escapes of whitespace and backslashes in mountinfo fields
+func unescapeMountInfo(field string) string {
+	if !strings.Contains(field, "\\") {
+		return field
+	}
+	var res strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if c, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				res.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		res.WriteByte(field[i])
+	}
+	return res.String()
+}
+
+// lock acquires an exclusive lock on the ledger directory. The lock is a flock(2) on a file in the
+// shared directory, hence it serializes the access of all processes, and of all usage replicas.
+func (l *Ledger) lock() (unlock func(), err error) {
+	f, err := os.OpenFile(filepath.Join(l.dir, lockFilename), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger lock: %w", err)
+	}
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock ledger: %w", err)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+type invoice struct {
+	ID            string           `json:"id"`
+	AttributionID db.AttributionID `json:"attributionId"`
+	Period        string           `json:"period"`
+	Credits       int64            `json:"credits"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+	FinalizedAt   *time.Time       `json:"finalizedAt,omitempty"`
+}
+
+type dispute struct {
+	ID        string `json:"id"`
+	InvoiceID string `json:"invoiceId"`
+	Reason    string `json:"reason"`
+}
+
+// Statement charges an attribution ID for the credits it used in a month
+type Statement struct {
+	InvoiceID     string           `json:"invoiceId"`
+	AttributionID db.AttributionID `json:"attributionId"`
+	Period        string           `json:"period"`
+	Credits       int64            `json:"credits"`
+	FinalizedAt   time.Time        `json:"finalizedAt"`
+	Lines         []StatementLine  `json:"lines"`
+}
+
+// StatementLine is the usage of a single workspace instance
+type StatementLine struct {
+	EffectiveTime  time.Time `json:"effectiveTime"`
+	WorkspaceID    string    `json:"workspaceId"`
+	WorkspaceClass string    `json:"workspaceClass"`
+	UserName       string    `json:"userName"`
+	Credits        float64   `json:"credits"`
+}
+
+// InvoiceID produces the ID of the invoice for an attribution ID and month
+func InvoiceID(attributionID db.AttributionID, period time.Time) string {
+	entity, id := attributionID.Values()
+	return fmt.Sprintf("%s_%s_%s", period.UTC().Format(periodLayout), entity, id)
+}
+
+func (l *Ledger) Name() string {
+	return "internal ledger"
+}
+
+// Charges determines whether the ledger charges for the usage of a cost center.
+// The ledger charges all cost centers which are not billed through Stripe.
+func (l *Ledger) Charges(costCenter db.CostCenter) bool {
+	return costCenter.BillingStrategy != db.CostCenter_Stripe
+}
+
+func (l *Ledger) CreateCustomer(ctx context.Context, params billing.CreateCustomerParams) (*billing.Customer, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	customers := make(map[db.AttributionID]*billing.Customer)
+	err = l.readJSON("customers.json", &customers)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if customer, ok := customers[params.AttributionID]; ok {
+		return customer, nil
+	}
+
+	customer := &billing.Customer{
+		ID:            string(params.AttributionID),
+		AttributionID: params.AttributionID,
+		Currency:      params.Currency,
+		CreatedAt:     l.now(),
+	}
+	customers[params.AttributionID] = customer
+	err = l.writeJSON("customers.json", customers)
+	if err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// UpdateUsage records the credits on the invoice of the current month. Like Stripe, the ledger charges
+// for the credits which were reported last before the invoice is finalized.
+func (l *Ledger) UpdateUsage(ctx context.Context, creditsPerAttributionID map[db.AttributionID]int64) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := l.now()
+	for attributionID, credits := range creditsPerAttributionID {
+		inv := invoice{
+			ID:            InvoiceID(attributionID, now),
+			AttributionID: attributionID,
+			Period:        now.UTC().Format(periodLayout),
+		}
+		fn := invoiceFilename(inv.ID)
+		err := l.readJSON(fn, &inv)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if inv.FinalizedAt != nil {
+			log.WithField("invoice_id", inv.ID).Warn("Not updating usage on finalized invoice.")
+			continue
+		}
+
+		inv.Credits = credits
+		inv.UpdatedAt = now
+		err = l.writeJSON(fn, inv)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenInvoices lists the invoices of months which ended before the given time, but haven't been finalized yet
+func (l *Ledger) OpenInvoices(ctx context.Context, before time.Time) ([]string, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(filepath.Join(l.dir, "invoices"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	currentPeriod := before.UTC().Format(periodLayout)
+	var res []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+
+		var inv invoice
+		err := l.readJSON(filepath.Join("invoices", e.Name()), &inv)
+		if err != nil {
+			return nil, err
+		}
+		if inv.FinalizedAt != nil || inv.Period >= currentPeriod {
+			continue
+		}
+		res = append(res, inv.ID)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// FinalizeInvoice finalizes an invoice and writes its JSON and CSV statement
+func (l *Ledger) FinalizeInvoice(ctx context.Context, invoiceID string) (*billing.Invoice, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var inv invoice
+	fn := invoiceFilename(invoiceID)
+	err = l.readJSON(fn, &inv)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", billing.ErrInvoiceNotFound, invoiceID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if inv.FinalizedAt != nil {
+		return nil, fmt.Errorf("%w: %s", billing.ErrInvoiceFinalized, invoiceID)
+	}
+
+	now := l.now()
+	statement := Statement{
+		InvoiceID:     inv.ID,
+		AttributionID: inv.AttributionID,
+		Period:        inv.Period,
+		Credits:       inv.Credits,
+		FinalizedAt:   now,
+	}
+	statement.Lines, err = l.statementLines(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+	err = l.writeStatement(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.FinalizedAt = &now
+	err = l.writeJSON(fn, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &billing.Invoice{
+		ID:            inv.ID,
+		AttributionID: inv.AttributionID,
+		Credits:       inv.Credits,
+		FinalizedAt:   now,
+	}, nil
+}
+
+// FileDispute records that the attribution ID disputes the charges of an invoice
+func (l *Ledger) FileDispute(ctx context.Context, disputeID, invoiceID, reason string) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var inv invoice
+	err = l.readJSON(invoiceFilename(invoiceID), &inv)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", billing.ErrInvoiceNotFound, invoiceID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return l.writeJSON(disputeFilename(disputeID), dispute{
+		ID:        disputeID,
+		InvoiceID: invoiceID,
+		Reason:    reason,
+	})
+}
+
+func (l *Ledger) GetDispute(ctx context.Context, disputeID string) (*billing.Dispute, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var d dispute
+	err = l.readJSON(disputeFilename(disputeID), &d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dispute %s: %w", disputeID, err)
+	}
+	var inv invoice
+	err = l.readJSON(invoiceFilename(d.InvoiceID), &inv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read invoice %s of dispute %s: %w", d.InvoiceID, disputeID, err)
+	}
+
+	return &billing.Dispute{
+		ID:            d.ID,
+		AttributionID: inv.AttributionID,
+	}, nil
+}
+
+func (l *Ledger) statementLines(ctx context.Context, inv invoice) ([]StatementLine, error) {
+	if l.conn == nil {
+		return nil, nil
+	}
+
+	from, err := time.Parse(periodLayout, inv.Period)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period %s of invoice %s: %w", inv.Period, inv.ID, err)
+	}
+	records, err := db.FindUsage(ctx, l.conn, &db.FindUsageParams{
+		AttributionId: inv.AttributionID,
+		From:          from,
+		To:            from.AddDate(0, 1, 0),
+		ExcludeDrafts: true,
+		Order:         db.AscendingOrder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]StatementLine, 0, len(records))
+	for _, r := range records {
+		line := StatementLine{
+			EffectiveTime: r.EffectiveTime.Time(),
+			Credits:       r.CreditCents.ToCredits(),
+		}
+		if data, err := r.GetMetadataAsWorkspaceInstanceData(); err == nil {
+			line.WorkspaceID = data.WorkspaceId
+			line.WorkspaceClass = data.WorkspaceClass
+			line.UserName = data.UserName
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func (l *Ledger) writeStatement(s Statement) error {
+	dir := filepath.Join("statements", s.Period)
+	err := os.MkdirAll(filepath.Join(l.dir, dir), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create statement directory: %w", err)
+	}
+
+	err = l.writeJSON(filepath.Join(dir, s.InvoiceID+".json"), s)
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"invoice_id", "attribution_id", "period", "effective_time", "workspace_id", "workspace_class", "user_name", "credits"})
+	for _, line := range s.Lines {
+		_ = w.Write([]string{
+			s.InvoiceID,
+			string(s.AttributionID),
+			s.Period,
+			line.EffectiveTime.UTC().Format(time.RFC3339),
+			line.WorkspaceID,
+			line.WorkspaceClass,
+			line.UserName,
+			strconv.FormatFloat(line.Credits, 'f', 2, 64),
+		})
+	}
+	_ = w.Write([]string{s.InvoiceID, string(s.AttributionID), s.Period, "total", "", "", "", strconv.FormatInt(s.Credits, 10)})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to produce CSV statement: %w", err)
+	}
+
+	return writeFileAtomically(filepath.Join(l.dir, dir, s.InvoiceID+".csv"), []byte(buf.String()))
+}
+
+func invoiceFilename(invoiceID string) string {
+	return filepath.Join("invoices", filepath.Base(invoiceID)+".json")
+}
+
+func disputeFilename(disputeID string) string {
+	return filepath.Join("disputes", filepath.Base(disputeID)+".json")
+}
+
+func (l *Ledger) readJSON(fn string, v interface{}) error {
+	fc, err := os.ReadFile(filepath.Join(l.dir, fn))
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(fc, v)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", fn, err)
+	}
+	return nil
+}
+
+func (l *Ledger) writeJSON(fn string, v interface{}) error {
+	fc, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", fn, err)
+	}
+	return writeFileAtomically(filepath.Join(l.dir, fn), fc)
+}
+
+// writeFileAtomically ensures readers never see a partially written file
+func writeFileAtomically(fn string, content []byte) error {
+	tmp := fn + ".tmp"
+	err := os.WriteFile(tmp, content, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", fn, err)
+	}
+	err = os.Rename(tmp, fn)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", fn, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger_FinalizeInvoice(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	l, err := newLedger(dir, nil)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	teamID := uuid.New().String()
+	attributionID := db.NewTeamAttributionID(teamID)
+	require.NoError(t, l.UpdateUsage(ctx, map[db.AttributionID]int64{attributionID: 100}))
+	require.NoError(t, l.UpdateUsage(ctx, map[db.AttributionID]int64{attributionID: 150}))
+
+	invoiceID := InvoiceID(attributionID, now)
+	require.Equal(t, "2023-05_team_"+teamID, invoiceID)
+
+	open, err := l.OpenInvoices(ctx, now)
+	require.NoError(t, err)
+	require.Empty(t, open, "invoices of the current month must not be finalized")
+
+	open, err = l.OpenInvoices(ctx, now.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.Equal(t, []string{invoiceID}, open)
+
+	now = now.AddDate(0, 1, 0)
+	invoice, err := l.FinalizeInvoice(ctx, invoiceID)
+	require.NoError(t, err)
+	require.Equal(t, &billing.Invoice{
+		ID:            invoiceID,
+		AttributionID: attributionID,
+		Credits:       150,
+		FinalizedAt:   now,
+	}, invoice)
+
+	_, err = l.FinalizeInvoice(ctx, invoiceID)
+	require.ErrorIs(t, err, billing.ErrInvoiceFinalized)
+	_, err = l.FinalizeInvoice(ctx, "2023-05_team_unknown")
+	require.ErrorIs(t, err, billing.ErrInvoiceNotFound)
+
+	open, err = l.OpenInvoices(ctx, now)
+	require.NoError(t, err)
+	require.Empty(t, open)
+
+	fc, err := os.ReadFile(filepath.Join(dir, "statements", "2023-05", invoiceID+".json"))
+	require.NoError(t, err)
+	var statement Statement
+	require.NoError(t, json.Unmarshal(fc, &statement))
+	require.Equal(t, int64(150), statement.Credits)
+	require.Equal(t, attributionID, statement.AttributionID)
+	require.Equal(t, "2023-05", statement.Period)
+
+	fc, err = os.ReadFile(filepath.Join(dir, "statements", "2023-05", invoiceID+".csv"))
+	require.NoError(t, err)
+	require.Equal(t, "invoice_id,attribution_id,period,effective_time,workspace_id,workspace_class,user_name,credits\n"+
+		invoiceID+",team:"+teamID+",2023-05,total,,,,150\n", string(fc))
+}
+
+func TestLedger_GetDispute(t *testing.T) {
+	ctx := context.Background()
+	l, err := newLedger(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	require.NoError(t, l.UpdateUsage(ctx, map[db.AttributionID]int64{attributionID: 100}))
+
+	err = l.FileDispute(ctx, "dp_1", "unknown", "too expensive")
+	require.ErrorIs(t, err, billing.ErrInvoiceNotFound)
+
+	require.NoError(t, l.FileDispute(ctx, "dp_1", InvoiceID(attributionID, l.now()), "too expensive"))
+	dispute, err := l.GetDispute(ctx, "dp_1")
+	require.NoError(t, err)
+	require.Equal(t, &billing.Dispute{ID: "dp_1", AttributionID: attributionID}, dispute)
+
+	_, err = l.GetDispute(ctx, "dp_2")
+	require.Error(t, err)
+}
+
+func TestLedger_CreateCustomer(t *testing.T) {
+	ctx := context.Background()
+	l, err := newLedger(t.TempDir(), nil)
+	require.NoError(t, err)
+	l.now = func() time.Time { return time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC) }
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	first, err := l.CreateCustomer(ctx, billing.CreateCustomerParams{AttributionID: attributionID, Currency: "EUR"})
+	require.NoError(t, err)
+	require.Equal(t, string(attributionID), first.ID)
+
+	second, err := l.CreateCustomer(ctx, billing.CreateCustomerParams{AttributionID: attributionID, Currency: "USD"})
+	require.NoError(t, err)
+	require.Equal(t, first, second, "customers must only be created once")
+}
+
+func TestNew_RequiresVolume(t *testing.T) {
+	_, err := New(Config{}, nil)
+	require.Error(t, err)
+
+	// the test's temporary directory is not a volume of its own
+	_, err = New(Config{Directory: t.TempDir()}, nil)
+	require.ErrorContains(t, err, "must be a persistent volume")
+
+	mounted, err := isMountPoint("/")
+	require.NoError(t, err)
+	require.True(t, mounted)
+}
+
+func TestUnescapeMountInfo(t *testing.T) {
+	require.Equal(t, "/var/lib/ledger", unescapeMountInfo("/var/lib/ledger"))
+	require.Equal(t, "/mnt/my ledger", unescapeMountInfo(`/mnt/my\040ledger`))
+	require.Equal(t, `/mnt/back\slash`, unescapeMountInfo(`/mnt/back\134slash`))
+}
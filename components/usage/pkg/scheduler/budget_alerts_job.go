@@ -0,0 +1,35 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// BudgetAlertEvaluator checks the usage of cost centers against their alert thresholds
+type BudgetAlertEvaluator interface {
+	Evaluate(ctx context.Context) error
+}
+
+// NewBudgetAlertsJob evaluates budget alerts after each ledger run, i.e. once usage has been reconciled.
+func NewBudgetAlertsJob(evaluator BudgetAlertEvaluator) JobSpec {
+	return JobSpec{
+		Job: JobFunc(func() error {
+			log.Info("Evaluating budget alerts.")
+			err := evaluator.Evaluate(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to evaluate budget alerts: %w", err)
+			}
+			return nil
+		}),
+		ID:                  "budget_alerts",
+		After:               "ledger",
+		InitialLockDuration: 5 * time.Minute,
+	}
+}
@@ -37,55 +37,84 @@ type JobSpec struct {
 	ID                  string
 	Schedule            cron.Schedule
 	InitialLockDuration time.Duration
+
+	// After is the ID of a job after whose successful completion this job runs.
+	// Jobs which run after another job are not scheduled on their own, hence Schedule is ignored.
+	After string
 }
 
 func (c *Scheduler) Start() {
 	log.Infof("Starting usage scheduler. Setting up %d jobs.", len(c.specs))
 
+	followUps := make(map[string][]JobSpec)
+	for _, job := range c.specs {
+		if job.After != "" {
+			followUps[job.After] = append(followUps[job.After], job)
+		}
+	}
+
 	for _, job := range c.specs {
+		if job.After != "" {
+			continue
+		}
+
 		// need to re-assign job to avoid pointing to a different job spec once the `cron.FuncJob` executes.
 		j := job
 		c.cron.Schedule(job.Schedule, cron.FuncJob(func() {
-			ctx := context.Background()
 			c.runningJobs.Add(1)
 			defer c.runningJobs.Done()
 
-			now := time.Now().UTC()
-			logger := log.WithField("job_id", j.ID)
-
-			err := WithRefreshingMutex(ctx, c.mutex, j.ID, j.InitialLockDuration, func(ctx context.Context) error {
-				logger.Infof("Starting scheduled job %s", j.ID)
-				reportJobStarted(j.ID)
-				jobErr := j.Job.Run()
-				reportJobCompleted(j.ID, time.Since(now), jobErr)
-
-				if jobErr != nil {
-					// We don't propagate the job erros outside of run mutex context deliberately
-					// to contain each job errors
-					logger.WithError(jobErr).Errorf("Scheduled job %s failed.", job.ID)
-					return nil
-				}
-
-				logger.Infof("Scheduled job %s completed succesfully.", j.ID)
-				return jobErr
-			})
-			if err != nil {
-				if errors.Is(err, redsync.ErrFailed) {
-					logger.WithError(err).Info("Failed to acquire lock, another instance holds the lock already.")
-					return
-				}
-
-				logger.WithError(err).Error("Failed to execute job inside a mutex.")
-				return
-			}
-
-			logger.Debug("Succesfully obtained mutex and executed job.")
+			c.run(j, followUps)
 		}))
 	}
 
 	c.cron.Start()
 }
 
+// run executes a job inside a mutex, followed by the jobs which run after it
+func (c *Scheduler) run(j JobSpec, followUps map[string][]JobSpec) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	logger := log.WithField("job_id", j.ID)
+
+	var succeeded bool
+	err := WithRefreshingMutex(ctx, c.mutex, j.ID, j.InitialLockDuration, func(ctx context.Context) error {
+		logger.Infof("Starting scheduled job %s", j.ID)
+		reportJobStarted(j.ID)
+		jobErr := j.Job.Run()
+		reportJobCompleted(j.ID, time.Since(now), jobErr)
+
+		if jobErr != nil {
+			// We don't propagate the job erros outside of run mutex context deliberately
+			// to contain each job errors
+			logger.WithError(jobErr).Errorf("Scheduled job %s failed.", j.ID)
+			return nil
+		}
+
+		logger.Infof("Scheduled job %s completed succesfully.", j.ID)
+		succeeded = true
+		return jobErr
+	})
+	if err != nil {
+		if errors.Is(err, redsync.ErrFailed) {
+			logger.WithError(err).Info("Failed to acquire lock, another instance holds the lock already.")
+			return
+		}
+
+		logger.WithError(err).Error("Failed to execute job inside a mutex.")
+		return
+	}
+
+	logger.Debug("Succesfully obtained mutex and executed job.")
+
+	if !succeeded {
+		return
+	}
+	for _, followUp := range followUps[j.ID] {
+		c.run(followUp, followUps)
+	}
+}
+
 // Stop terminates the Scheduler and awaits for all running jobs to complete.
 func (c *Scheduler) Stop() {
 	log.Info("Stopping scheduler.")
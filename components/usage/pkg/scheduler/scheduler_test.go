@@ -5,6 +5,8 @@
 package scheduler
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -53,3 +55,75 @@ func TestScheduler_StopsAll(t *testing.T) {
 	require.True(t, firstRan)
 	require.True(t, secondRan)
 }
+
+func TestScheduler_RunsFollowUpsAfterSuccess(t *testing.T) {
+	red := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: red.Addr()})
+
+	pool := goredis.NewPool(client)
+	rs := redsync.New(pool)
+
+	var (
+		mu              sync.Mutex
+		ran             []string
+		ranAfterFailing bool
+	)
+	record := func(id string, err error) Job {
+		return JobFunc(func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran = append(ran, id)
+			return err
+		})
+	}
+
+	s := New(
+		rs,
+		JobSpec{
+			Job:                 record("first", nil),
+			ID:                  "first",
+			Schedule:            cron.ConstantDelaySchedule{Delay: time.Second},
+			InitialLockDuration: time.Second,
+		},
+		JobSpec{
+			Job:                 record("failing", fmt.Errorf("failed")),
+			ID:                  "failing",
+			Schedule:            cron.ConstantDelaySchedule{Delay: time.Second},
+			InitialLockDuration: time.Second,
+		},
+		JobSpec{
+			Job:                 record("after-first", nil),
+			ID:                  "after-first",
+			InitialLockDuration: time.Second,
+			After:               "first",
+		},
+		JobSpec{
+			Job: JobFunc(func() error {
+				ranAfterFailing = true
+				return nil
+			}),
+			ID:                  "after-failing",
+			InitialLockDuration: time.Second,
+			After:               "failing",
+		},
+	)
+	s.Start()
+	time.Sleep(1 * time.Second)
+	s.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Contains(t, ran, "first")
+	require.Contains(t, ran, "after-first")
+	require.Less(t, indexOf(ran, "first"), indexOf(ran, "after-first"))
+	require.False(t, ranAfterFailing, "follow-ups of failed jobs must not run")
+}
+
+func indexOf(l []string, e string) int {
+	for i, v := range l {
+		if v == e {
+			return i
+		}
+	}
+	return -1
+}
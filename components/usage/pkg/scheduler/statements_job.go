@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/robfig/cron"
+)
+
+// OpenInvoiceLister lists invoices which are due to be finalized
+type OpenInvoiceLister interface {
+	OpenInvoices(ctx context.Context, before time.Time) ([]string, error)
+}
+
+// NewStatementsJob periodically finalizes the invoices of past months, such that their statements are produced.
+func NewStatementsJob(schedule time.Duration, invoices OpenInvoiceLister, clientsConstructor ClientsConstructor) (JobSpec, error) {
+	parsed, err := cron.Parse(fmt.Sprintf("@every %s", schedule.String()))
+	if err != nil {
+		return JobSpec{}, fmt.Errorf("failed to parse period into schedule: %w", err)
+	}
+
+	return JobSpec{
+		Job: &StatementsJob{
+			invoices:           invoices,
+			clientsConstructor: clientsConstructor,
+		},
+		ID:                  "statements",
+		Schedule:            parsed,
+		InitialLockDuration: schedule,
+	}, nil
+}
+
+type StatementsJob struct {
+	invoices           OpenInvoiceLister
+	clientsConstructor ClientsConstructor
+}
+
+func (j *StatementsJob) Run() error {
+	ctx := context.Background()
+
+	invoiceIDs, err := j.invoices.OpenInvoices(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to list open invoices: %w", err)
+	}
+	if len(invoiceIDs) == 0 {
+		return nil
+	}
+
+	_, billingClient, err := j.clientsConstructor()
+	if err != nil {
+		return fmt.Errorf("failed to construct statements job clients: %w", err)
+	}
+
+	log.WithField("invoices", len(invoiceIDs)).Info("Finalizing invoices of past months.")
+	var failed int
+	for _, id := range invoiceIDs {
+		_, err := billingClient.FinalizeInvoice(ctx, &v1.FinalizeInvoiceRequest{InvoiceId: id})
+		if err != nil {
+			log.WithError(err).WithField("invoice_id", id).Error("Failed to finalize invoice.")
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to finalize %d of %d invoices", failed, len(invoiceIDs))
+	}
+
+	return nil
+}
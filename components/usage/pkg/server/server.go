@@ -21,7 +21,10 @@ import (
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/alerts"
 	"github.com/gitpod-io/gitpod/usage/pkg/apiv1"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"github.com/gitpod-io/gitpod/usage/pkg/ledger"
 	"github.com/gitpod-io/gitpod/usage/pkg/stripe"
 	"gorm.io/gorm"
 
@@ -41,8 +44,19 @@ type Config struct {
 
 	CreditsPerMinuteByWorkspaceClass map[string]float64 `json:"creditsPerMinuteByWorkspaceClass,omitempty"`
 
+	// PricingRules price usage beyond the flat CreditsPerMinuteByWorkspaceClass, which remain in effect for usage no rule matches
+	PricingRules *apiv1.PricingRules `json:"pricingRules,omitempty"`
+
 	StripeCredentialsFile string `json:"stripeCredentialsFile,omitempty"`
 
+	// InternalLedger charges back usage through monthly statements instead of billing it through Stripe.
+	// The internal ledger settles usage itself, hence ResetUsageSchedule must be empty when it is configured.
+	InternalLedger *ledger.Config `json:"internalLedger,omitempty"`
+
+	// StatementsSchedule determines how frequently to check for internal ledger invoices of past months
+	// which need to be finalized. When empty, the job is disabled.
+	StatementsSchedule string `json:"statementsSchedule,omitempty"`
+
 	Server *baseserver.Configuration `json:"server,omitempty"`
 
 	DefaultSpendingLimit db.DefaultSpendingLimit `json:"defaultSpendingLimit"`
@@ -50,6 +64,10 @@ type Config struct {
 	// StripePrices configure which Stripe Price IDs should be used
 	StripePrices stripe.StripePrices `json:"stripePrices"`
 
+	// BudgetAlerts notifies about cost centers approaching their spending limit.
+	// Alerts are evaluated after each ledger run, hence require the LedgerSchedule to be set.
+	BudgetAlerts *alerts.Config `json:"budgetAlerts,omitempty"`
+
 	// Redis configures the connection to Redis
 	Redis RedisConfiguration `json:"redis"`
 
@@ -100,7 +118,11 @@ func Start(cfg Config, version string) error {
 		)
 	}
 
-	pricer, err := apiv1.NewWorkspacePricer(cfg.CreditsPerMinuteByWorkspaceClass)
+	var pricingRules apiv1.PricingRules
+	if cfg.PricingRules != nil {
+		pricingRules = *cfg.PricingRules
+	}
+	pricer, err := apiv1.NewWorkspacePricerWithRules(cfg.CreditsPerMinuteByWorkspaceClass, pricingRules)
 	if err != nil {
 		return fmt.Errorf("failed to create workspace pricer: %w", err)
 	}
@@ -120,6 +142,21 @@ func Start(cfg Config, version string) error {
 		stripeClient = c
 	}
 
+	var internalLedger *ledger.Ledger
+	if cfg.InternalLedger != nil {
+		if stripeClient != nil {
+			return fmt.Errorf("cannot use both, Stripe and the internal ledger")
+		}
+		if cfg.ResetUsageSchedule != "" {
+			return fmt.Errorf("the internal ledger settles usage itself, resetUsageSchedule must be empty")
+		}
+
+		internalLedger, err = ledger.New(*cfg.InternalLedger, conn)
+		if err != nil {
+			return fmt.Errorf("failed to initialize internal ledger: %w", err)
+		}
+	}
+
 	redisClient := redis.NewClient(&redis.Options{
 		Addr: cfg.Redis.Address,
 	})
@@ -171,11 +208,42 @@ func Start(cfg Config, version string) error {
 		schedulerJobSpecs = append(schedulerJobSpecs, spec)
 	}
 
+	if cfg.BudgetAlerts != nil {
+		if cfg.LedgerSchedule == "" {
+			return fmt.Errorf("budget alerts require the ledger schedule to be set")
+		}
+
+		sinks, err := alerts.NewSinks(*cfg.BudgetAlerts)
+		if err != nil {
+			return fmt.Errorf("failed to setup budget alert sinks: %w", err)
+		}
+		evaluator, err := alerts.NewEvaluator(conn, db.NewCostCenterManager(conn, cfg.DefaultSpendingLimit), *cfg.BudgetAlerts, sinks...)
+		if err != nil {
+			return fmt.Errorf("failed to setup budget alerts: %w", err)
+		}
+
+		schedulerJobSpecs = append(schedulerJobSpecs, scheduler.NewBudgetAlertsJob(evaluator))
+	}
+
+	if internalLedger != nil && cfg.StatementsSchedule != "" {
+		schedule, err := time.ParseDuration(cfg.StatementsSchedule)
+		if err != nil {
+			return fmt.Errorf("failed to parse statements schedule as duration: %w", err)
+		}
+
+		spec, err := scheduler.NewStatementsJob(schedule, internalLedger, jobClientsConstructor)
+		if err != nil {
+			return fmt.Errorf("failed to setup statements job: %w", err)
+		}
+
+		schedulerJobSpecs = append(schedulerJobSpecs, spec)
+	}
+
 	sched := scheduler.New(redsyncPool, schedulerJobSpecs...)
 	sched.Start()
 	defer sched.Stop()
 
-	err = registerGRPCServices(srv, conn, stripeClient, pricer, cfg)
+	err = registerGRPCServices(srv, conn, stripeClient, internalLedger, pricer, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to register gRPC services: %w", err)
 	}
@@ -198,17 +266,25 @@ func Start(cfg Config, version string) error {
 	return nil
 }
 
-func registerGRPCServices(srv *baseserver.Server, conn *gorm.DB, stripeClient *stripe.Client, pricer *apiv1.WorkspacePricer, cfg Config) error {
+func registerGRPCServices(srv *baseserver.Server, conn *gorm.DB, stripeClient *stripe.Client, internalLedger *ledger.Ledger, pricer *apiv1.WorkspacePricer, cfg Config) error {
 	ccManager := db.NewCostCenterManager(conn, cfg.DefaultSpendingLimit)
 	v1.RegisterUsageServiceServer(srv.GRPC(), apiv1.NewUsageService(conn, pricer, ccManager))
 
 	teamsService := v1connect.NewTeamsServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", cfg.ServerAddress))
 	userService := v1connect.NewUserServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", cfg.ServerAddress))
 
-	if stripeClient == nil {
+	var provider billing.Provider
+	switch {
+	case stripeClient != nil:
+		provider = stripe.NewBillingProvider(stripeClient)
+	case internalLedger != nil:
+		provider = internalLedger
+	}
+
+	if provider == nil {
 		v1.RegisterBillingServiceServer(srv.GRPC(), &apiv1.BillingServiceNoop{})
 	} else {
-		v1.RegisterBillingServiceServer(srv.GRPC(), apiv1.NewBillingService(stripeClient, conn, ccManager, cfg.StripePrices, teamsService, userService))
+		v1.RegisterBillingServiceServer(srv.GRPC(), apiv1.NewBillingService(provider, stripeClient, conn, ccManager, cfg.StripePrices, teamsService, userService))
 	}
 	return nil
 }
@@ -0,0 +1,119 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// NewBillingProvider bills usage through Stripe
+func NewBillingProvider(client *Client) *BillingProvider {
+	return &BillingProvider{client: client}
+}
+
+// BillingProvider implements billing.Provider using Stripe
+type BillingProvider struct {
+	client *Client
+}
+
+var _ billing.Provider = &BillingProvider{}
+
+func (p *BillingProvider) Name() string {
+	return "Stripe"
+}
+
+// Charges determines whether the cost center is billed against Stripe
+func (p *BillingProvider) Charges(costCenter db.CostCenter) bool {
+	return costCenter.BillingStrategy == db.CostCenter_Stripe
+}
+
+func (p *BillingProvider) CreateCustomer(ctx context.Context, params billing.CreateCustomerParams) (*billing.Customer, error) {
+	customer, err := p.client.CreateCustomer(ctx, CreateCustomerParams{
+		AttributionID:        string(params.AttributionID),
+		Currency:             params.Currency,
+		Email:                params.Email,
+		Name:                 params.Name,
+		BillingCreatorUserID: params.BillingCreatorUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &billing.Customer{
+		ID:            customer.ID,
+		AttributionID: params.AttributionID,
+		Currency:      customer.Metadata[PreferredCurrencyMetadataKey],
+		CreatedAt:     time.Unix(customer.Created, 0),
+	}, nil
+}
+
+func (p *BillingProvider) UpdateUsage(ctx context.Context, creditsPerAttributionID map[db.AttributionID]int64) error {
+	return p.client.UpdateUsage(ctx, creditsPerAttributionID)
+}
+
+// FinalizeInvoice retrieves an invoice which Stripe has finalized
+func (p *BillingProvider) FinalizeInvoice(ctx context.Context, invoiceID string) (*billing.Invoice, error) {
+	invoice, err := p.client.GetInvoiceWithCustomer(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", billing.ErrInvoiceNotFound, err.Error())
+	}
+	return ConvertInvoice(ctx, invoice)
+}
+
+func (p *BillingProvider) GetDispute(ctx context.Context, disputeID string) (*billing.Dispute, error) {
+	dispute, err := p.client.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dispute.PaymentIntent == nil || dispute.PaymentIntent.Customer == nil {
+		return nil, fmt.Errorf("dispute did not contain customer of payment intent in expanded fields")
+	}
+
+	customer := dispute.PaymentIntent.Customer
+	attributionIDValue, ok := customer.Metadata[AttributionIDMetadataKey]
+	if !ok {
+		return nil, fmt.Errorf("customer %s object did not contain attribution ID in metadata", customer.ID)
+	}
+	attributionID, err := db.ParseAttributionID(attributionIDValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse attribution ID from customer metadata: %w", err)
+	}
+
+	return &billing.Dispute{
+		ID:            dispute.ID,
+		AttributionID: attributionID,
+	}, nil
+}
+
+// ConvertInvoice converts a Stripe invoice, which must have its customer expanded.
+// The credits on the invoice are the sum of the quantity of all its lines.
+func ConvertInvoice(ctx context.Context, invoice *stripe.Invoice) (*billing.Invoice, error) {
+	attributionID, err := GetAttributionID(ctx, invoice.Customer)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Lines == nil || len(invoice.Lines.Data) == 0 {
+		return nil, fmt.Errorf("invoice %s did not contain any lines so we cannot extract quantity to reflect it in usage", invoice.ID)
+	}
+
+	var credits int64
+	for _, line := range invoice.Lines.Data {
+		credits += line.Quantity
+	}
+
+	return &billing.Invoice{
+		ID:            invoice.ID,
+		AttributionID: attributionID,
+		Credits:       credits,
+		FinalizedAt:   time.Unix(invoice.StatusTransitions.FinalizedAt, 0),
+	}, nil
+}
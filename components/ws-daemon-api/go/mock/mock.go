@@ -389,6 +389,26 @@ func (mr *MockInWorkspaceServiceClientMockRecorder) PrepareForUserNS(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareForUserNS", reflect.TypeOf((*MockInWorkspaceServiceClient)(nil).PrepareForUserNS), varargs...)
 }
 
+// ResizeStorageQuota mocks base method.
+func (m *MockInWorkspaceServiceClient) ResizeStorageQuota(arg0 context.Context, arg1 *api.ResizeStorageQuotaRequest, arg2 ...grpc.CallOption) (*api.ResizeStorageQuotaResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResizeStorageQuota", varargs...)
+	ret0, _ := ret[0].(*api.ResizeStorageQuotaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResizeStorageQuota indicates an expected call of ResizeStorageQuota.
+func (mr *MockInWorkspaceServiceClientMockRecorder) ResizeStorageQuota(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeStorageQuota", reflect.TypeOf((*MockInWorkspaceServiceClient)(nil).ResizeStorageQuota), varargs...)
+}
+
 // SetupPairVeths mocks base method.
 func (m *MockInWorkspaceServiceClient) SetupPairVeths(arg0 context.Context, arg1 *api.SetupPairVethsRequest, arg2 ...grpc.CallOption) (*api.SetupPairVethsResponse, error) {
 	m.ctrl.T.Helper()
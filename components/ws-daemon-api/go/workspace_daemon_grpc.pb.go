@@ -63,6 +63,9 @@ type InWorkspaceServiceClient interface {
 	SetupPairVeths(ctx context.Context, in *SetupPairVethsRequest, opts ...grpc.CallOption) (*SetupPairVethsResponse, error)
 	// Get information about the workspace
 	WorkspaceInfo(ctx context.Context, in *WorkspaceInfoRequest, opts ...grpc.CallOption) (*WorkspaceInfoResponse, error)
+	// ResizeStorageQuota changes the storage quota of the running workspace. The new size must not
+	// be smaller than the storage currently in use, nor exceed the maximum configured in ws-daemon.
+	ResizeStorageQuota(ctx context.Context, in *ResizeStorageQuotaRequest, opts ...grpc.CallOption) (*ResizeStorageQuotaResponse, error)
 }
 
 type inWorkspaceServiceClient struct {
@@ -163,6 +166,15 @@ func (c *inWorkspaceServiceClient) WorkspaceInfo(ctx context.Context, in *Worksp
 	return out, nil
 }
 
+func (c *inWorkspaceServiceClient) ResizeStorageQuota(ctx context.Context, in *ResizeStorageQuotaRequest, opts ...grpc.CallOption) (*ResizeStorageQuotaResponse, error) {
+	out := new(ResizeStorageQuotaResponse)
+	err := c.cc.Invoke(ctx, "/iws.InWorkspaceService/ResizeStorageQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InWorkspaceServiceServer is the server API for InWorkspaceService service.
 // All implementations must embed UnimplementedInWorkspaceServiceServer
 // for forward compatibility
@@ -204,6 +216,9 @@ type InWorkspaceServiceServer interface {
 	SetupPairVeths(context.Context, *SetupPairVethsRequest) (*SetupPairVethsResponse, error)
 	// Get information about the workspace
 	WorkspaceInfo(context.Context, *WorkspaceInfoRequest) (*WorkspaceInfoResponse, error)
+	// ResizeStorageQuota changes the storage quota of the running workspace. The new size must not
+	// be smaller than the storage currently in use, nor exceed the maximum configured in ws-daemon.
+	ResizeStorageQuota(context.Context, *ResizeStorageQuotaRequest) (*ResizeStorageQuotaResponse, error)
 	mustEmbedUnimplementedInWorkspaceServiceServer()
 }
 
@@ -241,6 +256,9 @@ func (UnimplementedInWorkspaceServiceServer) SetupPairVeths(context.Context, *Se
 func (UnimplementedInWorkspaceServiceServer) WorkspaceInfo(context.Context, *WorkspaceInfoRequest) (*WorkspaceInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WorkspaceInfo not implemented")
 }
+func (UnimplementedInWorkspaceServiceServer) ResizeStorageQuota(context.Context, *ResizeStorageQuotaRequest) (*ResizeStorageQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeStorageQuota not implemented")
+}
 func (UnimplementedInWorkspaceServiceServer) mustEmbedUnimplementedInWorkspaceServiceServer() {}
 
 // UnsafeInWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -434,6 +452,24 @@ func _InWorkspaceService_WorkspaceInfo_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InWorkspaceService_ResizeStorageQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeStorageQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InWorkspaceServiceServer).ResizeStorageQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iws.InWorkspaceService/ResizeStorageQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InWorkspaceServiceServer).ResizeStorageQuota(ctx, req.(*ResizeStorageQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InWorkspaceService_ServiceDesc is the grpc.ServiceDesc for InWorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -481,6 +517,10 @@ var InWorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WorkspaceInfo",
 			Handler:    _InWorkspaceService_WorkspaceInfo_Handler,
 		},
+		{
+			MethodName: "ResizeStorageQuota",
+			Handler:    _InWorkspaceService_ResizeStorageQuota_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "workspace_daemon.proto",
@@ -76,6 +76,28 @@ function deserialize_iws_PrepareForUserNSResponse(buffer_arg) {
   return workspace_daemon_pb.PrepareForUserNSResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_iws_ResizeStorageQuotaRequest(arg) {
+  if (!(arg instanceof workspace_daemon_pb.ResizeStorageQuotaRequest)) {
+    throw new Error('Expected argument of type iws.ResizeStorageQuotaRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_iws_ResizeStorageQuotaRequest(buffer_arg) {
+  return workspace_daemon_pb.ResizeStorageQuotaRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_iws_ResizeStorageQuotaResponse(arg) {
+  if (!(arg instanceof workspace_daemon_pb.ResizeStorageQuotaResponse)) {
+    throw new Error('Expected argument of type iws.ResizeStorageQuotaResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_iws_ResizeStorageQuotaResponse(buffer_arg) {
+  return workspace_daemon_pb.ResizeStorageQuotaResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_iws_SetupPairVethsRequest(arg) {
   if (!(arg instanceof workspace_daemon_pb.SetupPairVethsRequest)) {
     throw new Error('Expected argument of type iws.SetupPairVethsRequest');
@@ -325,6 +347,19 @@ workspaceInfo: {
     responseSerialize: serialize_iws_WorkspaceInfoResponse,
     responseDeserialize: deserialize_iws_WorkspaceInfoResponse,
   },
+  // ResizeStorageQuota changes the storage quota of the running workspace. The new size must not
+// be smaller than the storage currently in use, nor exceed the maximum configured in ws-daemon.
+resizeStorageQuota: {
+    path: '/iws.InWorkspaceService/ResizeStorageQuota',
+    requestStream: false,
+    responseStream: false,
+    requestType: workspace_daemon_pb.ResizeStorageQuotaRequest,
+    responseType: workspace_daemon_pb.ResizeStorageQuotaResponse,
+    requestSerialize: serialize_iws_ResizeStorageQuotaRequest,
+    requestDeserialize: deserialize_iws_ResizeStorageQuotaRequest,
+    responseSerialize: serialize_iws_ResizeStorageQuotaResponse,
+    responseDeserialize: deserialize_iws_ResizeStorageQuotaResponse,
+  },
 };
 
 exports.InWorkspaceServiceClient = grpc.makeGenericClientConstructor(InWorkspaceServiceService);
@@ -371,6 +371,11 @@ export class Resources extends jspb.Message {
     getMemory(): Memory | undefined;
     setMemory(value?: Memory): Resources;
 
+    hasStorage(): boolean;
+    clearStorage(): void;
+    getStorage(): Storage | undefined;
+    setStorage(value?: Storage): Resources;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Resources.AsObject;
     static toObject(includeInstance: boolean, msg: Resources): Resources.AsObject;
@@ -385,6 +390,7 @@ export namespace Resources {
     export type AsObject = {
         cpu?: Cpu.AsObject,
         memory?: Memory.AsObject,
+        storage?: Storage.AsObject,
     }
 }
 
@@ -434,6 +440,72 @@ export namespace Memory {
     }
 }
 
+export class Storage extends jspb.Message {
+    getUsed(): number;
+    setUsed(value: number): Storage;
+    getLimit(): number;
+    setLimit(value: number): Storage;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): Storage.AsObject;
+    static toObject(includeInstance: boolean, msg: Storage): Storage.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: Storage, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): Storage;
+    static deserializeBinaryFromReader(message: Storage, reader: jspb.BinaryReader): Storage;
+}
+
+export namespace Storage {
+    export type AsObject = {
+        used: number,
+        limit: number,
+    }
+}
+
+export class ResizeStorageQuotaRequest extends jspb.Message {
+    getSize(): number;
+    setSize(value: number): ResizeStorageQuotaRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ResizeStorageQuotaRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: ResizeStorageQuotaRequest): ResizeStorageQuotaRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ResizeStorageQuotaRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ResizeStorageQuotaRequest;
+    static deserializeBinaryFromReader(message: ResizeStorageQuotaRequest, reader: jspb.BinaryReader): ResizeStorageQuotaRequest;
+}
+
+export namespace ResizeStorageQuotaRequest {
+    export type AsObject = {
+        size: number,
+    }
+}
+
+export class ResizeStorageQuotaResponse extends jspb.Message {
+
+    hasStorage(): boolean;
+    clearStorage(): void;
+    getStorage(): Storage | undefined;
+    setStorage(value?: Storage): ResizeStorageQuotaResponse;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ResizeStorageQuotaResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: ResizeStorageQuotaResponse): ResizeStorageQuotaResponse.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ResizeStorageQuotaResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ResizeStorageQuotaResponse;
+    static deserializeBinaryFromReader(message: ResizeStorageQuotaResponse, reader: jspb.BinaryReader): ResizeStorageQuotaResponse;
+}
+
+export namespace ResizeStorageQuotaResponse {
+    export type AsObject = {
+        storage?: Storage.AsObject,
+    }
+}
+
 export enum FSShiftMethod {
     SHIFTFS = 0,
     FUSE = 1,
@@ -30,9 +30,12 @@ goog.exportSymbol('proto.iws.MountProcRequest', null, global);
 goog.exportSymbol('proto.iws.MountProcResponse', null, global);
 goog.exportSymbol('proto.iws.PrepareForUserNSRequest', null, global);
 goog.exportSymbol('proto.iws.PrepareForUserNSResponse', null, global);
+goog.exportSymbol('proto.iws.ResizeStorageQuotaRequest', null, global);
+goog.exportSymbol('proto.iws.ResizeStorageQuotaResponse', null, global);
 goog.exportSymbol('proto.iws.Resources', null, global);
 goog.exportSymbol('proto.iws.SetupPairVethsRequest', null, global);
 goog.exportSymbol('proto.iws.SetupPairVethsResponse', null, global);
+goog.exportSymbol('proto.iws.Storage', null, global);
 goog.exportSymbol('proto.iws.TeardownRequest', null, global);
 goog.exportSymbol('proto.iws.TeardownResponse', null, global);
 goog.exportSymbol('proto.iws.UmountProcRequest', null, global);
@@ -462,6 +465,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.iws.Memory.displayName = 'proto.iws.Memory';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.Storage = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.Storage, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.iws.Storage.displayName = 'proto.iws.Storage';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.ResizeStorageQuotaRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.ResizeStorageQuotaRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.iws.ResizeStorageQuotaRequest.displayName = 'proto.iws.ResizeStorageQuotaRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.ResizeStorageQuotaResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.ResizeStorageQuotaResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.iws.ResizeStorageQuotaResponse.displayName = 'proto.iws.ResizeStorageQuotaResponse';
+}
 
 
 
@@ -2764,7 +2830,8 @@ proto.iws.Resources.prototype.toObject = function(opt_includeInstance) {
 proto.iws.Resources.toObject = function(includeInstance, msg) {
   var f, obj = {
     cpu: (f = msg.getCpu()) && proto.iws.Cpu.toObject(includeInstance, f),
-    memory: (f = msg.getMemory()) && proto.iws.Memory.toObject(includeInstance, f)
+    memory: (f = msg.getMemory()) && proto.iws.Memory.toObject(includeInstance, f),
+    storage: (f = msg.getStorage()) && proto.iws.Storage.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -2811,6 +2878,11 @@ proto.iws.Resources.deserializeBinaryFromReader = function(msg, reader) {
       reader.readMessage(value,proto.iws.Memory.deserializeBinaryFromReader);
       msg.setMemory(value);
       break;
+    case 3:
+      var value = new proto.iws.Storage;
+      reader.readMessage(value,proto.iws.Storage.deserializeBinaryFromReader);
+      msg.setStorage(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -2856,6 +2928,14 @@ proto.iws.Resources.serializeBinaryToWriter = function(message, writer) {
       proto.iws.Memory.serializeBinaryToWriter
     );
   }
+  f = message.getStorage();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      proto.iws.Storage.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -2933,6 +3013,43 @@ proto.iws.Resources.prototype.hasMemory = function() {
 };
 
 
+/**
+ * optional Storage storage = 3;
+ * @return {?proto.iws.Storage}
+ */
+proto.iws.Resources.prototype.getStorage = function() {
+  return /** @type{?proto.iws.Storage} */ (
+    jspb.Message.getWrapperField(this, proto.iws.Storage, 3));
+};
+
+
+/**
+ * @param {?proto.iws.Storage|undefined} value
+ * @return {!proto.iws.Resources} returns this
+*/
+proto.iws.Resources.prototype.setStorage = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.clearStorage = function() {
+  return this.setStorage(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.iws.Resources.prototype.hasStorage = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
 
 
 
@@ -3253,6 +3370,447 @@ proto.iws.Memory.prototype.setLimit = function(value) {
 };
 
 
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.iws.Storage.prototype.toObject = function(opt_includeInstance) {
+  return proto.iws.Storage.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.iws.Storage} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.Storage.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    used: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    limit: jspb.Message.getFieldWithDefault(msg, 2, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.Storage}
+ */
+proto.iws.Storage.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.iws.Storage;
+  return proto.iws.Storage.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.Storage} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.Storage}
+ */
+proto.iws.Storage.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setUsed(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setLimit(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.Storage.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.iws.Storage.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.Storage} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.Storage.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUsed();
+  if (f !== 0) {
+    writer.writeInt64(
+      1,
+      f
+    );
+  }
+  f = message.getLimit();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional int64 used = 1;
+ * @return {number}
+ */
+proto.iws.Storage.prototype.getUsed = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Storage} returns this
+ */
+proto.iws.Storage.prototype.setUsed = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional int64 limit = 2;
+ * @return {number}
+ */
+proto.iws.Storage.prototype.getLimit = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Storage} returns this
+ */
+proto.iws.Storage.prototype.setLimit = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.iws.ResizeStorageQuotaRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.iws.ResizeStorageQuotaRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.iws.ResizeStorageQuotaRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.ResizeStorageQuotaRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    size: jspb.Message.getFieldWithDefault(msg, 1, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.ResizeStorageQuotaRequest}
+ */
+proto.iws.ResizeStorageQuotaRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.iws.ResizeStorageQuotaRequest;
+  return proto.iws.ResizeStorageQuotaRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.ResizeStorageQuotaRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.ResizeStorageQuotaRequest}
+ */
+proto.iws.ResizeStorageQuotaRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.ResizeStorageQuotaRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.iws.ResizeStorageQuotaRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.ResizeStorageQuotaRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.ResizeStorageQuotaRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional int64 size = 1;
+ * @return {number}
+ */
+proto.iws.ResizeStorageQuotaRequest.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.ResizeStorageQuotaRequest} returns this
+ */
+proto.iws.ResizeStorageQuotaRequest.prototype.setSize = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.iws.ResizeStorageQuotaResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.iws.ResizeStorageQuotaResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.iws.ResizeStorageQuotaResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.ResizeStorageQuotaResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    storage: (f = msg.getStorage()) && proto.iws.Storage.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.ResizeStorageQuotaResponse}
+ */
+proto.iws.ResizeStorageQuotaResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.iws.ResizeStorageQuotaResponse;
+  return proto.iws.ResizeStorageQuotaResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.ResizeStorageQuotaResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.ResizeStorageQuotaResponse}
+ */
+proto.iws.ResizeStorageQuotaResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.iws.Storage;
+      reader.readMessage(value,proto.iws.Storage.deserializeBinaryFromReader);
+      msg.setStorage(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.ResizeStorageQuotaResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.iws.ResizeStorageQuotaResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.ResizeStorageQuotaResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.ResizeStorageQuotaResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getStorage();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.iws.Storage.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional Storage storage = 1;
+ * @return {?proto.iws.Storage}
+ */
+proto.iws.ResizeStorageQuotaResponse.prototype.getStorage = function() {
+  return /** @type{?proto.iws.Storage} */ (
+    jspb.Message.getWrapperField(this, proto.iws.Storage, 1));
+};
+
+
+/**
+ * @param {?proto.iws.Storage|undefined} value
+ * @return {!proto.iws.ResizeStorageQuotaResponse} returns this
+*/
+proto.iws.ResizeStorageQuotaResponse.prototype.setStorage = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.iws.ResizeStorageQuotaResponse} returns this
+ */
+proto.iws.ResizeStorageQuotaResponse.prototype.clearStorage = function() {
+  return this.setStorage(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.iws.ResizeStorageQuotaResponse.prototype.hasStorage = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
 /**
  * @enum {number}
  */
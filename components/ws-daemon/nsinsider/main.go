@@ -627,6 +627,48 @@ func main() {
 					return nil
 				},
 			},
+			{
+				Name:  "setup-network-shaping",
+				Usage: "set up bandwidth shaping and destination allow/deny lists",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{
+						Name:  "egress-rate",
+						Usage: "egress bytes per second, zero means unlimited",
+					},
+					&cli.Int64Flag{
+						Name:  "ingress-rate",
+						Usage: "ingress bytes per second, zero means unlimited",
+					},
+					&cli.StringSliceFlag{
+						Name:  "always-allow",
+						Usage: "destination (cidr[:port]) the workspace may connect to regardless of the allow and deny lists",
+					},
+					&cli.StringSliceFlag{
+						Name:  "allow",
+						Usage: "destination (cidr[:port]) the workspace may connect to",
+					},
+					&cli.StringSliceFlag{
+						Name:  "deny",
+						Usage: "destination (cidr[:port]) the workspace must not connect to",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					alwaysAllow, err := parseDestinations(c.StringSlice("always-allow"))
+					if err != nil {
+						return err
+					}
+					allow, err := parseDestinations(c.StringSlice("allow"))
+					if err != nil {
+						return err
+					}
+					deny, err := parseDestinations(c.StringSlice("deny"))
+					if err != nil {
+						return err
+					}
+
+					return setupNetworkShaping(c.Int64("egress-rate"), c.Int64("ingress-rate"), alwaysAllow, allow, deny)
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,292 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package main
+
+import (
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// keep in sync with pkg/netlimit
+	shapingTable        = "gitpod-shaping"
+	egressBytesCounter  = "ws-egress-bytes"
+	ingressBytesCounter = "ws-ingress-bytes"
+	egressDropCounter   = "ws-egress-drop-stats"
+	ingressDropCounter  = "ws-ingress-drop-stats"
+	policyDropCounter   = "ws-policy-drop-stats"
+
+	// objectTypeCounter is NFT_OBJECT_COUNTER
+	objectTypeCounter = 1
+
+	// dnsPort is always allowed, such that workspaces can resolve the destinations they may connect to
+	dnsPort = 53
+)
+
+type destination struct {
+	Net  *net.IPNet
+	Port uint16
+}
+
+// parseDestinations parses destinations in cidr[:port] notation. IPv6 destinations with a port are
+// written as [cidr]:port.
+func parseDestinations(specs []string) ([]destination, error) {
+	res := make([]destination, 0, len(specs))
+	for _, spec := range specs {
+		var (
+			cidr = spec
+			port uint64
+			err  error
+		)
+		if _, _, perr := net.ParseCIDR(spec); perr != nil {
+			if idx := strings.LastIndex(spec, ":"); idx >= 0 {
+				cidr = strings.TrimSuffix(strings.TrimPrefix(spec[:idx], "["), "]")
+				port, err = strconv.ParseUint(spec[idx+1:], 10, 16)
+				if err != nil {
+					return nil, xerrors.Errorf("invalid port in destination %s: %w", spec, err)
+				}
+			}
+		}
+
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid destination %s: %w", spec, err)
+		}
+		res = append(res, destination{Net: ipnet, Port: uint16(port)})
+	}
+	return res, nil
+}
+
+// setupNetworkShaping (re-)creates the gitpod-shaping table. All previous rules and counters
+// are replaced in a single, atomic transaction. The table handles IPv4 and IPv6 traffic alike.
+//
+// Egress traffic passes the allow/deny lists for new connections first, and the egress byte-rate
+// limit second. Ingress traffic is only subject to the ingress byte-rate limit. Loopback traffic
+// is never restricted. DNS and the always allowed destinations, i.e. the ones supervisor and the
+// IDE depend on, are never subject to the allow/deny lists.
+func setupNetworkShaping(egressRate, ingressRate int64, alwaysAllow, allow, deny []destination) error {
+	nftcon := nftables.Conn{}
+
+	// nft add table inet gitpod-shaping; nft delete table inet gitpod-shaping
+	// adding the table first makes sure the deletion succeeds even if the table doesn't exist yet.
+	table := &nftables.Table{
+		Family: nftables.TableFamilyINet,
+		Name:   shapingTable,
+	}
+	nftcon.AddTable(table)
+	nftcon.DelTable(table)
+	table = nftcon.AddTable(table)
+
+	for _, name := range []string{egressBytesCounter, ingressBytesCounter, egressDropCounter, ingressDropCounter, policyDropCounter} {
+		nftcon.AddObject(&nftables.CounterObj{
+			Table: table,
+			Name:  name,
+		})
+	}
+
+	// nft add chain inet gitpod-shaping egress { type filter hook postrouting priority 0 \; }
+	egress := nftcon.AddChain(&nftables.Chain{
+		Table:    table,
+		Name:     "egress",
+		Type:     nftables.ChainTypeFilter,
+		Hooknum:  nftables.ChainHookPostrouting,
+		Priority: nftables.ChainPriorityFilter,
+	})
+	// nft add chain inet gitpod-shaping egress-shaping
+	egressShaping := nftcon.AddChain(&nftables.Chain{
+		Table: table,
+		Name:  "egress-shaping",
+	})
+	// nft add chain inet gitpod-shaping ingress { type filter hook prerouting priority 0 \; }
+	ingress := nftcon.AddChain(&nftables.Chain{
+		Table:    table,
+		Name:     "ingress",
+		Type:     nftables.ChainTypeFilter,
+		Hooknum:  nftables.ChainHookPrerouting,
+		Priority: nftables.ChainPriorityFilter,
+	})
+
+	addRule := func(chain *nftables.Chain, exprs ...expr.Any) {
+		nftcon.AddRule(&nftables.Rule{
+			Table: table,
+			Chain: chain,
+			Exprs: exprs,
+		})
+	}
+	toShaping := &expr.Verdict{Kind: expr.VerdictGoto, Chain: egressShaping.Name}
+	policyDrop := []expr.Any{
+		&expr.Objref{Type: objectTypeCounter, Name: policyDropCounter},
+		&expr.Verdict{Kind: expr.VerdictDrop},
+	}
+
+	// oifname "lo" accept
+	addRule(egress,
+		&expr.Meta{Key: expr.MetaKeyOIFNAME, Register: 1},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: ifname("lo")},
+		&expr.Verdict{Kind: expr.VerdictAccept},
+	)
+	// ct state established,related goto egress-shaping
+	addRule(egress,
+		&expr.Ct{Key: expr.CtKeySTATE, Register: 1},
+		&expr.Bitwise{
+			DestRegister:   1,
+			SourceRegister: 1,
+			Len:            4,
+			Mask:           binaryutil.NativeEndian.PutUint32(expr.CtStateBitESTABLISHED | expr.CtStateBitRELATED),
+			Xor:            binaryutil.NativeEndian.PutUint32(0),
+		},
+		&expr.Cmp{Op: expr.CmpOpNeq, Register: 1, Data: []byte{0, 0, 0, 0}},
+		toShaping,
+	)
+	// meta l4proto {tcp, udp} th dport 53 goto egress-shaping
+	for _, match := range matchPort(nil, dnsPort) {
+		addRule(egress, append(match, toShaping)...)
+	}
+	// ip daddr <cidr> [meta l4proto tcp th dport <port>] goto egress-shaping
+	for _, d := range alwaysAllow {
+		for _, match := range matchDestination(d) {
+			addRule(egress, append(match, toShaping)...)
+		}
+	}
+	// ip daddr <cidr> [meta l4proto tcp th dport <port>] counter name ws-policy-drop-stats drop
+	for _, d := range deny {
+		for _, match := range matchDestination(d) {
+			addRule(egress, append(match, policyDrop...)...)
+		}
+	}
+	if len(allow) > 0 {
+		// ip daddr <cidr> [meta l4proto tcp th dport <port>] goto egress-shaping
+		for _, d := range allow {
+			for _, match := range matchDestination(d) {
+				addRule(egress, append(match, toShaping)...)
+			}
+		}
+		// counter name ws-policy-drop-stats drop
+		addRule(egress, policyDrop...)
+	} else {
+		addRule(egress, toShaping)
+	}
+
+	// limit rate over <rate> bytes/second counter name ws-egress-drop-stats drop
+	// counter name ws-egress-bytes
+	if egressRate > 0 {
+		addRule(egressShaping, limitBytes(egressRate, egressDropCounter)...)
+	}
+	addRule(egressShaping, &expr.Objref{Type: objectTypeCounter, Name: egressBytesCounter})
+
+	// iifname "lo" accept
+	addRule(ingress,
+		&expr.Meta{Key: expr.MetaKeyIIFNAME, Register: 1},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: ifname("lo")},
+		&expr.Verdict{Kind: expr.VerdictAccept},
+	)
+	// limit rate over <rate> bytes/second counter name ws-ingress-drop-stats drop
+	// counter name ws-ingress-bytes
+	if ingressRate > 0 {
+		addRule(ingress, limitBytes(ingressRate, ingressDropCounter)...)
+	}
+	addRule(ingress, &expr.Objref{Type: objectTypeCounter, Name: ingressBytesCounter})
+
+	if err := nftcon.Flush(); err != nil {
+		return xerrors.Errorf("failed to apply network shaping: %v", err)
+	}
+
+	return nil
+}
+
+// limitBytes produces a rule which drops all traffic beyond rate bytes per second. We allow bursts
+// of one second worth of traffic.
+func limitBytes(rate int64, dropCounter string) []expr.Any {
+	burst := uint32(math.MaxUint32)
+	if rate < math.MaxUint32 {
+		burst = uint32(rate)
+	}
+	return []expr.Any{
+		&expr.Limit{Type: expr.LimitTypePktBytes, Rate: uint64(rate), Unit: expr.LimitTimeSecond, Burst: burst, Over: true},
+		&expr.Objref{Type: objectTypeCounter, Name: dropCounter},
+		&expr.Verdict{Kind: expr.VerdictDrop},
+	}
+}
+
+// matchDestination produces the expressions matching traffic to a destination. Destinations with a port
+// produce one match for TCP and one for UDP.
+func matchDestination(d destination) [][]expr.Any {
+	var (
+		family = byte(unix.NFPROTO_IPV4)
+		// offset and length of the destination address in the IPv4 header
+		offset, size uint32 = 16, net.IPv4len
+		ip                  = d.Net.IP.To4()
+		mask                = []byte(net.IP(d.Net.Mask).To4())
+	)
+	if ip == nil {
+		// offset and length of the destination address in the IPv6 header
+		family, offset, size = unix.NFPROTO_IPV6, 24, net.IPv6len
+		ip, mask = d.Net.IP.To16(), []byte(d.Net.Mask)
+	}
+
+	daddr := []expr.Any{
+		// meta nfproto ipv4|ipv6
+		&expr.Meta{Key: expr.MetaKeyNFPROTO, Register: 1},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{family}},
+		// ip[6] daddr & <mask> == <network>
+		&expr.Payload{
+			DestRegister: 1,
+			Base:         expr.PayloadBaseNetworkHeader,
+			Offset:       offset,
+			Len:          size,
+		},
+		&expr.Bitwise{
+			DestRegister:   1,
+			SourceRegister: 1,
+			Len:            size,
+			Mask:           mask,
+			Xor:            make([]byte, size),
+		},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: ip},
+	}
+	if d.Port == 0 {
+		return [][]expr.Any{daddr}
+	}
+	return matchPort(daddr, d.Port)
+}
+
+// matchPort produces the expressions matching TCP and UDP traffic to a port in addition to match,
+// one match per protocol.
+func matchPort(match []expr.Any, port uint16) [][]expr.Any {
+	var res [][]expr.Any
+	for _, proto := range []byte{unix.IPPROTO_TCP, unix.IPPROTO_UDP} {
+		m := append([]expr.Any{}, match...)
+		m = append(m,
+			// meta l4proto <proto>
+			&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+			&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{proto}},
+			// th dport <port>
+			&expr.Payload{
+				DestRegister: 1,
+				Base:         expr.PayloadBaseTransportHeader,
+				Offset:       2,
+				Len:          2,
+			},
+			&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: binaryutil.BigEndian.PutUint16(port)},
+		)
+		res = append(res, m)
+	}
+	return res
+}
+
+// ifname pads an interface name to IFNAMSIZ as nftables expects it
+func ifname(name string) []byte {
+	res := make([]byte, unix.IFNAMSIZ)
+	copy(res, name+"\x00")
+	return res
+}
@@ -47,6 +47,31 @@ type Runtime interface {
 
 	// IsContainerdReady returns is the status of containerd.
 	IsContainerdReady(ctx context.Context) (bool, error)
+
+	// CheckpointContainer checkpoints the process tree of a running container using CRIU and writes the checkpoint
+	// image to imagePath. imagePath is relative to the root mount namespace. The processes exit once they're checkpointed.
+	// Returns the container's OCI runtime spec, which the container runtime needs to restore the checkpoint.
+	//
+	// If the container is not found ErrNotFound is returned.
+	// If the container runtime cannot restore checkpoints ErrCheckpointRestoreUnsupported is returned.
+	CheckpointContainer(ctx context.Context, id ID, imagePath string) (spec []byte, err error)
+
+	// WorkspaceImage waits for the restore container of a workspace which restores its checkpoint to come into existence
+	// and returns the image it was created from, i.e. the image the workspace container would be started afresh from.
+	// Implementors have to respect context cancelation.
+	WorkspaceImage(ctx context.Context, workspaceInstanceID string) (ref string, err error)
+
+	// ImportCheckpoint stores the checkpoint archive found in archive as checkpoint image ref. The container runtime
+	// restores containers created from a checkpoint image, rather than starting them afresh. archive has to be
+	// accessible from the calling process.
+	//
+	// If the container runtime cannot restore checkpoints ErrCheckpointRestoreUnsupported is returned.
+	ImportCheckpoint(ctx context.Context, ref string, archive string) error
+
+	// TagImage makes the image target available as ref, too.
+	//
+	// If the image target is not found ErrNotFound is returned.
+	TagImage(ctx context.Context, ref, target string) error
 }
 
 var (
@@ -58,6 +83,10 @@ var (
 
 	// ErrNoCGroup means the container has no cgroup
 	ErrNoCGroup = xerrors.Errorf("no cgroup available")
+
+	// ErrCheckpointRestoreUnsupported means the container runtime cannot restore checkpoints, i.e. hibernated
+	// workspaces would cold start anyways. Restoring checkpoints requires containerd 2.0 or later on the node.
+	ErrCheckpointRestoreUnsupported = xerrors.Errorf("container runtime cannot restore checkpoints, containerd 2.0 or later is required")
 )
 
 // ID represents the ID of a CRI container
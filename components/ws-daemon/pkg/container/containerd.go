@@ -5,11 +5,16 @@
 package container
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,8 +24,15 @@ import (
 	"github.com/containerd/containerd/api/services/tasks/v1"
 	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/runtime/linux/runctypes"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
 	"github.com/containerd/typeurl"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	ocispecs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
@@ -28,6 +40,7 @@ import (
 	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 )
 
 const (
@@ -35,6 +48,11 @@ const (
 	containerLabelCRIKind          = "io.cri-containerd.kind"
 	containerLabelK8sContainerName = "io.kubernetes.container.name"
 	containerLabelK8sPodName       = "io.kubernetes.pod.name"
+
+	// criImageLabel marks images the CRI plugin manages, i.e. images kubelet can create containers from
+	criImageLabel = "io.cri-containerd.image"
+	// checkpointAnnotationName marks checkpoint images, see https://github.com/checkpoint-restore/checkpointctl
+	checkpointAnnotationName = "org.criu.checkpoint.container.name"
 )
 
 // NewContainerd creates a new containerd adapter
@@ -45,7 +63,7 @@ func NewContainerd(cfg *ContainerdConfig, mounts *NodeMountsLookup, pathMapping
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	_, err = cc.Version(ctx)
+	version, err := cc.Version(ctx)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot connect to containerd: %w", err)
 	}
@@ -55,6 +73,8 @@ func NewContainerd(cfg *ContainerdConfig, mounts *NodeMountsLookup, pathMapping
 		Mounts:  mounts,
 		Mapping: pathMapping,
 
+		checkpointRestore: supportsCheckpointRestore(version.Version),
+
 		cond:   sync.NewCond(&sync.Mutex{}),
 		cntIdx: make(map[string]*containerInfo),
 		podIdx: make(map[string]*containerInfo),
@@ -71,6 +91,9 @@ type Containerd struct {
 	Mounts  *NodeMountsLookup
 	Mapping PathMapping
 
+	// checkpointRestore is true if the CRI plugin creates containers from checkpoint images by restoring them
+	checkpointRestore bool
+
 	cond   *sync.Cond
 	podIdx map[string]*containerInfo
 	wsiIdx map[string]*containerInfo
@@ -90,6 +113,8 @@ type containerInfo struct {
 	UpperDir    string
 	CGroupPath  string
 	PID         uint32
+	// Image is the image the restore container of a workspace which restores its checkpoint was created from
+	Image string
 }
 
 // start listening to containerd
@@ -250,6 +275,22 @@ func (s *Containerd) handleNewContainer(c containers.Container) {
 		return
 	}
 
+	if c.Labels[containerLabelCRIKind] == "container" && c.Labels[containerLabelK8sContainerName] == workspacev1.RestoreContainerName {
+		s.cond.L.Lock()
+		defer s.cond.L.Unlock()
+
+		info, ok := s.podIdx[podName]
+		if !ok {
+			// we haven't seen this container's sandbox, hence have no info about it
+			return
+		}
+
+		// the restore container runs the workspace image before the workspace container is created from the checkpoint
+		info.Image = c.Image
+		s.cond.Broadcast()
+		return
+	}
+
 	if c.Labels[containerLabelCRIKind] == "container" && c.Labels[containerLabelK8sContainerName] == "workspace" {
 		s.cond.L.Lock()
 		defer s.cond.L.Unlock()
@@ -492,6 +533,267 @@ func (s *Containerd) IsContainerdReady(ctx context.Context) (bool, error) {
 	return s.Client.IsServing(ctx)
 }
 
+// CheckpointContainer checkpoints the process tree of a running container using CRIU
+func (s *Containerd) CheckpointContainer(ctx context.Context, id ID, imagePath string) (spec []byte, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CheckpointContainer")
+	span.LogKV("containerID", id, "imagePath", imagePath)
+	defer tracing.FinishSpan(span, &err)
+
+	// a checkpoint which cannot be restored would only stop the workspace processes
+	if !s.checkpointRestore {
+		return nil, ErrCheckpointRestoreUnsupported
+	}
+
+	cnt, err := s.Client.LoadContainer(ctx, string(id))
+	if errdefs.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot load container %s: %w", id, err)
+	}
+	info, err := cnt.Info(ctx, containerd.WithoutRefreshedMetadata)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot load container %s: %w", id, err)
+	}
+	task, err := cnt.Task(ctx, nil)
+	if errdefs.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot load task of container %s: %w", id, err)
+	}
+
+	// With an image path runc writes the CRIU image there instead of producing a checkpoint image in the content store.
+	// The processes exit once they're checkpointed, such that nothing changes the workspace content after the checkpoint.
+	_, err = task.Checkpoint(ctx, containerd.WithCheckpointImagePath(imagePath), withCheckpointExit)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot checkpoint container %s: %w", id, err)
+	}
+
+	return info.Spec.Value, nil
+}
+
+// withCheckpointExit makes runc stop the processes of the task once they're checkpointed
+func withCheckpointExit(r *containerd.CheckpointTaskInfo) error {
+	if containerd.CheckRuntime(r.Runtime(), "io.containerd.runc") {
+		if r.Options == nil {
+			r.Options = &options.CheckpointOptions{}
+		}
+		opts, ok := r.Options.(*options.CheckpointOptions)
+		if !ok {
+			return xerrors.Errorf("invalid v2 shim checkpoint options format")
+		}
+		opts.Exit = true
+		return nil
+	}
+
+	if r.Options == nil {
+		r.Options = &runctypes.CheckpointOptions{}
+	}
+	opts, ok := r.Options.(*runctypes.CheckpointOptions)
+	if !ok {
+		return xerrors.Errorf("invalid v1 shim checkpoint options format")
+	}
+	opts.Exit = true
+	return nil
+}
+
+// supportsCheckpointRestore returns true if containerd restores containers created from checkpoint images,
+// which its CRI plugin supports as of containerd 2.0.
+func supportsCheckpointRestore(version string) bool {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	v, err := strconv.Atoi(major)
+	return err == nil && v >= 2
+}
+
+// WorkspaceImage returns the image the restore container of a workspace was created from
+func (s *Containerd) WorkspaceImage(ctx context.Context, workspaceInstanceID string) (ref string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "WorkspaceImage")
+	span.LogKV("workspaceInstanceID", workspaceInstanceID)
+	defer tracing.FinishSpan(span, &err)
+
+	rchan := make(chan string, 1)
+	go func() {
+		s.cond.L.Lock()
+		defer s.cond.L.Unlock()
+
+		for {
+			info, ok := s.wsiIdx[workspaceInstanceID]
+			if ok && info.Image != "" {
+				rchan <- info.Image
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			s.cond.Wait()
+		}
+	}()
+
+	select {
+	case ref = <-rchan:
+		return
+	case <-ctx.Done():
+		// wake up the waiting goroutine so that it notices the cancellation
+		s.cond.Broadcast()
+		err = ctx.Err()
+		return
+	}
+}
+
+// ImportCheckpoint stores a checkpoint archive as a checkpoint image
+func (s *Containerd) ImportCheckpoint(ctx context.Context, ref string, archive string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ImportCheckpoint")
+	span.LogKV("ref", ref, "archive", archive)
+	defer tracing.FinishSpan(span, &err)
+
+	if !s.checkpointRestore {
+		return ErrCheckpointRestoreUnsupported
+	}
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return xerrors.Errorf("cannot open checkpoint archive: %w", err)
+	}
+	defer f.Close()
+
+	layerDigest, err := digest.FromReader(f)
+	if err != nil {
+		return xerrors.Errorf("cannot digest checkpoint archive: %w", err)
+	}
+	layerSize, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return xerrors.Errorf("cannot digest checkpoint archive: %w", err)
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return xerrors.Errorf("cannot read checkpoint archive: %w", err)
+	}
+	layer := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    layerDigest,
+		Size:      layerSize,
+	}
+
+	store := s.Client.ContentStore()
+	err = content.WriteBlob(ctx, store, layer.Digest.String(), f, layer)
+	if err != nil {
+		return xerrors.Errorf("cannot store checkpoint archive: %w", err)
+	}
+
+	imgConfig := ocispec.Image{
+		Config: ocispec.ImageConfig{
+			Labels: map[string]string{checkpointAnnotationName: ref},
+		},
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{layer.Digest},
+		},
+	}
+	imgConfig.Architecture = runtime.GOARCH
+	imgConfig.OS = "linux"
+	config, err := writeJSONBlob(ctx, store, ocispec.MediaTypeImageConfig, imgConfig, nil)
+	if err != nil {
+		return xerrors.Errorf("cannot store checkpoint image config: %w", err)
+	}
+
+	manifest, err := writeJSONBlob(ctx, store, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+		// the container runtime restores containers created from images with this annotation
+		Annotations: map[string]string{checkpointAnnotationName: ref},
+	}, map[string]string{
+		// keep the config and layer around as long as the manifest is
+		"containerd.io/gc.ref.content.config": config.Digest.String(),
+		"containerd.io/gc.ref.content.l.0":    layer.Digest.String(),
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot store checkpoint image manifest: %w", err)
+	}
+
+	img, err := s.storeImage(ctx, images.Image{
+		Name:   ref,
+		Target: manifest,
+		Labels: map[string]string{criImageLabel: "managed"},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = containerd.NewImage(s.Client, img).Unpack(ctx, "")
+	if err != nil {
+		return xerrors.Errorf("cannot unpack checkpoint image %s: %w", ref, err)
+	}
+
+	return nil
+}
+
+// TagImage makes an image available under another name
+func (s *Containerd) TagImage(ctx context.Context, ref, target string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TagImage")
+	span.LogKV("ref", ref, "target", target)
+	defer tracing.FinishSpan(span, &err)
+
+	img, err := s.Client.ImageService().Get(ctx, target)
+	if errdefs.IsNotFound(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return xerrors.Errorf("cannot find image %s: %w", target, err)
+	}
+
+	_, err = s.storeImage(ctx, images.Image{
+		Name:   ref,
+		Target: img.Target,
+		Labels: img.Labels,
+	})
+	return err
+}
+
+// storeImage creates an image, or points an existing image with the same name to the new target
+func (s *Containerd) storeImage(ctx context.Context, img images.Image) (images.Image, error) {
+	is := s.Client.ImageService()
+	res, err := is.Create(ctx, img)
+	if errdefs.IsAlreadyExists(err) {
+		res, err = is.Update(ctx, img, "target", "labels")
+	}
+	if err != nil {
+		return images.Image{}, xerrors.Errorf("cannot store image %s: %w", img.Name, err)
+	}
+	return res, nil
+}
+
+// writeJSONBlob stores a JSON document in the content store
+func writeJSONBlob(ctx context.Context, store content.Store, mediaType string, doc interface{}, labels map[string]string) (ocispec.Descriptor, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(b),
+		Size:      int64(len(b)),
+	}
+
+	var opts []content.Opt
+	if labels != nil {
+		opts = append(opts, content.WithLabels(labels))
+	}
+	err = content.WriteBlob(ctx, store, desc.Digest.String(), bytes.NewReader(b), desc, opts...)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
 var kubepodsQoSRegexp = regexp.MustCompile(`([^/]+)-([^/]+)-pod`)
 var kubepodsRegexp = regexp.MustCompile(`([^/]+)-pod`)
 
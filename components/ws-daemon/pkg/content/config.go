@@ -11,6 +11,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	cntntcfg "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/ws-daemon/api"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 	"golang.org/x/xerrors"
 )
 
@@ -39,6 +40,10 @@ type Config struct {
 
 	// Initializer configures the isolated content initializer runtime
 	Initializer InitializerConfig `json:"initializer"`
+
+	// MaxStorageQuota is the largest storage quota a running workspace can resize itself to.
+	// Resizing is disabled if this is not set.
+	MaxStorageQuota quota.Size `json:"maxStorageQuota,omitempty"`
 }
 
 type BackupConfig struct {
@@ -52,6 +57,11 @@ type BackupConfig struct {
 
 	// Period is the time between regular workspace backups
 	Period util.Duration `json:"period"`
+
+	// Incremental enables chunked, content-addressed backups which only upload
+	// the parts of a workspace that changed since previous backups. Incremental backups
+	// are not available if the storage encrypts backups client-side.
+	Incremental bool `json:"incremental,omitempty"`
 }
 
 type UserNamespacesConfig struct {
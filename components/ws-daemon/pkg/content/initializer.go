@@ -9,11 +9,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 	"google.golang.org/protobuf/proto"
 
@@ -73,8 +77,16 @@ func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 	} else if err != nil {
 		return nil, err
 	} else {
+		err = addDataKey(ctx, rs, rs.Bucket(workspaceOwner), rs.BackupObject(storage.DefaultBackup), backup)
+		if err != nil {
+			return nil, err
+		}
 		rc[storage.DefaultBackup] = *backup
 	}
+	err = collectIncrementalContent(ctx, ps, rs.Bucket(workspaceOwner), rs.BackupObject(storage.IncrementalManifestName(storage.DefaultBackup)), storage.DefaultBackup, rc)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot collect incremental backup: %w", err)
+	}
 
 	si := initializer.GetSnapshot()
 	pi := initializer.GetPrebuild()
@@ -93,27 +105,44 @@ func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 		if err != nil {
 			return nil, err
 		}
+		err = collectIncrementalContent(ctx, ps, bkt, obj+storage.IncrementalManifestSuffix, si.Snapshot, rc)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot collect incremental snapshot: %w", err)
+		}
 		info, err := ps.SignDownload(ctx, bkt, obj, &storage.SignedURLOptions{})
 		if err == storage.ErrNotFound {
-			return nil, errCannotFindSnapshot
-		}
-		if err != nil {
+			if _, incremental := rc[storage.IncrementalManifestName(si.Snapshot)]; !incremental {
+				return nil, errCannotFindSnapshot
+			}
+		} else if err != nil {
 			return nil, xerrors.Errorf("cannot find snapshot: %w", err)
+		} else {
+			err = addDataKey(ctx, rs, bkt, obj, info)
+			if err != nil {
+				return nil, err
+			}
+			rc[si.Snapshot] = *info
 		}
-
-		rc[si.Snapshot] = *info
 	}
 	if pi != nil && pi.Prebuild != nil && pi.Prebuild.Snapshot != "" {
 		bkt, obj, err := storage.ParseSnapshotName(pi.Prebuild.Snapshot)
 		if err != nil {
 			return nil, err
 		}
+		err = collectIncrementalContent(ctx, ps, bkt, obj+storage.IncrementalManifestSuffix, pi.Prebuild.Snapshot, rc)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot collect incremental prebuild: %w", err)
+		}
 		info, err := ps.SignDownload(ctx, bkt, obj, &storage.SignedURLOptions{})
 		if err == storage.ErrNotFound {
 			// no prebuild found - that's fine
 		} else if err != nil {
 			return nil, xerrors.Errorf("cannot find prebuild: %w", err)
 		} else {
+			err = addDataKey(ctx, rs, bkt, obj, info)
+			if err != nil {
+				return nil, err
+			}
 			rc[pi.Prebuild.Snapshot] = *info
 		}
 	}
@@ -121,6 +150,105 @@ func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 	return rc, nil
 }
 
+// addDataKey hands the data key of client-side encrypted objects to the content initializer, which
+// cannot access the keyring itself.
+func addDataKey(ctx context.Context, rs storage.DirectAccess, bkt, obj string, info *storage.DownloadInfo) error {
+	ea, ok := rs.(storage.EncryptedAccess)
+	if !ok {
+		return nil
+	}
+
+	dataKey, err := ea.ObjectDataKey(ctx, bkt, obj)
+	if err != nil {
+		return xerrors.Errorf("cannot get data key of %s: %w", obj, err)
+	}
+	info.DataKey = dataKey
+	return nil
+}
+
+// collectIncrementalContent adds the manifest of an incremental backup and all chunks it references to the remote content.
+// The manifest is stored under the manifest name of the backup, the chunks under their digest.
+func collectIncrementalContent(ctx context.Context, ps storage.PresignedAccess, bkt, obj, name string, rc map[string]storage.DownloadInfo) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "collectIncrementalContent")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	info, err := ps.SignDownload(ctx, bkt, obj, &storage.SignedURLOptions{})
+	if err == storage.ErrNotFound {
+		// no incremental backup - that's fine
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	manifest, err := downloadIncrementalManifest(ctx, info.URL)
+	if err != nil {
+		return err
+	}
+
+	var (
+		missing = make(map[string]storage.IncrementalChunk)
+		mu      sync.Mutex
+		eg      errgroup.Group
+	)
+	for _, c := range manifest.Chunks {
+		if _, exists := rc[c.Digest.String()]; !exists {
+			missing[c.Digest.String()] = c
+		}
+	}
+	eg.SetLimit(16)
+	for _, c := range missing {
+		c := c
+		eg.Go(func() error {
+			chunk, err := ps.SignDownload(ctx, bkt, manifest.ChunkObject(c.Digest), &storage.SignedURLOptions{})
+			if err != nil {
+				return xerrors.Errorf("cannot sign chunk %s: %w", c.Digest, err)
+			}
+
+			mu.Lock()
+			rc[c.Digest.String()] = *chunk
+			mu.Unlock()
+			return nil
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return err
+	}
+
+	rc[storage.IncrementalManifestName(name)] = *info
+	return nil
+}
+
+func downloadIncrementalManifest(ctx context.Context, url string) (*storage.IncrementalManifest, error) {
+	body, err := httpGet(ctx, url)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download incremental backup manifest: %w", err)
+	}
+	defer body.Close()
+
+	return storage.ParseIncrementalManifest(body)
+}
+
+func httpGet(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, xerrors.Errorf("non-OK status code: %v", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
 // RunInitializer runs a content initializer in a user, PID and mount namespace to isolate it from ws-daemon
 func RunInitializer(ctx context.Context, destination string, initializer *csapi.WorkspaceInitializer, remoteContent map[string]storage.DownloadInfo, opts RunInitializerOpts) (err error) {
 	//nolint:ineffassign,staticcheck
@@ -171,7 +299,15 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filepath.Join(tmpdir, "rootfs", "content.json"), fc, 0644)
+	// content.json carries the data keys of encrypted backups, hence only the initializer may read it,
+	// and it must not outlive the initializer.
+	contentFile := filepath.Join(tmpdir, "rootfs", "content.json")
+	defer os.Remove(contentFile)
+	err = os.WriteFile(contentFile, fc, 0600)
+	if err != nil {
+		return err
+	}
+	err = os.Chown(contentFile, int(opts.UID), int(opts.GID))
 	if err != nil {
 		return err
 	}
@@ -274,6 +410,9 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 	err = cmd.Run()
 	log.FromBuffer(&cmdOut, log.WithFields(opts.OWI.Fields()))
 	errOut.Close()
+	if rerr := os.Remove(contentFile); rerr != nil && !os.IsNotExist(rerr) {
+		log.WithError(rerr).WithFields(opts.OWI.Fields()).Warn("cannot remove content initializer message")
+	}
 
 	var errmsg []byte
 	select {
@@ -361,6 +500,8 @@ func RunInitializerChild() (err error) {
 }
 
 var _ storage.DirectAccess = &remoteContentStorage{}
+var _ storage.IncrementalDownloader = &remoteContentStorage{}
+var _ storage.DownloadInfoProvider = &remoteContentStorage{}
 
 type remoteContentStorage struct {
 	RemoteContent map[string]storage.DownloadInfo
@@ -421,7 +562,15 @@ func (rs *remoteContentStorage) Download(ctx context.Context, destination string
 	defer os.Remove(tempFile.Name())
 	defer tempFile.Close()
 
-	err = archive.ExtractTarbal(ctx, tempFile, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	var src io.Reader = tempFile
+	if info.DataKey != nil {
+		src, err = storage.NewDecryptingReader(tempFile, info.DataKey)
+		if err != nil {
+			return true, xerrors.Errorf("cannot decrypt %s: %w", name, err)
+		}
+	}
+
+	err = archive.ExtractTarbal(ctx, src, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
 	}
@@ -429,6 +578,46 @@ func (rs *remoteContentStorage) Download(ctx context.Context, destination string
 	return true, nil
 }
 
+// DownloadInfo describes the remote content of the given name
+func (rs *remoteContentStorage) DownloadInfo(name string) (*storage.DownloadInfo, error) {
+	info, exists := rs.RemoteContent[name]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	return &info, nil
+}
+
+// DownloadIncremental restores an incremental backup from its chunks
+func (rs *remoteContentStorage) DownloadIncremental(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (exists bool, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "remoteContentStorage.DownloadIncremental")
+	span.SetTag("destination", destination)
+	span.SetTag("name", name)
+	defer tracing.FinishSpan(span, &err)
+
+	info, exists := rs.RemoteContent[storage.IncrementalManifestName(name)]
+	if !exists {
+		return false, nil
+	}
+
+	manifest, err := downloadIncrementalManifest(ctx, info.URL)
+	if err != nil {
+		return true, err
+	}
+
+	err = storage.RestoreIncremental(ctx, destination, manifest, func(ctx context.Context, chunk storage.IncrementalChunk) (io.ReadCloser, error) {
+		info, exists := rs.RemoteContent[chunk.Digest.String()]
+		if !exists {
+			return nil, xerrors.Errorf("missing chunk %s", chunk.Digest)
+		}
+		return httpGet(ctx, info.URL)
+	}, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
 // DownloadSnapshot always returns false and does nothing
 func (rs *remoteContentStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
 	return rs.Download(ctx, destination, name, mappings)
@@ -39,6 +39,22 @@ func (m *MockWorkspaceOperations) EXPECT() *MockWorkspaceOperationsMockRecorder
 	return m.recorder
 }
 
+// BackupIDs mocks base method.
+func (m *MockWorkspaceOperations) BackupIDs(arg0 context.Context, arg1 string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupIDs", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BackupIDs indicates an expected call of BackupIDs.
+func (mr *MockWorkspaceOperationsMockRecorder) BackupIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupIDs", reflect.TypeOf((*MockWorkspaceOperations)(nil).BackupIDs), arg0, arg1)
+}
+
 // BackupWorkspace mocks base method.
 func (m *MockWorkspaceOperations) BackupWorkspace(arg0 context.Context, arg1 BackupOptions) (*api.GitStatus, error) {
 	m.ctrl.T.Helper()
@@ -54,6 +70,20 @@ func (mr *MockWorkspaceOperationsMockRecorder) BackupWorkspace(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupWorkspace", reflect.TypeOf((*MockWorkspaceOperations)(nil).BackupWorkspace), arg0, arg1)
 }
 
+// CheckpointWorkspace mocks base method.
+func (m *MockWorkspaceOperations) CheckpointWorkspace(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckpointWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckpointWorkspace indicates an expected call of CheckpointWorkspace.
+func (mr *MockWorkspaceOperationsMockRecorder) CheckpointWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckpointWorkspace", reflect.TypeOf((*MockWorkspaceOperations)(nil).CheckpointWorkspace), arg0, arg1)
+}
+
 // DeleteWorkspace mocks base method.
 func (m *MockWorkspaceOperations) DeleteWorkspace(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -83,6 +113,21 @@ func (mr *MockWorkspaceOperationsMockRecorder) InitWorkspace(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitWorkspace", reflect.TypeOf((*MockWorkspaceOperations)(nil).InitWorkspace), arg0, arg1)
 }
 
+// RestoreCheckpoint mocks base method.
+func (m *MockWorkspaceOperations) RestoreCheckpoint(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCheckpoint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCheckpoint indicates an expected call of RestoreCheckpoint.
+func (mr *MockWorkspaceOperationsMockRecorder) RestoreCheckpoint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCheckpoint", reflect.TypeOf((*MockWorkspaceOperations)(nil).RestoreCheckpoint), arg0, arg1, arg2)
+}
+
 // SetupWorkspace mocks base method.
 func (m *MockWorkspaceOperations) SetupWorkspace(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -89,7 +90,15 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	snapshotURL, snapshotName, snapshotErr := ssc.operations.SnapshotIDs(ctx, snapshot.Spec.WorkspaceID)
+	var (
+		snapshotURL, snapshotName string
+		snapshotErr               error
+	)
+	if snapshot.Spec.Type == workspacev1.SnapshotTypeBackup {
+		snapshotURL, snapshotName, snapshotErr = ssc.operations.BackupIDs(ctx, snapshot.Spec.WorkspaceID)
+	} else {
+		snapshotURL, snapshotName, snapshotErr = ssc.operations.SnapshotIDs(ctx, snapshot.Spec.WorkspaceID)
+	}
 	if snapshotErr != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get snapshot name and URL: %w", snapshotErr)
 	}
@@ -101,6 +110,7 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 
 		snapshot.Status.URL = snapshotURL
+		snapshot.Status.SetCondition(workspacev1.NewSnapshotConditionStarted())
 		return ssc.Client.Status().Update(ctx, &snapshot)
 	})
 
@@ -109,7 +119,11 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("could not set snapshot url: %w", err)
 	}
 
-	snapshotErr = ssc.operations.Snapshot(ctx, snapshot.Spec.WorkspaceID, snapshotName)
+	if snapshot.Spec.Type == workspacev1.SnapshotTypeBackup {
+		snapshotErr = ssc.backup(ctx, &snapshot, snapshotName)
+	} else {
+		snapshotErr = ssc.operations.Snapshot(ctx, snapshot.Spec.WorkspaceID, snapshotName)
+	}
 	if snapshotErr != nil {
 		log.Error(snapshotErr, "could not take snapshot", "workspace", snapshot.Spec.WorkspaceID)
 	}
@@ -123,6 +137,9 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		snapshot.Status.Completed = true
 		if snapshotErr != nil {
 			snapshot.Status.Error = fmt.Errorf("could not take snapshot: %w", snapshotErr).Error()
+			snapshot.Status.SetCondition(workspacev1.NewSnapshotConditionFailed(snapshot.Status.Error))
+		} else {
+			snapshot.Status.SetCondition(workspacev1.NewSnapshotConditionSucceeded())
 		}
 
 		return ssc.Status().Update(ctx, &snapshot)
@@ -137,6 +154,32 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, err
 }
 
+// backup uploads the workspace content as the regular backup of a running workspace.
+func (ssc *SnapshotReconciler) backup(ctx context.Context, snapshot *workspacev1.Snapshot, backupName string) error {
+	var ws workspacev1.Workspace
+	err := ssc.Client.Get(ctx, types.NamespacedName{Namespace: snapshot.Namespace, Name: snapshot.Spec.WorkspaceID}, &ws)
+	if err != nil {
+		return fmt.Errorf("cannot get workspace: %w", err)
+	}
+	if ws.Status.Phase != workspacev1.WorkspacePhaseRunning || ws.IsConditionTrue(workspacev1.WorkspaceConditionBackupComplete) {
+		// the final backup of a stopping workspace must not be overwritten by an outdated online backup
+		return fmt.Errorf("workspace is not running")
+	}
+
+	// BackupWorkspace serializes the backups of a workspace and refuses online backups once the final backup started
+	_, err = ssc.operations.BackupWorkspace(ctx, BackupOptions{
+		Meta: WorkspaceMeta{
+			Owner:       ws.Spec.Ownership.Owner,
+			WorkspaceID: ws.Spec.Ownership.WorkspaceID,
+			InstanceID:  ws.Name,
+		},
+		WorkspaceLocation: ws.Spec.WorkspaceLocation,
+		SnapshotName:      backupName,
+		Online:            true,
+	})
+	return err
+}
+
 func (ssc *SnapshotReconciler) emitEvent(s *workspacev1.Snapshot, failure error) {
 	eventType := corev1.EventTypeNormal
 	reason := "Succeeded"
@@ -188,6 +188,13 @@ func (wsc *WorkspaceController) handleWorkspaceInit(ctx context.Context, ws *wor
 			StorageQuota: ws.Spec.StorageQuota,
 		})
 
+		// The workspace container of a workspace which was hibernated is created from its checkpoint image
+		// once content initialization is done.
+		var restored *metav1.Condition
+		if failure == "" && ws.Spec.RestoreCheckpoint {
+			restored = wsc.restoreCheckpoint(ctx, ws)
+		}
+
 		err = retry.RetryOnConflict(retryParams, func() error {
 			if err := wsc.Get(ctx, req.NamespacedName, ws); err != nil {
 				return err
@@ -199,6 +206,9 @@ func (wsc *WorkspaceController) handleWorkspaceInit(ctx context.Context, ws *wor
 			} else {
 				ws.Status.SetCondition(workspacev1.NewWorkspaceConditionContentReady(metav1.ConditionTrue, workspacev1.ReasonInitializationSuccess, ""))
 			}
+			if restored != nil {
+				ws.Status.SetCondition(*restored)
+			}
 
 			return wsc.Status().Update(ctx, ws)
 		})
@@ -223,7 +233,74 @@ func (wsc *WorkspaceController) handleWorkspaceRunning(ctx context.Context, ws *
 	log := log.FromContext(ctx)
 	log.Info("handling running workspace")
 
-	return ctrl.Result{}, wsc.operations.SetupWorkspace(ctx, ws.Name)
+	err = wsc.operations.SetupWorkspace(ctx, ws.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if ws.IsConditionTrue(workspacev1.WorkspaceConditionHibernationRequested) &&
+		!ws.IsConditionTrue(workspacev1.WorkspaceConditionCheckpointComplete) &&
+		!ws.IsConditionTrue(workspacev1.WorkspaceConditionCheckpointFailure) {
+		return wsc.checkpointWorkspace(ctx, ws, req)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// checkpointWorkspace checkpoints the processes of a workspace which is about to hibernate. ws-manager stops the
+// workspace once the checkpoint is complete or failed, in which case the workspace cold starts next time.
+func (wsc *WorkspaceController) checkpointWorkspace(ctx context.Context, ws *workspacev1.Workspace, req ctrl.Request) (result ctrl.Result, err error) {
+	log := log.FromContext(ctx)
+	span, ctx := opentracing.StartSpanFromContext(ctx, "checkpointWorkspace")
+	defer tracing.FinishSpan(span, &err)
+
+	if wsc.latestWorkspace(ctx, ws) != nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: 100 * time.Millisecond}, nil
+	}
+
+	checkpointErr := wsc.operations.CheckpointWorkspace(ctx, ws.Name)
+
+	err = retry.RetryOnConflict(retryParams, func() error {
+		if err := wsc.Get(ctx, req.NamespacedName, ws); err != nil {
+			return err
+		}
+
+		if checkpointErr != nil {
+			log.Error(checkpointErr, "failed to checkpoint workspace", "name", ws.Name)
+			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionCheckpointFailure(checkpointErr.Error()))
+		} else {
+			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionCheckpointComplete())
+		}
+
+		return wsc.Status().Update(ctx, ws)
+	})
+
+	wsc.emitEvent(ws, "Checkpoint", checkpointErr)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set checkpoint condition: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// restoreCheckpoint prepares the checkpoint image the workspace container of a hibernated workspace is created from,
+// such that the container runtime resumes its processes. Returns the CheckpointRestored condition, or nil if there was
+// nothing to restore. If the checkpoint cannot be restored the workspace cold starts.
+func (wsc *WorkspaceController) restoreCheckpoint(ctx context.Context, ws *workspacev1.Workspace) *metav1.Condition {
+	found, err := wsc.operations.RestoreCheckpoint(ctx, ws.Name, ws.CheckpointImage())
+	if err == nil && !found {
+		return nil
+	}
+
+	var cond metav1.Condition
+	if err != nil {
+		log.FromContext(ctx).Error(err, "could not restore checkpoint, falling back to cold start", "name", ws.Name)
+		cond = workspacev1.NewWorkspaceConditionCheckpointRestored(metav1.ConditionFalse, workspacev1.ReasonColdStart, err.Error())
+	} else {
+		cond = workspacev1.NewWorkspaceConditionCheckpointRestored(metav1.ConditionTrue, workspacev1.ReasonCheckpointRestored, "")
+	}
+	wsc.emitEvent(ws, "Checkpoint restore", err)
+	return &cond
 }
 
 func (wsc *WorkspaceController) handleWorkspaceStop(ctx context.Context, ws *workspacev1.Workspace, req ctrl.Request) (result ctrl.Result, err error) {
@@ -56,6 +56,78 @@ var _ = Describe("WorkspaceController", func() {
 			expectConditionEventually(ws, string(workspacev1.WorkspaceConditionContentReady), metav1.ConditionTrue, "InitializationSuccess")
 		})
 
+		It("should restore the checkpoint of a hibernated workspace", func() {
+			name := uuid.NewString()
+
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			ops := NewMockWorkspaceOperations(mockCtrl)
+
+			ops.EXPECT().InitWorkspace(gomock.Any(), gomock.Any()).Return("", nil).Times(1)
+			_ = createSecret(fmt.Sprintf("%s-tokens", name), secretsNamespace)
+			ws := newWorkspace(name, workspaceNamespace, workspacev1.WorkspacePhaseCreating)
+			ws.Spec.Initializer = backupInitializer()
+			ws.Spec.RestoreCheckpoint = true
+
+			ops.EXPECT().RestoreCheckpoint(gomock.Any(), name, ws.CheckpointImage()).Return(true, nil).Times(1)
+			workspaceCtrl.operations = ops
+			createWorkspace(ws)
+			markCreating(ws)
+
+			expectConditionEventually(ws, string(workspacev1.WorkspaceConditionContentReady), metav1.ConditionTrue, workspacev1.ReasonInitializationSuccess)
+			expectConditionEventually(ws, string(workspacev1.WorkspaceConditionCheckpointRestored), metav1.ConditionTrue, workspacev1.ReasonCheckpointRestored)
+		})
+
+		It("should cold start if the checkpoint cannot be restored", func() {
+			name := uuid.NewString()
+
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			ops := NewMockWorkspaceOperations(mockCtrl)
+
+			ops.EXPECT().InitWorkspace(gomock.Any(), gomock.Any()).Return("", nil).Times(1)
+			_ = createSecret(fmt.Sprintf("%s-tokens", name), secretsNamespace)
+			ws := newWorkspace(name, workspaceNamespace, workspacev1.WorkspacePhaseCreating)
+			ws.Spec.Initializer = backupInitializer()
+			ws.Spec.RestoreCheckpoint = true
+
+			ops.EXPECT().RestoreCheckpoint(gomock.Any(), name, ws.CheckpointImage()).Return(true, fmt.Errorf("BOOM!")).Times(1)
+			workspaceCtrl.operations = ops
+			createWorkspace(ws)
+			markCreating(ws)
+
+			expectConditionEventually(ws, string(workspacev1.WorkspaceConditionContentReady), metav1.ConditionTrue, workspacev1.ReasonInitializationSuccess)
+			expectConditionEventually(ws, string(workspacev1.WorkspaceConditionCheckpointRestored), metav1.ConditionFalse, workspacev1.ReasonColdStart)
+		})
+
+		It("should checkpoint a hibernating workspace", func() {
+			name := uuid.NewString()
+
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			ops := NewMockWorkspaceOperations(mockCtrl)
+
+			ops.EXPECT().SetupWorkspace(gomock.Any(), name).Return(nil).AnyTimes()
+			ops.EXPECT().CheckpointWorkspace(gomock.Any(), name).Return(nil).Times(1)
+			workspaceCtrl.operations = ops
+
+			_ = createSecret(fmt.Sprintf("%s-tokens", name), secretsNamespace)
+			ws := newWorkspace(name, workspaceNamespace, workspacev1.WorkspacePhaseRunning)
+			createWorkspace(ws)
+			updateObjWithRetries(k8sClient, ws, true, func(ws *workspacev1.Workspace) {
+				ws.Status.Phase = workspacev1.WorkspacePhaseRunning
+				ws.Status.Conditions = []metav1.Condition{
+					workspacev1.NewWorkspaceConditionContentReady(metav1.ConditionTrue, workspacev1.ReasonInitializationSuccess, ""),
+					workspacev1.NewWorkspaceConditionHibernationRequested(""),
+				}
+				ws.Status.Runtime = &workspacev1.WorkspaceRuntimeStatus{
+					NodeName: NodeName,
+				}
+			})
+
+			expectConditionEventually(ws, string(workspacev1.WorkspaceConditionCheckpointComplete), metav1.ConditionTrue, "CheckpointComplete")
+		})
+
 		It("should handle regular content backup", func() {
 			name := uuid.NewString()
 
@@ -177,6 +249,27 @@ func newWorkspace(name, namespace string, phase workspacev1.WorkspacePhase) *wor
 	}
 }
 
+func backupInitializer() []byte {
+	GinkgoHelper()
+	initializer := &csapi.WorkspaceInitializer{
+		Spec: &csapi.WorkspaceInitializer_Backup{Backup: &csapi.FromBackupInitializer{}},
+	}
+	initializerBytes, err := proto.Marshal(initializer)
+	Expect(err).ToNot(HaveOccurred())
+	return initializerBytes
+}
+
+func markCreating(ws *workspacev1.Workspace) {
+	GinkgoHelper()
+	updateObjWithRetries(k8sClient, ws, true, func(ws *workspacev1.Workspace) {
+		ws.Status.Phase = workspacev1.WorkspacePhaseCreating
+		ws.Status.Conditions = []metav1.Condition{}
+		ws.Status.Runtime = &workspacev1.WorkspaceRuntimeStatus{
+			NodeName: NodeName,
+		}
+	})
+}
+
 func createWorkspace(ws *workspacev1.Workspace) {
 	GinkgoHelper()
 	By("creating workspace")
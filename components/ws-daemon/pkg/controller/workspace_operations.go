@@ -6,11 +6,13 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	glog "github.com/gitpod-io/gitpod/common-go/log"
@@ -20,14 +22,31 @@ import (
 	wsinit "github.com/gitpod-io/gitpod/content-service/pkg/initializer"
 	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/container"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+	"github.com/opencontainers/go-digest"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 )
 
+const (
+	// checkpointDir is the directory in the workspace's daemon location which holds the checkpoint while it's transferred.
+	// It's laid out like the checkpoint archives of the container runtime: the CRIU image is in the checkpoint
+	// directory, next to the container's OCI runtime spec and the checkpoint config.
+	checkpointDir = "checkpoint"
+	// checkpointSpecFile holds the OCI runtime spec of a checkpointed container
+	checkpointSpecFile = "spec.dump"
+	// checkpointConfigFile holds the checkpointConfig of a checkpoint
+	checkpointConfigFile = "config.dump"
+
+	// workspaceContainerTimeout is the time we wait for the workspace container to show up
+	workspaceContainerTimeout = 1 * time.Minute
+)
+
 type Metrics struct {
 	BackupWaitingTimeHist       prometheus.Histogram
 	BackupWaitingTimeoutCounter prometheus.Counter
@@ -68,17 +87,37 @@ type WorkspaceOperations interface {
 	DeleteWorkspace(ctx context.Context, instanceID string) error
 	// SnapshotIDs generates the name and url for a snapshot
 	SnapshotIDs(ctx context.Context, instanceID string) (snapshotUrl, snapshotName string, err error)
+	// BackupIDs returns the name and url of the regular backup of a workspace
+	BackupIDs(ctx context.Context, instanceID string) (backupUrl, backupName string, err error)
 	// Snapshot takes a snapshot of the workspace
 	Snapshot(ctx context.Context, instanceID, snapshotName string) (err error)
 	// Setup ensures that the workspace has been setup
 	SetupWorkspace(ctx context.Context, instanceID string) error
+	// CheckpointWorkspace checkpoints the processes of the workspace and uploads the checkpoint next to its backup
+	CheckpointWorkspace(ctx context.Context, instanceID string) error
+	// RestoreCheckpoint prepares the checkpoint image the workspace container of a hibernated workspace is restored from,
+	// using the checkpoint uploaded by CheckpointWorkspace. If there is no checkpoint, or it cannot be restored, the
+	// checkpoint image is the workspace image and the workspace cold starts. Returns found == false if there is no
+	// checkpoint to restore.
+	RestoreCheckpoint(ctx context.Context, instanceID, checkpointImage string) (found bool, err error)
 }
 
 type DefaultWorkspaceOperations struct {
 	config                 content.Config
 	provider               *WorkspaceProvider
+	runtime                container.Runtime
 	backupWorkspaceLimiter chan struct{}
 	metrics                *Metrics
+
+	// backupLocks serialize the backups of a workspace, such that an online backup cannot overwrite its final backup
+	backupLocksMu sync.Mutex
+	backupLocks   map[string]*backupLock
+}
+
+type backupLock struct {
+	sync.Mutex
+	// final is true once the final backup of the workspace has started
+	final bool
 }
 
 var _ WorkspaceOperations = (*DefaultWorkspaceOperations)(nil)
@@ -102,9 +141,11 @@ type BackupOptions struct {
 	BackupLogs        bool
 	UpdateGitStatus   bool
 	SnapshotName      string
+	// Online is true if the workspace keeps running after the backup, i.e. it's not the final backup of the workspace
+	Online bool
 }
 
-func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider, reg prometheus.Registerer) (WorkspaceOperations, error) {
+func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider, runtime container.Runtime, reg prometheus.Registerer) (WorkspaceOperations, error) {
 	waitingTimeHist, waitingTimeoutCounter, err := registerConcurrentBackupMetrics(reg, "_mk2")
 	if err != nil {
 		return nil, err
@@ -113,12 +154,14 @@ func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider,
 	return &DefaultWorkspaceOperations{
 		config:   config,
 		provider: provider,
+		runtime:  runtime,
 		metrics: &Metrics{
 			BackupWaitingTimeHist:       waitingTimeHist,
 			BackupWaitingTimeoutCounter: waitingTimeoutCounter,
 		},
 		// we permit five concurrent backups at any given time, hence the five in the channel
 		backupWorkspaceLimiter: make(chan struct{}, 5),
+		backupLocks:            make(map[string]*backupLock),
 	}, nil
 }
 
@@ -230,6 +273,16 @@ func (wso *DefaultWorkspaceOperations) BackupWorkspace(ctx context.Context, opts
 		return nil, fmt.Errorf("workspace has no remote storage")
 	}
 
+	lock := wso.backupLock(opts.Meta.InstanceID)
+	lock.Lock()
+	defer lock.Unlock()
+	if opts.Online && lock.final {
+		return nil, fmt.Errorf("workspace %s is stopping", opts.Meta.InstanceID)
+	}
+	if !opts.Online {
+		lock.final = true
+	}
+
 	if opts.BackupLogs {
 		err := wso.uploadWorkspaceLogs(ctx, opts)
 		if err != nil {
@@ -238,7 +291,7 @@ func (wso *DefaultWorkspaceOperations) BackupWorkspace(ctx context.Context, opts
 		}
 	}
 
-	err = wso.uploadWorkspaceContent(ctx, ws, opts.SnapshotName)
+	err = wso.uploadWorkspaceContent(ctx, ws, opts.SnapshotName, opts.Online)
 	if err != nil {
 		glog.WithError(err).WithFields(ws.OWI()).Error("final backup failed for workspace")
 		return nil, fmt.Errorf("final backup failed for workspace %s", opts.Meta.InstanceID)
@@ -278,6 +331,10 @@ func (wso *DefaultWorkspaceOperations) DeleteWorkspace(ctx context.Context, inst
 	}
 	wso.provider.Remove(ctx, instanceID)
 
+	wso.backupLocksMu.Lock()
+	delete(wso.backupLocks, instanceID)
+	wso.backupLocksMu.Unlock()
+
 	return nil
 }
 
@@ -298,6 +355,20 @@ func (wso *DefaultWorkspaceOperations) SnapshotIDs(ctx context.Context, instance
 	return rs.Qualify(snapshotName), snapshotName, nil
 }
 
+func (wso *DefaultWorkspaceOperations) BackupIDs(ctx context.Context, instanceID string) (backupUrl, backupName string, err error) {
+	sess, err := wso.provider.GetAndConnect(ctx, instanceID)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot find workspace %s during BackupIDs: %w", instanceID, err)
+	}
+
+	rs, ok := sess.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
+	if rs == nil || !ok {
+		return "", "", fmt.Errorf("no remote storage configured")
+	}
+
+	return rs.Qualify(storage.DefaultBackup), storage.DefaultBackup, nil
+}
+
 func (wso *DefaultWorkspaceOperations) Snapshot(ctx context.Context, workspaceID, snapshotName string) (err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "TakeSnapshot")
@@ -317,7 +388,7 @@ func (wso *DefaultWorkspaceOperations) Snapshot(ctx context.Context, workspaceID
 		return fmt.Errorf("workspace has no remote storage")
 	}
 
-	err = wso.uploadWorkspaceContent(ctx, ws, snapshotName)
+	err = wso.uploadWorkspaceContent(ctx, ws, snapshotName, false)
 	if err != nil {
 		glog.WithError(err).WithFields(ws.OWI()).Error("snapshot failed for workspace")
 		return fmt.Errorf("snapshot failed for workspace %s", workspaceID)
@@ -326,6 +397,243 @@ func (wso *DefaultWorkspaceOperations) Snapshot(ctx context.Context, workspaceID
 	return nil
 }
 
+func (wso *DefaultWorkspaceOperations) CheckpointWorkspace(ctx context.Context, instanceID string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CheckpointWorkspace")
+	span.SetTag("workspace", instanceID)
+	defer tracing.FinishSpan(span, &err)
+
+	ws, err := wso.provider.GetAndConnect(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("cannot find workspace %s during CheckpointWorkspace: %w", instanceID, err)
+	}
+
+	if ws.RemoteStorageDisabled {
+		return fmt.Errorf("workspace has no remote storage")
+	}
+
+	rs, ok := ws.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
+	if rs == nil || !ok {
+		return fmt.Errorf("no remote storage configured")
+	}
+
+	containerID, err := wso.workspaceContainer(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(ws.ServiceLocDaemon, checkpointDir)
+	err = ensureEmptyDir(dir)
+	if err != nil {
+		return xerrors.Errorf("cannot create checkpoint directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// containerd writes the CRIU image from the node's point of view
+	spec, err := wso.runtime.CheckpointContainer(ctx, containerID, filepath.Join(ws.ServiceLocNode, checkpointDir, checkpointDir))
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(dir, checkpointSpecFile), spec, 0644)
+	if err != nil {
+		return xerrors.Errorf("cannot write checkpoint spec: %w", err)
+	}
+
+	tmpf, err := os.CreateTemp(wso.config.TmpDir, fmt.Sprintf("wsckpt-%s-*.tar", instanceID))
+	if err != nil {
+		return xerrors.Errorf("cannot create checkpoint archive: %w", err)
+	}
+	tmpf.Close()
+	defer os.Remove(tmpf.Name())
+
+	err = content.BuildTarbal(ctx, dir, tmpf.Name())
+	if err != nil {
+		return xerrors.Errorf("cannot create checkpoint archive: %w", err)
+	}
+
+	err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(ws.OWI()).WithField("op", "upload checkpoint"), func(ctx context.Context) (err error) {
+		_, _, err = rs.Upload(ctx, tmpf.Name(), storage.DefaultCheckpoint)
+		return
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot upload checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+func (wso *DefaultWorkspaceOperations) RestoreCheckpoint(ctx context.Context, instanceID, checkpointImage string) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RestoreCheckpoint")
+	span.SetTag("workspace", instanceID)
+	defer tracing.FinishSpan(span, &err)
+
+	ws, err := wso.provider.GetAndConnect(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("cannot find workspace %s during RestoreCheckpoint: %w", instanceID, err)
+	}
+
+	// The restore container holds back the workspace container until its image is ready, regardless of
+	// whether the workspace resumes its processes or cold starts.
+	defer func() {
+		ferr := os.WriteFile(filepath.Join(ws.ServiceLocDaemon, workspacev1.CheckpointImageReadyFile), nil, 0644)
+		if ferr != nil {
+			glog.WithError(ferr).WithFields(ws.OWI()).Error("cannot release workspace container")
+		}
+	}()
+
+	wctx, cancel := context.WithTimeout(ctx, workspaceContainerTimeout)
+	workspaceImage, err := wso.runtime.WorkspaceImage(wctx, instanceID)
+	cancel()
+	if err != nil {
+		return false, xerrors.Errorf("cannot find workspace image: %w", err)
+	}
+
+	found, err = wso.importCheckpoint(ctx, ws, checkpointImage, workspaceImage)
+	if found && err == nil {
+		return true, nil
+	}
+
+	tagErr := wso.runtime.TagImage(ctx, checkpointImage, workspaceImage)
+	if tagErr != nil && err != nil {
+		return found, xerrors.Errorf("cannot cold start after failed restore (%v): %w", err, tagErr)
+	}
+	if tagErr != nil {
+		return found, xerrors.Errorf("cannot cold start: %w", tagErr)
+	}
+	return found, err
+}
+
+// checkpointConfig is the config.dump of a checkpoint, which tells the container runtime which image provides the
+// root filesystem of the restored container
+type checkpointConfig struct {
+	Name            string    `json:"name"`
+	RootfsImageName string    `json:"rootfsImageName"`
+	CheckpointedAt  time.Time `json:"checkpointedTime"`
+}
+
+// importCheckpoint downloads the checkpoint of a workspace and imports it as its checkpoint image
+func (wso *DefaultWorkspaceOperations) importCheckpoint(ctx context.Context, ws *session.Workspace, checkpointImage, workspaceImage string) (found bool, err error) {
+	if ws.RemoteStorageDisabled {
+		return false, nil
+	}
+
+	// we need to look at the age of the checkpoint and delete it once restored, which only the chunk access provides.
+	// The storage of every workspace with remote storage implements it.
+	ca, ok := ws.NonPersistentAttrs[session.AttrRemoteStorage].(storage.ChunkAccess)
+	if ca == nil || !ok {
+		return false, xerrors.Errorf("remote storage does not support checkpoints")
+	}
+
+	checkpointObj := ca.BackupObject(storage.DefaultCheckpoint)
+	checkpointUpdated, err := ca.ObjectUpdated(ctx, checkpointObj)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, xerrors.Errorf("cannot find checkpoint: %w", err)
+	}
+
+	// Whatever happens from here on, the checkpoint does not match the workspace content anymore
+	// once the workspace is stopped again.
+	defer func() {
+		err := ca.DeleteObject(ctx, checkpointObj)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			glog.WithError(err).WithFields(ws.OWI()).Warn("cannot delete checkpoint")
+		}
+	}()
+
+	// The backup of a hibernating workspace is taken after its checkpoint. If it's older than the checkpoint,
+	// that backup failed and the processes would find content they don't expect.
+	backupUpdated, err := latestBackupUpdate(ctx, ca)
+	if err != nil {
+		return true, xerrors.Errorf("cannot find backup: %w", err)
+	}
+	if backupUpdated.Before(checkpointUpdated) {
+		return true, xerrors.Errorf("checkpoint is newer than the backup")
+	}
+
+	dir := filepath.Join(ws.ServiceLocDaemon, checkpointDir)
+	err = ensureEmptyDir(dir)
+	if err != nil {
+		return true, xerrors.Errorf("cannot create checkpoint directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = ca.Download(ctx, dir, storage.DefaultCheckpoint, nil)
+	if err != nil {
+		return true, xerrors.Errorf("cannot download checkpoint: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, checkpointSpecFile)); err != nil {
+		return true, xerrors.Errorf("checkpoint has no spec: %w", err)
+	}
+
+	// The processes resume on top of the image of this workspace instance
+	cfg, err := json.Marshal(checkpointConfig{
+		Name:            "workspace",
+		RootfsImageName: workspaceImage,
+		CheckpointedAt:  checkpointUpdated,
+	})
+	if err != nil {
+		return true, err
+	}
+	err = os.WriteFile(filepath.Join(dir, checkpointConfigFile), cfg, 0644)
+	if err != nil {
+		return true, xerrors.Errorf("cannot write checkpoint config: %w", err)
+	}
+
+	tmpf, err := os.CreateTemp(wso.config.TmpDir, fmt.Sprintf("wsckpt-%s-*.tar", ws.InstanceID))
+	if err != nil {
+		return true, xerrors.Errorf("cannot create checkpoint archive: %w", err)
+	}
+	tmpf.Close()
+	defer os.Remove(tmpf.Name())
+
+	err = content.BuildTarbal(ctx, dir, tmpf.Name())
+	if err != nil {
+		return true, xerrors.Errorf("cannot create checkpoint archive: %w", err)
+	}
+
+	err = wso.runtime.ImportCheckpoint(ctx, checkpointImage, tmpf.Name())
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// workspaceContainer finds the container of a workspace which is about to run or running already
+func (wso *DefaultWorkspaceOperations) workspaceContainer(ctx context.Context, instanceID string) (container.ID, error) {
+	wctx, cancel := context.WithTimeout(ctx, workspaceContainerTimeout)
+	defer cancel()
+
+	id, err := wso.runtime.WaitForContainer(wctx, instanceID)
+	if err != nil {
+		return "", xerrors.Errorf("cannot find workspace container: %w", err)
+	}
+	return id, nil
+}
+
+// latestBackupUpdate returns when the regular backup of a workspace was last uploaded
+func latestBackupUpdate(ctx context.Context, ca storage.ChunkAccess) (time.Time, error) {
+	for _, name := range []string{storage.IncrementalManifestName(storage.DefaultBackup), storage.DefaultBackup} {
+		updated, err := ca.ObjectUpdated(ctx, ca.BackupObject(name))
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		return updated, err
+	}
+	return time.Time{}, storage.ErrNotFound
+}
+
+func ensureEmptyDir(location string) error {
+	err := os.RemoveAll(location)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(location, 0755)
+}
+
 func ensureCleanSlate(location string) error {
 	// do not remove the location itself but only
 	// the children
@@ -389,7 +697,20 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceLogs(ctx context.Context,
 	return err
 }
 
-func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Context, sess *session.Workspace, backupName string) error {
+// backupLock returns the lock which serializes the backups of a workspace.
+func (wso *DefaultWorkspaceOperations) backupLock(instanceID string) *backupLock {
+	wso.backupLocksMu.Lock()
+	defer wso.backupLocksMu.Unlock()
+
+	lock, ok := wso.backupLocks[instanceID]
+	if !ok {
+		lock = &backupLock{}
+		wso.backupLocks[instanceID] = lock
+	}
+	return lock
+}
+
+func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Context, sess *session.Workspace, backupName string, online bool) error {
 	// Avoid too many simultaneous backups in order to avoid excessive memory utilization.
 	var timedOut bool
 	waitStart := time.Now()
@@ -420,11 +741,14 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		opts []storage.UploadOption
 	)
 
-	err := os.Remove(filepath.Join(sess.Location, wsinit.WorkspaceReadyFile))
-	if err != nil && !errors.Is(err, fs.ErrNotExist) {
-		// We'll still upload the backup, well aware that the UX during restart will be broken.
-		// But it's better to have a backup with all files (albeit one too many), than having no backup at all.
-		glog.WithError(err).WithFields(sess.OWI()).Warn("cannot remove workspace ready file")
+	if !online {
+		// the ready file must stay in place while the workspace is running
+		err := os.Remove(filepath.Join(sess.Location, wsinit.WorkspaceReadyFile))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			// We'll still upload the backup, well aware that the UX during restart will be broken.
+			// But it's better to have a backup with all files (albeit one too many), than having no backup at all.
+			glog.WithError(err).WithFields(sess.OWI()).Warn("cannot remove workspace ready file")
+		}
 	}
 
 	rs, ok := sess.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
@@ -435,6 +759,7 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 	var (
 		tmpf     *os.File
 		tmpfSize int64
+		dgst     digest.Digest
 	)
 
 	defer func() {
@@ -443,7 +768,7 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		}
 	}()
 
-	err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "create archive"), func(ctx context.Context) (err error) {
+	err := retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "create archive"), func(ctx context.Context) (err error) {
 		tmpf, err = os.CreateTemp(wso.config.TmpDir, fmt.Sprintf("wsbkp-%s-*.tar", sess.InstanceID))
 		if err != nil {
 			return
@@ -487,13 +812,32 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		tmpfSize = stat.Size()
 		glog.WithField("size", tmpfSize).WithField("location", tmpf.Name()).WithFields(sess.OWI()).Debug("created temp file for workspace backup upload")
 
+		// the digest lets restores verify the backup they downloaded
+		dgst, err = digest.FromReader(tmpf)
+		if err != nil {
+			return
+		}
+
 		return
 	})
 	if err != nil {
 		return xerrors.Errorf("cannot create archive: %w", err)
 	}
+	opts = append(opts, storage.WithAnnotations(map[string]string{
+		storage.ObjectAnnotationDigest: dgst.String(),
+	}))
 
+	// Chunks are shared among all workspaces of the owner, while encrypted storage uses a data key per workspace.
+	// Hence we upload encrypted backups as a whole.
+	ca, incremental := rs.(storage.ChunkAccess)
+	_, encrypted := rs.(storage.EncryptedAccess)
+	incremental = incremental && wso.config.Backup.Incremental && !encrypted
 	err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "upload layer"), func(ctx context.Context) (err error) {
+		if incremental {
+			_, err = storage.UploadIncremental(ctx, ca, tmpf.Name(), backupName)
+			return
+		}
+
 		_, _, err = rs.Upload(ctx, tmpf.Name(), backupName, opts...)
 		if err != nil {
 			return
@@ -505,6 +849,22 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		return xerrors.Errorf("cannot upload workspace content: %w", err)
 	}
 
+	if incremental {
+		// garbage collection is best effort - unreferenced chunks are collected during the next backup
+		deleted, err := storage.CollectGarbageChunks(ctx, ca, storage.DefaultChunkGCGracePeriod)
+		if err != nil {
+			glog.WithError(err).WithFields(sess.OWI()).Warn("cannot collect garbage chunks")
+		} else if deleted > 0 {
+			glog.WithField("deleted", deleted).WithFields(sess.OWI()).Debug("collected garbage chunks")
+		}
+	} else if ca, ok := rs.(storage.ChunkAccess); ok {
+		// incremental backups take precedence during restore, hence we must not leave a stale one behind
+		err = ca.DeleteObject(ctx, ca.BackupObject(storage.IncrementalManifestName(backupName)))
+		if err != nil && err != storage.ErrNotFound {
+			return xerrors.Errorf("cannot remove stale incremental backup: %w", err)
+		}
+	}
+
 	return nil
 }
 
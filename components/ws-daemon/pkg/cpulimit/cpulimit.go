@@ -23,6 +23,11 @@ type Workspace struct {
 	Usage       CPUTime
 	QoS         int
 	Annotations map[string]string
+
+	// Owner, Team and Tenant identify who owns a workspace. They are used to share the bandwidth fairly.
+	Owner  string
+	Team   string
+	Tenant string
 }
 
 type WorkspaceHistory struct {
@@ -32,6 +37,11 @@ type WorkspaceHistory struct {
 	UsageT0     CPUTime
 	ThrottleLag uint64
 	Limit       Bandwidth
+
+	// Bandwidth is the bandwidth the workspace used during the last tick.
+	// It is only known once the workspace has been around for a full tick.
+	Bandwidth      Bandwidth
+	BandwidthKnown bool
 }
 
 func (h *WorkspaceHistory) Usage() CPUTime {
@@ -127,6 +137,10 @@ func (d *Distributor) Tick(dt time.Duration) (DistributorDebug, error) {
 			}
 			d.History[w.ID] = h
 		}
+		if h.LastUpdate != nil {
+			h.Bandwidth, err = BandwithFromUsage(h.LastUpdate.Usage, w.Usage, dt)
+			h.BandwidthKnown = err == nil
+		}
 		h.Update(w)
 		f[w.ID] = struct{}{}
 	}
@@ -173,6 +187,12 @@ func (d *Distributor) Tick(dt time.Duration) (DistributorDebug, error) {
 		}, err
 	}
 
+	for _, limiter := range []ResourceLimiter{d.Limiter, d.BurstLimiter} {
+		if sl, ok := limiter.(SharingLimiter); ok {
+			sl.Share(d.History, d.TotalBandwidth)
+		}
+	}
+
 	// enforce limits
 	var burstBandwidth Bandwidth
 	for _, id := range wsOrder {
@@ -189,11 +209,15 @@ func (d *Distributor) Tick(dt time.Duration) (DistributorDebug, error) {
 		// entire bandwidth at once.
 		var burst bool
 		if totalBandwidth < d.TotalBandwidth && ws.Throttled() {
-			limit, err = d.BurstLimiter.Limit(ws)
+			burstLimit, err := d.BurstLimiter.Limit(ws)
 			if err != nil {
 				log.WithError(err).Errorf("unable to apply burst limit")
 				continue
 			}
+			// a sharing burst limiter may hand out less than the regular limit - bursting must never lower the limit
+			if burstLimit > limit {
+				limit = burstLimit
+			}
 
 			// We assume the workspace is going to use as much as their limit allows.
 			// This might not be true, because their process which consumed so much CPU
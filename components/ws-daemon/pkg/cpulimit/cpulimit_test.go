@@ -136,6 +136,9 @@ func NewNode(c ...Consumer) *Node {
 type Node struct {
 	Consumer []Consumer
 	State    map[string]*consumerState
+	// Teams and Owners map consumer IDs to the team and user they belong to
+	Teams  map[string]string
+	Owners map[string]string
 
 	ClampOnAvailableBandwidth bool
 	bandwidthReq              cpulimit.Bandwidth
@@ -217,6 +220,8 @@ func (n *Node) Source(context.Context) ([]cpulimit.Workspace, error) {
 			NrThrottled: w.Throttled,
 			Usage:       w.Usage,
 			QoS:         w.Consumer.QoS(),
+			Owner:       n.Owners[id],
+			Team:        n.Teams[id],
 		})
 	}
 	return res, nil
@@ -330,6 +335,76 @@ func TestProdBehaviour(t *testing.T) {
 	runSimulation(t, node, dist)
 }
 
+func TestFairShareTeams(t *testing.T) {
+	newTeamNode := func() *Node {
+		var cs []Consumer
+		teams := make(map[string]string)
+		for i := 0; i < 30; i++ {
+			id := fmt.Sprintf("prebuild%02d", i)
+			cs = append(cs, SteadyConsumer{id: id, rate: 2000})
+			teams[id] = "prebuilds"
+		}
+		for i := 0; i < 3; i++ {
+			id := fmt.Sprintf("a%02d", i)
+			cs = append(cs, SteadyConsumer{id: id, rate: 3000})
+			teams[id] = "team-a"
+		}
+		owners := map[string]string{"solo": "alice"}
+		cs = append(cs, SteadyConsumer{id: "solo", rate: 1000})
+		for i := 0; i < 3; i++ {
+			id := fmt.Sprintf("bob%02d", i)
+			cs = append(cs, SteadyConsumer{id: id, rate: 3000})
+			owners[id] = "bob"
+		}
+
+		node := NewNode(cs...)
+		node.Teams = teams
+		node.Owners = owners
+		return node
+	}
+	groupBandwidth := func(node *Node, group string) cpulimit.Bandwidth {
+		var usage cpulimit.CPUTime
+		for id, state := range node.State {
+			if node.Teams[id] == group || node.Owners[id] == group {
+				usage += state.Usage
+			}
+		}
+		bw, _ := cpulimit.BandwithFromUsage(0, usage, testDuration)
+		return bw
+	}
+
+	unfair := newTeamNode()
+	runSimulation(t, unfair, cpulimit.NewDistributor(unfair.Source, unfair.Sink, defaultLimit, defaultBreakoutLimit, totalCapacity))
+
+	node := newTeamNode()
+	limiter := &cpulimit.FairShareLimiter{Limiter: defaultLimit}
+	breakoutLimiter := &cpulimit.FairShareLimiter{Limiter: defaultBreakoutLimit}
+	runSimulation(t, node, cpulimit.NewDistributor(node.Source, node.Sink, limiter, breakoutLimiter, totalCapacity))
+
+	// alice's personal workspace asks for less than a quarter of the node and gets all of it. team-a,
+	// the prebuilds and bob's personal workspaces split the rest evenly, regardless of how many
+	// workspaces they run. Bandwidth alice does not use goes to the others.
+	const tolerance = 200
+	expectations := []struct {
+		Group string
+		BW    cpulimit.Bandwidth
+	}{
+		{"alice", 1000},
+		{"bob", 3660},
+		{"team-a", 3660},
+		{"prebuilds", 3660},
+	}
+	for _, exp := range expectations {
+		act := groupBandwidth(node, exp.Group)
+		if act+tolerance < exp.BW || act > exp.BW+tolerance {
+			t.Errorf("group %q got %d bandwidth: expected %d", exp.Group, act, exp.BW)
+		}
+	}
+	if groupBandwidth(unfair, "team-a") >= groupBandwidth(node, "team-a") {
+		t.Errorf("fair-sharing did not improve team-a's bandwidth")
+	}
+}
+
 func runSimulation(t *testing.T, node *Node, dist *cpulimit.Distributor) {
 	f, err := os.OpenFile(fmt.Sprintf("sim_%s.csv", t.Name()), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0744)
 	if err != nil {
@@ -33,6 +33,44 @@ type Config struct {
 
 	ControlPeriod  util.Duration `json:"controlPeriod"`
 	CGroupBasePath string        `json:"cgroupBasePath"`
+
+	FairShare FairShareConfig `json:"fairShare"`
+}
+
+// FairShareConfig configures how the total bandwidth is shared among tenants, teams and users
+type FairShareConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// DefaultWeight applies to all tenants, teams and users not listed in Groups
+	DefaultWeight uint `json:"defaultWeight"`
+	// Groups configures individual tenants, teams or users by their ID
+	Groups map[string]FairShareGroupConfig `json:"groups,omitempty"`
+}
+
+// FairShareGroupConfig configures the share of a single tenant, team or user
+type FairShareGroupConfig struct {
+	Weight  uint              `json:"weight"`
+	Minimum resource.Quantity `json:"minimum"`
+}
+
+// Limiter wraps the limiter in a fair-share limiter if fair-sharing is enabled
+func (c FairShareConfig) Limiter(limiter ResourceLimiter) ResourceLimiter {
+	if !c.Enabled {
+		return limiter
+	}
+
+	groups := make(map[string]FairShareGroup, len(c.Groups))
+	for name, grp := range c.Groups {
+		groups[name] = FairShareGroup{
+			Weight:  grp.Weight,
+			Minimum: BandwidthFromQuantity(grp.Minimum),
+		}
+	}
+	return &FairShareLimiter{
+		Limiter:      limiter,
+		Groups:       groups,
+		DefaultGroup: FairShareGroup{Weight: c.DefaultWeight},
+	}
 }
 
 // NewDispatchListener creates a new resource governer dispatch listener
@@ -66,8 +104,8 @@ func NewDispatchListener(cfg *Config, prom prometheus.Registerer) *DispatchListe
 
 	if cfg.Enabled {
 		dist := NewDistributor(d.source, d.sink,
-			CompositeLimiter(AnnotationLimiter(kubernetes.WorkspaceCpuMinLimitAnnotation), FixedLimiter(BandwidthFromQuantity(d.Config.Limit))),
-			CompositeLimiter(AnnotationLimiter(kubernetes.WorkspaceCpuBurstLimitAnnotation), FixedLimiter(BandwidthFromQuantity(d.Config.BurstLimit))),
+			d.Config.FairShare.Limiter(CompositeLimiter(AnnotationLimiter(kubernetes.WorkspaceCpuMinLimitAnnotation), FixedLimiter(BandwidthFromQuantity(d.Config.Limit)))),
+			d.Config.FairShare.Limiter(CompositeLimiter(AnnotationLimiter(kubernetes.WorkspaceCpuBurstLimitAnnotation), FixedLimiter(BandwidthFromQuantity(d.Config.BurstLimit)))),
 			BandwidthFromQuantity(d.Config.TotalBandwidth),
 		)
 		go dist.Run(context.Background(), time.Duration(d.Config.ControlPeriod))
@@ -104,6 +142,9 @@ type workspace struct {
 	OWI         logrus.Fields
 	HardLimit   ResourceLimiter
 	Annotations map[string]string
+	Owner       string
+	Team        string
+	Tenant      string
 
 	lastThrottled uint64
 }
@@ -143,6 +184,9 @@ func (d *DispatchListener) source(context.Context) ([]Workspace, error) {
 			NrThrottled: throttled,
 			Usage:       usage,
 			Annotations: w.Annotations,
+			Owner:       w.Owner,
+			Team:        w.Team,
+			Tenant:      w.Tenant,
 		})
 	}
 	return res, nil
@@ -193,6 +237,9 @@ func (d *DispatchListener) WorkspaceAdded(ctx context.Context, ws *dispatch.Work
 		CFS:         controller,
 		OWI:         ws.OWI(),
 		Annotations: ws.Pod.Annotations,
+		Owner:       ws.Pod.Labels[kubernetes.OwnerLabel],
+		Team:        ws.Pod.Labels[kubernetes.TeamLabel],
+		Tenant:      ws.Pod.Labels[kubernetes.TenantLabel],
 	}
 	go func() {
 		<-ctx.Done()
@@ -0,0 +1,260 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cpulimit
+
+import "sort"
+
+// SharingLimiter is a ResourceLimiter which needs to see all workspaces before it can
+// decide on the limit of an individual one. The distributor calls Share once per tick,
+// before asking for any limits.
+type SharingLimiter interface {
+	ResourceLimiter
+
+	Share(history map[string]*WorkspaceHistory, totalBandwidth Bandwidth)
+}
+
+var _ SharingLimiter = (*FairShareLimiter)(nil)
+
+// FairShareGroup configures which part of the total bandwidth a group of workspaces is entitled to.
+type FairShareGroup struct {
+	// Weight is the share of a group relative to all other groups which currently have workspaces.
+	// A weight of zero is treated as one.
+	Weight uint
+	// Minimum is the bandwidth a group is guaranteed to get, as long as its workspaces ask for it.
+	Minimum Bandwidth
+}
+
+// FairShareLimiter distributes the total bandwidth among tenants first, among the teams of a tenant second and
+// among the workspaces of a team last. Workspaces which do not belong to a team are grouped by their owner instead,
+// so that every user's personal workspaces compete with teams on equal terms.
+//
+// A workspace's demand is derived from the bandwidth it used during the last tick, with some headroom to grow.
+// Workspaces which were throttled or have not been around for a full tick yet ask for the limit produced by
+// Limiter. Bandwidth is handed out against that demand first: guaranteed minimums are served before the rest
+// is split by weight, and whatever a group does not ask for is passed on to the others. If the minimums exceed
+// the bandwidth they are scaled down proportionally. Bandwidth that is left over afterwards is split by weight
+// again, this time up to the limit of each workspace.
+type FairShareLimiter struct {
+	Limiter ResourceLimiter
+
+	// Groups configures individual tenants, teams or users by their ID
+	Groups map[string]FairShareGroup
+	// DefaultGroup applies to all tenants, teams and users which are not listed in Groups
+	DefaultGroup FairShareGroup
+
+	shares map[string]Bandwidth
+}
+
+const (
+	// demandHeadroom is the part of its current usage a workspace may grow by without being throttled first
+	demandHeadroom = 4
+	// idleDemand is the least demand of a workspace, such that idle workspaces can still start to do something
+	idleDemand Bandwidth = 100
+)
+
+// shareNode is a tenant, team or workspace in the tree the bandwidth is shared along
+type shareNode struct {
+	group    FairShareGroup
+	children map[string]*shareNode
+
+	// demand is what the node asks for in the current round, given is what it got across all rounds
+	demand Bandwidth
+	given  Bandwidth
+
+	// the usage based demand and the limit of workspaces
+	used  Bandwidth
+	limit Bandwidth
+}
+
+// Share computes the fair share of all workspaces
+func (l *FairShareLimiter) Share(history map[string]*WorkspaceHistory, totalBandwidth Bandwidth) {
+	var (
+		tenants    = make(map[string]*shareNode)
+		workspaces = make(map[string]*shareNode, len(history))
+	)
+	for id, h := range history {
+		if h.LastUpdate == nil {
+			continue
+		}
+		limit, err := l.Limiter.Limit(h)
+		if err != nil {
+			// the distributor will come across the same error when it asks for this workspace's limit
+			continue
+		}
+
+		tenant := l.child(tenants, h.LastUpdate.Tenant, h.LastUpdate.Tenant)
+		key, name := "team/"+h.LastUpdate.Team, h.LastUpdate.Team
+		if h.LastUpdate.Team == "" {
+			key, name = "user/"+h.LastUpdate.Owner, h.LastUpdate.Owner
+		}
+		team := l.child(tenant.children, key, name)
+
+		ws := &shareNode{group: FairShareGroup{Weight: 1}, used: usageDemand(h, limit), limit: limit}
+		team.children[id] = ws
+		workspaces[id] = ws
+	}
+
+	// first we serve what the workspaces use, then we hand out what nobody asked for up to their limits
+	for _, ws := range workspaces {
+		ws.demand = ws.used
+	}
+	fill(totalBandwidth, tenants, true)
+
+	var given Bandwidth
+	for _, ws := range workspaces {
+		ws.demand = ws.limit - ws.given
+		given += ws.given
+	}
+	fill(totalBandwidth-given, tenants, false)
+
+	l.shares = make(map[string]Bandwidth, len(workspaces))
+	for id, ws := range workspaces {
+		l.shares[id] = ws.given
+	}
+}
+
+// Limit returns the fair share of a workspace. Workspaces which appeared after the last
+// call to Share get the limit of the underlying limiter.
+func (l *FairShareLimiter) Limit(wsh *WorkspaceHistory) (Bandwidth, error) {
+	limit, err := l.Limiter.Limit(wsh)
+	if err != nil {
+		return 0, err
+	}
+
+	share, ok := l.shares[wsh.ID]
+	if !ok {
+		return limit, nil
+	}
+	return minBandwidth(share, limit), nil
+}
+
+func (l *FairShareLimiter) group(name string) FairShareGroup {
+	grp, ok := l.Groups[name]
+	if !ok {
+		grp = l.DefaultGroup
+	}
+	if grp.Weight == 0 {
+		grp.Weight = 1
+	}
+	return grp
+}
+
+// child returns the node with the given key, and creates it configured by the group called name if it doesn't exist
+func (l *FairShareLimiter) child(nodes map[string]*shareNode, key, name string) *shareNode {
+	n, ok := nodes[key]
+	if !ok {
+		n = &shareNode{group: l.group(name), children: make(map[string]*shareNode)}
+		nodes[key] = n
+	}
+	return n
+}
+
+// usageDemand estimates the bandwidth a workspace asks for from what it used during the last tick
+func usageDemand(h *WorkspaceHistory, limit Bandwidth) Bandwidth {
+	if !h.BandwidthKnown || h.Throttled() {
+		return limit
+	}
+
+	demand := h.Bandwidth + h.Bandwidth/demandHeadroom
+	if demand < idleDemand {
+		demand = idleDemand
+	}
+	return minBandwidth(demand, limit)
+}
+
+// fill distributes capacity among the nodes, and the share of every node among its children. Guaranteed minimums
+// are only served if withMinimum is set.
+func fill(capacity Bandwidth, nodes map[string]*shareNode, withMinimum bool) {
+	var (
+		demand = make(map[string]Bandwidth, len(nodes))
+		given  = make(map[string]Bandwidth, len(nodes))
+	)
+	for k, n := range nodes {
+		demand[k] = n.totalDemand()
+	}
+
+	if withMinimum {
+		var guaranteed Bandwidth
+		for k, n := range nodes {
+			given[k] = minBandwidth(n.group.Minimum, demand[k])
+			guaranteed += given[k]
+		}
+		if guaranteed > capacity {
+			for k, g := range given {
+				given[k] = g * capacity / guaranteed
+			}
+			guaranteed = capacity
+		}
+		capacity -= guaranteed
+	}
+	waterfill(capacity, demand, given, func(k string) Bandwidth {
+		return Bandwidth(nodes[k].group.Weight)
+	})
+
+	for k, n := range nodes {
+		n.given += given[k]
+		if n.children != nil {
+			fill(given[k], n.children, withMinimum)
+		}
+	}
+}
+
+func (n *shareNode) totalDemand() Bandwidth {
+	if n.children == nil {
+		return n.demand
+	}
+
+	var res Bandwidth
+	for _, c := range n.children {
+		res += c.totalDemand()
+	}
+	return res
+}
+
+// waterfill distributes capacity among the keys of demand in proportion to their weight, without giving any key
+// more than it demands. Whatever a key does not need is redistributed among the others. The result is added to given.
+func waterfill(capacity Bandwidth, demand map[string]Bandwidth, given map[string]Bandwidth, weight func(key string) Bandwidth) {
+	keys := make([]string, 0, len(demand))
+	for k := range demand {
+		keys = append(keys, k)
+	}
+	// we sort the keys so that rounding errors always fall the same way
+	sort.Strings(keys)
+
+	for capacity > 0 {
+		var (
+			active      []string
+			totalWeight Bandwidth
+		)
+		for _, k := range keys {
+			if given[k] < demand[k] {
+				active = append(active, k)
+				totalWeight += weight(k)
+			}
+		}
+		if len(active) == 0 || totalWeight == 0 {
+			return
+		}
+
+		var handedOut Bandwidth
+		for _, k := range active {
+			portion := minBandwidth(capacity*weight(k)/totalWeight, demand[k]-given[k])
+			given[k] += portion
+			handedOut += portion
+		}
+		if handedOut == 0 {
+			// what's left is smaller than the number of keys asking for it
+			return
+		}
+		capacity -= handedOut
+	}
+}
+
+func minBandwidth(a, b Bandwidth) Bandwidth {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -76,3 +76,169 @@ func TestUnmarshalBucket(t *testing.T) {
 		})
 	}
 }
+
+func TestFairShareLimiter(t *testing.T) {
+	workspaces := func(team string, n int) []cpulimit.Workspace {
+		res := make([]cpulimit.Workspace, n)
+		for i := range res {
+			res[i] = cpulimit.Workspace{ID: fmt.Sprintf("%s-%02d", team, i), Team: team}
+		}
+		return res
+	}
+	personal := func(owner string, n int) []cpulimit.Workspace {
+		res := make([]cpulimit.Workspace, n)
+		for i := range res {
+			res[i] = cpulimit.Workspace{ID: fmt.Sprintf("%s-%02d", owner, i), Owner: owner}
+		}
+		return res
+	}
+	inTenant := func(tenant string, ws []cpulimit.Workspace) []cpulimit.Workspace {
+		for i := range ws {
+			ws[i].Tenant = tenant
+		}
+		return ws
+	}
+	concat := func(ws ...[]cpulimit.Workspace) (res []cpulimit.Workspace) {
+		for _, w := range ws {
+			res = append(res, w...)
+		}
+		return res
+	}
+
+	tests := []struct {
+		Desc         string
+		Groups       map[string]cpulimit.FairShareGroup
+		DefaultGroup cpulimit.FairShareGroup
+		Workspaces   []cpulimit.Workspace
+		// Used maps teams to the bandwidth each of their workspaces used during the last tick
+		Used        map[string]cpulimit.Bandwidth
+		Expectation map[string]cpulimit.Bandwidth
+	}{
+		{
+			Desc:        "single workspace gets its limit",
+			Workspaces:  workspaces("a", 1),
+			Expectation: map[string]cpulimit.Bandwidth{"a-00": 2000},
+		},
+		{
+			Desc:       "teams share evenly",
+			Workspaces: append(workspaces("a", 10), workspaces("b", 2)...),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 400,
+				"b-00": 2000,
+			},
+		},
+		{
+			Desc:       "unused share is passed on",
+			Workspaces: append(workspaces("a", 10), workspaces("b", 1)...),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 600,
+				"b-00": 2000,
+			},
+		},
+		{
+			Desc: "weights",
+			Groups: map[string]cpulimit.FairShareGroup{
+				"a": {Weight: 3},
+			},
+			Workspaces: append(workspaces("a", 10), workspaces("b", 10)...),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 600,
+				"b-00": 200,
+			},
+		},
+		{
+			Desc: "guaranteed minimum",
+			Groups: map[string]cpulimit.FairShareGroup{
+				"b": {Minimum: 6000},
+			},
+			Workspaces: append(workspaces("a", 10), workspaces("b", 10)...),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 100,
+				"b-00": 700,
+			},
+		},
+		{
+			Desc: "minimums exceeding the total are scaled down",
+			Groups: map[string]cpulimit.FairShareGroup{
+				"a": {Minimum: 15000},
+				"b": {Minimum: 5000},
+			},
+			Workspaces: append(workspaces("a", 10), workspaces("b", 10)...),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 600,
+				"b-00": 200,
+			},
+		},
+		{
+			Desc:        "tenant without a team",
+			Workspaces:  append(workspaces("a", 10), cpulimit.Workspace{ID: "t", Tenant: "tenant"}),
+			Expectation: map[string]cpulimit.Bandwidth{"a-00": 600, "t": 2000},
+		},
+		{
+			Desc:       "personal workspaces are grouped by owner",
+			Workspaces: concat(workspaces("a", 10), personal("u1", 2), personal("u2", 2)),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00":  266,
+				"u1-00": 1334,
+				"u2-00": 1334,
+			},
+		},
+		{
+			Desc: "teams are nested in tenants",
+			Workspaces: concat(
+				inTenant("t1", workspaces("a", 10)),
+				inTenant("t1", workspaces("b", 10)),
+				inTenant("t2", workspaces("c", 10)),
+			),
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 200,
+				"b-00": 200,
+				"c-00": 400,
+			},
+		},
+		{
+			Desc:       "demand follows usage",
+			Workspaces: concat(workspaces("a", 10), workspaces("b", 10), workspaces("c", 10)),
+			Used:       map[string]cpulimit.Bandwidth{"a": 100, "b": 0},
+			Expectation: map[string]cpulimit.Bandwidth{
+				"a-00": 125,
+				"b-00": 100,
+				"c-00": 575,
+			},
+		},
+		{
+			Desc:        "unused bandwidth is handed out up to the limit",
+			Workspaces:  workspaces("a", 2),
+			Used:        map[string]cpulimit.Bandwidth{"a": 100},
+			Expectation: map[string]cpulimit.Bandwidth{"a-00": 2000},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			limiter := &cpulimit.FairShareLimiter{
+				Limiter:      cpulimit.FixedLimiter(2000),
+				Groups:       test.Groups,
+				DefaultGroup: test.DefaultGroup,
+			}
+			history := make(map[string]*cpulimit.WorkspaceHistory, len(test.Workspaces))
+			for _, ws := range test.Workspaces {
+				h := &cpulimit.WorkspaceHistory{ID: ws.ID}
+				h.Update(ws)
+				if bw, ok := test.Used[ws.Team]; ok {
+					h.Bandwidth, h.BandwidthKnown = bw, true
+				}
+				history[ws.ID] = h
+			}
+			limiter.Share(history, 8000)
+
+			act := make(map[string]cpulimit.Bandwidth, len(test.Expectation))
+			for id := range test.Expectation {
+				act[id], _ = limiter.Limit(history[id])
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected limits (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
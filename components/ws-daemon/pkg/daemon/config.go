@@ -14,6 +14,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/diskguard"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/pressure"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -29,6 +30,7 @@ type Config struct {
 	NetLimit            netlimit.Config           `json:"netlimit"`
 	OOMScores           cgroup.OOMScoreAdjConfig  `json:"oomScores"`
 	DiskSpaceGuard      diskguard.Config          `json:"disk"`
+	MemoryPressure      pressure.Config           `json:"memoryPressure"`
 	WorkspaceController WorkspaceControllerConfig `json:"workspaceController"`
 }
 
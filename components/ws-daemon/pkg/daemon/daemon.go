@@ -35,6 +35,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/pressure"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 )
 
@@ -194,7 +195,7 @@ func NewDaemon(config Config) (*Daemon, error) {
 		config.CPULimit.CGroupBasePath,
 	)
 
-	workspaceOps, err := controller.NewWorkspaceOperations(contentCfg, controller.NewWorkspaceProvider(contentCfg.WorkingArea, hooks), wrappedReg)
+	workspaceOps, err := controller.NewWorkspaceOperations(contentCfg, controller.NewWorkspaceProvider(contentCfg.WorkingArea, hooks), containerRuntime, wrappedReg)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +217,22 @@ func NewDaemon(config Config) (*Daemon, error) {
 		return nil, err
 	}
 
+	if config.MemoryPressure.Enabled {
+		pressureCtrl, err := pressure.NewController(config.MemoryPressure, config.CPULimit.CGroupBasePath, config.Runtime.KubernetesNamespace,
+			mgr.GetClient(), mgr.GetEventRecorderFor("memory-pressure"), wrappedReg)
+		if err != nil {
+			return nil, err
+		}
+		err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			pressureCtrl.Start(ctx)
+			return nil
+		}))
+		if err != nil {
+			return nil, err
+		}
+		listener = append(listener, pressureCtrl)
+	}
+
 	housekeeping := controller.NewHousekeeping(contentCfg.WorkingArea, 5*time.Minute)
 	go housekeeping.Start(context.Background())
 
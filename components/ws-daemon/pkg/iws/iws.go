@@ -39,6 +39,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/container"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
 	nsi "github.com/gitpod-io/gitpod/ws-daemon/pkg/nsinsider"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 )
 
 //
@@ -84,7 +85,7 @@ var (
 )
 
 // ServeWorkspace establishes the IWS server for a workspace
-func ServeWorkspace(uidmapper *Uidmapper, fsshift api.FSShiftMethod, cgroupMountPoint string, workspaceCIDR string) func(ctx context.Context, ws *session.Workspace) error {
+func ServeWorkspace(uidmapper *Uidmapper, fsshift api.FSShiftMethod, cgroupMountPoint string, workspaceCIDR string, xfs *quota.XFS, maxStorageQuota quota.Size) func(ctx context.Context, ws *session.Workspace) error {
 	return func(ctx context.Context, ws *session.Workspace) (err error) {
 		span, _ := opentracing.StartSpanFromContext(ctx, "iws.ServeWorkspace")
 		defer tracing.FinishSpan(span, &err)
@@ -99,6 +100,8 @@ func ServeWorkspace(uidmapper *Uidmapper, fsshift api.FSShiftMethod, cgroupMount
 			FSShift:          fsshift,
 			CGroupMountPoint: cgroupMountPoint,
 			WorkspaceCIDR:    workspaceCIDR,
+			XFS:              xfs,
+			MaxStorageQuota:  maxStorageQuota,
 		}
 		err = iws.Start()
 		if err != nil {
@@ -142,6 +145,12 @@ type InWorkspaceServiceServer struct {
 
 	WorkspaceCIDR string
 
+	// XFS is nil if the working area does not support project quota
+	XFS *quota.XFS
+	// MaxStorageQuota is the largest storage quota a workspace can resize itself to.
+	// Resizing is disabled if this is zero.
+	MaxStorageQuota quota.Size
+
 	srv  *grpc.Server
 	sckt io.Closer
 
@@ -946,11 +955,79 @@ func (wbs *InWorkspaceServiceServer) WorkspaceInfo(ctx context.Context, req *api
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
+	if wbs.XFS != nil && wbs.Session.XFSProjectID != 0 {
+		resources.Storage, err = wbs.storageInfo()
+		if err != nil {
+			// storage is optional - we'd rather report CPU and memory than nothing at all
+			log.WithError(err).WithFields(wbs.Session.OWI()).Warn("could not get storage information")
+		}
+	}
+
 	return &api.WorkspaceInfoResponse{
 		Resources: resources,
 	}, nil
 }
 
+// ResizeStorageQuota changes the storage quota of the running workspace
+func (wbs *InWorkspaceServiceServer) ResizeStorageQuota(ctx context.Context, req *api.ResizeStorageQuotaRequest) (*api.ResizeStorageQuotaResponse, error) {
+	if wbs.XFS == nil || wbs.Session.XFSProjectID == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "workspace has no storage quota")
+	}
+	if wbs.MaxStorageQuota == 0 {
+		return nil, status.Errorf(codes.PermissionDenied, "storage quota resizing is disabled")
+	}
+
+	size := quota.Size(req.Size)
+	if size <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "size must be positive")
+	}
+	if size > wbs.MaxStorageQuota {
+		return nil, status.Errorf(codes.InvalidArgument, "size must not exceed %s", wbs.MaxStorageQuota)
+	}
+
+	used, _, err := wbs.XFS.GetUsage(wbs.Session.XFSProjectID)
+	if err != nil {
+		log.WithError(err).WithFields(wbs.Session.OWI()).Error("ResizeStorageQuota: cannot get storage usage")
+		return nil, status.Errorf(codes.Internal, "cannot get storage usage")
+	}
+	if size < used {
+		return nil, status.Errorf(codes.FailedPrecondition, "size must not be smaller than the %s currently in use", used)
+	}
+
+	err = wbs.XFS.SetLimit(wbs.Session.XFSProjectID, size, true)
+	if err != nil {
+		log.WithError(err).WithFields(wbs.Session.OWI()).Error("ResizeStorageQuota: cannot set storage quota")
+		return nil, status.Errorf(codes.Internal, "cannot set storage quota")
+	}
+
+	// The quota is re-installed when ws-daemon restarts. Make sure that doesn't undo the resize.
+	wbs.Session.StorageQuota = int(size)
+	err = wbs.Session.Persist()
+	if err != nil {
+		log.WithError(err).WithFields(wbs.Session.OWI()).Warn("ResizeStorageQuota: cannot persist new storage quota")
+	}
+	log.WithFields(wbs.Session.OWI()).WithField("size", size).Info("resized storage quota")
+
+	return &api.ResizeStorageQuotaResponse{
+		Storage: &api.Storage{
+			Used:  int64(used),
+			Limit: int64(size),
+		},
+	}, nil
+}
+
+func (wbs *InWorkspaceServiceServer) storageInfo() (*api.Storage, error) {
+	used, limit, err := wbs.XFS.GetUsage(wbs.Session.XFSProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Storage{
+		Used:  int64(used),
+		Limit: int64(limit),
+	}, nil
+}
+
 func getWorkspaceResourceInfo(mountPoint, cgroupPath string) (*api.Resources, error) {
 	cpu, err := getCpuResourceInfoV2(mountPoint, cgroupPath)
 	if err != nil {
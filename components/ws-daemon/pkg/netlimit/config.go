@@ -4,9 +4,105 @@
 
 package netlimit
 
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
 type Config struct {
 	Enabled              bool  `json:"enabled"`
 	Enforce              bool  `json:"enforce"`
 	ConnectionsPerMinute int64 `json:"connectionsPerMinute"`
 	BucketSize           int64 `json:"bucketSize"`
+
+	// Classes configures bandwidth shaping and network policy per workspace class.
+	// Workspaces of classes not listed here are neither shaped nor restricted.
+	Classes map[string]ClassConfig `json:"classes,omitempty"`
+
+	// AlwaysAllow lists destinations workspaces may connect to regardless of the allow and deny lists of their class.
+	// It has to include the Gitpod installation, which supervisor and the IDE connect to. DNS is always allowed.
+	AlwaysAllow []Destination `json:"alwaysAllow,omitempty"`
+}
+
+// ClassConfig configures the network of all workspaces of a class
+type ClassConfig struct {
+	// EgressBytesPerSecond limits the rate at which a workspace sends data. Zero means unlimited.
+	EgressBytesPerSecond int64 `json:"egressBytesPerSecond,omitempty"`
+	// IngressBytesPerSecond limits the rate at which a workspace receives data. Zero means unlimited.
+	IngressBytesPerSecond int64 `json:"ingressBytesPerSecond,omitempty"`
+
+	// Allow lists the only destinations a workspace may open connections to. An empty list allows all destinations.
+	Allow []Destination `json:"allow,omitempty"`
+	// Deny lists destinations a workspace must not open connections to. Deny takes precedence over Allow.
+	Deny []Destination `json:"deny,omitempty"`
+}
+
+// Destination is a network a workspace connects to
+type Destination struct {
+	CIDR string `json:"cidr"`
+	// Ports restricts the destination to TCP and UDP connections to these ports. An empty list matches all traffic.
+	Ports []uint16 `json:"ports,omitempty"`
+}
+
+// Validate returns an error if the class configuration cannot be applied
+func (c ClassConfig) Validate() error {
+	if c.EgressBytesPerSecond < 0 || c.IngressBytesPerSecond < 0 {
+		return fmt.Errorf("byte rates must not be negative")
+	}
+	return validateDestinations(append(append([]Destination{}, c.Allow...), c.Deny...))
+}
+
+func validateDestinations(dsts []Destination) error {
+	for _, d := range dsts {
+		_, _, err := net.ParseCIDR(d.CIDR)
+		if err != nil {
+			return fmt.Errorf("invalid destination: %w", err)
+		}
+	}
+	return nil
+}
+
+// args produces the nsinsider arguments which set up this class configuration
+func (c ClassConfig) args(alwaysAllow []Destination) []string {
+	res := []string{
+		"setup-network-shaping",
+		"--egress-rate", strconv.FormatInt(c.EgressBytesPerSecond, 10),
+		"--ingress-rate", strconv.FormatInt(c.IngressBytesPerSecond, 10),
+	}
+	for _, d := range alwaysAllow {
+		for _, dst := range d.specs() {
+			res = append(res, "--always-allow", dst)
+		}
+	}
+	for _, d := range c.Allow {
+		for _, dst := range d.specs() {
+			res = append(res, "--allow", dst)
+		}
+	}
+	for _, d := range c.Deny {
+		for _, dst := range d.specs() {
+			res = append(res, "--deny", dst)
+		}
+	}
+	return res
+}
+
+// specs renders the destination in the cidr[:port] notation nsinsider understands, one spec per port.
+// IPv6 destinations with a port are rendered as [cidr]:port.
+func (d Destination) specs() []string {
+	if len(d.Ports) == 0 {
+		return []string{d.CIDR}
+	}
+
+	format := "%s:%d"
+	if ip, _, err := net.ParseCIDR(d.CIDR); err == nil && ip.To4() == nil {
+		format = "[%s]:%d"
+	}
+
+	res := make([]string, 0, len(d.Ports))
+	for _, p := range d.Ports {
+		res = append(res, fmt.Sprintf(format, d.CIDR, p))
+	}
+	return res
 }
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package netlimit
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClassConfigArgs(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Class       ClassConfig
+		AlwaysAllow []Destination
+		Expectation []string
+	}{
+		{
+			Name:        "unlimited",
+			Expectation: []string{"setup-network-shaping", "--egress-rate", "0", "--ingress-rate", "0"},
+		},
+		{
+			Name: "rates and destinations",
+			Class: ClassConfig{
+				EgressBytesPerSecond:  1024,
+				IngressBytesPerSecond: 2048,
+				Allow: []Destination{
+					{CIDR: "10.0.0.0/8", Ports: []uint16{80, 443}},
+					{CIDR: "192.168.0.0/16"},
+				},
+				Deny: []Destination{
+					{CIDR: "169.254.169.254/32"},
+				},
+			},
+			Expectation: []string{
+				"setup-network-shaping", "--egress-rate", "1024", "--ingress-rate", "2048",
+				"--allow", "10.0.0.0/8:80",
+				"--allow", "10.0.0.0/8:443",
+				"--allow", "192.168.0.0/16",
+				"--deny", "169.254.169.254/32",
+			},
+		},
+		{
+			Name: "always allowed and IPv6 destinations",
+			Class: ClassConfig{
+				Allow: []Destination{
+					{CIDR: "fd00::/8", Ports: []uint16{443}},
+					{CIDR: "2001:db8::/32"},
+				},
+			},
+			AlwaysAllow: []Destination{
+				{CIDR: "35.1.2.3/32", Ports: []uint16{443}},
+			},
+			Expectation: []string{
+				"setup-network-shaping", "--egress-rate", "0", "--ingress-rate", "0",
+				"--always-allow", "35.1.2.3/32:443",
+				"--allow", "[fd00::/8]:443",
+				"--allow", "2001:db8::/32",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := test.Class.args(test.AlwaysAllow)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClassConfigValidate(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Class ClassConfig
+		Valid bool
+	}{
+		{Name: "empty", Valid: true},
+		{Name: "valid destinations", Class: ClassConfig{Allow: []Destination{{CIDR: "10.0.0.0/8"}}, Deny: []Destination{{CIDR: "10.1.0.0/16", Ports: []uint16{22}}}}, Valid: true},
+		{Name: "negative rate", Class: ClassConfig{EgressBytesPerSecond: -1}},
+		{Name: "no CIDR", Class: ClassConfig{Deny: []Destination{{CIDR: "10.0.0.1"}}}},
+		{Name: "IPv6", Class: ClassConfig{Allow: []Destination{{CIDR: "fd00::/8"}}}, Valid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := test.Class.Validate()
+			if test.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"reflect"
 
 	"runtime"
 	"strconv"
@@ -24,12 +25,38 @@ import (
 	"github.com/vishvananda/netns"
 )
 
+const (
+	// shapingTable is the nftables table nsinsider sets up bandwidth shaping and network policy in
+	shapingTable = "gitpod-shaping"
+
+	egressBytesCounter  = "ws-egress-bytes"
+	ingressBytesCounter = "ws-ingress-bytes"
+	egressDropCounter   = "ws-egress-drop-stats"
+	ingressDropCounter  = "ws-ingress-drop-stats"
+	policyDropCounter   = "ws-policy-drop-stats"
+)
+
 type ConnLimiter struct {
 	mu             sync.RWMutex
 	limited        map[string]struct{}
+	workspaces     map[string]*shapedWorkspace
 	droppedBytes   *prometheus.GaugeVec
 	droppedPackets *prometheus.GaugeVec
-	config         Config
+
+	transferredBytes      *prometheus.GaugeVec
+	shapingDroppedBytes   *prometheus.GaugeVec
+	shapingDroppedPackets *prometheus.GaugeVec
+	policyDroppedPackets  *prometheus.GaugeVec
+	config                Config
+}
+
+// shapedWorkspace is a running workspace whose bandwidth shaping and network policy follow the configuration
+type shapedWorkspace struct {
+	ctx context.Context
+	ws  *dispatch.Workspace
+	pid uint64
+	// args are the nsinsider arguments the rules were last set up with, nil if the workspace is not shaped
+	args []string
 }
 
 func NewConnLimiter(config Config, prom prometheus.Registerer) *ConnLimiter {
@@ -43,7 +70,29 @@ func NewConnLimiter(config Config, prom prometheus.Registerer) *ConnLimiter {
 			Name: "netlimit_connections_dropped_packets",
 			Help: "Number of packets dropped due to connection limiting",
 		}, []string{"node", "workspace"}),
-		limited: map[string]struct{}{},
+
+		transferredBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netlimit_transferred_bytes",
+			Help: "Number of bytes a shaped workspace sent or received",
+		}, []string{"node", "workspace", "direction"}),
+
+		shapingDroppedBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netlimit_bandwidth_dropped_bytes",
+			Help: "Number of bytes dropped due to bandwidth shaping",
+		}, []string{"node", "workspace", "direction"}),
+
+		shapingDroppedPackets: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netlimit_bandwidth_dropped_packets",
+			Help: "Number of packets dropped due to bandwidth shaping",
+		}, []string{"node", "workspace", "direction"}),
+
+		policyDroppedPackets: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netlimit_policy_dropped_packets",
+			Help: "Number of packets dropped because their destination is not allowed",
+		}, []string{"node", "workspace"}),
+
+		limited:    map[string]struct{}{},
+		workspaces: map[string]*shapedWorkspace{},
 	}
 
 	s.config = config
@@ -52,6 +101,10 @@ func NewConnLimiter(config Config, prom prometheus.Registerer) *ConnLimiter {
 		prom.MustRegister(
 			s.droppedBytes,
 			s.droppedPackets,
+			s.transferredBytes,
+			s.shapingDroppedBytes,
+			s.shapingDroppedPackets,
+			s.policyDroppedPackets,
 		)
 	}
 
@@ -63,17 +116,25 @@ func (c *ConnLimiter) WorkspaceAdded(ctx context.Context, ws *dispatch.Workspace
 	defer c.mu.Unlock()
 
 	_, hasAnnotation := ws.Pod.Annotations[kubernetes.WorkspaceNetConnLimitAnnotation]
-	if !hasAnnotation {
-		return nil
+	if hasAnnotation {
+		err := c.limitWorkspace(ctx, ws)
+		if err != nil {
+			return err
+		}
 	}
 
-	return c.limitWorkspace(ctx, ws)
+	return c.shapeWorkspace(ctx, ws)
 }
 
 func (c *ConnLimiter) WorkspaceUpdated(ctx context.Context, ws *dispatch.Workspace) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	err := c.shapeWorkspace(ctx, ws)
+	if err != nil {
+		return err
+	}
+
 	_, hasAnnotation := ws.Pod.Annotations[kubernetes.WorkspaceNetConnLimitAnnotation]
 	if !hasAnnotation {
 		return nil
@@ -87,6 +148,16 @@ func (c *ConnLimiter) WorkspaceUpdated(ctx context.Context, ws *dispatch.Workspa
 }
 
 func (n *ConnLimiter) GetConnectionDropCounter(pid uint64) (*nftables.CounterObj, error) {
+	counters, err := getCounters(pid, nftables.TableFamilyIPv4, "gitpod", "ws-connection-drop-stats")
+	if err != nil {
+		return nil, fmt.Errorf("could not get connection drop stats: %w", err)
+	}
+
+	return counters["ws-connection-drop-stats"], nil
+}
+
+// getCounters reads the named counter objects from a table in the network namespace of pid
+func getCounters(pid uint64, family nftables.TableFamily, table string, names ...string) (map[string]*nftables.CounterObj, error) {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
@@ -94,45 +165,44 @@ func (n *ConnLimiter) GetConnectionDropCounter(pid uint64) (*nftables.CounterObj
 	if err != nil {
 		return nil, fmt.Errorf("could not get handle for network namespace: %w", err)
 	}
+	defer netns.Close()
 
 	nftconn, err := nftables.New(nftables.WithNetNSFd(int(netns)))
 	if err != nil {
 		return nil, fmt.Errorf("could not establish netlink connection for nft: %w", err)
 	}
 
-	gitpodTable := &nftables.Table{
-		Name:   "gitpod",
-		Family: nftables.TableFamilyIPv4,
+	tbl := &nftables.Table{
+		Name:   table,
+		Family: family,
 	}
 
-	counterObject, err := nftconn.GetObject(&nftables.CounterObj{
-		Table: gitpodTable,
-		Name:  "ws-connection-drop-stats",
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("could not get connection drop stats: %w", err)
-	}
+	res := make(map[string]*nftables.CounterObj, len(names))
+	for _, name := range names {
+		counterObject, err := nftconn.GetObject(&nftables.CounterObj{
+			Table: tbl,
+			Name:  name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get counter %s: %w", name, err)
+		}
 
-	dropCounter, ok := counterObject.(*nftables.CounterObj)
-	if !ok {
-		return nil, fmt.Errorf("could not cast counter object")
+		counter, ok := counterObject.(*nftables.CounterObj)
+		if !ok {
+			return nil, fmt.Errorf("could not cast counter object %s", name)
+		}
+		res[name] = counter
 	}
 
-	return dropCounter, nil
+	return res, nil
 }
 
 func (c *ConnLimiter) limitWorkspace(ctx context.Context, ws *dispatch.Workspace) error {
 	log.WithFields(ws.OWI()).Infof("will limit network connections")
 
-	disp := dispatch.GetFromContext(ctx)
-	if disp == nil {
-		return fmt.Errorf("no dispatch available")
-	}
-
-	pid, err := disp.Runtime.ContainerPID(context.Background(), ws.ContainerID)
+	pid, err := workspacePID(ctx, ws)
 	if err != nil {
-		return fmt.Errorf("could not get pid for container %s of workspace %s", ws.ContainerID, ws.WorkspaceID)
+		return err
 	}
 
 	err = nsinsider.Nsinsider(ws.InstanceID, int(pid), func(cmd *exec.Cmd) {
@@ -169,6 +239,10 @@ func (c *ConnLimiter) limitWorkspace(ctx context.Context, ws *dispatch.Workspace
 				c.mu.Lock()
 				delete(c.limited, ws.InstanceID)
 				c.mu.Unlock()
+
+				nodeName := os.Getenv("NODENAME")
+				c.droppedBytes.DeleteLabelValues(nodeName, ws.Pod.Name)
+				c.droppedPackets.DeleteLabelValues(nodeName, ws.Pod.Name)
 				return
 			}
 		}
@@ -177,10 +251,155 @@ func (c *ConnLimiter) limitWorkspace(ctx context.Context, ws *dispatch.Workspace
 	return nil
 }
 
+// shapeWorkspace tracks the workspace and sets up bandwidth shaping and network policy if the workspace's class is configured for it
+func (c *ConnLimiter) shapeWorkspace(ctx context.Context, ws *dispatch.Workspace) error {
+	sws, ok := c.workspaces[ws.InstanceID]
+	if !ok {
+		sws = &shapedWorkspace{ctx: ctx, ws: ws}
+		c.workspaces[ws.InstanceID] = sws
+		go c.watchShaping(sws)
+	}
+	sws.ws = ws
+
+	return c.shape(sws)
+}
+
+// shape brings the bandwidth shaping and network policy of a workspace in line with the current configuration.
+// Rules are only (re-)applied if the configuration of the workspace's class changed.
+func (c *ConnLimiter) shape(sws *shapedWorkspace) error {
+	class, ok := c.config.Classes[sws.ws.Pod.Labels[kubernetes.WorkspaceClassLabel]]
+	if !ok && sws.args == nil {
+		return nil
+	}
+
+	var args []string
+	if ok {
+		args = class.args(c.config.AlwaysAllow)
+	} else {
+		// the class was removed from the config: the zero value lifts all restrictions
+		args = ClassConfig{}.args(nil)
+	}
+	if reflect.DeepEqual(args, sws.args) {
+		return nil
+	}
+
+	if sws.pid == 0 {
+		pid, err := workspacePID(sws.ctx, sws.ws)
+		if err != nil {
+			return err
+		}
+		sws.pid = pid
+	}
+
+	log.WithFields(sws.ws.OWI()).Infof("will shape network bandwidth")
+	err := applyClass(sws.ws, sws.pid, class, c.config.AlwaysAllow, args)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		sws.args = args
+	} else {
+		sws.args = nil
+	}
+	return nil
+}
+
+// watchShaping exports the bandwidth shaping stats of a workspace while it is shaped and forgets the workspace once it is gone
+func (c *ConnLimiter) watchShaping(sws *shapedWorkspace) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	nodeName := os.Getenv("NODENAME")
+	podName := sws.ws.Pod.Name
+	for {
+		select {
+		case <-ticker.C:
+			c.mu.RLock()
+			pid, shaped := sws.pid, sws.args != nil
+			c.mu.RUnlock()
+			if !shaped {
+				continue
+			}
+
+			counters, err := getCounters(pid, nftables.TableFamilyINet, shapingTable, egressBytesCounter, ingressBytesCounter, egressDropCounter, ingressDropCounter, policyDropCounter)
+			if err != nil {
+				log.WithError(err).Errorf("could not get bandwidth shaping stats for %s", sws.ws.WorkspaceID)
+				continue
+			}
+
+			c.transferredBytes.WithLabelValues(nodeName, podName, "egress").Set(float64(counters[egressBytesCounter].Bytes))
+			c.transferredBytes.WithLabelValues(nodeName, podName, "ingress").Set(float64(counters[ingressBytesCounter].Bytes))
+			c.shapingDroppedBytes.WithLabelValues(nodeName, podName, "egress").Set(float64(counters[egressDropCounter].Bytes))
+			c.shapingDroppedBytes.WithLabelValues(nodeName, podName, "ingress").Set(float64(counters[ingressDropCounter].Bytes))
+			c.shapingDroppedPackets.WithLabelValues(nodeName, podName, "egress").Set(float64(counters[egressDropCounter].Packets))
+			c.shapingDroppedPackets.WithLabelValues(nodeName, podName, "ingress").Set(float64(counters[ingressDropCounter].Packets))
+			c.policyDroppedPackets.WithLabelValues(nodeName, podName).Set(float64(counters[policyDropCounter].Packets))
+
+		case <-sws.ctx.Done():
+			c.mu.Lock()
+			delete(c.workspaces, sws.ws.InstanceID)
+			c.mu.Unlock()
+
+			for _, direction := range []string{"egress", "ingress"} {
+				c.transferredBytes.DeleteLabelValues(nodeName, podName, direction)
+				c.shapingDroppedBytes.DeleteLabelValues(nodeName, podName, direction)
+				c.shapingDroppedPackets.DeleteLabelValues(nodeName, podName, direction)
+			}
+			c.policyDroppedPackets.DeleteLabelValues(nodeName, podName)
+			return
+		}
+	}
+}
+
+// applyClass (re-)creates the bandwidth shaping and network policy rules of a workspace.
+// Existing rules are replaced atomically.
+func applyClass(ws *dispatch.Workspace, pid uint64, class ClassConfig, alwaysAllow []Destination, args []string) error {
+	err := class.Validate()
+	if err == nil {
+		err = validateDestinations(alwaysAllow)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid network configuration for workspace %s: %w", ws.InstanceID, err)
+	}
+
+	err = nsinsider.Nsinsider(ws.InstanceID, int(pid), func(cmd *exec.Cmd) {
+		cmd.Args = append(cmd.Args, args...)
+	}, nsinsider.EnterMountNS(false), nsinsider.EnterNetNS(true))
+	if err != nil {
+		log.WithError(err).WithFields(ws.OWI()).Error("cannot enable bandwidth shaping")
+		return err
+	}
+	return nil
+}
+
+func workspacePID(ctx context.Context, ws *dispatch.Workspace) (uint64, error) {
+	disp := dispatch.GetFromContext(ctx)
+	if disp == nil {
+		return 0, fmt.Errorf("no dispatch available")
+	}
+
+	pid, err := disp.Runtime.ContainerPID(context.Background(), ws.ContainerID)
+	if err != nil {
+		return 0, fmt.Errorf("could not get pid for container %s of workspace %s", ws.ContainerID, ws.WorkspaceID)
+	}
+	return pid, nil
+}
+
+// Update replaces the configuration. The bandwidth shaping and network policy of running workspaces
+// are updated if their class configuration changed, including classes which were added or removed.
+// Connection limits only apply to new workspaces.
 func (c *ConnLimiter) Update(config Config) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.config = config
 	log.WithField("config", config).Info("updating network connection limits")
+
+	for _, sws := range c.workspaces {
+		err := c.shape(sws)
+		if err != nil {
+			log.WithError(err).WithFields(sws.ws.OWI()).Warn("cannot update bandwidth shaping")
+		}
+	}
 }
@@ -0,0 +1,63 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pressure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+)
+
+const (
+	// supervisorAPIPort is the port supervisor serves its API on
+	supervisorAPIPort = 22999
+)
+
+// notifySupervisor shows a warning to the user through supervisor's notification service.
+// Supervisor serves the service's REST gateway next to its gRPC API.
+func notifySupervisor(ctx context.Context, ws *dispatch.Workspace, message string) error {
+	if ws.Pod == nil || ws.Pod.Status.PodIP == "" {
+		return xerrors.Errorf("workspace has no IP")
+	}
+
+	body, err := json.Marshal(struct {
+		Level   string `json:"level"`
+		Message string `json:"message"`
+	}{
+		Level:   "WARNING",
+		Message: message,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	url := fmt.Sprintf("http://%s:%d/_supervisor/v1/notification/notify", ws.Pod.Status.PodIP, supervisorAPIPort)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("cannot reach supervisor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("supervisor responded with %s", resp.Status)
+	}
+	return nil
+}
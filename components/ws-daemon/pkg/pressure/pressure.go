@@ -0,0 +1,538 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pressure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	cgroups_v2 "github.com/gitpod-io/gitpod/common-go/cgroups/v2"
+	"github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+const (
+	// evictionGracePeriod is the time an evicted workspace gets to shut down
+	evictionGracePeriod = 30 * time.Second
+)
+
+// Config configures the memory pressure controller
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Interval is the time between two pressure stall samples
+	Interval util.Duration `json:"interval"`
+	// SustainedFor is how long a workspace must be under pressure before the controller takes the next step,
+	// and how long it must be free of pressure before all steps are undone.
+	SustainedFor util.Duration `json:"sustainedFor"`
+
+	// MemoryThreshold and IOThreshold are the percentage of time some of a workspace's tasks may stall
+	// on memory or IO before the workspace is considered under pressure. They default to 10 and 50.
+	MemoryThreshold float64 `json:"memoryThreshold"`
+	IOThreshold     float64 `json:"ioThreshold"`
+
+	// MemoryHighRatio determines memory.high as a ratio of the workspace's memory limit,
+	// or of its current memory use if it has no limit. Defaults to 0.9.
+	MemoryHighRatio float64 `json:"memoryHighRatio"`
+	// SwapMax is the amount of swap a workspace under pressure may use
+	SwapMax resource.Quantity `json:"swapMax"`
+	// Evict enables stopping the lowest-priority workspace on the node as a last resort
+	Evict bool `json:"evict"`
+}
+
+// Validate returns an error if the configuration cannot be applied
+func (c Config) Validate() error {
+	if c.MemoryThreshold <= 0 || c.MemoryThreshold > 100 {
+		return xerrors.Errorf("memoryThreshold must be between 0 and 100, got %v", c.MemoryThreshold)
+	}
+	if c.IOThreshold <= 0 || c.IOThreshold > 100 {
+		return xerrors.Errorf("ioThreshold must be between 0 and 100, got %v", c.IOThreshold)
+	}
+	if c.MemoryHighRatio <= 0 || c.MemoryHighRatio > 1 {
+		return xerrors.Errorf("memoryHighRatio must be between 0 and 1, got %v", c.MemoryHighRatio)
+	}
+	if c.SwapMax.Sign() < 0 {
+		return xerrors.Errorf("swapMax must not be negative")
+	}
+	return nil
+}
+
+// Step is a measure the controller takes against memory pressure. Steps are taken in order.
+type Step int
+
+const (
+	StepNone Step = iota
+	StepMemoryHigh
+	StepSwap
+	StepNotify
+	StepEvict
+)
+
+func (s Step) String() string {
+	switch s {
+	case StepNone:
+		return "none"
+	case StepMemoryHigh:
+		return "memory-high"
+	case StepSwap:
+		return "swap"
+	case StepNotify:
+		return "notify"
+	case StepEvict:
+		return "evict"
+	default:
+		return fmt.Sprintf("step-%d", int(s))
+	}
+}
+
+// NewController creates a new memory pressure controller. Call Start to start sampling.
+func NewController(cfg Config, cgroupBasePath, namespace string, c client.Client, recorder record.EventRecorder, prom prometheus.Registerer) (*Controller, error) {
+	if cfg.Interval == 0 {
+		cfg.Interval = util.Duration(10 * time.Second)
+	}
+	if cfg.SustainedFor == 0 {
+		cfg.SustainedFor = util.Duration(time.Minute)
+	}
+	if cfg.MemoryThreshold == 0 {
+		cfg.MemoryThreshold = 10
+	}
+	if cfg.IOThreshold == 0 {
+		cfg.IOThreshold = 50
+	}
+	if cfg.MemoryHighRatio == 0 {
+		cfg.MemoryHighRatio = 0.9
+	}
+	err := cfg.Validate()
+	if err != nil {
+		return nil, xerrors.Errorf("invalid memory pressure config: %w", err)
+	}
+
+	res := &Controller{
+		Config:         cfg,
+		CGroupBasePath: cgroupBasePath,
+		Namespace:      namespace,
+		Client:         c,
+		Recorder:       recorder,
+		notify:         notifySupervisor,
+		now:            time.Now,
+		workspaces:     make(map[string]*workspace),
+		stepsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "memory_pressure_steps_total",
+			Help: "Number of steps taken against memory pressure",
+		}, []string{"step"}),
+	}
+	res.stop = res.stopWorkspace
+
+	err = prom.Register(res.stepsTotal)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Controller watches the memory and IO pressure of workspaces. Under sustained pressure it escalates
+// step by step: it throttles the workspace using memory.high, lets it swap, notifies the user, and
+// as a last resort stops the lowest-priority workspace on the node. Every step is recorded as event
+// on the workspace.
+type Controller struct {
+	Config         Config
+	CGroupBasePath string
+	Namespace      string
+	Client         client.Client
+	Recorder       record.EventRecorder
+
+	notify func(ctx context.Context, ws *dispatch.Workspace, message string) error
+	stop   func(ctx context.Context, instanceID string) error
+	now    func() time.Time
+
+	// mu guards the workspaces map. The workspaces themselves and lastEviction are only accessed by tick.
+	mu           sync.Mutex
+	workspaces   map[string]*workspace
+	lastEviction time.Time
+
+	stepsTotal *prometheus.CounterVec
+}
+
+// memoryCgroup is the part of a workspace cgroup the controller reads and writes
+type memoryCgroup interface {
+	MemoryPSI() (cgroups.PSI, error)
+	IOPSI() (cgroups.PSI, error)
+	MemoryCurrent() (uint64, error)
+	MemoryMax() (uint64, error)
+	MemoryHigh() (uint64, error)
+	SetMemoryHigh(uint64) error
+	SwapMax() (uint64, error)
+	SetSwapMax(uint64) error
+}
+
+type workspace struct {
+	ws     *dispatch.Workspace
+	cgroup memoryCgroup
+
+	lastMemory, lastIO uint64
+	lastSample         time.Time
+	memoryCurrent      uint64
+	evicted            bool
+
+	// originalHigh and originalSwapMax are the values the controller changed, restored once the pressure is relieved
+	originalHigh, originalSwapMax *uint64
+
+	state state
+}
+
+// state tracks how long a workspace has been under pressure, or free of it
+type state struct {
+	Step        Step
+	Pressured   bool
+	StreakStart time.Time
+}
+
+// observe records whether the workspace is under pressure and returns the step it should be at.
+// We take one step at a time, each after pressure persisted for sustainedFor. Once the pressure
+// has been gone for sustainedFor, we undo all steps at once.
+func (s *state) observe(pressured bool, now time.Time, sustainedFor time.Duration) (step Step, changed bool) {
+	if pressured != s.Pressured || s.StreakStart.IsZero() {
+		s.Pressured = pressured
+		s.StreakStart = now
+		return s.Step, false
+	}
+	if now.Sub(s.StreakStart) < sustainedFor {
+		return s.Step, false
+	}
+
+	switch {
+	case pressured && s.Step < StepEvict:
+		s.Step++
+	case !pressured && s.Step > StepNone:
+		s.Step = StepNone
+	default:
+		return s.Step, false
+	}
+	s.StreakStart = now
+	return s.Step, true
+}
+
+// WorkspaceAdded starts watching a workspace
+func (c *Controller) WorkspaceAdded(ctx context.Context, ws *dispatch.Workspace) error {
+	disp := dispatch.GetFromContext(ctx)
+	if disp == nil {
+		return xerrors.Errorf("no dispatch available")
+	}
+
+	cgroupPath, err := disp.Runtime.ContainerCGroupPath(context.Background(), ws.ContainerID)
+	if err != nil {
+		return xerrors.Errorf("cannot get cgroup path for container %s: %w", ws.ContainerID, err)
+	}
+	fullPath := filepath.Join(c.CGroupBasePath, cgroupPath)
+
+	c.mu.Lock()
+	c.workspaces[ws.InstanceID] = &workspace{
+		ws: ws,
+		cgroup: &v2Cgroup{
+			memory: cgroups_v2.NewMemoryController(fullPath),
+			io:     cgroups_v2.NewIOController(fullPath),
+		},
+	}
+	c.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.workspaces, ws.InstanceID)
+	}()
+
+	return nil
+}
+
+// Start samples the pressure of all workspaces until the context is canceled
+func (c *Controller) Start(ctx context.Context) {
+	t := time.NewTicker(time.Duration(c.Config.Interval))
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			c.tick(ctx)
+		}
+	}
+}
+
+func (c *Controller) tick(ctx context.Context) {
+	// we must not hold the lock while talking to supervisor or Kubernetes
+	c.mu.Lock()
+	workspaces := make([]*workspace, 0, len(c.workspaces))
+	for _, w := range c.workspaces {
+		workspaces = append(workspaces, w)
+	}
+	c.mu.Unlock()
+
+	now := c.now()
+	var evict *workspace
+	for _, w := range workspaces {
+		if w.evicted {
+			continue
+		}
+
+		memPressure, ioPressure, ok := w.sample(now)
+		if !ok {
+			continue
+		}
+		pressured := memPressure > c.Config.MemoryThreshold || ioPressure > c.Config.IOThreshold
+
+		step, changed := w.state.observe(pressured, now, time.Duration(c.Config.SustainedFor))
+		if !changed {
+			continue
+		}
+		c.stepsTotal.WithLabelValues(step.String()).Inc()
+
+		reason := fmt.Sprintf("memory pressure %.1f%%, IO pressure %.1f%%", memPressure, ioPressure)
+		switch step {
+		case StepNone:
+			c.relieve(w)
+		case StepMemoryHigh:
+			c.setMemoryHigh(w, reason)
+		case StepSwap:
+			c.enableSwap(w, reason)
+		case StepNotify:
+			c.notifyUser(ctx, w, reason)
+		case StepEvict:
+			evict = w
+		}
+	}
+
+	if evict != nil {
+		c.evict(ctx, evict, workspaces)
+	}
+}
+
+// sample returns the share of time some tasks of the workspace stalled on memory and IO since the last sample, in percent
+func (w *workspace) sample(now time.Time) (memory, io float64, ok bool) {
+	mem, err := w.cgroup.MemoryPSI()
+	if err != nil {
+		return 0, 0, false
+	}
+	iop, err := w.cgroup.IOPSI()
+	if err != nil {
+		return 0, 0, false
+	}
+	if current, err := w.cgroup.MemoryCurrent(); err == nil {
+		w.memoryCurrent = current
+	}
+
+	defer func() {
+		w.lastMemory, w.lastIO, w.lastSample = mem.Some, iop.Some, now
+	}()
+	if w.lastSample.IsZero() {
+		return 0, 0, false
+	}
+
+	// PSI totals are in microseconds
+	dt := float64(now.Sub(w.lastSample).Microseconds())
+	if dt <= 0 || mem.Some < w.lastMemory || iop.Some < w.lastIO {
+		return 0, 0, false
+	}
+	return float64(mem.Some-w.lastMemory) / dt * 100, float64(iop.Some-w.lastIO) / dt * 100, true
+}
+
+func (c *Controller) setMemoryHigh(w *workspace, reason string) {
+	limit, err := w.cgroup.MemoryMax()
+	if err != nil || limit == math.MaxUint64 {
+		limit = w.memoryCurrent
+	}
+	high := uint64(float64(limit) * c.Config.MemoryHighRatio)
+
+	if w.originalHigh == nil {
+		original, err := w.cgroup.MemoryHigh()
+		if err != nil {
+			log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot read memory.high")
+			return
+		}
+		w.originalHigh = &original
+	}
+	err = w.cgroup.SetMemoryHigh(high)
+	if err != nil {
+		log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot set memory.high")
+		return
+	}
+	c.recordEvent(w, corev1.EventTypeWarning, "MemoryThrottled", fmt.Sprintf("Set memory.high to %d bytes due to sustained %s", high, reason))
+}
+
+func (c *Controller) enableSwap(w *workspace, reason string) {
+	swap := uint64(c.Config.SwapMax.Value())
+	if w.originalSwapMax == nil {
+		original, err := w.cgroup.SwapMax()
+		if err != nil {
+			log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot read memory.swap.max")
+			return
+		}
+		w.originalSwapMax = &original
+	}
+	err := w.cgroup.SetSwapMax(swap)
+	if err != nil {
+		log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot set memory.swap.max")
+		return
+	}
+	c.recordEvent(w, corev1.EventTypeWarning, "SwapEnabled", fmt.Sprintf("Allowed %d bytes of swap due to sustained %s", swap, reason))
+}
+
+func (c *Controller) notifyUser(ctx context.Context, w *workspace, reason string) {
+	err := c.notify(ctx, w.ws, "Your workspace is running low on memory and has been slowed down. Consider stopping processes you don't need, or your workspace might be stopped.")
+	if err != nil {
+		log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot notify user about memory pressure")
+		return
+	}
+	c.recordEvent(w, corev1.EventTypeWarning, "MemoryPressureNotified", fmt.Sprintf("Notified the user due to sustained %s", reason))
+}
+
+// relieve restores memory.high and memory.swap.max to the values they had before the controller changed them
+func (c *Controller) relieve(w *workspace) {
+	if w.originalHigh != nil {
+		err := w.cgroup.SetMemoryHigh(*w.originalHigh)
+		if err != nil {
+			log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot reset memory.high")
+		} else {
+			w.originalHigh = nil
+		}
+	}
+	if w.originalSwapMax != nil {
+		err := w.cgroup.SetSwapMax(*w.originalSwapMax)
+		if err != nil {
+			log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot reset memory.swap.max")
+		} else {
+			w.originalSwapMax = nil
+		}
+	}
+	c.recordEvent(w, corev1.EventTypeNormal, "MemoryPressureRelieved", "Restored memory.high and swap after pressure subsided")
+}
+
+// evict stops the lowest-priority workspace on the node, which is not necessarily the one under pressure
+func (c *Controller) evict(ctx context.Context, pressured *workspace, workspaces []*workspace) {
+	// the workspace will escalate again if the pressure persists
+	pressured.state.Step = StepNotify
+
+	if !c.Config.Evict {
+		return
+	}
+	now := c.now()
+	if !c.lastEviction.IsZero() && now.Sub(c.lastEviction) < time.Duration(c.Config.SustainedFor) {
+		// give the node time to recover from the last eviction
+		return
+	}
+
+	candidates := make([]*workspace, 0, len(workspaces))
+	for _, w := range workspaces {
+		if !w.evicted {
+			candidates = append(candidates, w)
+		}
+	}
+	victim := selectVictim(candidates)
+	if victim == nil {
+		return
+	}
+
+	err := c.stop(ctx, victim.ws.InstanceID)
+	if err != nil {
+		log.WithError(err).WithFields(victim.ws.OWI()).Error("cannot stop workspace to relieve memory pressure")
+		return
+	}
+	victim.evicted = true
+	c.lastEviction = now
+	c.recordEvent(victim, corev1.EventTypeWarning, "MemoryPressureEvicted", fmt.Sprintf("Stopped workspace to relieve sustained memory pressure of workspace %s", pressured.ws.InstanceID))
+}
+
+// selectVictim picks the workspace to stop: headless workspaces go before regular ones,
+// and within the same priority the workspace which uses the most memory goes first.
+func selectVictim(candidates []*workspace) *workspace {
+	var victim *workspace
+	for _, w := range candidates {
+		if victim == nil {
+			victim = w
+			continue
+		}
+
+		pw, pv := priority(w.ws), priority(victim.ws)
+		if pw < pv || (pw == pv && w.memoryCurrent > victim.memoryCurrent) {
+			victim = w
+		}
+	}
+	return victim
+}
+
+// priority ranks workspaces for eviction - lower priority workspaces are stopped first
+func priority(ws *dispatch.Workspace) int {
+	if ws.Pod == nil {
+		return 0
+	}
+	if ws.Pod.Labels[kubernetes.TypeLabel] == "regular" {
+		return 1
+	}
+	return 0
+}
+
+func (c *Controller) stopWorkspace(ctx context.Context, instanceID string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var ws workspacev1.Workspace
+		err := c.Client.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: instanceID}, &ws)
+		if err != nil {
+			return err
+		}
+
+		ws.Status.SetCondition(workspacev1.NewWorkspaceConditionStoppedByRequest(evictionGracePeriod.String()))
+		return c.Client.Status().Update(ctx, &ws)
+	})
+}
+
+func (c *Controller) recordEvent(w *workspace, eventType, reason, message string) {
+	log.WithFields(w.ws.OWI()).WithField("reason", reason).Info(message)
+	if c.Recorder == nil || c.Client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ws workspacev1.Workspace
+	err := c.Client.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: w.ws.InstanceID}, &ws)
+	if err != nil {
+		log.WithError(err).WithFields(w.ws.OWI()).Warn("cannot record memory pressure event")
+		return
+	}
+	c.Recorder.Event(&ws, eventType, reason, message)
+}
+
+type v2Cgroup struct {
+	memory *cgroups_v2.Memory
+	io     *cgroups_v2.IO
+}
+
+func (c *v2Cgroup) MemoryPSI() (cgroups.PSI, error) { return c.memory.PSI() }
+func (c *v2Cgroup) IOPSI() (cgroups.PSI, error)     { return c.io.PSI() }
+func (c *v2Cgroup) MemoryCurrent() (uint64, error)  { return c.memory.Current() }
+func (c *v2Cgroup) MemoryMax() (uint64, error)      { return c.memory.Max() }
+func (c *v2Cgroup) MemoryHigh() (uint64, error)     { return c.memory.High() }
+func (c *v2Cgroup) SetMemoryHigh(v uint64) error    { return c.memory.SetHigh(v) }
+func (c *v2Cgroup) SwapMax() (uint64, error)        { return c.memory.SwapMax() }
+func (c *v2Cgroup) SetSwapMax(v uint64) error       { return c.memory.SetSwapMax(v) }
@@ -0,0 +1,281 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pressure
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	"github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+)
+
+func TestStateObserve(t *testing.T) {
+	const sustainedFor = time.Minute
+	t0 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type observation struct {
+		After     time.Duration
+		Pressured bool
+	}
+	tests := []struct {
+		Name         string
+		Observations []observation
+		Expectation  []Step
+	}{
+		{
+			Name:         "no pressure",
+			Observations: []observation{{0, false}, {time.Minute, false}, {2 * time.Minute, false}},
+			Expectation:  []Step{StepNone, StepNone, StepNone},
+		},
+		{
+			Name: "sustained pressure escalates one step at a time",
+			Observations: []observation{
+				{0, true},
+				{30 * time.Second, true},
+				{time.Minute, true},
+				{2 * time.Minute, true},
+				{3 * time.Minute, true},
+				{4 * time.Minute, true},
+				{5 * time.Minute, true},
+			},
+			Expectation: []Step{StepNone, StepNone, StepMemoryHigh, StepSwap, StepNotify, StepEvict, StepEvict},
+		},
+		{
+			Name: "short pressure does not escalate",
+			Observations: []observation{
+				{0, true},
+				{30 * time.Second, false},
+				{time.Minute, true},
+				{90 * time.Second, false},
+			},
+			Expectation: []Step{StepNone, StepNone, StepNone, StepNone},
+		},
+		{
+			Name: "sustained relief undoes all steps",
+			Observations: []observation{
+				{0, true},
+				{time.Minute, true},
+				{2 * time.Minute, true},
+				{150 * time.Second, false},
+				{3 * time.Minute, false},
+				{210 * time.Second, false},
+			},
+			Expectation: []Step{StepNone, StepMemoryHigh, StepSwap, StepSwap, StepSwap, StepNone},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				s   state
+				act []Step
+			)
+			for _, o := range test.Observations {
+				step, _ := s.observe(o.Pressured, t0.Add(o.After), sustainedFor)
+				act = append(act, step)
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected steps (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSelectVictim(t *testing.T) {
+	ws := func(id, tpe string, mem uint64) *workspace {
+		return &workspace{
+			ws: &dispatch.Workspace{
+				InstanceID: id,
+				Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{kubernetes.TypeLabel: tpe},
+				}},
+			},
+			memoryCurrent: mem,
+		}
+	}
+
+	tests := []struct {
+		Name        string
+		Candidates  []*workspace
+		Expectation string
+	}{
+		{Name: "no candidates"},
+		{
+			Name:        "headless before regular",
+			Candidates:  []*workspace{ws("regular", "regular", 100), ws("prebuild", "prebuild", 10)},
+			Expectation: "prebuild",
+		},
+		{
+			Name:        "most memory first",
+			Candidates:  []*workspace{ws("small", "regular", 10), ws("large", "regular", 100)},
+			Expectation: "large",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act string
+			if victim := selectVictim(test.Candidates); victim != nil {
+				act = victim.ws.InstanceID
+			}
+			if act != test.Expectation {
+				t.Errorf("unexpected victim %q: expected %q", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestControllerEscalation(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctrl, err := NewController(Config{
+		Interval:        util.Duration(10 * time.Second),
+		SustainedFor:    util.Duration(30 * time.Second),
+		MemoryThreshold: 10,
+		IOThreshold:     50,
+		MemoryHighRatio: 0.8,
+		SwapMax:         resource.MustParse("1Gi"),
+		Evict:           true,
+	}, "/", "default", nil, nil, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl.now = func() time.Time { return now }
+
+	var notified, stopped []string
+	ctrl.notify = func(ctx context.Context, ws *dispatch.Workspace, message string) error {
+		notified = append(notified, ws.InstanceID)
+		return nil
+	}
+	ctrl.stop = func(ctx context.Context, instanceID string) error {
+		stopped = append(stopped, instanceID)
+		return nil
+	}
+
+	pressured := &fakeCgroup{max: 1000, swapMax: 1 << 20, high: math.MaxUint64}
+	calm := &fakeCgroup{max: 1000, swapMax: 0, high: math.MaxUint64, current: 100}
+	prebuild := &fakeCgroup{max: math.MaxUint64, swapMax: 0, high: math.MaxUint64, current: 50}
+	ctrl.workspaces = map[string]*workspace{
+		"pressured": {ws: newWorkspace("pressured", "regular"), cgroup: pressured},
+		"calm":      {ws: newWorkspace("calm", "regular"), cgroup: calm},
+		"prebuild":  {ws: newWorkspace("prebuild", "prebuild"), cgroup: prebuild},
+	}
+
+	tick := func() {
+		now = now.Add(10 * time.Second)
+		// 20% of the time stalled on memory
+		pressured.memory += 2 * uint64(time.Second.Microseconds())
+		ctrl.tick(context.Background())
+	}
+
+	// first sample, then 30s of pressure until the first step
+	for i := 0; i < 5; i++ {
+		tick()
+	}
+	if pressured.high != 800 {
+		t.Errorf("expected memory.high to be 800, got %d", pressured.high)
+	}
+	if calm.high != math.MaxUint64 {
+		t.Errorf("workspace without pressure was throttled")
+	}
+
+	for i := 0; i < 3; i++ {
+		tick()
+	}
+	if pressured.swapMax != 1<<30 {
+		t.Errorf("expected swap to be enabled, got %d", pressured.swapMax)
+	}
+
+	for i := 0; i < 3; i++ {
+		tick()
+	}
+	if diff := cmp.Diff([]string{"pressured"}, notified); diff != "" {
+		t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+	}
+	if len(stopped) != 0 {
+		t.Errorf("stopped workspaces before last resort: %v", stopped)
+	}
+
+	for i := 0; i < 3; i++ {
+		tick()
+	}
+	if diff := cmp.Diff([]string{"prebuild"}, stopped); diff != "" {
+		t.Errorf("unexpected stopped workspaces (-want +got):\n%s", diff)
+	}
+
+	// pressure subsides
+	for i := 0; i < 5; i++ {
+		now = now.Add(10 * time.Second)
+		ctrl.tick(context.Background())
+	}
+	if pressured.high != math.MaxUint64 || pressured.swapMax != 1<<20 {
+		t.Errorf("expected pressure relief, got memory.high=%d swap.max=%d", pressured.high, pressured.swapMax)
+	}
+}
+
+func TestNewControllerConfig(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Config Config
+		Valid  bool
+	}{
+		{Name: "defaults", Valid: true},
+		{Name: "negative threshold", Config: Config{MemoryThreshold: -1}},
+		{Name: "threshold above 100", Config: Config{IOThreshold: 101}},
+		{Name: "ratio above 1", Config: Config{MemoryHighRatio: 1.5}},
+		{Name: "negative swap", Config: Config{SwapMax: resource.MustParse("-1Gi")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctrl, err := NewController(test.Config, "/", "default", nil, nil, prometheus.NewRegistry())
+			if (err == nil) != test.Valid {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if ctrl.Config.MemoryHighRatio == 0 || ctrl.Config.MemoryThreshold == 0 || ctrl.Config.IOThreshold == 0 {
+				t.Errorf("expected defaults, got %+v", ctrl.Config)
+			}
+		})
+	}
+}
+
+func newWorkspace(id, tpe string) *dispatch.Workspace {
+	return &dispatch.Workspace{
+		InstanceID: id,
+		Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:   id,
+			Labels: map[string]string{kubernetes.TypeLabel: tpe},
+		}},
+	}
+}
+
+type fakeCgroup struct {
+	memory, io    uint64
+	current, max  uint64
+	high, swapMax uint64
+}
+
+func (f *fakeCgroup) MemoryPSI() (cgroups.PSI, error) { return cgroups.PSI{Some: f.memory}, nil }
+func (f *fakeCgroup) IOPSI() (cgroups.PSI, error)     { return cgroups.PSI{Some: f.io}, nil }
+func (f *fakeCgroup) MemoryCurrent() (uint64, error)  { return f.current, nil }
+func (f *fakeCgroup) MemoryMax() (uint64, error)      { return f.max, nil }
+func (f *fakeCgroup) MemoryHigh() (uint64, error)     { return f.high, nil }
+func (f *fakeCgroup) SetMemoryHigh(v uint64) error    { f.high = v; return nil }
+func (f *fakeCgroup) SwapMax() (uint64, error)        { return f.swapMax, nil }
+func (f *fakeCgroup) SetSwapMax(v uint64) error       { f.swapMax = v; return nil }
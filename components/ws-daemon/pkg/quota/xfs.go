@@ -127,16 +127,54 @@ func (xfs *XFS) SetQuotaWithPrjId(path string, quota Size, prjID int, isHard boo
 		return 0, err
 	}
 
+	err = xfs.SetLimit(prjID, quota, isHard)
+	if err != nil {
+		return 0, err
+	}
+	return prjID, nil
+}
+
+// SetLimit changes the block limit of an existing project. The limit can be changed at any time,
+// e.g. to grow the quota of a running workspace.
+func (xfs *XFS) SetLimit(prjID int, quota Size, isHard bool) (err error) {
 	if isHard {
 		_, err = xfs.exec(xfs.Dir, fmt.Sprintf("limit -p bhard=%d %d", quota, prjID))
 	} else {
 		_, err = xfs.exec(xfs.Dir, fmt.Sprintf("limit -p bsoft=%d %d", quota, prjID))
 	}
+	return err
+}
 
+// GetUsage returns the number of bytes a project uses and its limit. The limit is the hard limit
+// if one is set, and the soft limit otherwise. A limit of zero means the project is unlimited.
+func (xfs *XFS) GetUsage(prjID int) (used Size, limit Size, err error) {
+	out, err := xfs.exec(xfs.Dir, "report -p -b -n -N")
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return prjID, nil
+
+	for _, l := range strings.Split(out, "\n") {
+		// #<project ID> <used> <soft> <hard> <warn/grace> [<grace period>]
+		fields := strings.Fields(l)
+		if len(fields) < 4 || fields[0] != fmt.Sprintf("#%d", prjID) {
+			continue
+		}
+
+		// xfs_quota reports blocks in kilobytes
+		var blocks [3]int64
+		for i := range blocks {
+			blocks[i], err = strconv.ParseInt(fields[i+1], 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("cannot parse quota report for project %d: %w", prjID, err)
+			}
+		}
+		used, soft, hard := Size(blocks[0])*Kilobyte, Size(blocks[1])*Kilobyte, Size(blocks[2])*Kilobyte
+		if hard != 0 {
+			return used, hard, nil
+		}
+		return used, soft, nil
+	}
+	return 0, 0, fmt.Errorf("project %d not found in quota report", prjID)
 }
 
 // RegisterProject tells this implementation that a projectID is already in use
@@ -191,3 +191,66 @@ func TestSetQuota(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUsage(t *testing.T) {
+	type Expectation struct {
+		Used  Size
+		Limit Size
+		Error string
+	}
+	tests := []struct {
+		Name        string
+		Input       string
+		InputErr    error
+		ProjectID   int
+		Expectation Expectation
+	}{
+		{
+			Name:        "hard limit",
+			Input:       "#0              0      0      0  00 [------]\n#1000        2048      0  10240  00 [------]",
+			ProjectID:   1000,
+			Expectation: Expectation{Used: 2 * Megabyte, Limit: 10 * Megabyte},
+		},
+		{
+			Name:        "soft limit",
+			Input:       "#1000        2048  5120      0  00 [------]\n#1001        4096      0  10240  00 [------]",
+			ProjectID:   1000,
+			Expectation: Expectation{Used: 2 * Megabyte, Limit: 5 * Megabyte},
+		},
+		{
+			Name:        "unknown project",
+			Input:       "#1000        2048      0  10240  00 [------]",
+			ProjectID:   10000,
+			Expectation: Expectation{Error: "project 10000 not found in quota report"},
+		},
+		{
+			Name:        "exec failure",
+			InputErr:    fmt.Errorf("exec failed"),
+			ProjectID:   1000,
+			Expectation: Expectation{Error: "exec failed"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			xfs := &XFS{
+				exec: func(dir, command string) (output string, err error) {
+					return test.Input, test.InputErr
+				},
+			}
+
+			var (
+				act Expectation
+				err error
+			)
+			act.Used, act.Limit, err = xfs.GetUsage(test.ProjectID)
+			if err != nil {
+				act.Error = err.Error()
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected GetUsage (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
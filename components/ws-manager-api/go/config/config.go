@@ -62,6 +62,10 @@ type ServiceConfiguration struct {
 			PrivateKey  string `json:"key"`
 		} `json:"tls"`
 	} `json:"imageBuilderProxy"`
+	// ContentService is the content-service which deletes the content of snapshots
+	ContentService struct {
+		Addr string `json:"addr"`
+	} `json:"contentService"`
 
 	PProf struct {
 		Addr string `json:"addr"`
@@ -72,6 +76,12 @@ type ServiceConfiguration struct {
 	Health struct {
 		Addr string `json:"addr"`
 	} `json:"health"`
+	// Webhook configures the workspace admission webhook, which defaults and validates all workspaces.
+	// The webhook server expects its certificate in controller-runtime's default certificate directory.
+	Webhook struct {
+		// Disabled turns off the webhook, e.g. for development setups without a serving certificate
+		Disabled bool `json:"disabled"`
+	} `json:"webhook"`
 }
 
 // Configuration is the configuration of the ws-manager
@@ -5,6 +5,7 @@
 package v1
 
 import (
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,12 +16,29 @@ type SnapshotSpec struct {
 
 	// +kubebuilder:validation:Required
 	WorkspaceID string `json:"workspaceID"`
+
+	// Type is the kind of content upload ws-daemon performs
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=snapshot
+	Type SnapshotType `json:"type,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=snapshot;backup
+type SnapshotType string
+
+const (
+	// SnapshotTypeSnapshot uploads the workspace content under a new name, s.t. it can initialize other workspaces.
+	SnapshotTypeSnapshot SnapshotType = "snapshot"
+
+	// SnapshotTypeBackup uploads the workspace content as the regular backup of the workspace,
+	// i.e. the content the workspace is restored from once it's started again.
+	SnapshotTypeBackup SnapshotType = "backup"
+)
+
 // SnapshotStatus defines the observed state of the snapshot
 type SnapshotStatus struct {
-	// // +kubebuilder:validation:Optional
-	// Conditions []metav1.Condition `json:"conditions"`
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Erorr is the error observed during snapshot creation if any
 	// +kubebuilder:validation:Optional
@@ -35,6 +53,52 @@ type SnapshotStatus struct {
 	Completed bool `json:"completed"`
 }
 
+func (s *SnapshotStatus) SetCondition(cond metav1.Condition) {
+	s.Conditions = wsk8s.AddUniqueCondition(s.Conditions, cond)
+}
+
+// +kubebuilder:validation:Enum=Started;Succeeded;Failed
+type SnapshotCondition string
+
+const (
+	// Started indicates that ws-daemon has begun uploading the workspace content.
+	SnapshotConditionStarted SnapshotCondition = "Started"
+
+	// Succeeded indicates that the content was uploaded successfully.
+	SnapshotConditionSucceeded SnapshotCondition = "Succeeded"
+
+	// Failed contains the reason the upload failed.
+	SnapshotConditionFailed SnapshotCondition = "Failed"
+)
+
+func NewSnapshotConditionStarted() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(SnapshotConditionStarted),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "UploadStarted",
+	}
+}
+
+func NewSnapshotConditionSucceeded() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(SnapshotConditionSucceeded),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "UploadSucceeded",
+	}
+}
+
+func NewSnapshotConditionFailed(message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(SnapshotConditionFailed),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "UploadFailed",
+		Message:            message,
+	}
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:shortName=snapshot
@@ -42,6 +106,7 @@ type SnapshotStatus struct {
 // showing up when doing e.g. `kubectl get snapshots`.
 // Columns with priority > 0 will only show up with `-o wide`.
 //+kubebuilder:printcolumn:name="Workspace",type="string",JSONPath=".spec.workspaceID"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
 //+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url",priority=10
 //+kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
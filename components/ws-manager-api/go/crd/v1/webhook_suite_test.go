@@ -86,7 +86,10 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&Workspace{}).SetupWebhookWithManager(mgr)
+	err = (&Workspace{}).SetupWebhookWithManager(mgr, &WorkspaceWebhook{
+		DefaultClass: "g1-standard",
+		Classes:      []string{"g1-standard"},
+	})
 	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:webhook
@@ -20,6 +20,18 @@ const (
 	// ReasonInitializationFailure is a Reason for the WorkspaceConditionContentReady condition,
 	// indicating that content init failed. The condition's message will contain the failure details.
 	ReasonInitializationFailure = "InitializationFailure"
+
+	// ReasonCheckpointRestored is a Reason for the WorkspaceConditionCheckpointRestored condition,
+	// indicating that the processes of the hibernated workspace were restored.
+	ReasonCheckpointRestored = "CheckpointRestored"
+	// ReasonColdStart is a Reason for the WorkspaceConditionCheckpointRestored condition, indicating that
+	// the checkpoint could not be restored and the workspace started from its backup only.
+	// The condition's message will contain the failure details.
+	ReasonColdStart = "ColdStart"
+
+	// ReasonScheduledStop is a Reason for the WorkspaceConditionTimeout condition, indicating that
+	// the workspace was stopped because of its schedule rather than a timeout.
+	ReasonScheduledStop = "ScheduledStop"
 )
 
 // WorkspaceSpec defines the desired state of Workspace
@@ -60,6 +72,10 @@ type WorkspaceSpec struct {
 
 	// the XFS quota to enforce on the workspace's /workspace folder
 	StorageQuota int `json:"storageQuota,omitempty"`
+
+	// RestoreCheckpoint is true if the workspace resumes the processes it was hibernated with. Its pod is then
+	// created from a checkpoint image ws-daemon prepares during content initialization.
+	RestoreCheckpoint bool `json:"restoreCheckpoint,omitempty"`
 }
 
 type Ownership struct {
@@ -112,6 +128,42 @@ type TimeoutSpec struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h)?)+$"
 	MaximumLifetime *metav1.Duration `json:"maximumLifetime,omitempty"`
+	// +kubebuilder:validation:Optional
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+}
+
+// ScheduleSpec restricts the times at which a workspace runs. All cron expressions
+// use the standard five-field format (minute hour day-of-month month day-of-week).
+type ScheduleSpec struct {
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. Europe/Berlin. Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Stop lists cron expressions at which a running workspace is stopped, e.g. "0 19 * * *".
+	// +kubebuilder:validation:Optional
+	Stop []string `json:"stop,omitempty"`
+
+	// Blackouts lists windows during which the workspace must not run.
+	// +kubebuilder:validation:Optional
+	Blackouts []ScheduleWindow `json:"blackouts,omitempty"`
+
+	// Start lists cron expressions at which the workspace should be started, e.g. "30 8 * * 1-5".
+	// ws-manager reports the next start time and warms up a pool pod ahead of it, but the start itself is up to the client.
+	// +kubebuilder:validation:Optional
+	Start []string `json:"start,omitempty"`
+}
+
+// ScheduleWindow is a recurring period of time
+type ScheduleWindow struct {
+	// Start is a cron expression which opens the window, e.g. "0 0 * * 6".
+	// +kubebuilder:validation:Required
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	Duration metav1.Duration `json:"duration"`
 }
 
 type AdmissionSpec struct {
@@ -169,13 +221,18 @@ type WorkspaceStatus struct {
 
 	// +kubebuilder:validation:Optional
 	Runtime *WorkspaceRuntimeStatus `json:"runtime,omitempty"`
+
+	// NextScheduledStart is the next time the workspace's schedule asks for it to be started.
+	// ws-manager warms up a pool pod for the workspace ahead of that time.
+	// +kubebuilder:validation:Optional
+	NextScheduledStart *metav1.Time `json:"nextScheduledStart,omitempty"`
 }
 
 func (s *WorkspaceStatus) SetCondition(cond metav1.Condition) {
 	s.Conditions = wsk8s.AddUniqueCondition(s.Conditions, cond)
 }
 
-// +kubebuilder:validation:Enum=Deployed;Failed;Timeout;FirstUserActivity;Closed;HeadlessTaskFailed;StoppedByRequest;Aborted;ContentReady;EverReady;BackupComplete;BackupFailure;Refresh;NodeDisappeared
+// +kubebuilder:validation:Enum=Deployed;Failed;Timeout;FirstUserActivity;Closed;HeadlessTaskFailed;StoppedByRequest;Aborted;ContentReady;EverReady;BackupComplete;BackupFailure;Refresh;NodeDisappeared;HibernationRequested;CheckpointComplete;CheckpointFailure;CheckpointRestored
 type WorkspaceCondition string
 
 const (
@@ -223,6 +280,20 @@ const (
 
 	// NodeDisappeared is true if the workspace's node disappeared before the workspace was stopped
 	WorkspaceConditionNodeDisappeared WorkspaceCondition = "NodeDisappeared"
+
+	// HibernationRequested is true if StopWorkspace was called with StopWorkspacePolicy set to HIBERNATE.
+	// The condition message will contain the requested grace period.
+	WorkspaceConditionHibernationRequested WorkspaceCondition = "HibernationRequested"
+
+	// CheckpointComplete is true once the process state of a hibernating workspace has been checkpointed
+	WorkspaceConditionCheckpointComplete WorkspaceCondition = "CheckpointComplete"
+
+	// CheckpointFailure contains information about the checkpoint failure. The workspace is stopped regardless.
+	WorkspaceConditionCheckpointFailure WorkspaceCondition = "CheckpointFailure"
+
+	// CheckpointRestored is true if the workspace resumed from the checkpoint of a hibernated workspace,
+	// and false with the reason in the message if it fell back to a cold start.
+	WorkspaceConditionCheckpointRestored WorkspaceCondition = "CheckpointRestored"
 )
 
 func NewWorkspaceConditionDeployed() metav1.Condition {
@@ -261,6 +332,16 @@ func NewWorkspaceConditionTimeout(message string) metav1.Condition {
 	}
 }
 
+func NewWorkspaceConditionScheduledStop(message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionTimeout),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonScheduledStop,
+		Message:            message,
+	}
+}
+
 func NewWorkspaceConditionFirstUserActivity(reason string) metav1.Condition {
 	return metav1.Condition{
 		Type:               string(WorkspaceConditionFirstUserActivity),
@@ -351,7 +432,46 @@ func NewWorkspaceConditionNodeDisappeared() metav1.Condition {
 	}
 }
 
-// +kubebuilder:validation:Enum:=Unknown;Pending;Imagebuild;Creating;Initializing;Running;Stopping;Stopped
+func NewWorkspaceConditionHibernationRequested(message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionHibernationRequested),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "StopWorkspaceRequest",
+		Message:            message,
+	}
+}
+
+func NewWorkspaceConditionCheckpointComplete() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionCheckpointComplete),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "CheckpointComplete",
+	}
+}
+
+func NewWorkspaceConditionCheckpointFailure(message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionCheckpointFailure),
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "CheckpointFailed",
+		Message:            message,
+	}
+}
+
+func NewWorkspaceConditionCheckpointRestored(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionCheckpointRestored),
+		LastTransitionTime: metav1.Now(),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// +kubebuilder:validation:Enum:=Unknown;Pending;Imagebuild;Creating;Initializing;Running;Stopping;Stopped;Hibernated
 type WorkspacePhase string
 
 const (
@@ -363,6 +483,10 @@ const (
 	WorkspacePhaseRunning      WorkspacePhase = "Running"
 	WorkspacePhaseStopping     WorkspacePhase = "Stopping"
 	WorkspacePhaseStopped      WorkspacePhase = "Stopped"
+
+	// WorkspacePhaseHibernated is the final phase of a workspace which was stopped with its process
+	// state checkpointed next to its backup. It's otherwise handled like WorkspacePhaseStopped.
+	WorkspacePhaseHibernated WorkspacePhase = "Hibernated"
 )
 
 type GitStatus struct {
@@ -433,6 +557,27 @@ func (w *Workspace) IsHeadless() bool {
 	return w.Spec.Type != WorkspaceTypeRegular
 }
 
+// IsStopped returns whether the workspace has reached its final phase,
+// i.e. whether it was stopped or hibernated.
+func (w *Workspace) IsStopped() bool {
+	return w.Status.Phase == WorkspacePhaseStopped || w.Status.Phase == WorkspacePhaseHibernated
+}
+
+const (
+	// RestoreContainerName is the name of the init container which holds back the workspace container of a workspace
+	// which restores its checkpoint, until ws-daemon prepared the checkpoint image.
+	RestoreContainerName = "restore"
+	// CheckpointImageReadyFile is the file ws-daemon creates in the daemon directory of a workspace once its checkpoint
+	// image is ready. The daemon directory is mounted at /.workspace in the workspace pod.
+	CheckpointImageReadyFile = "checkpoint-image-ready"
+)
+
+// CheckpointImage returns the node-local image the workspace container of a workspace which restores its
+// checkpoint is created from. The container runtime restores containers created from checkpoint images.
+func (w *Workspace) CheckpointImage() string {
+	return "localhost/gitpod/checkpoint/" + w.Name + ":latest"
+}
+
 func (w *Workspace) IsConditionTrue(condition WorkspaceCondition) bool {
 	return wsk8s.ConditionPresentAndTrue(w.Status.Conditions, string(condition))
 }
@@ -5,61 +5,337 @@
 package v1
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/robfig/cron"
 )
 
 // log is for logging in this package.
 var workspacelog = logf.Log.WithName("workspace-resource")
 
-func (r *Workspace) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *Workspace) SetupWebhookWithManager(mgr ctrl.Manager, wh *WorkspaceWebhook) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
-// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// WorkspaceWebhook defaults and validates workspace resources against the ws-manager configuration.
+// It applies to all workspaces, no matter whether they were created through ws-manager or directly.
+type WorkspaceWebhook struct {
+	// DefaultClass is the workspace class of workspaces which do not specify one
+	DefaultClass string
+	// Classes are the names of all workspace classes workspaces can use
+	Classes  []string
+	Timeouts WorkspaceWebhookTimeouts
+}
+
+// WorkspaceWebhookTimeouts are the timeouts of workspaces which do not specify them. Zero means no default.
+type WorkspaceWebhookTimeouts struct {
+	RegularWorkspace  time.Duration
+	HeadlessWorkspace time.Duration
+	AfterClose        time.Duration
+	// MaxLifetime also limits all other timeouts of workspaces which do not specify their maximum lifetime
+	MaxLifetime time.Duration
+}
 
 //+kubebuilder:webhook:path=/mutate-workspace-gitpod-io-v1-workspace,mutating=true,failurePolicy=fail,sideEffects=None,groups=workspace.gitpod.io,resources=workspaces,verbs=create;update,versions=v1,name=mworkspace.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Defaulter = &Workspace{}
+var _ webhook.CustomDefaulter = &WorkspaceWebhook{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *Workspace) Default() {
-	workspacelog.Info("default", "name", r.Name)
+// Default fills in the workspace class and all timeouts which are not set explicitly.
+func (wh *WorkspaceWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	ws, ok := obj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", obj)
+	}
+	workspacelog.V(1).Info("default", "name", ws.Name)
 
-	// TODO(user): fill in your defaulting logic.
+	if ws.Spec.Class == "" {
+		ws.Spec.Class = wh.DefaultClass
+	}
+
+	timeouts := wh.Timeouts
+	defaultDuration := func(d **metav1.Duration, def time.Duration) {
+		if *d == nil && def > 0 {
+			*d = &metav1.Duration{Duration: def}
+		}
+	}
+	if ws.IsHeadless() {
+		defaultDuration(&ws.Spec.Timeout.Time, timeouts.HeadlessWorkspace)
+	} else {
+		defaultDuration(&ws.Spec.Timeout.Time, timeouts.RegularWorkspace)
+	}
+	defaultDuration(&ws.Spec.Timeout.ClosedTimeout, timeouts.AfterClose)
+	defaultDuration(&ws.Spec.Timeout.MaximumLifetime, timeouts.MaxLifetime)
+
+	return nil
 }
 
-// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-workspace-gitpod-io-v1-workspace,mutating=false,failurePolicy=fail,sideEffects=None,groups=workspace.gitpod.io,resources=workspaces,verbs=create;update,versions=v1,name=vworkspace.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &Workspace{}
+var _ webhook.CustomValidator = &WorkspaceWebhook{}
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *Workspace) ValidateCreate() error {
-	workspacelog.Info("validate create", "name", r.Name)
+// ValidateCreate implements webhook.CustomValidator
+func (wh *WorkspaceWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ws, ok := obj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", obj)
+	}
+	workspacelog.V(1).Info("validate create", "name", ws.Name)
 
-	return r.validateWorkspace()
+	var errs field.ErrorList
+	if !wh.isClass(ws.Spec.Class) {
+		errs = append(errs, field.NotFound(field.NewPath("spec", "class"), ws.Spec.Class))
+	}
+	errs = append(errs, wh.validateSpec(&ws.Spec)...)
+
+	return invalid(ws, errs)
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *Workspace) ValidateUpdate(old runtime.Object) error {
-	workspacelog.Info("validate update", "name", r.Name)
+// ValidateUpdate implements webhook.CustomValidator
+func (wh *WorkspaceWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	old, ok := oldObj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", oldObj)
+	}
+	ws, ok := newObj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", newObj)
+	}
+	workspacelog.V(1).Info("validate update", "name", ws.Name)
 
-	return r.validateWorkspace()
-}
+	errs := validateImmutableFields(&old.Spec, &ws.Spec)
+	// Workspaces which are being deleted must always be able to drop their finalizers,
+	// even if they were created before the webhook was in place.
+	if ws.DeletionTimestamp == nil {
+		errs = append(errs, wh.validateSpec(&ws.Spec)...)
+	}
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *Workspace) ValidateDelete() error {
-	workspacelog.Info("validate delete", "name", r.Name)
+	return invalid(ws, errs)
+}
 
-	// TODO(user): fill in your validation logic upon object deletion.
+// ValidateDelete implements webhook.CustomValidator
+func (wh *WorkspaceWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
 	return nil
 }
 
-func (r *Workspace) validateWorkspace() error {
-	return nil
+func (wh *WorkspaceWebhook) isClass(name string) bool {
+	for _, c := range wh.Classes {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (wh *WorkspaceWebhook) validateSpec(spec *WorkspaceSpec) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateOwnership(field.NewPath("spec", "ownership"), &spec.Ownership)...)
+	errs = append(errs, validateAdmissionLevel(field.NewPath("spec", "admission", "level"), spec.Admission.Level)...)
+	errs = append(errs, validatePorts(field.NewPath("spec", "ports"), spec.Ports)...)
+	errs = append(errs, wh.validateTimeouts(field.NewPath("spec", "timeout"), &spec.Timeout)...)
+	errs = append(errs, validateSchedule(field.NewPath("spec", "timeout", "schedule"), spec.Timeout.Schedule)...)
+	errs = append(errs, validateUserEnvVars(field.NewPath("spec", "userEnvVars"), spec.UserEnvVars, spec.SysEnvVars)...)
+	return errs
+}
+
+// validateOwnership ensures the team and tenant can be put on the workspace pod, where ws-daemon reads them from its labels
+func validateOwnership(path *field.Path, ownership *Ownership) field.ErrorList {
+	var errs field.ErrorList
+	if ownership.Team != "" {
+		for _, msg := range validation.IsValidLabelValue(ownership.Team) {
+			errs = append(errs, field.Invalid(path.Child("team"), ownership.Team, msg))
+		}
+	}
+	if ownership.Tenant != "" {
+		for _, msg := range validation.IsValidLabelValue(ownership.Tenant) {
+			errs = append(errs, field.Invalid(path.Child("tenant"), ownership.Tenant, msg))
+		}
+	}
+	return errs
+}
+
+func validateAdmissionLevel(path *field.Path, level AdmissionLevel) field.ErrorList {
+	switch level {
+	case AdmissionLevelOwner, AdmissionLevelEveryone:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(path, level, []string{string(AdmissionLevelOwner), string(AdmissionLevelEveryone)})}
+	}
+}
+
+func validatePorts(path *field.Path, ports []PortSpec) field.ErrorList {
+	var (
+		errs field.ErrorList
+		seen = make(map[uint32]struct{}, len(ports))
+	)
+	for i, p := range ports {
+		idx := path.Index(i)
+		if p.Port == 0 || p.Port > 65535 {
+			errs = append(errs, field.Invalid(idx.Child("port"), p.Port, "must be between 1 and 65535"))
+		}
+		if _, exists := seen[p.Port]; exists {
+			errs = append(errs, field.Duplicate(idx.Child("port"), p.Port))
+		}
+		seen[p.Port] = struct{}{}
+
+		errs = append(errs, validateAdmissionLevel(idx.Child("visibility"), p.Visibility)...)
+		switch p.Protocol {
+		case PortProtocolHttp, PortProtocolHttps:
+		default:
+			errs = append(errs, field.NotSupported(idx.Child("protocol"), p.Protocol, []string{string(PortProtocolHttp), string(PortProtocolHttps)}))
+		}
+	}
+	return errs
+}
+
+// validateTimeouts ensures all timeouts are positive and none exceeds the workspace's maximum lifetime.
+// A closed timeout of zero is valid and disables the timeout.
+func (wh *WorkspaceWebhook) validateTimeouts(path *field.Path, timeout *TimeoutSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	maxLifetime := wh.Timeouts.MaxLifetime
+	if timeout.MaximumLifetime != nil {
+		if timeout.MaximumLifetime.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("maximumLifetime"), timeout.MaximumLifetime.Duration.String(), "must be positive"))
+		}
+		maxLifetime = timeout.MaximumLifetime.Duration
+	}
+
+	if timeout.Time != nil {
+		if timeout.Time.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("time"), timeout.Time.Duration.String(), "must be positive"))
+		} else if maxLifetime > 0 && timeout.Time.Duration > maxLifetime {
+			errs = append(errs, field.Invalid(path.Child("time"), timeout.Time.Duration.String(), fmt.Sprintf("must not exceed the maximum lifetime of %s", maxLifetime)))
+		}
+	}
+	if timeout.ClosedTimeout != nil {
+		if timeout.ClosedTimeout.Duration < 0 {
+			errs = append(errs, field.Invalid(path.Child("closed"), timeout.ClosedTimeout.Duration.String(), "must not be negative"))
+		} else if maxLifetime > 0 && timeout.ClosedTimeout.Duration > maxLifetime {
+			errs = append(errs, field.Invalid(path.Child("closed"), timeout.ClosedTimeout.Duration.String(), fmt.Sprintf("must not exceed the maximum lifetime of %s", maxLifetime)))
+		}
+	}
+
+	return errs
+}
+
+// validateSchedule ensures the time zone and all cron expressions of a schedule can be parsed,
+// and that blackout windows have a positive duration.
+func validateSchedule(path *field.Path, schedule *ScheduleSpec) field.ErrorList {
+	if schedule == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			errs = append(errs, field.Invalid(path.Child("timeZone"), schedule.TimeZone, err.Error()))
+		}
+	}
+	validateCron := func(path *field.Path, expr string) {
+		if _, err := cron.ParseStandard(expr); err != nil {
+			errs = append(errs, field.Invalid(path, expr, err.Error()))
+		}
+	}
+	for i, expr := range schedule.Stop {
+		validateCron(path.Child("stop").Index(i), expr)
+	}
+	for i, expr := range schedule.Start {
+		validateCron(path.Child("start").Index(i), expr)
+	}
+	for i, w := range schedule.Blackouts {
+		idx := path.Child("blackouts").Index(i)
+		validateCron(idx.Child("start"), w.Start)
+		if w.Duration.Duration <= 0 {
+			errs = append(errs, field.Invalid(idx.Child("duration"), w.Duration.Duration.String(), "must be positive"))
+		}
+	}
+	return errs
+}
+
+// validateUserEnvVars ensures that protected user environment variables are not stored in plain text
+// on the workspace resource, but are sourced from a secret instead.
+func validateUserEnvVars(path *field.Path, userEnvVars, sysEnvVars []corev1.EnvVar) field.ErrorList {
+	var errs field.ErrorList
+	for i, e := range userEnvVars {
+		if e.Value == "" || !IsProtectedEnvVar(e.Name, sysEnvVars) {
+			continue
+		}
+		errs = append(errs, field.Forbidden(path.Index(i).Child("value"), fmt.Sprintf("%s is protected and must be sourced from a secret", e.Name)))
+	}
+	return errs
+}
+
+// validateImmutableFields rejects changes to the parts of the spec which determine how and where the workspace
+// pod is created. Changes to those fields would have no effect once the workspace is running.
+func validateImmutableFields(old, spec *WorkspaceSpec) field.ErrorList {
+	var (
+		errs field.ErrorList
+		path = field.NewPath("spec")
+	)
+	immutable := func(name string, changed bool) {
+		if changed {
+			errs = append(errs, field.Forbidden(path.Child(name), "field is immutable"))
+		}
+	}
+	immutable("ownership", old.Ownership != spec.Ownership)
+	immutable("type", old.Type != spec.Type)
+	immutable("class", old.Class != spec.Class)
+	immutable("image", !equality.Semantic.DeepEqual(old.Image, spec.Image))
+	immutable("initializer", !bytes.Equal(old.Initializer, spec.Initializer))
+	immutable("workspaceLocation", old.WorkspaceLocation != spec.WorkspaceLocation)
+	return errs
+}
+
+func invalid(ws *Workspace, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("Workspace").GroupKind(), ws.Name, errs)
+}
+
+// IsProtectedEnvVar returns true if the value of a user environment variable must not be stored in plain text.
+// Gitpod-internal environment variables and those which are also set as system environment variables are not protected.
+func IsProtectedEnvVar(name string, sysEnvVars []corev1.EnvVar) bool {
+	switch name {
+	case "THEIA_SUPERVISOR_TOKENS":
+		return true
+	default:
+		if isGitpodInternalEnvVar(name) {
+			return false
+		}
+		for _, env := range sysEnvVars {
+			if env.Name == name {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isGitpodInternalEnvVar(name string) bool {
+	return strings.HasPrefix(name, "GITPOD_") ||
+		strings.HasPrefix(name, "SUPERVISOR_") ||
+		strings.HasPrefix(name, "BOB_") ||
+		strings.HasPrefix(name, "THEIA_") ||
+		name == "NODE_EXTRA_CA_CERTS" ||
+		name == "VSX_REGISTRY_URL"
 }
@@ -0,0 +1,295 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestWebhook() *WorkspaceWebhook {
+	return &WorkspaceWebhook{
+		DefaultClass: "g1-standard",
+		Classes:      []string{"g1-standard"},
+		Timeouts: WorkspaceWebhookTimeouts{
+			RegularWorkspace:  30 * time.Minute,
+			HeadlessWorkspace: time.Hour,
+			AfterClose:        2 * time.Minute,
+			MaxLifetime:       36 * time.Hour,
+		},
+	}
+}
+
+func newTestWorkspace() *Workspace {
+	return &Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: WorkspaceSpec{
+			Ownership:         Ownership{Owner: "owner", WorkspaceID: "ws"},
+			Type:              WorkspaceTypeRegular,
+			Class:             "g1-standard",
+			WorkspaceLocation: "/workspace",
+			Admission:         AdmissionSpec{Level: AdmissionLevelOwner},
+		},
+	}
+}
+
+func duration(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
+
+func TestWebhookDefault(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Modifier    func(ws *Workspace)
+		Expectation TimeoutSpec
+	}{
+		{
+			Name:        "regular",
+			Expectation: TimeoutSpec{Time: duration(30 * time.Minute), ClosedTimeout: duration(2 * time.Minute), MaximumLifetime: duration(36 * time.Hour)},
+		},
+		{
+			Name:        "headless",
+			Modifier:    func(ws *Workspace) { ws.Spec.Type = WorkspaceTypePrebuild },
+			Expectation: TimeoutSpec{Time: duration(time.Hour), ClosedTimeout: duration(2 * time.Minute), MaximumLifetime: duration(36 * time.Hour)},
+		},
+		{
+			Name: "explicit timeouts are kept",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Timeout = TimeoutSpec{Time: duration(time.Hour), ClosedTimeout: duration(0)}
+			},
+			Expectation: TimeoutSpec{Time: duration(time.Hour), ClosedTimeout: duration(0), MaximumLifetime: duration(36 * time.Hour)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ws := newTestWorkspace()
+			ws.Spec.Class = ""
+			if test.Modifier != nil {
+				test.Modifier(ws)
+			}
+
+			err := newTestWebhook().Default(context.Background(), ws)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ws.Spec.Class != "g1-standard" {
+				t.Errorf("expected class to default to %s, got %q", "g1-standard", ws.Spec.Class)
+			}
+			if diff := cmp.Diff(test.Expectation, ws.Spec.Timeout); diff != "" {
+				t.Errorf("unexpected timeouts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookValidateCreate(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Modifier func(ws *Workspace)
+		Valid    bool
+	}{
+		{Name: "valid", Valid: true},
+		{Name: "unknown class", Modifier: func(ws *Workspace) { ws.Spec.Class = "does-not-exist" }},
+		{
+			Name: "team and tenant",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Ownership.Team = "2d1b7a1c-5b1e-4c1f-9d0e-3f5a8b6c7d8e"
+				ws.Spec.Ownership.Tenant = "acme.example_com"
+			},
+			Valid: true,
+		},
+		{Name: "team is no label value", Modifier: func(ws *Workspace) { ws.Spec.Ownership.Team = "team a" }},
+		{Name: "tenant is no label value", Modifier: func(ws *Workspace) { ws.Spec.Ownership.Tenant = strings.Repeat("t", 64) }},
+		{Name: "invalid admission level", Modifier: func(ws *Workspace) { ws.Spec.Admission.Level = "Nobody" }},
+		{
+			Name: "valid ports",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Ports = []PortSpec{
+					{Port: 3000, Visibility: AdmissionLevelOwner, Protocol: PortProtocolHttp},
+					{Port: 8080, Visibility: AdmissionLevelEveryone, Protocol: PortProtocolHttps},
+				}
+			},
+			Valid: true,
+		},
+		{
+			Name: "port out of range",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Ports = []PortSpec{{Port: 70000, Visibility: AdmissionLevelOwner, Protocol: PortProtocolHttp}}
+			},
+		},
+		{
+			Name: "duplicate port",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Ports = []PortSpec{
+					{Port: 3000, Visibility: AdmissionLevelOwner, Protocol: PortProtocolHttp},
+					{Port: 3000, Visibility: AdmissionLevelEveryone, Protocol: PortProtocolHttp},
+				}
+			},
+		},
+		{
+			Name: "invalid port visibility",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Ports = []PortSpec{{Port: 3000, Visibility: "Nobody", Protocol: PortProtocolHttp}}
+			},
+		},
+		{
+			Name: "invalid port protocol",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Ports = []PortSpec{{Port: 3000, Visibility: AdmissionLevelOwner, Protocol: "Ftp"}}
+			},
+		},
+		{
+			Name:     "disabled closed timeout",
+			Modifier: func(ws *Workspace) { ws.Spec.Timeout.ClosedTimeout = duration(0) },
+			Valid:    true,
+		},
+		{Name: "negative timeout", Modifier: func(ws *Workspace) { ws.Spec.Timeout.Time = duration(-time.Minute) }},
+		{Name: "timeout exceeds configured lifetime", Modifier: func(ws *Workspace) { ws.Spec.Timeout.Time = duration(48 * time.Hour) }},
+		{
+			Name: "timeout exceeds workspace lifetime",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Timeout.Time = duration(2 * time.Hour)
+				ws.Spec.Timeout.MaximumLifetime = duration(time.Hour)
+			},
+		},
+		{Name: "zero maximum lifetime", Modifier: func(ws *Workspace) { ws.Spec.Timeout.MaximumLifetime = duration(0) }},
+		{
+			Name: "protected env var from secret",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.UserEnvVars = []corev1.EnvVar{{Name: "MY_TOKEN", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "secret"}, Key: "key"},
+				}}}
+			},
+			Valid: true,
+		},
+		{
+			Name:     "protected env var in plain text",
+			Modifier: func(ws *Workspace) { ws.Spec.UserEnvVars = []corev1.EnvVar{{Name: "MY_TOKEN", Value: "secret"}} },
+		},
+		{
+			Name:     "internal env var in plain text",
+			Modifier: func(ws *Workspace) { ws.Spec.UserEnvVars = []corev1.EnvVar{{Name: "GITPOD_WORKSPACE_ID", Value: "ws"}} },
+			Valid:    true,
+		},
+		{
+			Name: "supervisor tokens in plain text",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.UserEnvVars = []corev1.EnvVar{{Name: "THEIA_SUPERVISOR_TOKENS", Value: "[]"}}
+			},
+		},
+		{
+			Name: "system env var in plain text",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.UserEnvVars = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+				ws.Spec.SysEnvVars = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+			},
+			Valid: true,
+		},
+		{
+			Name: "valid schedule",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Timeout.Schedule = &ScheduleSpec{
+					TimeZone:  "Europe/Berlin",
+					Stop:      []string{"0 19 * * *"},
+					Start:     []string{"30 8 * * 1-5"},
+					Blackouts: []ScheduleWindow{{Start: "0 0 * * 6", Duration: metav1.Duration{Duration: 48 * time.Hour}}},
+				}
+			},
+			Valid: true,
+		},
+		{
+			Name:     "invalid schedule time zone",
+			Modifier: func(ws *Workspace) { ws.Spec.Timeout.Schedule = &ScheduleSpec{TimeZone: "Mars/Olympus"} },
+		},
+		{
+			Name:     "invalid schedule cron expression",
+			Modifier: func(ws *Workspace) { ws.Spec.Timeout.Schedule = &ScheduleSpec{Stop: []string{"at 7pm"}} },
+		},
+		{
+			Name: "blackout without duration",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Timeout.Schedule = &ScheduleSpec{Blackouts: []ScheduleWindow{{Start: "0 0 * * 6"}}}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ws := newTestWorkspace()
+			if test.Modifier != nil {
+				test.Modifier(ws)
+			}
+
+			err := newTestWebhook().ValidateCreate(context.Background(), ws)
+			if test.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestWebhookValidateUpdate(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Modifier func(ws *Workspace)
+		Valid    bool
+	}{
+		{Name: "no change", Valid: true},
+		{
+			Name: "mutable fields",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Timeout.Time = duration(time.Hour)
+				ws.Spec.Admission.Level = AdmissionLevelEveryone
+				ws.Spec.Ports = []PortSpec{{Port: 3000, Visibility: AdmissionLevelEveryone, Protocol: PortProtocolHttp}}
+				ws.Spec.SshPublicKeys = []string{"ssh-ed25519 AAAA"}
+			},
+			Valid: true,
+		},
+		{Name: "owner", Modifier: func(ws *Workspace) { ws.Spec.Ownership.Owner = "someone-else" }},
+		{Name: "type", Modifier: func(ws *Workspace) { ws.Spec.Type = WorkspaceTypePrebuild }},
+		{Name: "class", Modifier: func(ws *Workspace) { ws.Spec.Class = "other" }},
+		{Name: "image", Modifier: func(ws *Workspace) { ws.Spec.Image.IDE.Web = "ide:latest" }},
+		{Name: "initializer", Modifier: func(ws *Workspace) { ws.Spec.Initializer = []byte("init") }},
+		{Name: "workspace location", Modifier: func(ws *Workspace) { ws.Spec.WorkspaceLocation = "/elsewhere" }},
+		{Name: "invalid spec", Modifier: func(ws *Workspace) { ws.Spec.Admission.Level = "Nobody" }},
+		{
+			Name: "invalid spec during deletion",
+			Modifier: func(ws *Workspace) {
+				ws.Spec.Admission.Level = "Nobody"
+				ws.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			},
+			Valid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			old := newTestWorkspace()
+			ws := old.DeepCopy()
+			if test.Modifier != nil {
+				test.Modifier(ws)
+			}
+
+			err := newTestWebhook().ValidateUpdate(context.Background(), old, ws)
+			if test.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
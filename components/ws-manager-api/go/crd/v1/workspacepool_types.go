@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkspacePoolLabel marks the pods which belong to a workspace pool. Its value is the name of the pool.
+	WorkspacePoolLabel = "gitpod.io/workspacePool"
+
+	// WorkspacePoolClaimLabel marks workspaces which took over a pod of a workspace pool. Its value is the name of the pool.
+	WorkspacePoolClaimLabel = "gitpod.io/claimedWorkspacePool"
+
+	// WorkspacePoolClaimAnnotation records the node and the time saved of the pool pod a workspace took over,
+	// such that a workspace claims at most one pool pod even if creating its pod is retried.
+	WorkspacePoolClaimAnnotation = "gitpod.io/workspacePoolClaim"
+
+	// WorkspacePoolScheduledLabel marks pools which ws-manager created to warm up scheduled workspace starts.
+	// Such pools are deleted once they have neither a size nor scheduled starts anymore.
+	WorkspacePoolScheduledLabel = "gitpod.io/scheduledWorkspacePool"
+
+	// WorkspacePoolImageSpecPrefix prefixes the pool name in the image spec ID of pool pods. Pool pods pull their
+	// images through registry-facade like workspace pods do, which resolves such IDs to the images of the pool.
+	WorkspacePoolImageSpecPrefix = "pool."
+)
+
+// WorkspacePoolSpec defines the desired state of a workspace pool
+type WorkspacePoolSpec struct {
+	// Class is the workspace class the pool's pods are provisioned for
+	// +kubebuilder:validation:Required
+	Class string `json:"class"`
+
+	// Image is the workspace image the pool's pods pre-pull
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// IDE are the IDE images the pool's pods pre-pull along with the workspace image
+	// +kubebuilder:validation:Optional
+	IDE IDEImages `json:"ide,omitempty"`
+
+	// Size is the number of idle pods the pool keeps ready
+	// +kubebuilder:validation:Minimum=0
+	Size int32 `json:"size"`
+
+	// ScheduledStarts are the next scheduled starts of stopped workspaces which match the pool. The pool keeps an
+	// additional pod ready for every start which is due within the warm-up period. ws-manager adds a workspace's
+	// next scheduled start when the workspace stops, and removes starts once they have passed.
+	// +kubebuilder:validation:Optional
+	ScheduledStarts []ScheduledStart `json:"scheduledStarts,omitempty"`
+}
+
+// ScheduledStart is the next scheduled start of a stopped workspace
+type ScheduledStart struct {
+	// WorkspaceID identifies the workspace (rather than the workspace instance) which is scheduled to start
+	// +kubebuilder:validation:Required
+	WorkspaceID string `json:"workspaceID"`
+
+	// Time is when the workspace is scheduled to start
+	// +kubebuilder:validation:Required
+	Time metav1.Time `json:"time"`
+}
+
+// WorkspacePoolStatus defines the observed state of a workspace pool
+type WorkspacePoolStatus struct {
+	// Ready is the number of idle pods which have pulled the image and can be claimed
+	// +kubebuilder:validation:Optional
+	Ready int32 `json:"ready"`
+
+	// Pending is the number of pods which are still being scheduled or are pulling the image
+	// +kubebuilder:validation:Optional
+	Pending int32 `json:"pending"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=wspool
+// Custom print columns on the Custom Resource Definition. These are the columns
+// showing up when doing e.g. `kubectl get workspacepools`.
+// Columns with priority > 0 will only show up with `-o wide`.
+//+kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.class"
+//+kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.image",priority=10
+//+kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".spec.size"
+//+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.pending"
+
+// WorkspacePool keeps idle, pre-provisioned pods for regular workspaces of a class and image.
+// A starting workspace which matches a pool takes over the node of one of the pool's ready pods,
+// so that it neither waits for scheduling nor for pulling its image.
+type WorkspacePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkspacePoolSpec   `json:"spec,omitempty"`
+	Status WorkspacePoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WorkspacePoolList contains a list of WorkspacePools
+type WorkspacePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspacePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspacePool{}, &WorkspacePoolList{})
+}
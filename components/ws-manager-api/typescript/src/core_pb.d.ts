@@ -336,6 +336,11 @@ export class SetTimeoutRequest extends jspb.Message {
     getType(): TimeoutType;
     setType(value: TimeoutType): SetTimeoutRequest;
 
+    hasSchedule(): boolean;
+    clearSchedule(): void;
+    getSchedule(): WorkspaceSchedule | undefined;
+    setSchedule(value?: WorkspaceSchedule): SetTimeoutRequest;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): SetTimeoutRequest.AsObject;
     static toObject(includeInstance: boolean, msg: SetTimeoutRequest): SetTimeoutRequest.AsObject;
@@ -351,6 +356,65 @@ export namespace SetTimeoutRequest {
         id: string,
         duration: string,
         type: TimeoutType,
+        schedule?: WorkspaceSchedule.AsObject,
+    }
+}
+
+export class WorkspaceSchedule extends jspb.Message {
+    getTimeZone(): string;
+    setTimeZone(value: string): WorkspaceSchedule;
+    clearStopList(): void;
+    getStopList(): Array<string>;
+    setStopList(value: Array<string>): WorkspaceSchedule;
+    addStop(value: string, index?: number): string;
+    clearBlackoutsList(): void;
+    getBlackoutsList(): Array<ScheduleWindow>;
+    setBlackoutsList(value: Array<ScheduleWindow>): WorkspaceSchedule;
+    addBlackouts(value?: ScheduleWindow, index?: number): ScheduleWindow;
+    clearStartList(): void;
+    getStartList(): Array<string>;
+    setStartList(value: Array<string>): WorkspaceSchedule;
+    addStart(value: string, index?: number): string;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): WorkspaceSchedule.AsObject;
+    static toObject(includeInstance: boolean, msg: WorkspaceSchedule): WorkspaceSchedule.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: WorkspaceSchedule, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): WorkspaceSchedule;
+    static deserializeBinaryFromReader(message: WorkspaceSchedule, reader: jspb.BinaryReader): WorkspaceSchedule;
+}
+
+export namespace WorkspaceSchedule {
+    export type AsObject = {
+        timeZone: string,
+        stopList: Array<string>,
+        blackoutsList: Array<ScheduleWindow.AsObject>,
+        startList: Array<string>,
+    }
+}
+
+export class ScheduleWindow extends jspb.Message {
+    getStart(): string;
+    setStart(value: string): ScheduleWindow;
+    getDuration(): string;
+    setDuration(value: string): ScheduleWindow;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ScheduleWindow.AsObject;
+    static toObject(includeInstance: boolean, msg: ScheduleWindow): ScheduleWindow.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ScheduleWindow, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ScheduleWindow;
+    static deserializeBinaryFromReader(message: ScheduleWindow, reader: jspb.BinaryReader): ScheduleWindow;
+}
+
+export namespace ScheduleWindow {
+    export type AsObject = {
+        start: string,
+        duration: string,
     }
 }
 
@@ -917,6 +981,11 @@ export class WorkspaceMetadata extends jspb.Message {
     getProject(): string | undefined;
     setProject(value: string): WorkspaceMetadata;
 
+    hasNextScheduledStart(): boolean;
+    clearNextScheduledStart(): void;
+    getNextScheduledStart(): google_protobuf_timestamp_pb.Timestamp | undefined;
+    setNextScheduledStart(value?: google_protobuf_timestamp_pb.Timestamp): WorkspaceMetadata;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): WorkspaceMetadata.AsObject;
     static toObject(includeInstance: boolean, msg: WorkspaceMetadata): WorkspaceMetadata.AsObject;
@@ -936,6 +1005,7 @@ export namespace WorkspaceMetadata {
         annotationsMap: Array<[string, string]>,
         team?: string,
         project?: string,
+        nextScheduledStart?: google_protobuf_timestamp_pb.Timestamp.AsObject,
     }
 }
 
@@ -1045,6 +1115,11 @@ export class StartWorkspaceSpec extends jspb.Message {
     getMaximumLifetime(): string;
     setMaximumLifetime(value: string): StartWorkspaceSpec;
 
+    hasSchedule(): boolean;
+    clearSchedule(): void;
+    getSchedule(): WorkspaceSchedule | undefined;
+    setSchedule(value?: WorkspaceSchedule): StartWorkspaceSpec;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): StartWorkspaceSpec.AsObject;
     static toObject(includeInstance: boolean, msg: StartWorkspaceSpec): StartWorkspaceSpec.AsObject;
@@ -1074,6 +1149,7 @@ export namespace StartWorkspaceSpec {
         ideImageLayersList: Array<string>,
         closedTimeout: string,
         maximumLifetime: string,
+        schedule?: WorkspaceSchedule.AsObject,
     }
 }
 
@@ -1264,11 +1340,13 @@ export enum StopWorkspacePolicy {
     NORMALLY = 0,
     IMMEDIATELY = 1,
     ABORT = 2,
+    HIBERNATE = 3,
 }
 
 export enum TimeoutType {
     WORKSPACE_TIMEOUT = 0,
     CLOSED_TIMEOUT = 1,
+    SCHEDULE_TIMEOUT = 2,
 }
 
 export enum AdmissionLevel {
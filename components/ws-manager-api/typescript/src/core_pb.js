@@ -52,6 +52,7 @@ goog.exportSymbol('proto.wsman.PortProtocol', null, global);
 goog.exportSymbol('proto.wsman.PortSpec', null, global);
 goog.exportSymbol('proto.wsman.PortVisibility', null, global);
 goog.exportSymbol('proto.wsman.SSHPublicKeys', null, global);
+goog.exportSymbol('proto.wsman.ScheduleWindow', null, global);
 goog.exportSymbol('proto.wsman.SetTimeoutRequest', null, global);
 goog.exportSymbol('proto.wsman.SetTimeoutResponse', null, global);
 goog.exportSymbol('proto.wsman.StartWorkspaceRequest', null, global);
@@ -76,6 +77,7 @@ goog.exportSymbol('proto.wsman.WorkspaceFeatureFlag', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceMetadata', null, global);
 goog.exportSymbol('proto.wsman.WorkspacePhase', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceRuntimeInfo', null, global);
+goog.exportSymbol('proto.wsman.WorkspaceSchedule', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceSpec', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceStatus', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceType', null, global);
@@ -373,6 +375,48 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.wsman.SetTimeoutRequest.displayName = 'proto.wsman.SetTimeoutRequest';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.WorkspaceSchedule = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.wsman.WorkspaceSchedule.repeatedFields_, null);
+};
+goog.inherits(proto.wsman.WorkspaceSchedule, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.wsman.WorkspaceSchedule.displayName = 'proto.wsman.WorkspaceSchedule';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.ScheduleWindow = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.wsman.ScheduleWindow, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.wsman.ScheduleWindow.displayName = 'proto.wsman.ScheduleWindow';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -3212,7 +3256,8 @@ proto.wsman.SetTimeoutRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     id: jspb.Message.getFieldWithDefault(msg, 1, ""),
     duration: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    type: jspb.Message.getFieldWithDefault(msg, 3, 0)
+    type: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    schedule: (f = msg.getSchedule()) && proto.wsman.WorkspaceSchedule.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -3261,6 +3306,11 @@ proto.wsman.SetTimeoutRequest.deserializeBinaryFromReader = function(msg, reader
       var value = /** @type {!proto.wsman.TimeoutType} */ (reader.readEnum());
       msg.setType(value);
       break;
+    case 4:
+      var value = new proto.wsman.WorkspaceSchedule;
+      reader.readMessage(value,proto.wsman.WorkspaceSchedule.deserializeBinaryFromReader);
+      msg.setSchedule(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -3311,6 +3361,14 @@ proto.wsman.SetTimeoutRequest.serializeBinaryToWriter = function(message, writer
       f
     );
   }
+  f = message.getSchedule();
+  if (f != null) {
+    writer.writeMessage(
+      4,
+      f,
+      proto.wsman.WorkspaceSchedule.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -3368,6 +3426,491 @@ proto.wsman.SetTimeoutRequest.prototype.setType = function(value) {
 };
 
 
+/**
+ * optional WorkspaceSchedule schedule = 4;
+ * @return {?proto.wsman.WorkspaceSchedule}
+ */
+proto.wsman.SetTimeoutRequest.prototype.getSchedule = function() {
+  return /** @type{?proto.wsman.WorkspaceSchedule} */ (
+    jspb.Message.getWrapperField(this, proto.wsman.WorkspaceSchedule, 4));
+};
+
+
+/**
+ * @param {?proto.wsman.WorkspaceSchedule|undefined} value
+ * @return {!proto.wsman.SetTimeoutRequest} returns this
+*/
+proto.wsman.SetTimeoutRequest.prototype.setSchedule = function(value) {
+  return jspb.Message.setWrapperField(this, 4, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.SetTimeoutRequest} returns this
+ */
+proto.wsman.SetTimeoutRequest.prototype.clearSchedule = function() {
+  return this.setSchedule(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.SetTimeoutRequest.prototype.hasSchedule = function() {
+  return jspb.Message.getField(this, 4) != null;
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.wsman.WorkspaceSchedule.repeatedFields_ = [2,3,4];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.wsman.WorkspaceSchedule.prototype.toObject = function(opt_includeInstance) {
+  return proto.wsman.WorkspaceSchedule.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.wsman.WorkspaceSchedule} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceSchedule.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    timeZone: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    stopList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f,
+    blackoutsList: jspb.Message.toObjectList(msg.getBlackoutsList(),
+    proto.wsman.ScheduleWindow.toObject, includeInstance),
+    startList: (f = jspb.Message.getRepeatedField(msg, 4)) == null ? undefined : f
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.WorkspaceSchedule}
+ */
+proto.wsman.WorkspaceSchedule.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.wsman.WorkspaceSchedule;
+  return proto.wsman.WorkspaceSchedule.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.WorkspaceSchedule} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.WorkspaceSchedule}
+ */
+proto.wsman.WorkspaceSchedule.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTimeZone(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addStop(value);
+      break;
+    case 3:
+      var value = new proto.wsman.ScheduleWindow;
+      reader.readMessage(value,proto.wsman.ScheduleWindow.deserializeBinaryFromReader);
+      msg.addBlackouts(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addStart(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.WorkspaceSchedule.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.wsman.WorkspaceSchedule.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.WorkspaceSchedule} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceSchedule.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getTimeZone();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getStopList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      2,
+      f
+    );
+  }
+  f = message.getBlackoutsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      3,
+      f,
+      proto.wsman.ScheduleWindow.serializeBinaryToWriter
+    );
+  }
+  f = message.getStartList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string time_zone = 1;
+ * @return {string}
+ */
+proto.wsman.WorkspaceSchedule.prototype.getTimeZone = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.setTimeZone = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * repeated string stop = 2;
+ * @return {!Array<string>}
+ */
+proto.wsman.WorkspaceSchedule.prototype.getStopList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.setStopList = function(value) {
+  return jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.addStop = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.clearStopList = function() {
+  return this.setStopList([]);
+};
+
+
+/**
+ * repeated ScheduleWindow blackouts = 3;
+ * @return {!Array<!proto.wsman.ScheduleWindow>}
+ */
+proto.wsman.WorkspaceSchedule.prototype.getBlackoutsList = function() {
+  return /** @type{!Array<!proto.wsman.ScheduleWindow>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.wsman.ScheduleWindow, 3));
+};
+
+
+/**
+ * @param {!Array<!proto.wsman.ScheduleWindow>} value
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+*/
+proto.wsman.WorkspaceSchedule.prototype.setBlackoutsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 3, value);
+};
+
+
+/**
+ * @param {!proto.wsman.ScheduleWindow=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.ScheduleWindow}
+ */
+proto.wsman.WorkspaceSchedule.prototype.addBlackouts = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 3, opt_value, proto.wsman.ScheduleWindow, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.clearBlackoutsList = function() {
+  return this.setBlackoutsList([]);
+};
+
+
+/**
+ * repeated string start = 4;
+ * @return {!Array<string>}
+ */
+proto.wsman.WorkspaceSchedule.prototype.getStartList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 4));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.setStartList = function(value) {
+  return jspb.Message.setField(this, 4, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.addStart = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 4, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceSchedule} returns this
+ */
+proto.wsman.WorkspaceSchedule.prototype.clearStartList = function() {
+  return this.setStartList([]);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.wsman.ScheduleWindow.prototype.toObject = function(opt_includeInstance) {
+  return proto.wsman.ScheduleWindow.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.wsman.ScheduleWindow} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.ScheduleWindow.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    start: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    duration: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.ScheduleWindow}
+ */
+proto.wsman.ScheduleWindow.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.wsman.ScheduleWindow;
+  return proto.wsman.ScheduleWindow.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.ScheduleWindow} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.ScheduleWindow}
+ */
+proto.wsman.ScheduleWindow.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStart(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDuration(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.ScheduleWindow.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.wsman.ScheduleWindow.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.ScheduleWindow} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.ScheduleWindow.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getStart();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getDuration();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string start = 1;
+ * @return {string}
+ */
+proto.wsman.ScheduleWindow.prototype.getStart = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.ScheduleWindow} returns this
+ */
+proto.wsman.ScheduleWindow.prototype.setStart = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string duration = 2;
+ * @return {string}
+ */
+proto.wsman.ScheduleWindow.prototype.getDuration = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.ScheduleWindow} returns this
+ */
+proto.wsman.ScheduleWindow.prototype.setDuration = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
 
 
 
@@ -7394,7 +7937,8 @@ proto.wsman.WorkspaceMetadata.toObject = function(includeInstance, msg) {
     startedAt: (f = msg.getStartedAt()) && google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f),
     annotationsMap: (f = msg.getAnnotationsMap()) ? f.toObject(includeInstance, undefined) : [],
     team: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    project: jspb.Message.getFieldWithDefault(msg, 6, "")
+    project: jspb.Message.getFieldWithDefault(msg, 6, ""),
+    nextScheduledStart: (f = msg.getNextScheduledStart()) && google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -7458,6 +8002,11 @@ proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader = function(msg, reader
       var value = /** @type {string} */ (reader.readString());
       msg.setProject(value);
       break;
+    case 7:
+      var value = new google_protobuf_timestamp_pb.Timestamp;
+      reader.readMessage(value,google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
+      msg.setNextScheduledStart(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -7527,6 +8076,14 @@ proto.wsman.WorkspaceMetadata.serializeBinaryToWriter = function(message, writer
       f
     );
   }
+  f = message.getNextScheduledStart();
+  if (f != null) {
+    writer.writeMessage(
+      7,
+      f,
+      google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -7697,6 +8254,43 @@ proto.wsman.WorkspaceMetadata.prototype.hasProject = function() {
 };
 
 
+/**
+ * optional google.protobuf.Timestamp next_scheduled_start = 7;
+ * @return {?proto.google.protobuf.Timestamp}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getNextScheduledStart = function() {
+  return /** @type{?proto.google.protobuf.Timestamp} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 7));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Timestamp|undefined} value
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+*/
+proto.wsman.WorkspaceMetadata.prototype.setNextScheduledStart = function(value) {
+  return jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.clearNextScheduledStart = function() {
+  return this.setNextScheduledStart(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceMetadata.prototype.hasNextScheduledStart = function() {
+  return jspb.Message.getField(this, 7) != null;
+};
+
+
 
 
 
@@ -8105,7 +8699,8 @@ proto.wsman.StartWorkspaceSpec.toObject = function(includeInstance, msg) {
     proto.wsman.EnvironmentVariable.toObject, includeInstance),
     ideImageLayersList: (f = jspb.Message.getRepeatedField(msg, 17)) == null ? undefined : f,
     closedTimeout: jspb.Message.getFieldWithDefault(msg, 18, ""),
-    maximumLifetime: jspb.Message.getFieldWithDefault(msg, 19, "")
+    maximumLifetime: jspb.Message.getFieldWithDefault(msg, 19, ""),
+    schedule: (f = msg.getSchedule()) && proto.wsman.WorkspaceSchedule.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -8218,6 +8813,11 @@ proto.wsman.StartWorkspaceSpec.deserializeBinaryFromReader = function(msg, reade
       var value = /** @type {string} */ (reader.readString());
       msg.setMaximumLifetime(value);
       break;
+    case 20:
+      var value = new proto.wsman.WorkspaceSchedule;
+      reader.readMessage(value,proto.wsman.WorkspaceSchedule.deserializeBinaryFromReader);
+      msg.setSchedule(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -8372,6 +8972,14 @@ proto.wsman.StartWorkspaceSpec.serializeBinaryToWriter = function(message, write
       f
     );
   }
+  f = message.getSchedule();
+  if (f != null) {
+    writer.writeMessage(
+      20,
+      f,
+      proto.wsman.WorkspaceSchedule.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -8855,6 +9463,43 @@ proto.wsman.StartWorkspaceSpec.prototype.setMaximumLifetime = function(value) {
 };
 
 
+/**
+ * optional WorkspaceSchedule schedule = 20;
+ * @return {?proto.wsman.WorkspaceSchedule}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getSchedule = function() {
+  return /** @type{?proto.wsman.WorkspaceSchedule} */ (
+    jspb.Message.getWrapperField(this, proto.wsman.WorkspaceSchedule, 20));
+};
+
+
+/**
+ * @param {?proto.wsman.WorkspaceSchedule|undefined} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+*/
+proto.wsman.StartWorkspaceSpec.prototype.setSchedule = function(value) {
+  return jspb.Message.setWrapperField(this, 20, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearSchedule = function() {
+  return this.setSchedule(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.hasSchedule = function() {
+  return jspb.Message.getField(this, 20) != null;
+};
+
+
 
 
 
@@ -10129,7 +10774,8 @@ proto.wsman.WorkspaceClass.prototype.setDisplayname = function(value) {
 proto.wsman.StopWorkspacePolicy = {
   NORMALLY: 0,
   IMMEDIATELY: 1,
-  ABORT: 2
+  ABORT: 2,
+  HIBERNATE: 3
 };
 
 /**
@@ -10137,7 +10783,8 @@ proto.wsman.StopWorkspacePolicy = {
  */
 proto.wsman.TimeoutType = {
   WORKSPACE_TIMEOUT: 0,
-  CLOSED_TIMEOUT: 1
+  CLOSED_TIMEOUT: 1,
+  SCHEDULE_TIMEOUT: 2
 };
 
 /**
@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -72,12 +73,34 @@ func (r *WorkspaceReconciler) createWorkspacePod(sctx *startWorkspaceContext) (*
 		return nil, xerrors.Errorf("unknown workspace class: %s", sctx.Workspace.Spec.Class)
 	}
 
+	podTemplate, err := getWorkspacePodTemplate(class, sctx.Workspace.Spec.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := createDefiniteWorkspacePod(sctx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create definite workspace pod: %w", err)
+	}
+	if err := ctrl.SetControllerReference(sctx.Workspace, pod, r.Scheme); err != nil {
+		return nil, err
+	}
+	err = combineDefiniteWorkspacePodWithTemplate(pod, podTemplate)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create workspace pod: %w", err)
+	}
+	return pod, nil
+}
+
+// getWorkspacePodTemplate reads the pod template of a workspace class and combines it with the class' template
+// for the workspace type.
+func getWorkspacePodTemplate(class *config.WorkspaceClass, tpe workspacev1.WorkspaceType) (*corev1.Pod, error) {
 	podTemplate, err := config.GetWorkspacePodTemplate(class.Templates.DefaultPath)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot read pod template - this is a configuration problem: %w", err)
 	}
 	var typeSpecificTpl *corev1.Pod
-	switch sctx.Workspace.Spec.Type {
+	switch tpe {
 	case workspacev1.WorkspaceTypeRegular:
 		typeSpecificTpl, err = config.GetWorkspacePodTemplate(class.Templates.RegularPath)
 	case workspacev1.WorkspaceTypePrebuild:
@@ -94,19 +117,7 @@ func (r *WorkspaceReconciler) createWorkspacePod(sctx *startWorkspaceContext) (*
 			return nil, xerrors.Errorf("cannot apply type-specific pod template: %w", err)
 		}
 	}
-
-	pod, err := createDefiniteWorkspacePod(sctx)
-	if err != nil {
-		return nil, xerrors.Errorf("cannot create definite workspace pod: %w", err)
-	}
-	if err := ctrl.SetControllerReference(sctx.Workspace, pod, r.Scheme); err != nil {
-		return nil, err
-	}
-	err = combineDefiniteWorkspacePodWithTemplate(pod, podTemplate)
-	if err != nil {
-		return nil, xerrors.Errorf("cannot create workspace pod: %w", err)
-	}
-	return pod, nil
+	return podTemplate, nil
 }
 
 // combineDefiniteWorkspacePodWithTemplate merges a definite workspace pod with a user-provided template.
@@ -290,13 +301,6 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 		annotations[k] = v
 	}
 
-	// By default we embue our workspace pods with some tolerance towards pressure taints,
-	// see https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/#taint-based-evictions
-	// for more details. As hope/assume that the pressure might go away in this time.
-	// Memory and Disk pressure are no reason to stop a workspace - instead of stopping a workspace
-	// we'd rather wait things out or gracefully fail the workspace ourselves.
-	var perssureToleranceSeconds int64 = 30
-
 	// Mounting /dev/net/tun should be fine security-wise, because:
 	//   - the TAP driver documentation says so (see https://www.kernel.org/doc/Documentation/networking/tuntap.txt)
 	//   - systemd's nspawn does the same thing (if it's good enough for them, it's good enough for us)
@@ -317,8 +321,83 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 		},
 	}
 
+	graceSec := int64(gracePeriod.Seconds())
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", prefix, sctx.Workspace.Name),
+			Namespace:   sctx.Config.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			Finalizers:  []string{workspacev1.GitpodFinalizerName},
+		},
+		Spec: corev1.PodSpec{
+			Hostname:                     sctx.Workspace.Spec.Ownership.WorkspaceID,
+			AutomountServiceAccountToken: pointer.Bool(false),
+			ServiceAccountName:           "workspace",
+			SchedulerName:                sctx.Config.SchedulerName,
+			EnableServiceLinks:           pointer.Bool(false),
+			Affinity:                     workspaceNodeAffinity(sctx.Config, sctx.Headless),
+			SecurityContext: &corev1.PodSecurityContext{
+				// We're using a custom seccomp profile for user namespaces to allow clone, mount and chroot.
+				SeccompProfile: &corev1.SeccompProfile{
+					Type:             corev1.SeccompProfileTypeLocalhost,
+					LocalhostProfile: pointer.String(sctx.Config.SeccompProfile),
+				},
+			},
+			Containers: []corev1.Container{
+				*workspaceContainer,
+			},
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			Volumes:                       volumes,
+			TerminationGracePeriodSeconds: &graceSec,
+			Tolerations:                   workspaceTolerations(),
+		},
+	}
+
+	if sctx.Workspace.Spec.RestoreCheckpoint {
+		restoreCheckpoint(&pod, sctx.Workspace, daemonVolumeName)
+	}
+
+	return &pod, nil
+}
+
+// restoreCheckpoint makes a workspace pod resume the processes of a hibernated workspace. The container runtime
+// restores the workspace container because it is created from the checkpoint image, which ws-daemon prepares during
+// content initialization. Until then the restore init container holds back the workspace container. It also pulls the
+// workspace image, which is the root filesystem of the checkpoint and which ws-daemon falls back to if the checkpoint
+// cannot be restored.
+func restoreCheckpoint(pod *corev1.Pod, ws *workspacev1.Workspace, daemonVolumeName string) {
+	workspace := &pod.Spec.Containers[0]
+
+	sec := workspace.SecurityContext.DeepCopy()
+	sec.AllowPrivilegeEscalation = pointer.Bool(false)
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+		Name:            workspacev1.RestoreContainerName,
+		Image:           workspace.Image,
+		ImagePullPolicy: workspace.ImagePullPolicy,
+		SecurityContext: sec,
+		Command: []string{
+			"/bin/sh", "-c",
+			fmt.Sprintf("until [ -e /.workspace/%s ]; do sleep 0.2; done", workspacev1.CheckpointImageReadyFile),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				MountPath: "/.workspace",
+				Name:      daemonVolumeName,
+				ReadOnly:  true,
+			},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+	})
+
+	workspace.Image = ws.CheckpointImage()
+	workspace.ImagePullPolicy = corev1.PullNever
+}
+
+// workspaceNodeAffinity restricts workspace pods to nodes which are ready to run workspaces of their kind
+func workspaceNodeAffinity(cfg *config.Configuration, headless bool) *corev1.Affinity {
 	workloadType := "regular"
-	if sctx.Headless {
+	if headless {
 		workloadType = "headless"
 	}
 
@@ -328,16 +407,16 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 			Operator: corev1.NodeSelectorOpExists,
 		},
 		{
-			Key:      "gitpod.io/ws-daemon_ready_ns_" + sctx.Config.Namespace,
+			Key:      "gitpod.io/ws-daemon_ready_ns_" + cfg.Namespace,
 			Operator: corev1.NodeSelectorOpExists,
 		},
 		{
-			Key:      "gitpod.io/registry-facade_ready_ns_" + sctx.Config.Namespace,
+			Key:      "gitpod.io/registry-facade_ready_ns_" + cfg.Namespace,
 			Operator: corev1.NodeSelectorOpExists,
 		},
 	}
 
-	if sctx.Config.ExperimentalMode {
+	if cfg.ExperimentalMode {
 		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
 			Key:      "gitpod.io/experimental",
 			Operator: corev1.NodeSelectorOpExists,
@@ -349,7 +428,7 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 		})
 	}
 
-	affinity := &corev1.Affinity{
+	return &corev1.Affinity{
 		NodeAffinity: &corev1.NodeAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
 				NodeSelectorTerms: []corev1.NodeSelectorTerm{
@@ -360,60 +439,36 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 			},
 		},
 	}
+}
 
-	graceSec := int64(gracePeriod.Seconds())
-	pod := corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        fmt.Sprintf("%s-%s", prefix, sctx.Workspace.Name),
-			Namespace:   sctx.Config.Namespace,
-			Labels:      labels,
-			Annotations: annotations,
-			Finalizers:  []string{workspacev1.GitpodFinalizerName},
+func workspaceTolerations() []corev1.Toleration {
+	// By default we embue our workspace pods with some tolerance towards pressure taints,
+	// see https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/#taint-based-evictions
+	// for more details. As hope/assume that the pressure might go away in this time.
+	// Memory and Disk pressure are no reason to stop a workspace - instead of stopping a workspace
+	// we'd rather wait things out or gracefully fail the workspace ourselves.
+	var perssureToleranceSeconds int64 = 30
+
+	return []corev1.Toleration{
+		{
+			Key:      "node.kubernetes.io/disk-pressure",
+			Operator: "Exists",
+			Effect:   "NoExecute",
+			// Tolarate Indefinitely
 		},
-		Spec: corev1.PodSpec{
-			Hostname:                     sctx.Workspace.Spec.Ownership.WorkspaceID,
-			AutomountServiceAccountToken: pointer.Bool(false),
-			ServiceAccountName:           "workspace",
-			SchedulerName:                sctx.Config.SchedulerName,
-			EnableServiceLinks:           pointer.Bool(false),
-			Affinity:                     affinity,
-			SecurityContext: &corev1.PodSecurityContext{
-				// We're using a custom seccomp profile for user namespaces to allow clone, mount and chroot.
-				SeccompProfile: &corev1.SeccompProfile{
-					Type:             corev1.SeccompProfileTypeLocalhost,
-					LocalhostProfile: pointer.String(sctx.Config.SeccompProfile),
-				},
-			},
-			Containers: []corev1.Container{
-				*workspaceContainer,
-			},
-			RestartPolicy:                 corev1.RestartPolicyNever,
-			Volumes:                       volumes,
-			TerminationGracePeriodSeconds: &graceSec,
-			Tolerations: []corev1.Toleration{
-				{
-					Key:      "node.kubernetes.io/disk-pressure",
-					Operator: "Exists",
-					Effect:   "NoExecute",
-					// Tolarate Indefinitely
-				},
-				{
-					Key:      "node.kubernetes.io/memory-pressure",
-					Operator: "Exists",
-					Effect:   "NoExecute",
-					// Tolarate Indefinitely
-				},
-				{
-					Key:               "node.kubernetes.io/network-unavailable",
-					Operator:          "Exists",
-					Effect:            "NoExecute",
-					TolerationSeconds: &perssureToleranceSeconds,
-				},
-			},
+		{
+			Key:      "node.kubernetes.io/memory-pressure",
+			Operator: "Exists",
+			Effect:   "NoExecute",
+			// Tolarate Indefinitely
+		},
+		{
+			Key:               "node.kubernetes.io/network-unavailable",
+			Operator:          "Exists",
+			Effect:            "NoExecute",
+			TolerationSeconds: &perssureToleranceSeconds,
 		},
 	}
-
-	return &pod, nil
 }
 
 func createWorkspaceContainer(sctx *startWorkspaceContext) (*corev1.Container, error) {
@@ -670,17 +725,36 @@ func newStartWorkspaceContext(ctx context.Context, cfg *config.Configuration, ws
 	span, _ := tracing.FromContext(ctx, "newStartWorkspaceContext")
 	defer tracing.FinishSpan(span, &err)
 
+	labels := map[string]string{
+		"app":                  "gitpod",
+		"component":            "workspace",
+		wsk8s.MetaIDLabel:      ws.Spec.Ownership.WorkspaceID,
+		wsk8s.WorkspaceIDLabel: ws.Name,
+		wsk8s.OwnerLabel:       ws.Spec.Ownership.Owner,
+		wsk8s.TypeLabel:        strings.ToLower(string(ws.Spec.Type)),
+		instanceIDLabel:        ws.Name,
+		headlessLabel:          strconv.FormatBool(ws.IsHeadless()),
+	}
+	// ws-daemon shapes the network of a workspace according to its class
+	if ws.Spec.Class != "" {
+		labels[wsk8s.WorkspaceClassLabel] = ws.Spec.Class
+	}
+	// ws-daemon shares the CPU bandwidth of a node among teams and tenants
+	for label, value := range map[string]string{
+		wsk8s.TeamLabel:   ws.Spec.Ownership.Team,
+		wsk8s.TenantLabel: ws.Spec.Ownership.Tenant,
+	} {
+		if value == "" {
+			continue
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, xerrors.Errorf("%s is not a valid value for label %s: %s", value, label, strings.Join(errs, "; "))
+		}
+		labels[label] = value
+	}
+
 	return &startWorkspaceContext{
-		Labels: map[string]string{
-			"app":                  "gitpod",
-			"component":            "workspace",
-			wsk8s.MetaIDLabel:      ws.Spec.Ownership.WorkspaceID,
-			wsk8s.WorkspaceIDLabel: ws.Name,
-			wsk8s.OwnerLabel:       ws.Spec.Ownership.Owner,
-			wsk8s.TypeLabel:        strings.ToLower(string(ws.Spec.Type)),
-			instanceIDLabel:        ws.Name,
-			headlessLabel:          strconv.FormatBool(ws.IsHeadless()),
-		},
+		Labels:         labels,
 		Config:         cfg,
 		Workspace:      ws,
 		IDEPort:        23000,
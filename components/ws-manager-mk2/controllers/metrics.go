@@ -14,6 +14,7 @@ import (
 	"github.com/go-logr/logr"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,6 +27,9 @@ const (
 	workspaceBackupFailuresTotal  string = "workspace_backups_failure_total"
 	workspaceRestoresTotal        string = "workspace_restores_total"
 	workspaceRestoresFailureTotal string = "workspace_restores_failure_total"
+	workspacePoolHitsTotal        string = "workspace_pool_hits_total"
+	workspacePoolMissesTotal      string = "workspace_pool_misses_total"
+	workspacePoolTimeSavedSeconds string = "workspace_pool_time_saved_seconds"
 )
 
 type StopReason string
@@ -36,6 +40,7 @@ const (
 	StopReasonAborted      = "aborted"
 	StopReasonOutOfSpace   = "out-of-space"
 	StopReasonTimeout      = "timeout"
+	StopReasonSchedule     = "schedule"
 	StopReasonTabClosed    = "tab-closed"
 	StopReasonRegular      = "regular-stop"
 )
@@ -51,6 +56,10 @@ type controllerMetrics struct {
 	totalRestoreCounterVec        *prometheus.CounterVec
 	totalRestoreFailureCounterVec *prometheus.CounterVec
 
+	totalPoolHitsCounterVec   *prometheus.CounterVec
+	totalPoolMissesCounterVec *prometheus.CounterVec
+	poolTimeSavedHistVec      *prometheus.HistogramVec
+
 	workspacePhases *phaseTotalVec
 	timeoutSettings *timeoutSettingsVec
 
@@ -116,6 +125,26 @@ func newControllerMetrics(r *WorkspaceReconciler) (*controllerMetrics, error) {
 			Help:      "total number of workspace restore failures",
 		}, []string{"type", "class"}),
 
+		totalPoolHitsCounterVec: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsWorkspaceSubsystem,
+			Name:      workspacePoolHitsTotal,
+			Help:      "total number of workspaces which took over a pod from a workspace pool",
+		}, []string{"pool", "class"}),
+		totalPoolMissesCounterVec: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsWorkspaceSubsystem,
+			Name:      workspacePoolMissesTotal,
+			Help:      "total number of workspaces which matched a workspace pool that had no ready pod",
+		}, []string{"pool", "class"}),
+		poolTimeSavedHistVec: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsWorkspaceSubsystem,
+			Name:      workspacePoolTimeSavedSeconds,
+			Help:      "time it took the claimed pool pod to be scheduled and pull its image, which the workspace did not have to wait for",
+			Buckets:   prometheus.ExponentialBuckets(2, 2, 10),
+		}, []string{"pool", "class"}),
+
 		workspacePhases: newPhaseTotalVec(r),
 		timeoutSettings: newTimeoutSettingsVec(r),
 		cache:           cache,
@@ -172,8 +201,11 @@ func (m *controllerMetrics) countWorkspaceStop(log *logr.Logger, ws *workspacev1
 		}
 	} else if ws.IsConditionTrue(workspacev1.WorkspaceConditionAborted) {
 		reason = StopReasonAborted
-	} else if ws.IsConditionTrue(workspacev1.WorkspaceConditionTimeout) {
+	} else if c := wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionTimeout)); c != nil && c.Status == metav1.ConditionTrue {
 		reason = StopReasonTimeout
+		if c.Reason == workspacev1.ReasonScheduledStop {
+			reason = StopReasonSchedule
+		}
 	} else if ws.IsConditionTrue(workspacev1.WorkspaceConditionClosed) {
 		reason = StopReasonTabClosed
 	} else {
@@ -239,6 +271,33 @@ func (m *controllerMetrics) countTotalRestoreFailures(log *logr.Logger, ws *work
 	counter.Inc()
 }
 
+func (m *controllerMetrics) countPoolHit(log *logr.Logger, pool *workspacev1.WorkspacePool, timeSaved time.Duration) {
+	class := pool.Spec.Class
+
+	counter, err := m.totalPoolHitsCounterVec.GetMetricWithLabelValues(pool.Name, class)
+	if err != nil {
+		log.Error(err, "could not count workspace pool hit", "pool", pool.Name, "class", class)
+	}
+	counter.Inc()
+
+	hist, err := m.poolTimeSavedHistVec.GetMetricWithLabelValues(pool.Name, class)
+	if err != nil {
+		log.Error(err, "could not record workspace pool time saved", "pool", pool.Name, "class", class)
+	}
+	hist.Observe(timeSaved.Seconds())
+}
+
+func (m *controllerMetrics) countPoolMiss(log *logr.Logger, pool *workspacev1.WorkspacePool) {
+	class := pool.Spec.Class
+
+	counter, err := m.totalPoolMissesCounterVec.GetMetricWithLabelValues(pool.Name, class)
+	if err != nil {
+		log.Error(err, "could not count workspace pool miss", "pool", pool.Name, "class", class)
+	}
+
+	counter.Inc()
+}
+
 func (m *controllerMetrics) containsWorkspace(ws *workspacev1.Workspace) bool {
 	return m.cache.Contains(ws.Name)
 }
@@ -277,7 +336,7 @@ func newMetricState(ws *workspacev1.Workspace) metricState {
 		// each workspace.
 		recordedStartTime:       ws.Status.Phase == workspacev1.WorkspacePhaseRunning,
 		recordedInitFailure:     wsk8s.ConditionWithStatusAndReason(ws.Status.Conditions, string(workspacev1.WorkspaceConditionContentReady), false, workspacev1.ReasonInitializationFailure),
-		recordedStartFailure:    ws.Status.Phase == workspacev1.WorkspacePhaseStopped && isStartFailure(ws),
+		recordedStartFailure:    ws.IsStopped() && isStartFailure(ws),
 		recordedFailure:         ws.IsConditionTrue(workspacev1.WorkspaceConditionFailed),
 		recordedContentReady:    ws.IsConditionTrue(workspacev1.WorkspaceConditionContentReady),
 		recordedBackupFailed:    ws.IsConditionTrue(workspacev1.WorkspaceConditionBackupFailure),
@@ -307,6 +366,10 @@ func (m *controllerMetrics) Describe(ch chan<- *prometheus.Desc) {
 	m.totalRestoreCounterVec.Describe(ch)
 	m.totalRestoreFailureCounterVec.Describe(ch)
 
+	m.totalPoolHitsCounterVec.Describe(ch)
+	m.totalPoolMissesCounterVec.Describe(ch)
+	m.poolTimeSavedHistVec.Describe(ch)
+
 	m.workspacePhases.Describe(ch)
 	m.timeoutSettings.Describe(ch)
 }
@@ -323,6 +386,10 @@ func (m *controllerMetrics) Collect(ch chan<- prometheus.Metric) {
 	m.totalRestoreCounterVec.Collect(ch)
 	m.totalRestoreFailureCounterVec.Collect(ch)
 
+	m.totalPoolHitsCounterVec.Collect(ch)
+	m.totalPoolMissesCounterVec.Collect(ch)
+	m.poolTimeSavedHistVec.Collect(ch)
+
 	m.workspacePhases.Collect(ch)
 	m.timeoutSettings.Collect(ch)
 }
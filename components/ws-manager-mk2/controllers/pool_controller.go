@@ -0,0 +1,585 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	regapi "github.com/gitpod-io/gitpod/registry-facade/api"
+	config "github.com/gitpod-io/gitpod/ws-manager/api/config"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+const (
+	// poolPodReserveImage runs in pool pods to hold on to the resources of a workspace on the node
+	poolPodReserveImage = "registry.k8s.io/pause:3.9"
+
+	// scheduledStartWarmUp is how long ahead of a scheduled workspace start the pool provides a pod for the workspace
+	scheduledStartWarmUp = 15 * time.Minute
+)
+
+func NewWorkspacePoolReconciler(c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, cfg *config.Configuration) (*WorkspacePoolReconciler, error) {
+	return &WorkspacePoolReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Config:   cfg,
+		Recorder: recorder,
+	}, nil
+}
+
+// WorkspacePoolReconciler keeps the number of idle pods of each workspace pool at the pool's size.
+//
+// Pool pods are anonymous: they pull the pool's workspace image and reserve the resources of the pool's
+// workspace class on a node, but they do not belong to any workspace. When a matching workspace starts,
+// the WorkspaceReconciler releases one of the ready pool pods and creates the workspace pod, with its identity,
+// environment and initializer, on the pool pod's node instead.
+type WorkspacePoolReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	Config   *config.Configuration
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=workspacepools,verbs=get;list;watch;update;delete
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=workspacepools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=pod,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile replaces failed pool pods, and creates or deletes pool pods until the pool has the desired size.
+// The desired size is the pool's size plus one pod for every scheduled workspace start which is due soon.
+func (r *WorkspacePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var pool workspacev1.WorkspacePool
+	if err := r.Get(ctx, req.NamespacedName, &pool); err != nil {
+		// Pool pods of deleted pools are garbage collected through their owner reference.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	warmUp := warmUpScheduledStarts(pool.Spec.ScheduledStarts, time.Now())
+	if pool.Labels[workspacev1.WorkspacePoolScheduledLabel] == "true" && pool.Spec.Size == 0 && len(warmUp.Remaining) == 0 {
+		log.Info("deleting workspace pool without scheduled starts")
+		return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, &pool))
+	}
+	if len(warmUp.Remaining) != len(pool.Spec.ScheduledStarts) {
+		pool.Spec.ScheduledStarts = warmUp.Remaining
+		if err := r.Update(ctx, &pool); err != nil {
+			return errorResultLogConflict(log, fmt.Errorf("failed to remove passed scheduled starts: %w", err))
+		}
+	}
+	size := int(pool.Spec.Size) + warmUp.Due
+
+	var pods corev1.PodList
+	err := r.List(ctx, &pods, client.InNamespace(req.Namespace), client.MatchingLabels{workspacev1.WorkspacePoolLabel: pool.Name})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list pool pods: %w", err)
+	}
+
+	var ready, pending []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		switch {
+		case pod.DeletionTimestamp != nil:
+			// The pod was claimed by a workspace or is being scaled down.
+		case pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded:
+			log.Info("deleting terminated pool pod", "pod", pod.Name, "phase", pod.Status.Phase)
+			if err := r.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to delete terminated pool pod: %w", err)
+			}
+		case isPodReady(pod):
+			ready = append(ready, pod)
+		default:
+			pending = append(pending, pod)
+		}
+	}
+
+	for excess := len(ready) + len(pending) - size; excess > 0; excess-- {
+		// Release pending pods first, they have not pulled the image yet.
+		var pod *corev1.Pod
+		if len(pending) > 0 {
+			pod, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		} else {
+			pod, ready = ready[len(ready)-1], ready[:len(ready)-1]
+		}
+		log.Info("deleting surplus pool pod", "pod", pod.Name)
+		if err := r.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete surplus pool pod: %w", err)
+		}
+	}
+
+	var claimed []string
+	if size > len(ready)+len(pending) {
+		claimed, err = claimedNodes(ctx, r.Client, &pool)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	for missing := size - len(ready) - len(pending); missing > 0; missing-- {
+		pod, err := createPoolPod(r.Config, &pool, claimed)
+		if err != nil {
+			// This is a configuration problem which retrying won't fix. We'll try again when the pool changes.
+			log.Error(err, "unable to produce pool pod")
+			r.Recorder.Event(&pool, corev1.EventTypeWarning, "Failed", err.Error())
+			break
+		}
+		if err := ctrl.SetControllerReference(&pool, pod, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.Create(ctx, pod); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create pool pod: %w", err)
+		}
+		pending = append(pending, pod)
+	}
+
+	result := ctrl.Result{RequeueAfter: warmUp.RequeueAfter}
+	status := workspacev1.WorkspacePoolStatus{
+		Ready:   int32(len(ready)),
+		Pending: int32(len(pending)),
+	}
+	if status == pool.Status {
+		return result, nil
+	}
+	pool.Status = status
+	if err := r.Status().Update(ctx, &pool); err != nil {
+		return errorResultLogConflict(log, fmt.Errorf("failed to update pool status: %w", err))
+	}
+
+	return result, nil
+}
+
+// scheduledWarmUp is the effect of a pool's scheduled starts at a point in time
+type scheduledWarmUp struct {
+	// Due is the number of starts the pool has to provide a pod for
+	Due int
+	// Remaining are the starts which have not passed yet
+	Remaining []workspacev1.ScheduledStart
+	// RequeueAfter is when the next start becomes due or passes, zero if there is none
+	RequeueAfter time.Duration
+}
+
+func warmUpScheduledStarts(starts []workspacev1.ScheduledStart, now time.Time) scheduledWarmUp {
+	var res scheduledWarmUp
+	requeueAt := func(t time.Time) {
+		if d := t.Sub(now); res.RequeueAfter == 0 || d < res.RequeueAfter {
+			res.RequeueAfter = d
+		}
+	}
+	for _, s := range starts {
+		if !s.Time.After(now) {
+			continue
+		}
+		res.Remaining = append(res.Remaining, s)
+
+		if warmUpAt := s.Time.Add(-scheduledStartWarmUp); warmUpAt.After(now) {
+			requeueAt(warmUpAt)
+		} else {
+			res.Due++
+			requeueAt(s.Time.Time)
+		}
+	}
+	return res
+}
+
+// recordScheduledStart warms up the next scheduled start of a stopped workspace: it adds the start to the pool
+// which matches the workspace, and creates that pool if there is none.
+func recordScheduledStart(ctx context.Context, c client.Client, ws *workspacev1.Workspace, now time.Time) error {
+	if ws.Status.NextScheduledStart == nil || !ws.Status.NextScheduledStart.After(now) ||
+		ws.Spec.Type != workspacev1.WorkspaceTypeRegular || ws.Spec.Image.Workspace.Ref == nil {
+		return nil
+	}
+	start := workspacev1.ScheduledStart{
+		WorkspaceID: ws.Spec.Ownership.WorkspaceID,
+		Time:        *ws.Status.NextScheduledStart,
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		pool, err := findPool(ctx, c, ws)
+		if err != nil {
+			return err
+		}
+		if pool == nil {
+			pool = &workspacev1.WorkspacePool{
+				ObjectMeta: metav1.ObjectMeta{
+					// The name is derived from class and image, such that concurrently stopping workspaces share one pool.
+					Name:      fmt.Sprintf("scheduled-%x", sha256.Sum256([]byte(ws.Spec.Class+"\x00"+*ws.Spec.Image.Workspace.Ref)))[:63],
+					Namespace: ws.Namespace,
+					Labels: map[string]string{
+						workspacev1.WorkspacePoolScheduledLabel: "true",
+					},
+				},
+				Spec: workspacev1.WorkspacePoolSpec{
+					Class:           ws.Spec.Class,
+					Image:           *ws.Spec.Image.Workspace.Ref,
+					IDE:             ws.Spec.Image.IDE,
+					ScheduledStarts: []workspacev1.ScheduledStart{start},
+				},
+			}
+			err = c.Create(ctx, pool)
+			if apierrors.IsAlreadyExists(err) {
+				// Another workspace created the pool in the meantime, retry to add the start to it.
+				return apierrors.NewConflict(workspacev1.GroupVersion.WithResource("workspacepools").GroupResource(), pool.Name, err)
+			}
+			return err
+		}
+
+		for i, s := range pool.Spec.ScheduledStarts {
+			if s.WorkspaceID == start.WorkspaceID {
+				if s.Time.Equal(&start.Time) {
+					return nil
+				}
+				pool.Spec.ScheduledStarts = append(pool.Spec.ScheduledStarts[:i], pool.Spec.ScheduledStarts[i+1:]...)
+				break
+			}
+		}
+		pool.Spec.ScheduledStarts = append(pool.Spec.ScheduledStarts, start)
+		return c.Update(ctx, pool)
+	})
+}
+
+// forgetScheduledStart removes the scheduled start of a workspace from the pool once the workspace started
+func forgetScheduledStart(ctx context.Context, c client.Client, pool *workspacev1.WorkspacePool, workspaceID string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var current workspacev1.WorkspacePool
+		if err := c.Get(ctx, client.ObjectKeyFromObject(pool), &current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		for i, s := range current.Spec.ScheduledStarts {
+			if s.WorkspaceID == workspaceID {
+				current.Spec.ScheduledStarts = append(current.Spec.ScheduledStarts[:i], current.Spec.ScheduledStarts[i+1:]...)
+				return c.Update(ctx, &current)
+			}
+		}
+		return nil
+	})
+}
+
+// createPoolPod produces a pod which pulls the pool's image and reserves the resources of the pool's workspace class
+// on a node that workspaces of this class can be scheduled to. The pod must not take the resources on the nodes
+// which were claimed by starting workspaces.
+func createPoolPod(cfg *config.Configuration, pool *workspacev1.WorkspacePool, avoidNodes []string) (*corev1.Pod, error) {
+	class, ok := cfg.WorkspaceClasses[pool.Spec.Class]
+	if !ok {
+		return nil, xerrors.Errorf("unknown workspace class: %s", pool.Spec.Class)
+	}
+
+	requests, err := class.Container.Requests.ResourceList()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse workspace container requests: %w", err)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("pool-%s-", pool.Name),
+			Namespace:    pool.Namespace,
+			Labels: map[string]string{
+				workspacev1.WorkspacePoolLabel: pool.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			AutomountServiceAccountToken:  pointer.Bool(false),
+			EnableServiceLinks:            pointer.Bool(false),
+			SchedulerName:                 cfg.SchedulerName,
+			Affinity:                      workspaceNodeAffinity(cfg, false),
+			Tolerations:                   workspaceTolerations(),
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: pointer.Int64(0),
+			InitContainers: []corev1.Container{
+				{
+					// Workspace images are required to contain a shell. Running it ensures the image has been
+					// pulled and unpacked before the pod becomes ready. Like workspace pods, pool pods pull
+					// through registry-facade, which uses its pull credentials and adds the IDE layers.
+					Name:            "pull",
+					Image:           poolPodImage(cfg, pool),
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"/bin/sh", "-c", "true"},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            "reserve",
+					Image:           poolPodReserveImage,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Resources: corev1.ResourceRequirements{
+						Requests: requests,
+					},
+				},
+			},
+		},
+	}
+
+	// Pool pods must end up on the same nodes as the workspaces of their class,
+	// hence we apply the scheduling constraints of the class' pod templates.
+	tpl, err := getWorkspacePodTemplate(class, workspacev1.WorkspaceTypeRegular)
+	if err != nil {
+		return nil, err
+	}
+	if tpl != nil {
+		err = combineDefiniteWorkspacePodWithTemplate(pod, &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Affinity:     tpl.Spec.Affinity,
+				NodeSelector: tpl.Spec.NodeSelector,
+				Tolerations:  tpl.Spec.Tolerations,
+			},
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("cannot apply pod template to pool pod: %w", err)
+		}
+	}
+	if len(avoidNodes) > 0 {
+		requireNodes(pod, corev1.NodeSelectorOpNotIn, avoidNodes...)
+	}
+
+	return pod, nil
+}
+
+// poolPodImage is the image through which pool pods pull the pool's workspace and IDE images from registry-facade
+func poolPodImage(cfg *config.Configuration, pool *workspacev1.WorkspacePool) string {
+	return fmt.Sprintf("%s/%s/%s%s", cfg.RegistryFacadeHost, regapi.ProviderPrefixRemote, workspacev1.WorkspacePoolImageSpecPrefix, pool.Name)
+}
+
+// poolClaim is the outcome of a workspace looking for a pool pod to take over
+type poolClaim struct {
+	// Pool is the pool which matches the workspace
+	Pool *workspacev1.WorkspacePool
+	// Record describes the pool pod the workspace took over. It is nil if the pool had no ready pod.
+	Record *poolClaimRecord
+}
+
+// poolClaimRecord is stored on the workspace in the WorkspacePoolClaimAnnotation
+type poolClaimRecord struct {
+	// Node is the node of the pool pod, which the workspace pod has to run on
+	Node string `json:"node"`
+	// TimeSaved is the time it took the pool pod to be scheduled and pull its image
+	TimeSaved metav1.Duration `json:"timeSaved"`
+}
+
+// claimPoolPod releases the oldest ready pod of the pool which matches the workspace's class and image,
+// such that the workspace pod can take its place. It returns nil if no pool matches the workspace.
+//
+// The claim is recorded on the workspace before the pool pod is released. This keeps the pool from placing
+// a replacement pod on the claimed node, and makes a workspace claim at most one pool pod even if creating
+// its pod is retried.
+func claimPoolPod(ctx context.Context, c client.Client, ws *workspacev1.Workspace) (*poolClaim, error) {
+	if ws.Spec.Type != workspacev1.WorkspaceTypeRegular || ws.Spec.Image.Workspace.Ref == nil {
+		return nil, nil
+	}
+
+	if name, ok := ws.Labels[workspacev1.WorkspacePoolClaimLabel]; ok {
+		// The workspace claimed a pool pod before, e.g. when creating its pod failed.
+		var record poolClaimRecord
+		if err := json.Unmarshal([]byte(ws.Annotations[workspacev1.WorkspacePoolClaimAnnotation]), &record); err != nil {
+			return nil, fmt.Errorf("invalid pool claim: %w", err)
+		}
+		return &poolClaim{
+			Pool: &workspacev1.WorkspacePool{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ws.Namespace},
+				Spec:       workspacev1.WorkspacePoolSpec{Class: ws.Spec.Class, Image: *ws.Spec.Image.Workspace.Ref},
+			},
+			Record: &record,
+		}, nil
+	}
+
+	pool, err := findPool(ctx, c, ws)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, nil
+	}
+	claim := &poolClaim{Pool: pool}
+
+	for _, s := range pool.Spec.ScheduledStarts {
+		if s.WorkspaceID == ws.Spec.Ownership.WorkspaceID {
+			// The workspace is starting, the pool does not need to keep a pod for it anymore.
+			if err := forgetScheduledStart(ctx, c, pool, s.WorkspaceID); err != nil {
+				return nil, fmt.Errorf("failed to remove scheduled start: %w", err)
+			}
+			break
+		}
+	}
+
+	var pods corev1.PodList
+	err = c.List(ctx, &pods, client.InNamespace(claim.Pool.Namespace), client.MatchingLabels{workspacev1.WorkspacePoolLabel: claim.Pool.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pool pods: %w", err)
+	}
+	var candidates []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && pod.Spec.NodeName != "" && isPodReady(pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+	})
+
+	for _, pod := range candidates {
+		record := &poolClaimRecord{
+			Node:      pod.Spec.NodeName,
+			TimeSaved: metav1.Duration{Duration: poolPodTimeSaved(pod)},
+		}
+		if err := setPoolClaim(ctx, c, ws, pool.Name, record); err != nil {
+			return nil, fmt.Errorf("failed to record pool claim: %w", err)
+		}
+
+		// Without a grace period the pod is removed right away, hence only one of several concurrently
+		// starting workspaces can succeed in deleting, and thereby claiming, it.
+		err := c.Delete(ctx, pod, client.GracePeriodSeconds(0), client.Preconditions{UID: &pod.UID})
+		if err == nil {
+			claim.Record = record
+			break
+		}
+
+		if rerr := setPoolClaim(ctx, c, ws, "", nil); rerr != nil {
+			return nil, fmt.Errorf("failed to remove pool claim: %w", rerr)
+		}
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			continue
+		}
+		return nil, fmt.Errorf("failed to release pool pod: %w", err)
+	}
+	return claim, nil
+}
+
+// setPoolClaim records the claim of a pool pod on the workspace, or removes it if record is nil
+func setPoolClaim(ctx context.Context, c client.Client, ws *workspacev1.Workspace, pool string, record *poolClaimRecord) error {
+	patch := client.MergeFrom(ws.DeepCopy())
+	if record == nil {
+		delete(ws.Labels, workspacev1.WorkspacePoolClaimLabel)
+		delete(ws.Annotations, workspacev1.WorkspacePoolClaimAnnotation)
+	} else {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if ws.Labels == nil {
+			ws.Labels = make(map[string]string)
+		}
+		if ws.Annotations == nil {
+			ws.Annotations = make(map[string]string)
+		}
+		ws.Labels[workspacev1.WorkspacePoolClaimLabel] = pool
+		ws.Annotations[workspacev1.WorkspacePoolClaimAnnotation] = string(value)
+	}
+	return c.Patch(ctx, ws, patch)
+}
+
+// claimedNodes returns the nodes of the pool's pods which were claimed by workspaces whose pods have not
+// been scheduled yet. The resources on these nodes are reserved for the workspaces.
+func claimedNodes(ctx context.Context, c client.Client, pool *workspacev1.WorkspacePool) ([]string, error) {
+	var workspaces workspacev1.WorkspaceList
+	err := c.List(ctx, &workspaces, client.InNamespace(pool.Namespace), client.MatchingLabels{workspacev1.WorkspacePoolClaimLabel: pool.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces which claimed pool pods: %w", err)
+	}
+
+	var nodes []string
+	for _, ws := range workspaces.Items {
+		if ws.IsStopped() || (ws.Status.Runtime != nil && ws.Status.Runtime.NodeName != "") {
+			continue
+		}
+		var record poolClaimRecord
+		if err := json.Unmarshal([]byte(ws.Annotations[workspacev1.WorkspacePoolClaimAnnotation]), &record); err != nil || record.Node == "" {
+			continue
+		}
+		nodes = append(nodes, record.Node)
+	}
+	return nodes, nil
+}
+
+// findPool returns the pool which matches the class and image of a regular workspace, or nil if there is none
+func findPool(ctx context.Context, c client.Client, ws *workspacev1.Workspace) (*workspacev1.WorkspacePool, error) {
+	var pools workspacev1.WorkspacePoolList
+	if err := c.List(ctx, &pools, client.InNamespace(ws.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list workspace pools: %w", err)
+	}
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Spec.Class == ws.Spec.Class && pool.Spec.Image == *ws.Spec.Image.Workspace.Ref {
+			return pool, nil
+		}
+	}
+	return nil, nil
+}
+
+// poolPodTimeSaved is the time it took a pool pod to be scheduled and pull its image
+func poolPodTimeSaved(pod *corev1.Pod) time.Duration {
+	readyTime, ok := podReadyTime(pod)
+	if !ok {
+		return 0
+	}
+	return readyTime.Sub(pod.CreationTimestamp.Time)
+}
+
+// requireNodes adds a node name requirement to the required node affinity of a pod,
+// e.g. to make the pod run on the node of a claimed pool pod, or to keep it off certain nodes.
+func requireNodes(pod *corev1.Pod, op corev1.NodeSelectorOperator, nodes ...string) {
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	na := pod.Spec.Affinity.NodeAffinity
+	if na.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		na.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	terms := na.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(terms.NodeSelectorTerms) == 0 {
+		terms.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+
+	// Node selector terms are ORed, hence the requirement has to be part of every term.
+	req := corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: op,
+		Values:   nodes,
+	}
+	for i := range terms.NodeSelectorTerms {
+		terms.NodeSelectorTerms[i].MatchFields = append(terms.NodeSelectorTerms[i].MatchFields, req)
+	}
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	_, ok := podReadyTime(pod)
+	return ok
+}
+
+func podReadyTime(pod *corev1.Pod) (time.Time, bool) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return c.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkspacePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspacepool").
+		For(&workspacev1.WorkspacePool{}).
+		Owns(&corev1.Pod{}).
+		Complete(r)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("WorkspacePoolController", func() {
+	Context("with workspace pools", func() {
+		It("should keep the pool at its size", func() {
+			pool := createWorkspacePool(2)
+			pods := expectPoolPodsEventually(pool, 2)
+
+			By("checking the pool pods")
+			for _, pod := range pods {
+				Expect(pod.Spec.InitContainers).To(HaveLen(1))
+				Expect(pod.Spec.InitContainers[0].Image).To(Equal("registry-facade:30000/remote/" + workspacev1.WorkspacePoolImageSpecPrefix + pool.Name))
+				Expect(pod.Spec.Containers).To(HaveLen(1))
+				Expect(pod.Spec.Containers[0].Image).To(Equal(poolPodReserveImage))
+				Expect(metav1.IsControlledBy(&pod, pool)).To(BeTrue())
+			}
+			expectPoolStatusEventually(pool, workspacev1.WorkspacePoolStatus{Pending: 2})
+
+			By("marking a pool pod ready")
+			markPoolPodReady(&pods[0])
+			expectPoolStatusEventually(pool, workspacev1.WorkspacePoolStatus{Ready: 1, Pending: 1})
+
+			By("failing a pool pod")
+			updateObjWithRetries(k8sClient, &pods[1], true, func(pod *corev1.Pod) {
+				pod.Status.Phase = corev1.PodFailed
+			})
+			Eventually(func() error {
+				return checkNotFound(&pods[1])
+			}, timeout, interval).Should(Succeed(), "failed pool pod was not deleted")
+			expectPoolPodsEventually(pool, 2)
+
+			By("scaling the pool down")
+			updateObjWithRetries(k8sClient, pool, false, func(pool *workspacev1.WorkspacePool) {
+				pool.Spec.Size = 0
+			})
+			expectPoolPodsEventually(pool, 0)
+			expectPoolStatusEventually(pool, workspacev1.WorkspacePoolStatus{})
+		})
+
+		It("should hand over ready pool pods to matching workspaces", func() {
+			pool := createWorkspacePool(1)
+			pods := expectPoolPodsEventually(pool, 1)
+			const node = "pool-node"
+			bindPoolPod(&pods[0], node)
+			markPoolPodReady(&pods[0])
+			expectPoolStatusEventually(pool, workspacev1.WorkspacePoolStatus{Ready: 1})
+
+			hits := testutil.ToFloat64(wsMetrics.totalPoolHitsCounterVec.WithLabelValues(pool.Name, pool.Spec.Class))
+			misses := testutil.ToFloat64(wsMetrics.totalPoolMissesCounterVec.WithLabelValues(pool.Name, pool.Spec.Class))
+
+			ws := newWorkspace(uuid.NewString(), "default")
+			ws.Spec.Image.Workspace.Ref = &pool.Spec.Image
+			wsPod := createWorkspaceExpectPod(ws)
+
+			By("controller releasing the pool pod")
+			Eventually(func() error {
+				return checkNotFound(&pods[0])
+			}, timeout, interval).Should(Succeed(), "pool pod was not claimed")
+			Eventually(func() float64 {
+				return testutil.ToFloat64(wsMetrics.totalPoolHitsCounterVec.WithLabelValues(pool.Name, pool.Spec.Class))
+			}, timeout, interval).Should(Equal(hits + 1))
+
+			By("requiring the node of the pool pod for the workspace pod")
+			Expect(requiredNodes(wsPod, corev1.NodeSelectorOpIn)).To(Equal([]string{node}))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, ws)).To(Succeed())
+			Expect(ws.Labels).To(HaveKeyWithValue(workspacev1.WorkspacePoolClaimLabel, pool.Name))
+
+			By("controller replacing the claimed pool pod on another node")
+			pods = expectPoolPodsEventually(pool, 1)
+			expectPoolStatusEventually(pool, workspacev1.WorkspacePoolStatus{Pending: 1})
+			Expect(requiredNodes(&pods[0], corev1.NodeSelectorOpNotIn)).To(Equal([]string{node}))
+
+			By("starting another workspace while no pool pod is ready")
+			ws = newWorkspace(uuid.NewString(), "default")
+			ws.Spec.Image.Workspace.Ref = &pool.Spec.Image
+			createWorkspaceExpectPod(ws)
+			Eventually(func() float64 {
+				return testutil.ToFloat64(wsMetrics.totalPoolMissesCounterVec.WithLabelValues(pool.Name, pool.Spec.Class))
+			}, timeout, interval).Should(Equal(misses + 1))
+			expectPoolPodsEventually(pool, 1)
+		})
+
+		It("should warm up scheduled starts", func() {
+			now := time.Now()
+			name := uuid.NewString()
+			pool := &workspacev1.WorkspacePool{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: workspacev1.WorkspacePoolSpec{
+					Class: "default",
+					Image: fmt.Sprintf("pool-%s:latest", name),
+					ScheduledStarts: []workspacev1.ScheduledStart{
+						{WorkspaceID: "passed", Time: metav1.NewTime(now.Add(-time.Minute))},
+						{WorkspaceID: "due", Time: metav1.NewTime(now.Add(5 * time.Minute))},
+						{WorkspaceID: "later", Time: metav1.NewTime(now.Add(2 * time.Hour))},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+
+			expectPoolPodsEventually(pool, 1)
+			By("controller removing passed scheduled starts")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}, pool)).To(Succeed())
+				var ids []string
+				for _, s := range pool.Spec.ScheduledStarts {
+					ids = append(ids, s.WorkspaceID)
+				}
+				g.Expect(ids).To(Equal([]string{"due", "later"}))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
+
+func createWorkspacePool(size int32) *workspacev1.WorkspacePool {
+	GinkgoHelper()
+	name := uuid.NewString()
+	pool := &workspacev1.WorkspacePool{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "workspace.gitpod.io/v1",
+			Kind:       "WorkspacePool",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: workspacev1.WorkspacePoolSpec{
+			Class: "default",
+			// Use an image no other test uses, such that other workspaces don't claim the pool's pods.
+			Image: fmt.Sprintf("pool-%s:latest", name),
+			Size:  size,
+		},
+	}
+
+	By("creating workspace pool")
+	Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+	return pool
+}
+
+func expectPoolPodsEventually(pool *workspacev1.WorkspacePool, count int) []corev1.Pod {
+	GinkgoHelper()
+	By(fmt.Sprintf("controller keeping %d pool pods", count))
+	var pods corev1.PodList
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.List(ctx, &pods, client.InNamespace(pool.Namespace), client.MatchingLabels{workspacev1.WorkspacePoolLabel: pool.Name})).To(Succeed())
+		var active int
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp == nil {
+				active++
+			}
+		}
+		g.Expect(active).To(Equal(count))
+	}, timeout, interval).Should(Succeed())
+	return pods.Items
+}
+
+func expectPoolStatusEventually(pool *workspacev1.WorkspacePool, status workspacev1.WorkspacePoolStatus) {
+	GinkgoHelper()
+	By(fmt.Sprintf("controller setting pool status to %+v", status))
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}, pool)).To(Succeed())
+		g.Expect(pool.Status).To(Equal(status))
+	}, timeout, interval).Should(Succeed())
+}
+
+func bindPoolPod(pod *corev1.Pod, node string) {
+	GinkgoHelper()
+	Expect(k8sClient.SubResource("binding").Create(ctx, pod, &corev1.Binding{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		Target:     corev1.ObjectReference{Kind: "Node", Name: node},
+	})).To(Succeed())
+}
+
+// requiredNodes returns the node names the pod's required node affinity matches with the operator
+func requiredNodes(pod *corev1.Pod, op corev1.NodeSelectorOperator) []string {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	var nodes []string
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, req := range term.MatchFields {
+			if req.Key == "metadata.name" && req.Operator == op {
+				nodes = append(nodes, req.Values...)
+			}
+		}
+	}
+	return nodes
+}
+
+func markPoolPodReady(pod *corev1.Pod) {
+	GinkgoHelper()
+	updateObjWithRetries(k8sClient, pod, true, func(pod *corev1.Pod) {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		}}
+	})
+}
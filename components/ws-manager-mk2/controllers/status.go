@@ -47,7 +47,7 @@ func (r *WorkspaceReconciler) updateWorkspaceStatus(ctx context.Context, workspa
 		}
 
 		if workspace.Status.Phase == workspacev1.WorkspacePhaseStopping && isDisposalFinished(workspace) {
-			workspace.Status.Phase = workspacev1.WorkspacePhaseStopped
+			workspace.Status.Phase = stoppedPhase(workspace)
 		}
 		return nil
 	case 1:
@@ -128,8 +128,8 @@ func (r *WorkspaceReconciler) updateWorkspaceStatus(ctx context.Context, workspa
 	switch {
 	case isPodBeingDeleted(pod):
 		if workspace.Status.Phase == workspacev1.WorkspacePhaseStopping && isDisposalFinished(workspace) {
-			workspace.Status.Phase = workspacev1.WorkspacePhaseStopped
-		} else if workspace.Status.Phase != workspacev1.WorkspacePhaseStopped {
+			workspace.Status.Phase = stoppedPhase(workspace)
+		} else if !workspace.IsStopped() {
 			// Move to (or stay in) Stopping if not yet Stopped.
 			workspace.Status.Phase = workspacev1.WorkspacePhaseStopping
 		}
@@ -140,7 +140,9 @@ func (r *WorkspaceReconciler) updateWorkspaceStatus(ctx context.Context, workspa
 		for _, cs := range pod.Status.ContainerStatuses {
 			if cs.State.Waiting != nil {
 				switch cs.State.Waiting.Reason {
-				case "ContainerCreating", "ImagePullBackOff", "ErrImagePull":
+				// the workspace container of a workspace which restores its checkpoint waits for the restore
+				// init container, which waits for content initialization
+				case "ContainerCreating", "ImagePullBackOff", "ErrImagePull", "PodInitializing":
 					creating = true
 				}
 
@@ -195,8 +197,8 @@ func (r *WorkspaceReconciler) updateWorkspaceStatus(ctx context.Context, workspa
 		}
 
 		if workspace.Status.Phase == workspacev1.WorkspacePhaseStopping && isDisposalFinished(workspace) {
-			workspace.Status.Phase = workspacev1.WorkspacePhaseStopped
-		} else if workspace.Status.Phase != workspacev1.WorkspacePhaseStopped {
+			workspace.Status.Phase = stoppedPhase(workspace)
+		} else if !workspace.IsStopped() {
 			// Should be in Stopping phase, but isn't yet.
 			// Move to Stopping to start disposal, but only if maintenance mode is disabled.
 			if !r.maintenance.IsEnabled(ctx) {
@@ -258,6 +260,16 @@ func isDisposalFinished(ws *workspacev1.Workspace) bool {
 		ws.IsConditionTrue(workspacev1.WorkspaceConditionsHeadlessTaskFailed)
 }
 
+// stoppedPhase returns the final phase of a workspace whose disposal has finished. A workspace is only
+// considered hibernated if both its process state and its content made it to remote storage.
+func stoppedPhase(ws *workspacev1.Workspace) workspacev1.WorkspacePhase {
+	if ws.IsConditionTrue(workspacev1.WorkspaceConditionCheckpointComplete) &&
+		ws.IsConditionTrue(workspacev1.WorkspaceConditionBackupComplete) {
+		return workspacev1.WorkspacePhaseHibernated
+	}
+	return workspacev1.WorkspacePhaseStopped
+}
+
 // extractFailure returns a pod failure reason and possibly a phase. If phase is nil then
 // one should extract the phase themselves. If the pod has not failed, this function returns "", nil.
 // This failure is then stored in the Failed condition on the workspace.
@@ -411,6 +423,12 @@ func extractFailureFromLogs(logs []byte) string {
 	return string(logs)
 }
 
+// isCheckpointFinished returns true if ws-daemon is done checkpointing a hibernating workspace, regardless of the outcome.
+func isCheckpointFinished(ws *workspacev1.Workspace) bool {
+	return ws.IsConditionTrue(workspacev1.WorkspaceConditionCheckpointComplete) ||
+		ws.IsConditionTrue(workspacev1.WorkspaceConditionCheckpointFailure)
+}
+
 // isPodBeingDeleted returns true if the pod is currently being deleted
 func isPodBeingDeleted(pod *corev1.Pod) bool {
 	// if the pod is being deleted the only marker we have is that the deletionTimestamp is set
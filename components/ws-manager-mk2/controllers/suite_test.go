@@ -116,6 +116,10 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(timeoutReconciler.SetupWithManager(k8sManager)).To(Succeed())
 
+	poolReconciler, err := NewWorkspacePoolReconciler(k8sManager.GetClient(), k8sManager.GetScheme(), k8sManager.GetEventRecorderFor("workspacepool"), &conf)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(poolReconciler.SetupWithManager(k8sManager)).To(Succeed())
+
 	ctx, cancel = context.WithCancel(context.Background())
 	_ = createNamespace(secretsNamespace)
 
@@ -129,11 +133,12 @@ var _ = BeforeSuite(func() {
 
 func newTestConfig() config.Configuration {
 	return config.Configuration{
-		GitpodHostURL:     "gitpod.io",
-		HeartbeatInterval: util.Duration(30 * time.Second),
-		Namespace:         "default",
-		SecretsNamespace:  secretsNamespace,
-		SeccompProfile:    "default.json",
+		GitpodHostURL:      "gitpod.io",
+		HeartbeatInterval:  util.Duration(30 * time.Second),
+		Namespace:          "default",
+		SecretsNamespace:   secretsNamespace,
+		SeccompProfile:     "default.json",
+		RegistryFacadeHost: "registry-facade:30000",
 		Timeouts: config.WorkspaceTimeoutConfiguration{
 			AfterClose:          util.Duration(1 * time.Minute),
 			Initialization:      util.Duration(30 * time.Minute),
@@ -11,6 +11,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
@@ -22,6 +23,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	wsactivity "github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/activity"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/maintenance"
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/schedule"
 	config "github.com/gitpod-io/gitpod/ws-manager/api/config"
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 )
@@ -99,28 +101,51 @@ func (r *TimeoutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		result.RequeueAfter = r.reconcileInterval
 	}()
 
-	timedout := r.isWorkspaceTimedOut(&workspace)
-	if timedout == "" {
+	now := time.Now()
+	var sched *schedule.Schedule
+	if workspace.Spec.Timeout.Schedule != nil {
+		sched, err = schedule.Parse(workspace.Spec.Timeout.Schedule)
+		if err != nil {
+			log.Error(err, "invalid workspace schedule")
+			return ctrl.Result{}, nil
+		}
+	}
+	if err := r.updateNextScheduledStart(ctx, &workspace, sched, now); err != nil {
+		return errorResultLogConflict(log, fmt.Errorf("failed to update next scheduled start: %w", err))
+	}
+
+	var (
+		condition metav1.Condition
+		event     string
+	)
+	if timedout := r.isWorkspaceTimedOut(&workspace); timedout != "" {
+		log.Info("Workspace timed out", "reason", timedout)
+		condition = workspacev1.NewWorkspaceConditionTimeout(timedout)
+		event = "TimedOut"
+	} else if scheduled := isWorkspaceScheduledToStop(&workspace, sched, now); scheduled != "" {
+		log.Info("Workspace stopped by schedule", "reason", scheduled)
+		condition = workspacev1.NewWorkspaceConditionScheduledStop(scheduled)
+		event = "ScheduledStop"
+	} else {
 		// Hasn't timed out.
 		return ctrl.Result{}, nil
 	}
 
 	// Workspace timed out, set Timeout condition.
-	log.Info("Workspace timed out", "reason", timedout)
 	if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		err := r.Get(ctx, types.NamespacedName{Name: workspace.Name, Namespace: workspace.Namespace}, &workspace)
 		if err != nil {
 			return err
 		}
 
-		workspace.Status.SetCondition(workspacev1.NewWorkspaceConditionTimeout(timedout))
+		workspace.Status.SetCondition(condition)
 		return r.Status().Update(ctx, &workspace)
 	}); err != nil {
 		log.Error(err, "Failed to update workspace status with Timeout condition")
 		return ctrl.Result{}, fmt.Errorf("failed to add timeout condition: %w", err)
 	}
 
-	r.recorder.Event(&workspace, corev1.EventTypeNormal, "TimedOut", timedout)
+	r.recorder.Event(&workspace, corev1.EventTypeNormal, event, condition.Message)
 	return ctrl.Result{}, nil
 }
 
@@ -229,6 +254,41 @@ func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace) (reas
 	}
 }
 
+// isWorkspaceScheduledToStop determines if a running workspace has to stop because of its schedule, i.e. if a
+// scheduled stop passed since the workspace was created or if the workspace is within a blackout window.
+func isWorkspaceScheduledToStop(ws *workspacev1.Workspace, sched *schedule.Schedule, now time.Time) (reason string) {
+	if ws.Status.Phase != workspacev1.WorkspacePhaseRunning || sched == nil {
+		return ""
+	}
+
+	const layout = "Mon, 02 Jan 2006 15:04 MST"
+	if stop := sched.NextStop(ws.CreationTimestamp.Time); !stop.IsZero() && !stop.After(now) {
+		return fmt.Sprintf("workspace stopped by its schedule at %s", stop.Format(layout))
+	}
+	if end, blocked := sched.Blackout(now); blocked {
+		return fmt.Sprintf("workspace must not run until %s according to its schedule", end.Format(layout))
+	}
+	return ""
+}
+
+// updateNextScheduledStart records when the workspace's schedule next asks for it to be started,
+// such that the status can be reported and the start warmed up without parsing the schedule again.
+func (r *TimeoutReconciler) updateNextScheduledStart(ctx context.Context, ws *workspacev1.Workspace, sched *schedule.Schedule, now time.Time) error {
+	var next *metav1.Time
+	if sched != nil {
+		if t := sched.NextStart(now); !t.IsZero() {
+			next = &metav1.Time{Time: t}
+		}
+	}
+	if ws.Status.NextScheduledStart.Equal(next) {
+		return nil
+	}
+
+	patch := client.MergeFrom(ws.DeepCopy())
+	ws.Status.NextScheduledStart = next
+	return r.Status().Patch(ctx, ws, patch)
+}
+
 func (r *TimeoutReconciler) getMaxLifetime(ws *workspacev1.Workspace) util.Duration {
 	if ws.Spec.Timeout.MaximumLifetime != nil {
 		return util.Duration(ws.Spec.Timeout.MaximumLifetime.Duration)
@@ -42,6 +42,9 @@ const (
 	// kubernetesOperationTimeout is the time we give Kubernetes operations in general.
 	kubernetesOperationTimeout = 5 * time.Second
 	maintenanceRequeue         = 1 * time.Minute
+	// hibernationCheckpointTimeout is the time we give ws-daemon to checkpoint a hibernating workspace
+	// before we stop it without checkpoint.
+	hibernationCheckpointTimeout = 5 * time.Minute
 )
 
 func NewWorkspaceReconciler(c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, cfg *config.Configuration, reg prometheus.Registerer, maintenance maintenance.Maintenance) (*WorkspaceReconciler, error) {
@@ -80,6 +83,7 @@ type WorkspaceReconciler struct {
 //+kubebuilder:rbac:groups=workspace.gitpod.io,resources=workspaces/finalizers,verbs=update
 //+kubebuilder:rbac:groups=core,resources=pod,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=pod/status,verbs=get
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=workspacepools,verbs=get;list;watch;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -155,7 +159,7 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *workspacev1.Workspace, workspacePods corev1.PodList) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
-	if workspace.Status.Phase != workspacev1.WorkspacePhaseStopped && !r.metrics.containsWorkspace(workspace) {
+	if !workspace.IsStopped() && !r.metrics.containsWorkspace(workspace) {
 		// If the workspace hasn't stopped yet, and we don't know about this workspace yet, remember it.
 		r.metrics.rememberWorkspace(workspace, nil)
 	}
@@ -180,6 +184,17 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 				return ctrl.Result{}, err
 			}
 
+			claim, err := claimPoolPod(ctx, r.Client, workspace)
+			if err != nil {
+				// Pools only speed up workspace starts, the workspace can start without one.
+				log.Error(err, "unable to claim pool pod")
+			}
+			if claim != nil && claim.Record != nil {
+				// The pool pod's resources were released for this workspace, which must hence take its place.
+				log.Info("claimed pool pod", "pool", claim.Pool.Name, "node", claim.Record.Node)
+				requireNodes(pod, corev1.NodeSelectorOpIn, claim.Record.Node)
+			}
+
 			log.Info("creating workspace Pod for Workspace")
 			err = r.Create(ctx, pod)
 			if errors.IsAlreadyExists(err) {
@@ -204,9 +219,17 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 				}
 
 				r.Recorder.Event(workspace, corev1.EventTypeNormal, "Creating", "")
+
+				if claim != nil && claim.Record != nil {
+					// The pod can only run on the claimed node, hence it reuses the pool pod's image and resources.
+					r.metrics.countPoolHit(&log, claim.Pool, claim.Record.TimeSaved.Duration)
+				} else if claim != nil {
+					// Only workspaces which match a pool count as misses, all others never had a chance to hit.
+					r.metrics.countPoolMiss(&log, claim.Pool)
+				}
 			}
 
-		case workspace.Status.Phase == workspacev1.WorkspacePhaseStopped:
+		case workspace.IsStopped():
 			if err := r.deleteWorkspaceSecrets(ctx, workspace); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -223,6 +246,11 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 				}
 			}
 
+			if err := recordScheduledStart(ctx, r.Client, workspace, time.Now()); err != nil {
+				// Warming up only speeds up the next start, the workspace must stop regardless.
+				log.Error(err, "unable to warm up scheduled start")
+			}
+
 			// Workspace might have already been in a deleting state,
 			// but not guaranteed, so try deleting anyway.
 			r.Recorder.Event(workspace, corev1.EventTypeNormal, "Deleting", "")
@@ -246,21 +274,18 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 
 	// if the pod was stopped by request, delete it
 	case workspace.IsConditionTrue(workspacev1.WorkspaceConditionStoppedByRequest) && !isPodBeingDeleted(pod):
-		var gracePeriodSeconds *int64
-		if c := wsk8s.GetCondition(workspace.Status.Conditions, string(workspacev1.WorkspaceConditionStoppedByRequest)); c != nil {
-			if dt, err := time.ParseDuration(c.Message); err == nil {
-				s := int64(dt.Seconds())
-				gracePeriodSeconds = &s
+		return r.stopWorkspacePod(ctx, workspace, pod, workspacev1.WorkspaceConditionStoppedByRequest)
+
+	// if the workspace is hibernating, delete the pod once ws-daemon is done checkpointing it
+	case workspace.IsConditionTrue(workspacev1.WorkspaceConditionHibernationRequested) && !isPodBeingDeleted(pod):
+		if !isCheckpointFinished(workspace) {
+			c := wsk8s.GetCondition(workspace.Status.Conditions, string(workspacev1.WorkspaceConditionHibernationRequested))
+			if remaining := hibernationCheckpointTimeout - time.Since(c.LastTransitionTime.Time); remaining > 0 {
+				return ctrl.Result{RequeueAfter: remaining}, nil
 			}
+			log.Info("workspace was not checkpointed in time, stopping it without checkpoint")
 		}
-		err := r.Client.Delete(ctx, pod, &client.DeleteOptions{
-			GracePeriodSeconds: gracePeriodSeconds,
-		})
-		if errors.IsNotFound(err) {
-			// pod is gone - nothing to do here
-		} else {
-			return ctrl.Result{Requeue: true}, err
-		}
+		return r.stopWorkspacePod(ctx, workspace, pod, workspacev1.WorkspaceConditionHibernationRequested)
 
 	// if the node disappeared, delete the pod.
 	case workspace.IsConditionTrue(workspacev1.WorkspaceConditionNodeDisappeared) && !isPodBeingDeleted(pod):
@@ -277,7 +302,7 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 	case isWorkspaceBeingDeleted(workspace) && !isPodBeingDeleted(pod):
 		return r.deleteWorkspacePod(ctx, pod, "workspace deleted")
 
-	case workspace.IsHeadless() && workspace.Status.Phase == workspacev1.WorkspacePhaseStopped && !isPodBeingDeleted(pod):
+	case workspace.IsHeadless() && workspace.IsStopped() && !isPodBeingDeleted(pod):
 		// Workspace was requested to be deleted, propagate by deleting the Pod.
 		// The Pod deletion will then trigger workspace disposal steps.
 		err := r.Client.Delete(ctx, pod)
@@ -294,7 +319,7 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 		}
 
 	// we've disposed already - try to remove the finalizer and call it a day
-	case workspace.Status.Phase == workspacev1.WorkspacePhaseStopped:
+	case workspace.IsStopped():
 		hadFinalizer := controllerutil.ContainsFinalizer(pod, workspacev1.GitpodFinalizerName)
 		controllerutil.RemoveFinalizer(pod, workspacev1.GitpodFinalizerName)
 		if err := r.Client.Update(ctx, pod); err != nil {
@@ -349,7 +374,7 @@ func (r *WorkspaceReconciler) updateMetrics(ctx context.Context, workspace *work
 		lastState.recordedStartTime = true
 	}
 
-	if workspace.Status.Phase == workspacev1.WorkspacePhaseStopped {
+	if workspace.IsStopped() {
 		r.metrics.countWorkspaceStop(&log, workspace)
 
 		if !lastState.recordedStartFailure && isStartFailure(workspace) {
@@ -391,6 +416,26 @@ func (r *WorkspaceReconciler) emitPhaseEvents(ctx context.Context, ws *workspace
 	}
 }
 
+// stopWorkspacePod deletes the pod of a workspace which was stopped using a StopWorkspace call. The condition
+// set by the call contains the grace period.
+func (r *WorkspaceReconciler) stopWorkspacePod(ctx context.Context, ws *workspacev1.Workspace, pod *corev1.Pod, condition workspacev1.WorkspaceCondition) (ctrl.Result, error) {
+	var gracePeriodSeconds *int64
+	if c := wsk8s.GetCondition(ws.Status.Conditions, string(condition)); c != nil {
+		if dt, err := time.ParseDuration(c.Message); err == nil {
+			s := int64(dt.Seconds())
+			gracePeriodSeconds = &s
+		}
+	}
+	err := r.Client.Delete(ctx, pod, &client.DeleteOptions{
+		GracePeriodSeconds: gracePeriodSeconds,
+	})
+	if errors.IsNotFound(err) {
+		// pod is gone - nothing to do here
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{Requeue: true}, err
+}
+
 func (r *WorkspaceReconciler) deleteWorkspacePod(ctx context.Context, pod *corev1.Pod, reason string) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("pod", pod.Name, "reason", reason)
 	log.Info("deleting workspace pod")
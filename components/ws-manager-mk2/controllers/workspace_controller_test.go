@@ -106,6 +106,50 @@ var _ = Describe("WorkspaceController", func() {
 			})
 		})
 
+		It("should hibernate workspaces once they were checkpointed", func() {
+			ws := newWorkspace(uuid.NewString(), "default")
+			pod := createWorkspaceExpectPod(ws)
+			markReady(ws)
+
+			By("requesting hibernation")
+			updateObjWithRetries(k8sClient, ws, true, func(ws *workspacev1.Workspace) {
+				ws.Status.SetCondition(workspacev1.NewWorkspaceConditionHibernationRequested(""))
+			})
+
+			By("waiting for the checkpoint")
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pod.GetName(), Namespace: pod.GetNamespace()}, pod)).To(Succeed())
+				g.Expect(pod.DeletionTimestamp).To(BeNil())
+			}, duration, interval).Should(Succeed(), "pod was deleted before the workspace was checkpointed")
+
+			By("signalling checkpoint completed")
+			updateObjWithRetries(k8sClient, ws, true, func(ws *workspacev1.Workspace) {
+				ws.Status.SetCondition(workspacev1.NewWorkspaceConditionCheckpointComplete())
+			})
+
+			expectFinalizerAndMarkBackupCompleted(ws, pod)
+
+			expectWorkspaceCleanupInPhase(ws, pod, workspacev1.WorkspacePhaseHibernated)
+		})
+
+		It("should create the pod of a workspace which restores its checkpoint from the checkpoint image", func() {
+			ws := newWorkspace(uuid.NewString(), "default")
+			ws.Spec.RestoreCheckpoint = true
+			pod := createWorkspaceExpectPod(ws)
+
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			restore := pod.Spec.InitContainers[0]
+			Expect(restore.Name).To(Equal(workspacev1.RestoreContainerName))
+
+			workspace := pod.Spec.Containers[0]
+			Expect(restore.Image).NotTo(Equal(workspace.Image))
+			Expect(workspace.Image).To(Equal(ws.CheckpointImage()))
+			Expect(workspace.ImagePullPolicy).To(Equal(corev1.PullNever))
+
+			requestStop(ws)
+			expectWorkspaceCleanup(ws, pod)
+		})
+
 		It("should handle content init failure", func() {
 			ws := newWorkspace(uuid.NewString(), "default")
 			m := collectMetricCounts(wsMetrics, ws)
@@ -656,6 +700,11 @@ func expectFinalizerAndMarkBackupFailed(ws *workspacev1.Workspace, pod *corev1.P
 }
 
 func expectWorkspaceCleanup(ws *workspacev1.Workspace, pod *corev1.Pod) {
+	GinkgoHelper()
+	expectWorkspaceCleanupInPhase(ws, pod, workspacev1.WorkspacePhaseStopped)
+}
+
+func expectWorkspaceCleanupInPhase(ws *workspacev1.Workspace, pod *corev1.Pod, phase workspacev1.WorkspacePhase) {
 	GinkgoHelper()
 	By("controller removing pod finalizers")
 	Eventually(func() (int, error) {
@@ -695,8 +744,8 @@ func expectWorkspaceCleanup(ws *workspacev1.Workspace, pod *corev1.Pod) {
 		if err := checkNotFound(ws); err == nil {
 			return nil
 		}
-		g.Expect(ws.Status.Phase).To(Equal(workspacev1.WorkspacePhaseStopped))
-		return fmt.Errorf("workspace is Stopped, but hasn't been deleted yet")
+		g.Expect(ws.Status.Phase).To(Equal(phase))
+		return fmt.Errorf("workspace is %s, but hasn't been deleted yet", phase)
 	}, timeout, interval).Should(Succeed(), "workspace did not go away")
 }
 
@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sort"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,6 +38,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/pprof"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	imgbldr "github.com/gitpod-io/gitpod/image-builder/api"
 	regapi "github.com/gitpod-io/gitpod/registry-facade/api"
 	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
@@ -154,6 +157,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	poolReconciler, err := controllers.NewWorkspacePoolReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("workspacepool"), &cfg.Manager)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WorkspacePool")
+		os.Exit(1)
+	}
+
 	wsmanService, err := setupGRPCService(cfg, mgr.GetClient(), activity, maintenanceReconciler)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager service")
@@ -173,11 +182,17 @@ func main() {
 		setupLog.Error(err, "unable to setup maintenance controller with manager", "controller", "Maintenance")
 		os.Exit(1)
 	}
+	if err = poolReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to setup workspace pool controller with manager", "controller", "WorkspacePool")
+		os.Exit(1)
+	}
 
-	// if err = (&workspacev1.Workspace{}).SetupWebhookWithManager(mgr); err != nil {
-	// 	setupLog.Error(err, "unable to create webhook", "webhook", "Workspace")
-	// 	os.Exit(1)
-	// }
+	if !cfg.Webhook.Disabled {
+		if err = (&workspacev1.Workspace{}).SetupWebhookWithManager(mgr, newWorkspaceWebhook(&cfg.Manager)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Workspace")
+			os.Exit(1)
+		}
+	}
 
 	//+kubebuilder:scaffold:builder
 
@@ -253,7 +268,18 @@ func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activ
 		imgbldr.RegisterImageBuilderServer(grpcServer, imgproxy.ImageBuilder{D: imgbldr.NewImageBuilderClient(conn)})
 	}
 
-	srv := service.NewWorkspaceManagerServer(k8s, &cfg.Manager, metrics.Registry, activity, maintenance)
+	var contentService csapi.WorkspaceServiceClient
+	if cfg.ContentService.Addr != "" {
+		conn, err := grpc.Dial(cfg.ContentService.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.WithError(err).Fatal("failed to connect to content-service")
+		}
+		contentService = csapi.NewWorkspaceServiceClient(conn)
+	} else {
+		log.Warn("no content-service configured - snapshots cannot be deleted")
+	}
+
+	srv := service.NewWorkspaceManagerServer(k8s, &cfg.Manager, metrics.Registry, activity, maintenance, contentService)
 
 	grpc_prometheus.Register(grpcServer)
 	wsmanapi.RegisterWorkspaceManagerServer(grpcServer, srv)
@@ -277,6 +303,24 @@ func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activ
 	return srv, nil
 }
 
+// newWorkspaceWebhook makes the webhook default and validate workspaces with the workspace classes and timeouts of the configuration
+func newWorkspaceWebhook(cfg *config.Configuration) *workspacev1.WorkspaceWebhook {
+	wh := &workspacev1.WorkspaceWebhook{
+		DefaultClass: config.DefaultWorkspaceClass,
+		Timeouts: workspacev1.WorkspaceWebhookTimeouts{
+			RegularWorkspace:  time.Duration(cfg.Timeouts.RegularWorkspace),
+			HeadlessWorkspace: time.Duration(cfg.Timeouts.HeadlessWorkspace),
+			AfterClose:        time.Duration(cfg.Timeouts.AfterClose),
+			MaxLifetime:       time.Duration(cfg.Timeouts.MaxLifetime),
+		},
+	}
+	for name := range cfg.WorkspaceClasses {
+		wh.Classes = append(wh.Classes, name)
+	}
+	sort.Strings(wh.Classes)
+	return wh
+}
+
 func getConfig(fn string) (*config.ServiceConfiguration, error) {
 	ctnt, err := os.ReadFile(fn)
 	if err != nil {
@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+// maxStartCandidates limits how many scheduled starts we inspect to find one outside of all blackouts
+const maxStartCandidates = 1000
+
+// Schedule is the parsed form of a workspace's schedule
+type Schedule struct {
+	Location *time.Location
+
+	stop      []cron.Schedule
+	blackouts []window
+	start     []cron.Schedule
+}
+
+type window struct {
+	start    cron.Schedule
+	duration time.Duration
+}
+
+// Parse parses and validates a workspace schedule
+func Parse(spec *workspacev1.ScheduleSpec) (*Schedule, error) {
+	loc := time.UTC
+	if spec.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+		}
+	}
+
+	res := &Schedule{Location: loc}
+	for _, expr := range spec.Stop {
+		s, err := cron.ParseStandard(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stop schedule %q: %w", expr, err)
+		}
+		res.stop = append(res.stop, s)
+	}
+	for _, w := range spec.Blackouts {
+		s, err := cron.ParseStandard(w.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blackout schedule %q: %w", w.Start, err)
+		}
+		if w.Duration.Duration <= 0 {
+			return nil, fmt.Errorf("blackout %q must have a positive duration", w.Start)
+		}
+		res.blackouts = append(res.blackouts, window{start: s, duration: w.Duration.Duration})
+	}
+	for _, expr := range spec.Start {
+		s, err := cron.ParseStandard(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start schedule %q: %w", expr, err)
+		}
+		res.start = append(res.start, s)
+	}
+	return res, nil
+}
+
+// NextStop returns the first scheduled stop after t, or the zero time if there is none.
+func (s *Schedule) NextStop(t time.Time) time.Time {
+	return earliest(s.stop, t.In(s.Location))
+}
+
+// Blackout returns the end of the blackout window t falls into. If t is not within a blackout window, ok is false.
+func (s *Schedule) Blackout(t time.Time) (end time.Time, ok bool) {
+	t = t.In(s.Location)
+	for _, w := range s.blackouts {
+		// The window contains t if it opens in (t-duration, t]
+		open := w.start.Next(t.Add(-w.duration))
+		if open.IsZero() || open.After(t) {
+			continue
+		}
+		if e := open.Add(w.duration); !ok || e.After(end) {
+			end, ok = e, true
+		}
+	}
+	return
+}
+
+// NextStart returns the first scheduled start after t which does not fall into a blackout window,
+// or the zero time if there is none.
+func (s *Schedule) NextStart(t time.Time) time.Time {
+	t = t.In(s.Location)
+	for i := 0; i < maxStartCandidates; i++ {
+		t = earliest(s.start, t)
+		if t.IsZero() {
+			return t
+		}
+		if _, blocked := s.Blackout(t); !blocked {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func earliest(schedules []cron.Schedule, t time.Time) time.Time {
+	var res time.Time
+	for _, s := range schedules {
+		next := s.Next(t)
+		if next.IsZero() {
+			continue
+		}
+		if res.IsZero() || next.Before(res) {
+			res = next
+		}
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Spec  workspacev1.ScheduleSpec
+		Valid bool
+	}{
+		{Name: "empty", Valid: true},
+		{
+			Name: "valid",
+			Spec: workspacev1.ScheduleSpec{
+				TimeZone:  "Europe/Berlin",
+				Stop:      []string{"0 19 * * *"},
+				Blackouts: []workspacev1.ScheduleWindow{{Start: "0 0 * * 6", Duration: metav1.Duration{Duration: 48 * time.Hour}}},
+				Start:     []string{"30 8 * * 1-5"},
+			},
+			Valid: true,
+		},
+		{Name: "unknown time zone", Spec: workspacev1.ScheduleSpec{TimeZone: "Mars/Olympus"}},
+		{Name: "invalid stop", Spec: workspacev1.ScheduleSpec{Stop: []string{"0 25 * * *"}}},
+		{Name: "invalid start", Spec: workspacev1.ScheduleSpec{Start: []string{"every morning"}}},
+		{Name: "blackout without duration", Spec: workspacev1.ScheduleSpec{Blackouts: []workspacev1.ScheduleWindow{{Start: "0 0 * * 6"}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := Parse(&test.Spec)
+			if test.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	s, err := Parse(&workspacev1.ScheduleSpec{
+		TimeZone:  "Europe/Berlin",
+		Stop:      []string{"0 19 * * *"},
+		Blackouts: []workspacev1.ScheduleWindow{{Start: "0 0 * * 6", Duration: metav1.Duration{Duration: 48 * time.Hour}}},
+		Start:     []string{"30 8 * * *"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Friday, 3 March 2023
+	friday := func(hour, min int) time.Time { return time.Date(2023, 3, 3, hour, min, 0, 0, berlin) }
+
+	t.Run("next stop", func(t *testing.T) {
+		// 17:00 UTC is 18:00 in Berlin, so the stop happens an hour later
+		act := s.NextStop(time.Date(2023, 3, 3, 17, 0, 0, 0, time.UTC))
+		if exp := friday(19, 0); !act.Equal(exp) {
+			t.Errorf("unexpected next stop: expected %s, got %s", exp, act)
+		}
+	})
+
+	t.Run("blackout", func(t *testing.T) {
+		if _, ok := s.Blackout(friday(23, 59)); ok {
+			t.Errorf("friday is not part of the blackout")
+		}
+		end, ok := s.Blackout(friday(12, 0).Add(36 * time.Hour))
+		if !ok {
+			t.Fatalf("saturday is part of the blackout")
+		}
+		if exp := time.Date(2023, 3, 6, 0, 0, 0, 0, berlin); !end.Equal(exp) {
+			t.Errorf("unexpected blackout end: expected %s, got %s", exp, end)
+		}
+	})
+
+	t.Run("next start skips blackout", func(t *testing.T) {
+		act := s.NextStart(friday(12, 0))
+		if exp := time.Date(2023, 3, 6, 8, 30, 0, 0, berlin); !act.Equal(exp) {
+			t.Errorf("unexpected next start: expected %s, got %s", exp, act)
+		}
+	})
+
+	t.Run("no start schedule", func(t *testing.T) {
+		empty, _ := Parse(&workspacev1.ScheduleSpec{})
+		if act := empty.NextStart(friday(12, 0)); !act.IsZero() {
+			t.Errorf("expected no start, got %s", act)
+		}
+	})
+}
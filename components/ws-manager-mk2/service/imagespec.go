@@ -6,6 +6,7 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	regapi "github.com/gitpod-io/gitpod/registry-facade/api"
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
@@ -26,6 +27,10 @@ type WorkspaceImageSpecProvider struct {
 }
 
 func (is *WorkspaceImageSpecProvider) GetImageSpec(ctx context.Context, req *regapi.GetImageSpecRequest) (*regapi.GetImageSpecResponse, error) {
+	if strings.HasPrefix(req.Id, workspacev1.WorkspacePoolImageSpecPrefix) {
+		return is.getPoolImageSpec(ctx, strings.TrimPrefix(req.Id, workspacev1.WorkspacePoolImageSpecPrefix))
+	}
+
 	var ws workspacev1.Workspace
 	err := is.Client.Get(ctx, types.NamespacedName{Namespace: is.Namespace, Name: req.Id}, &ws)
 	if errors.IsNotFound(err) {
@@ -44,3 +49,24 @@ func (is *WorkspaceImageSpecProvider) GetImageSpec(ctx context.Context, req *reg
 		},
 	}, nil
 }
+
+// getPoolImageSpec serves the images pool pods pre-pull, which are the images of the workspaces the pool is for
+func (is *WorkspaceImageSpecProvider) getPoolImageSpec(ctx context.Context, name string) (*regapi.GetImageSpecResponse, error) {
+	var pool workspacev1.WorkspacePool
+	err := is.Client.Get(ctx, types.NamespacedName{Namespace: is.Namespace, Name: name}, &pool)
+	if errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &regapi.GetImageSpecResponse{
+		Spec: &regapi.ImageSpec{
+			BaseRef:       pool.Spec.Image,
+			IdeRef:        pool.Spec.IDE.Web,
+			IdeLayerRef:   pool.Spec.IDE.Refs,
+			SupervisorRef: pool.Spec.IDE.Supervisor,
+		},
+	}, nil
+}
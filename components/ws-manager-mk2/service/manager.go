@@ -9,7 +9,6 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -29,6 +28,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/activity"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/maintenance"
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/schedule"
 	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
 	"github.com/gitpod-io/gitpod/ws-manager/api/config"
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
@@ -53,6 +53,10 @@ const (
 	stopWorkspaceNormallyGracePeriod = 30 * time.Second
 	// stopWorkspaceImmediatelyGracePeriod is the grace period we use when stopping a pod as soon as possbile
 	stopWorkspaceImmediatelyGracePeriod = 1 * time.Second
+
+	// checkpointFilename is the name of the process checkpoint ws-daemon uploads next to the backup of a hibernating
+	// workspace, see storage.DefaultCheckpoint in content-service
+	checkpointFilename = "checkpoint.tar"
 )
 
 var (
@@ -66,7 +70,7 @@ var (
 	}
 )
 
-func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, reg prometheus.Registerer, activity *activity.WorkspaceActivity, maintenance maintenance.Maintenance) *WorkspaceManagerServer {
+func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, reg prometheus.Registerer, activity *activity.WorkspaceActivity, maintenance maintenance.Maintenance, contentService csapi.WorkspaceServiceClient) *WorkspaceManagerServer {
 	metrics := newWorkspaceMetrics(cfg.Namespace, clnt, activity)
 	reg.MustRegister(metrics)
 
@@ -76,6 +80,7 @@ func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, re
 		metrics:     metrics,
 		activity:    activity,
 		maintenance: maintenance,
+		content:     contentService,
 		subs: subscriptions{
 			subscribers: make(map[string]chan *wsmanapi.SubscribeResponse),
 		},
@@ -88,6 +93,9 @@ type WorkspaceManagerServer struct {
 	metrics     *workspaceMetrics
 	activity    *activity.WorkspaceActivity
 	maintenance maintenance.Maintenance
+	// content deletes the content of snapshots and finds the checkpoints of hibernated workspaces,
+	// nil if no content-service is configured
+	content csapi.WorkspaceServiceClient
 
 	subs subscriptions
 	wsmanapi.UnimplementedWorkspaceManagerServer
@@ -151,6 +159,16 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	sched, parsedSched, err := parseSchedule(req.Spec.Schedule)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if parsedSched != nil {
+		if end, blocked := parsedSched.Blackout(time.Now()); blocked {
+			return nil, status.Errorf(codes.FailedPrecondition, "workspace must not run until %s according to its schedule", end.Format(time.RFC3339))
+		}
+	}
+
 	var admissionLevel workspacev1.AdmissionLevel
 	switch req.Spec.Admission {
 	case wsmanapi.AdmissionLevel_ADMIT_EVERYONE:
@@ -273,13 +291,15 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 				Time:            timeout,
 				ClosedTimeout:   closedTimeout,
 				MaximumLifetime: maximumLifetime,
+				Schedule:        sched,
 			},
 			Admission: workspacev1.AdmissionSpec{
 				Level: admissionLevel,
 			},
-			Ports:         ports,
-			SshPublicKeys: req.Spec.SshPublicKeys,
-			StorageQuota:  int(storage.Value()),
+			Ports:             ports,
+			SshPublicKeys:     req.Spec.SshPublicKeys,
+			StorageQuota:      int(storage.Value()),
+			RestoreCheckpoint: wsm.hasCheckpoint(ctx, req),
 		},
 	}
 	controllerutil.AddFinalizer(&ws, workspacev1.GitpodFinalizerName)
@@ -324,32 +344,6 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 	}, nil
 }
 
-func isProtectedEnvVar(name string, sysEnvvars []*wsmanapi.EnvironmentVariable) bool {
-	switch name {
-	case "THEIA_SUPERVISOR_TOKENS":
-		return true
-	default:
-		if isGitpodInternalEnvVar(name) {
-			return false
-		}
-		for _, env := range sysEnvvars {
-			if env.Name == name {
-				return false
-			}
-		}
-		return true
-	}
-}
-
-func isGitpodInternalEnvVar(name string) bool {
-	return strings.HasPrefix(name, "GITPOD_") ||
-		strings.HasPrefix(name, "SUPERVISOR_") ||
-		strings.HasPrefix(name, "BOB_") ||
-		strings.HasPrefix(name, "THEIA_") ||
-		name == "NODE_EXTRA_CA_CERTS" ||
-		name == "VSX_REGISTRY_URL"
-}
-
 func (wsm *WorkspaceManagerServer) createWorkspaceSecret(ctx context.Context, owner client.Object, name, namespace string, data map[string]string) error {
 	secret := corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -367,6 +361,26 @@ func (wsm *WorkspaceManagerServer) createWorkspaceSecret(ctx context.Context, ow
 	return nil
 }
 
+// hasCheckpoint returns true if the workspace was hibernated and its processes were checkpointed next to its backup.
+// Processes cannot be restored into a running container, hence the pod of such a workspace is created from the
+// checkpoint right away. If we cannot tell, the workspace cold starts.
+func (wsm *WorkspaceManagerServer) hasCheckpoint(ctx context.Context, req *wsmanapi.StartWorkspaceRequest) bool {
+	if wsm.content == nil || req.Spec.Initializer.GetBackup() == nil {
+		return false
+	}
+
+	resp, err := wsm.content.WorkspaceSnapshotExists(ctx, &csapi.WorkspaceSnapshotExistsRequest{
+		OwnerId:     req.Metadata.Owner,
+		WorkspaceId: req.Metadata.MetaId,
+		Filename:    checkpointFilename,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(log.OWI(req.Metadata.Owner, req.Metadata.MetaId, req.Id)).Warn("cannot find out if workspace has a checkpoint, starting it without")
+		return false
+	}
+	return resp.Exists
+}
+
 func (wsm *WorkspaceManagerServer) StopWorkspace(ctx context.Context, req *wsmanapi.StopWorkspaceRequest) (res *wsmanapi.StopWorkspaceResponse, err error) {
 	owi := log.OWI("", "", req.Id)
 	span, ctx := tracing.FromContext(ctx, "StopWorkspace")
@@ -382,6 +396,8 @@ func (wsm *WorkspaceManagerServer) StopWorkspace(ctx context.Context, req *wsman
 	if req.Policy == wsmanapi.StopWorkspacePolicy_IMMEDIATELY {
 		span.LogKV("policy", "immediately")
 		gracePeriod = stopWorkspaceImmediatelyGracePeriod
+	} else if req.Policy == wsmanapi.StopWorkspacePolicy_HIBERNATE {
+		span.LogKV("policy", "hibernate")
 	} else if req.Policy == wsmanapi.StopWorkspacePolicy_ABORT {
 		span.LogKV("policy", "abort")
 		gracePeriod = stopWorkspaceImmediatelyGracePeriod
@@ -393,6 +409,11 @@ func (wsm *WorkspaceManagerServer) StopWorkspace(ctx context.Context, req *wsman
 		}
 	}
 	err = wsm.modifyWorkspace(ctx, req.Id, true, func(ws *workspacev1.Workspace) error {
+		if req.Policy == wsmanapi.StopWorkspacePolicy_HIBERNATE && !ws.IsHeadless() {
+			// The workspace controller stops the workspace once ws-daemon has checkpointed it.
+			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionHibernationRequested(gracePeriod.String()))
+			return nil
+		}
 		ws.Status.SetCondition(workspacev1.NewWorkspaceConditionStoppedByRequest(gracePeriod.String()))
 		return nil
 	})
@@ -531,6 +552,23 @@ func (wsm *WorkspaceManagerServer) MarkActive(ctx context.Context, req *wsmanapi
 }
 
 func (wsm *WorkspaceManagerServer) SetTimeout(ctx context.Context, req *wsmanapi.SetTimeoutRequest) (*wsmanapi.SetTimeoutResponse, error) {
+	if req.Type == wsmanapi.TimeoutType_SCHEDULE_TIMEOUT {
+		sched, _, err := parseSchedule(req.Schedule)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		err = wsm.modifyWorkspace(ctx, req.Id, false, func(ws *workspacev1.Workspace) error {
+			ws.Spec.Timeout.Schedule = sched
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &wsmanapi.SetTimeoutResponse{}, nil
+	}
+
 	duration, err := time.ParseDuration(req.Duration)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid duration: %v", err)
@@ -692,6 +730,167 @@ func (wsm *WorkspaceManagerServer) TakeSnapshot(ctx context.Context, req *wsmana
 	}, nil
 }
 
+func (wsm *WorkspaceManagerServer) BackupWorkspace(ctx context.Context, req *wsmanapi.BackupWorkspaceRequest) (res *wsmanapi.BackupWorkspaceResponse, err error) {
+	span, ctx := tracing.FromContext(ctx, "BackupWorkspace")
+	tracing.ApplyOWI(span, log.OWI("", "", req.Id))
+	defer tracing.FinishSpan(span, &err)
+
+	if wsm.maintenance.IsEnabled(ctx) {
+		return &wsmanapi.BackupWorkspaceResponse{}, status.Error(codes.FailedPrecondition, "under maintenance")
+	}
+
+	var ws workspacev1.Workspace
+	err = wsm.Client.Get(ctx, types.NamespacedName{Namespace: wsm.Config.Namespace, Name: req.Id}, &ws)
+	if errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "workspace %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot lookup workspace: %v", err)
+	}
+
+	if ws.Status.Phase != workspacev1.WorkspacePhaseRunning || ws.Status.Runtime == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "backups can only be taken of running workspaces, not %s workspaces", ws.Status.Phase)
+	}
+
+	// There is at most one backup per workspace at a time. Repeated requests while a backup
+	// is in progress wait for that backup instead of starting another one.
+	backup := workspacev1.Snapshot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: workspacev1.GroupVersion.String(),
+			Kind:       "Snapshot",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupSnapshotName(ws.Name),
+			Namespace: wsm.Config.Namespace,
+		},
+		Spec: workspacev1.SnapshotSpec{
+			NodeName:    ws.Status.Runtime.NodeName,
+			WorkspaceID: ws.Name,
+			Type:        workspacev1.SnapshotTypeBackup,
+		},
+	}
+	err = controllerutil.SetOwnerReference(&ws, &backup, wsm.Client.Scheme())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set owner for backup: %q", err)
+	}
+
+	err = retry.OnError(retryParams, errors.IsAlreadyExists, func() error {
+		err := wsm.Client.Create(ctx, backup.DeepCopy())
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+
+		var existing workspacev1.Snapshot
+		err = wsm.Client.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}, &existing)
+		if errors.IsNotFound(err) {
+			// removed in the meantime - try creating it again
+			return errors.NewAlreadyExists(workspacev1.GroupVersion.WithResource("snapshots").GroupResource(), backup.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if !existing.Status.Completed {
+			log.WithFields(log.OWI("", "", req.Id)).Info("backup already in progress - waiting for it to complete")
+			return nil
+		}
+
+		// the previous backup has completed - replace it with a new one
+		err = wsm.Client.Delete(ctx, &existing, client.Preconditions{UID: &existing.UID})
+		if err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			return err
+		}
+		return errors.NewAlreadyExists(workspacev1.GroupVersion.WithResource("snapshots").GroupResource(), backup.Name)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create backup object: %v", err)
+	}
+
+	var sso workspacev1.Snapshot
+	err = wait.PollImmediateUntilWithContext(ctx, 250*time.Millisecond, func(c context.Context) (done bool, err error) {
+		err = wsm.Client.Get(c, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}, &sso)
+		if errors.IsNotFound(err) {
+			return false, status.Error(codes.Aborted, "backup was deleted before it completed")
+		}
+		if err != nil {
+			return false, nil
+		}
+
+		return sso.Status.Completed, nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.DeadlineExceeded, "cannot wait for backup: %v", err)
+	}
+
+	if sso.Status.Error != "" {
+		return nil, status.Errorf(codes.Internal, "cannot back up workspace: %s", sso.Status.Error)
+	}
+
+	return &wsmanapi.BackupWorkspaceResponse{
+		Url: sso.Status.URL,
+	}, nil
+}
+
+// backupSnapshotName is the name of the snapshot object used to back up a running workspace.
+func backupSnapshotName(instanceID string) string {
+	return instanceID + "-backup"
+}
+
+// DeleteVolumeSnapshot deletes the snapshot objects of a workspace instance and their content. With ws-manager-mk2 there
+// are no volume snapshots we could restore from a volume handle, hence req.SoftDelete and req.VolumeHandle have no effect.
+// The content of backups is the regular backup of the workspace and is removed with the workspace itself.
+func (wsm *WorkspaceManagerServer) DeleteVolumeSnapshot(ctx context.Context, req *wsmanapi.DeleteVolumeSnapshotRequest) (res *wsmanapi.DeleteVolumeSnapshotResponse, err error) {
+	span, ctx := tracing.FromContext(ctx, "DeleteVolumeSnapshot")
+	tracing.ApplyOWI(span, log.OWI("", "", req.Id))
+	defer tracing.FinishSpan(span, &err)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	var snapshots workspacev1.SnapshotList
+	err = wsm.Client.List(ctx, &snapshots, client.InNamespace(wsm.Config.Namespace))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list snapshots: %v", err)
+	}
+
+	var wasDeleted bool
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if snapshot.Name != req.Id && snapshot.Spec.WorkspaceID != req.Id {
+			continue
+		}
+		if !snapshot.Status.Completed {
+			return nil, status.Errorf(codes.FailedPrecondition, "snapshot %s is still in progress", snapshot.Name)
+		}
+
+		if snapshot.Spec.Type != workspacev1.SnapshotTypeBackup && snapshot.Status.URL != "" {
+			if wsm.content == nil {
+				return nil, status.Errorf(codes.FailedPrecondition, "cannot delete content of snapshot %s: no content-service configured", snapshot.Name)
+			}
+			_, err = wsm.content.DeleteSnapshot(ctx, &csapi.DeleteSnapshotRequest{Name: snapshot.Status.URL})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "cannot delete content of snapshot %s: %v", snapshot.Name, err)
+			}
+		}
+
+		err = wsm.Client.Delete(ctx, snapshot)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot delete snapshot %s: %v", snapshot.Name, err)
+		}
+		wasDeleted = true
+	}
+
+	return &wsmanapi.DeleteVolumeSnapshotResponse{
+		WasDeleted: wasDeleted,
+	}, nil
+}
+
 func (wsm *WorkspaceManagerServer) ControlAdmission(ctx context.Context, req *wsmanapi.ControlAdmissionRequest) (*wsmanapi.ControlAdmissionResponse, error) {
 	err := wsm.modifyWorkspace(ctx, req.Id, false, func(ws *workspacev1.Workspace) error {
 		switch req.Level {
@@ -873,6 +1072,7 @@ func areValidFeatureFlags(value interface{}) error {
 }
 
 func extractWorkspaceUserEnv(secretName string, userEnvs, sysEnvs []*wsmanapi.EnvironmentVariable) ([]corev1.EnvVar, map[string]string) {
+	sysEnvVars := extractWorkspaceSysEnv(sysEnvs)
 	envVars := make([]corev1.EnvVar, 0, len(userEnvs))
 	secrets := make(map[string]string)
 	for _, e := range userEnvs {
@@ -893,7 +1093,7 @@ func extractWorkspaceUserEnv(secretName string, userEnvs, sysEnvs []*wsmanapi.En
 		case e.Value == "":
 			continue
 
-		case !isProtectedEnvVar(e.Name, sysEnvs):
+		case !workspacev1.IsProtectedEnvVar(e.Name, sysEnvVars):
 			unprotectedEnv := corev1.EnvVar{
 				Name:  e.Name,
 				Value: e.Value,
@@ -965,6 +1165,12 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 		closedTimeout = ws.Spec.Timeout.ClosedTimeout.Duration.String()
 	}
 
+	// the timeout controller keeps the next scheduled start up to date, such that we don't have to parse the schedule here
+	var nextScheduledStart *timestamppb.Timestamp
+	if ws.Spec.Timeout.Schedule != nil && ws.Status.NextScheduledStart != nil {
+		nextScheduledStart = timestamppb.New(ws.Status.NextScheduledStart.Time)
+	}
+
 	var phase wsmanapi.WorkspacePhase
 	switch ws.Status.Phase {
 	case workspacev1.WorkspacePhasePending:
@@ -980,7 +1186,7 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 		phase = wsmanapi.WorkspacePhase_RUNNING
 	case workspacev1.WorkspacePhaseStopping:
 		phase = wsmanapi.WorkspacePhase_STOPPING
-	case workspacev1.WorkspacePhaseStopped:
+	case workspacev1.WorkspacePhaseStopped, workspacev1.WorkspacePhaseHibernated:
 		phase = wsmanapi.WorkspacePhase_STOPPED
 	case workspacev1.WorkspacePhaseUnknown:
 		phase = wsmanapi.WorkspacePhase_UNKNOWN
@@ -1043,10 +1249,11 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 		Id:            ws.Name,
 		StatusVersion: version,
 		Metadata: &wsmanapi.WorkspaceMetadata{
-			Owner:       ws.Spec.Ownership.Owner,
-			MetaId:      ws.Spec.Ownership.WorkspaceID,
-			StartedAt:   timestamppb.New(ws.CreationTimestamp.Time),
-			Annotations: ws.Annotations,
+			Owner:              ws.Spec.Ownership.Owner,
+			MetaId:             ws.Spec.Ownership.WorkspaceID,
+			StartedAt:          timestamppb.New(ws.CreationTimestamp.Time),
+			Annotations:        ws.Annotations,
+			NextScheduledStart: nextScheduledStart,
 		},
 		Spec: &wsmanapi.WorkspaceSpec{
 			Class:          ws.Spec.Class,
@@ -1174,6 +1381,35 @@ func parseTimeout(timeout string) (*metav1.Duration, error) {
 	return duration, nil
 }
 
+// parseSchedule converts and validates a workspace schedule. An empty schedule yields nil.
+func parseSchedule(sched *wsmanapi.WorkspaceSchedule) (*workspacev1.ScheduleSpec, *schedule.Schedule, error) {
+	if sched == nil || (len(sched.Stop) == 0 && len(sched.Blackouts) == 0 && len(sched.Start) == 0) {
+		return nil, nil, nil
+	}
+
+	res := &workspacev1.ScheduleSpec{
+		TimeZone: sched.TimeZone,
+		Stop:     sched.Stop,
+		Start:    sched.Start,
+	}
+	for _, w := range sched.Blackouts {
+		d, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid blackout duration: %v", err)
+		}
+		res.Blackouts = append(res.Blackouts, workspacev1.ScheduleWindow{
+			Start:    w.Start,
+			Duration: metav1.Duration{Duration: d},
+		})
+	}
+
+	parsed, err := schedule.Parse(res)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule: %v", err)
+	}
+	return res, parsed, nil
+}
+
 type filteringSubscriber struct {
 	Sub    subscriber
 	Filter *wsmanapi.MetadataFilter
@@ -0,0 +1,284 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+var _ = Describe("WorkspaceManagerServer", func() {
+	Context("BackupWorkspace", func() {
+		It("should fail for unknown workspaces", func() {
+			_, err := wsm.BackupWorkspace(ctx, &wsmanapi.BackupWorkspaceRequest{Id: uuid.NewString()})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+
+		It("should fail for workspaces which are not running", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseStopping)
+
+			_, err := wsm.BackupWorkspace(ctx, &wsmanapi.BackupWorkspaceRequest{Id: ws.Name})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
+
+		It("should back up running workspaces", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+
+			res := backupAsync(ws.Name)
+			backup := expectBackupEventually(ws)
+			Expect(backup.Spec.NodeName).To(Equal("node"))
+			Expect(backup.OwnerReferences).To(HaveLen(1))
+			Expect(backup.OwnerReferences[0].UID).To(Equal(ws.UID))
+
+			completeBackup(backup, "gs://backup/full.tar", "")
+			Eventually(res, timeout, interval).Should(Receive(Equal(backupResult{URL: "gs://backup/full.tar"})))
+		})
+
+		It("should join a backup in progress", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+
+			first := backupAsync(ws.Name)
+			backup := expectBackupEventually(ws)
+			second := backupAsync(ws.Name)
+			Consistently(second, "1s", interval).ShouldNot(Receive())
+
+			completeBackup(backup, "gs://backup/full.tar", "")
+			Eventually(first, timeout, interval).Should(Receive(Equal(backupResult{URL: "gs://backup/full.tar"})))
+			Eventually(second, timeout, interval).Should(Receive(Equal(backupResult{URL: "gs://backup/full.tar"})))
+		})
+
+		It("should replace a completed backup", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+
+			res := backupAsync(ws.Name)
+			backup := expectBackupEventually(ws)
+			completeBackup(backup, "gs://backup/full.tar", "")
+			Eventually(res, timeout, interval).Should(Receive())
+
+			res = backupAsync(ws.Name)
+			var next *workspacev1.Snapshot
+			Eventually(func(g Gomega) {
+				next = expectBackupEventually(ws)
+				g.Expect(next.UID).ToNot(Equal(backup.UID))
+			}, timeout, interval).Should(Succeed())
+			Expect(next.Status.Completed).To(BeFalse())
+
+			completeBackup(next, "gs://backup/full.tar", "upload failed")
+			Eventually(res, timeout, interval).Should(Receive(Equal(backupResult{Code: codes.Internal})))
+		})
+	})
+
+	Context("StopWorkspace", func() {
+		It("should request hibernation", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+
+			_, err := wsm.StopWorkspace(ctx, &wsmanapi.StopWorkspaceRequest{Id: ws.Name, Policy: wsmanapi.StopWorkspacePolicy_HIBERNATE})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ws.Name}, ws)).To(Succeed())
+			Expect(ws.IsConditionTrue(workspacev1.WorkspaceConditionHibernationRequested)).To(BeTrue())
+			Expect(ws.IsConditionTrue(workspacev1.WorkspaceConditionStoppedByRequest)).To(BeFalse())
+		})
+	})
+
+	Context("DeleteVolumeSnapshot", func() {
+		It("should require an id", func() {
+			_, err := wsm.DeleteVolumeSnapshot(ctx, &wsmanapi.DeleteVolumeSnapshotRequest{})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should delete the snapshots of a workspace", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+			snapshot := createSnapshot(ws, fmt.Sprintf("%s-1", ws.Name), true)
+			other := createSnapshot(createWorkspace(workspacev1.WorkspacePhaseRunning), uuid.NewString(), true)
+
+			res, err := wsm.DeleteVolumeSnapshot(ctx, &wsmanapi.DeleteVolumeSnapshotRequest{Id: ws.Name})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.WasDeleted).To(BeTrue())
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: snapshot.Name}, &workspacev1.Snapshot{})
+			}, timeout, interval).Should(Satisfy(errors.IsNotFound))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: other.Name}, &workspacev1.Snapshot{})).To(Succeed())
+
+			By("being idempotent")
+			Eventually(func(g Gomega) {
+				res, err := wsm.DeleteVolumeSnapshot(ctx, &wsmanapi.DeleteVolumeSnapshotRequest{Id: ws.Name, SoftDelete: true})
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res.WasDeleted).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should delete the content of snapshots but not of backups", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+			snapshot := createSnapshot(ws, fmt.Sprintf("%s-snapshot", ws.Name), true)
+			snapshot.Status.URL = fmt.Sprintf("workspaces/%s/snapshot-1.tar@gitpod-user-1234", ws.Name)
+			Expect(k8sClient.Status().Update(ctx, snapshot)).To(Succeed())
+
+			backup := &workspacev1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-backup", ws.Name), Namespace: namespace},
+				Spec:       workspacev1.SnapshotSpec{NodeName: "node", WorkspaceID: ws.Name, Type: workspacev1.SnapshotTypeBackup},
+			}
+			Expect(k8sClient.Create(ctx, backup)).To(Succeed())
+			backup.Status.Completed = true
+			backup.Status.URL = fmt.Sprintf("workspaces/%s/full.tar@gitpod-user-1234", ws.Name)
+			Expect(k8sClient.Status().Update(ctx, backup)).To(Succeed())
+
+			_, err := wsm.DeleteVolumeSnapshot(ctx, &wsmanapi.DeleteVolumeSnapshotRequest{Id: ws.Name})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contentService.Deleted()).To(ContainElement(snapshot.Status.URL))
+			Expect(contentService.Deleted()).ToNot(ContainElement(backup.Status.URL))
+		})
+
+		It("should not delete snapshots in progress", func() {
+			ws := createWorkspace(workspacev1.WorkspacePhaseRunning)
+			snapshot := createSnapshot(ws, uuid.NewString(), false)
+
+			_, err := wsm.DeleteVolumeSnapshot(ctx, &wsmanapi.DeleteVolumeSnapshotRequest{Id: snapshot.Name})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: snapshot.Name}, &workspacev1.Snapshot{})).To(Succeed())
+		})
+	})
+})
+
+type backupResult struct {
+	URL  string
+	Code codes.Code
+}
+
+func backupAsync(id string) <-chan backupResult {
+	res := make(chan backupResult, 1)
+	go func() {
+		resp, err := wsm.BackupWorkspace(ctx, &wsmanapi.BackupWorkspaceRequest{Id: id})
+		if err != nil {
+			res <- backupResult{Code: status.Code(err)}
+			return
+		}
+		res <- backupResult{URL: resp.Url}
+	}()
+	return res
+}
+
+func expectBackupEventually(ws *workspacev1.Workspace) *workspacev1.Snapshot {
+	GinkgoHelper()
+
+	var backup workspacev1.Snapshot
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: backupSnapshotName(ws.Name)}, &backup)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+	Expect(backup.Spec.Type).To(Equal(workspacev1.SnapshotTypeBackup))
+	Expect(backup.Spec.WorkspaceID).To(Equal(ws.Name))
+	return &backup
+}
+
+// completeBackup does what ws-daemon's snapshot controller does once it has uploaded the content.
+func completeBackup(backup *workspacev1.Snapshot, url, failure string) {
+	GinkgoHelper()
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var s workspacev1.Snapshot
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}, &s)
+		if err != nil {
+			return err
+		}
+		s.Status.URL = url
+		s.Status.Completed = true
+		s.Status.Error = failure
+		if failure != "" {
+			s.Status.SetCondition(workspacev1.NewSnapshotConditionFailed(failure))
+		} else {
+			s.Status.SetCondition(workspacev1.NewSnapshotConditionSucceeded())
+		}
+		return k8sClient.Status().Update(ctx, &s)
+	})
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func createWorkspace(phase workspacev1.WorkspacePhase) *workspacev1.Workspace {
+	GinkgoHelper()
+
+	initializer, err := proto.Marshal(&csapi.WorkspaceInitializer{
+		Spec: &csapi.WorkspaceInitializer_Empty{Empty: &csapi.EmptyInitializer{}},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	ws := &workspacev1.Workspace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "workspace.gitpod.io/v1",
+			Kind:       "Workspace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: namespace,
+		},
+		Spec: workspacev1.WorkspaceSpec{
+			Ownership: workspacev1.Ownership{
+				Owner:       "foobar",
+				WorkspaceID: "cool-workspace",
+			},
+			Type:  workspacev1.WorkspaceTypeRegular,
+			Class: "default",
+			Image: workspacev1.WorkspaceImages{
+				Workspace: workspacev1.WorkspaceImage{
+					Ref: pointer.String("alpine:latest"),
+				},
+				IDE: workspacev1.IDEImages{
+					Refs: []string{},
+				},
+			},
+			Ports:             []workspacev1.PortSpec{},
+			Initializer:       initializer,
+			WorkspaceLocation: "/workspace",
+			Admission: workspacev1.AdmissionSpec{
+				Level: workspacev1.AdmissionLevelEveryone,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, ws)).To(Succeed())
+
+	ws.Status = workspacev1.WorkspaceStatus{
+		Phase:      phase,
+		Conditions: []metav1.Condition{},
+		Runtime: &workspacev1.WorkspaceRuntimeStatus{
+			NodeName: "node",
+		},
+	}
+	Expect(k8sClient.Status().Update(ctx, ws)).To(Succeed())
+	return ws
+}
+
+func createSnapshot(ws *workspacev1.Workspace, name string, completed bool) *workspacev1.Snapshot {
+	GinkgoHelper()
+
+	snapshot := &workspacev1.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: workspacev1.SnapshotSpec{
+			NodeName:    "node",
+			WorkspaceID: ws.Name,
+		},
+	}
+	Expect(k8sClient.Create(ctx, snapshot)).To(Succeed())
+
+	snapshot.Status.Completed = completed
+	Expect(k8sClient.Status().Update(ctx, snapshot)).To(Succeed())
+	return snapshot
+}
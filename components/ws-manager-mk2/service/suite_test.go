@@ -0,0 +1,125 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/activity"
+	"github.com/gitpod-io/gitpod/ws-manager/api/config"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+// These tests use Ginkgo (BDD-style Go testing framework). Refer to
+// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
+//
+// There are no controllers running in this suite: the tests play the part of
+// ws-daemon by updating the status of the objects the service creates.
+
+const (
+	timeout   = time.Second * 20
+	interval  = time.Millisecond * 250
+	namespace = "default"
+)
+
+var (
+	k8sClient client.Client
+	testEnv   *envtest.Environment
+	wsm       *WorkspaceManagerServer
+	// contentService records the snapshots whose content the service deleted
+	contentService = &fakeContentService{}
+	ctx            context.Context
+	cancel         context.CancelFunc
+)
+
+func TestAPIs(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Service Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{
+		ControlPlaneStartTimeout: 1 * time.Minute,
+		ControlPlaneStopTimeout:  1 * time.Minute,
+		CRDDirectoryPaths:        []string{filepath.Join("..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing:    true,
+	}
+
+	cfg, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	err = workspacev1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient).NotTo(BeNil())
+
+	conf := config.Configuration{
+		Namespace: namespace,
+	}
+	wsm = NewWorkspaceManagerServer(k8sClient, &conf, prometheus.NewRegistry(), activity.NewWorkspaceActivity(), &fakeMaintenance{}, contentService)
+
+	ctx, cancel = context.WithCancel(context.Background())
+})
+
+type fakeContentService struct {
+	csapi.WorkspaceServiceClient
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (f *fakeContentService) DeleteSnapshot(ctx context.Context, req *csapi.DeleteSnapshotRequest, opts ...grpc.CallOption) (*csapi.DeleteSnapshotResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, req.Name)
+	return &csapi.DeleteSnapshotResponse{}, nil
+}
+
+func (f *fakeContentService) WorkspaceSnapshotExists(ctx context.Context, req *csapi.WorkspaceSnapshotExistsRequest, opts ...grpc.CallOption) (*csapi.WorkspaceSnapshotExistsResponse, error) {
+	return &csapi.WorkspaceSnapshotExistsResponse{}, nil
+}
+
+func (f *fakeContentService) Deleted() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.deleted...)
+}
+
+type fakeMaintenance struct {
+	enabled bool
+}
+
+func (f *fakeMaintenance) IsEnabled(context.Context) bool {
+	return f.enabled
+}
+
+var _ = AfterSuite(func() {
+	cancel()
+	By("tearing down the test environment")
+	err := testEnv.Stop()
+	Expect(err).NotTo(HaveOccurred())
+})
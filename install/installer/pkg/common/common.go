@@ -730,6 +730,10 @@ var (
 		APIVersion: "v1",
 		Kind:       "ResourceQuota",
 	}
+	TypeMetaPersistentVolumeClaim = metav1.TypeMeta{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+	}
 	TypeMetaBatchJob = metav1.TypeMeta{
 		APIVersion: "batch/v1",
 		Kind:       "Job",
@@ -746,6 +750,14 @@ var (
 		APIVersion: "trust.cert-manager.io/v1alpha1",
 		Kind:       "Bundle",
 	}
+	TypeMetaMutatingWebhookConfiguration = metav1.TypeMeta{
+		APIVersion: "admissionregistration.k8s.io/v1",
+		Kind:       "MutatingWebhookConfiguration",
+	}
+	TypeMetaValidatingWebhookConfiguration = metav1.TypeMeta{
+		APIVersion: "admissionregistration.k8s.io/v1",
+		Kind:       "ValidatingWebhookConfiguration",
+	}
 )
 
 // validCookieChars contains all characters which may occur in an HTTP Cookie value (unicode \u0021 through \u007E),
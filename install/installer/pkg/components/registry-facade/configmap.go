@@ -22,6 +22,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 	var (
 		ipfsCache  *regfac.IPFSCacheConfig
 		redisCache *regfac.RedisCacheConfig
+		lazyPull   *regfac.LazyPullConfig
 	)
 	remoteSpecProviders := []*regfac.RSProvider{
 		{
@@ -57,6 +58,13 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			}
 		}
 
+		if ucfg.Workspace.RegistryFacade.LazyPull.Enabled {
+			lazyPull = &regfac.LazyPullConfig{
+				Enabled: true,
+				Images:  ucfg.Workspace.RegistryFacade.LazyPull.Images,
+			}
+		}
+
 		return nil
 	})
 
@@ -86,6 +94,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			},
 			IPFSCache:  ipfsCache,
 			RedisCache: redisCache,
+			LazyPull:   lazyPull,
 		},
 		AuthCfg:            "/mnt/pull-secret/pull-secret.json",
 		PProfAddr:          common.LocalhostAddressFromPort(baseserver.BuiltinDebugPort),
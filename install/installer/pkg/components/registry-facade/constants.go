@@ -17,4 +17,7 @@ const (
 	SupervisorImage   = workspace.SupervisorImage
 	WorkspacekitImage = workspace.WorkspacekitImage
 	ReadinessPort     = 8086
+
+	// ConvertCronSchedule is the default schedule of the job which converts images for lazy pulling
+	ConvertCronSchedule = "@daily"
 )
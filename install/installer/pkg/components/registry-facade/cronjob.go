@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registryfacade
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
+)
+
+// cronjob converts the images configured for lazy pulling to eStargz
+func cronjob(ctx *common.RenderContext) ([]runtime.Object, error) {
+	var (
+		enabled  bool
+		schedule = ConvertCronSchedule
+	)
+	_ = ctx.WithExperimental(func(ucfg *experimental.Config) error {
+		if ucfg.Workspace == nil {
+			return nil
+		}
+		enabled = ucfg.Workspace.RegistryFacade.LazyPull.Enabled
+		if s := ucfg.Workspace.RegistryFacade.LazyPull.Schedule; s != "" {
+			schedule = s
+		}
+		return nil
+	})
+	if !enabled {
+		return nil, nil
+	}
+
+	secretName, err := pullSecretName(ctx)
+	if err != nil {
+		return nil, err
+	}
+	envvars, err := cacheEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s-convert", Component)
+	objectMeta := metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   ctx.Namespace,
+		Labels:      common.CustomizeLabel(ctx, Component, common.TypeMetaBatchCronJob),
+		Annotations: common.CustomizeAnnotation(ctx, Component, common.TypeMetaBatchCronJob),
+	}
+
+	return []runtime.Object{
+		&batchv1.CronJob{
+			TypeMeta:   common.TypeMetaBatchCronJob,
+			ObjectMeta: objectMeta,
+			Spec: batchv1.CronJobSpec{
+				Schedule:                   schedule,
+				SuccessfulJobsHistoryLimit: pointer.Int32(1),
+				FailedJobsHistoryLimit:     pointer.Int32(1),
+				ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+				JobTemplate: batchv1.JobTemplateSpec{
+					ObjectMeta: objectMeta,
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: objectMeta,
+							Spec: corev1.PodSpec{
+								RestartPolicy:      corev1.RestartPolicyOnFailure,
+								ServiceAccountName: Component,
+								EnableServiceLinks: pointer.Bool(false),
+								Containers: []corev1.Container{{
+									Name:            name,
+									Image:           ctx.ImageName(ctx.Config.Repository, Component, ctx.VersionManifest.Components.RegistryFacade.Version),
+									ImagePullPolicy: corev1.PullIfNotPresent,
+									Args:            []string{"convert", "/mnt/config/config.json"},
+									Resources: common.ResourceRequirements(ctx, Component, name, corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											"cpu":    resource.MustParse("500m"),
+											"memory": resource.MustParse("256Mi"),
+										},
+									}),
+									SecurityContext: &corev1.SecurityContext{
+										Privileged:               pointer.Bool(false),
+										AllowPrivilegeEscalation: pointer.Bool(false),
+										RunAsUser:                pointer.Int64(1000),
+									},
+									Env: common.CustomizeEnvvar(ctx, Component, common.MergeEnv(
+										common.DefaultEnv(&ctx.Config),
+										envvars,
+									)),
+									VolumeMounts: []corev1.VolumeMount{
+										{
+											Name:      "config",
+											MountPath: "/mnt/config",
+											ReadOnly:  true,
+										},
+										{
+											Name:      "pull-secret",
+											MountPath: "/mnt/pull-secret",
+										},
+										{
+											Name:      "tmp",
+											MountPath: "/tmp",
+										},
+										common.CAVolumeMount(),
+									},
+								}},
+								Volumes: []corev1.Volume{
+									{
+										Name: "config",
+										VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+											LocalObjectReference: corev1.LocalObjectReference{Name: Component},
+										}},
+									},
+									{
+										Name: "pull-secret",
+										VolumeSource: corev1.VolumeSource{
+											Secret: &corev1.SecretVolumeSource{
+												SecretName: secretName,
+												Items:      []corev1.KeyToPath{{Key: ".dockerconfigjson", Path: "pull-secret.json"}},
+											},
+										},
+									},
+									{
+										// converted layers are buffered on disk before they're stored
+										Name:         "tmp",
+										VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+									},
+									common.CAVolume(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
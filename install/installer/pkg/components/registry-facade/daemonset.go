@@ -78,56 +78,12 @@ func daemonset(ctx *common.RenderContext) ([]runtime.Object, error) {
 	}
 
 	name := "pull-secret"
-	var secretName string
-	if pointer.BoolDeref(ctx.Config.ContainerRegistry.InCluster, false) {
-		secretName = dockerregistry.BuiltInRegistryAuth
-	} else if ctx.Config.ContainerRegistry.External != nil {
-		secretName = ctx.Config.ContainerRegistry.External.Certificate.Name
-	} else {
-		return nil, fmt.Errorf("%s: invalid container registry config", Component)
+	secretName, err := pullSecretName(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	var envvars []corev1.EnvVar
-	err = ctx.WithExperimental(func(ucfg *experimental.Config) error {
-		if ucfg.Workspace == nil {
-			return nil
-		}
-
-		if ucfg.Workspace.RegistryFacade.IPFSCache.Enabled && !ucfg.Workspace.RegistryFacade.RedisCache.Enabled {
-			return fmt.Errorf("IPFS cache requires Redis")
-		}
-
-		if ucfg.Workspace.RegistryFacade.IPFSCache.Enabled {
-			envvars = []corev1.EnvVar{
-				{
-					Name: "IPFS_HOST",
-					ValueFrom: &corev1.EnvVarSource{
-						FieldRef: &corev1.ObjectFieldSelector{
-							FieldPath: "status.hostIP",
-						},
-					},
-				},
-			}
-		}
-
-		if ucfg.Workspace.RegistryFacade.RedisCache.Enabled {
-			if scr := ucfg.Workspace.RegistryFacade.RedisCache.PasswordSecret; scr != "" {
-				envvars = append(envvars, corev1.EnvVar{
-					Name: "REDIS_PASSWORD",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: scr,
-							},
-							Key: "password",
-						},
-					},
-				})
-			}
-		}
-
-		return nil
-	})
+	envvars, err := cacheEnv(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -310,3 +266,65 @@ func daemonset(ctx *common.RenderContext) ([]runtime.Object, error) {
 		},
 	}}, nil
 }
+
+func pullSecretName(ctx *common.RenderContext) (string, error) {
+	if pointer.BoolDeref(ctx.Config.ContainerRegistry.InCluster, false) {
+		return dockerregistry.BuiltInRegistryAuth, nil
+	} else if ctx.Config.ContainerRegistry.External != nil {
+		return ctx.Config.ContainerRegistry.External.Certificate.Name, nil
+	}
+	return "", fmt.Errorf("%s: invalid container registry config", Component)
+}
+
+// cacheEnv produces the environment registry-facade needs to connect to its Redis/IPFS caches
+func cacheEnv(ctx *common.RenderContext) ([]corev1.EnvVar, error) {
+	var envvars []corev1.EnvVar
+	err := ctx.WithExperimental(func(ucfg *experimental.Config) error {
+		if ucfg.Workspace == nil {
+			return nil
+		}
+
+		if ucfg.Workspace.RegistryFacade.IPFSCache.Enabled && !ucfg.Workspace.RegistryFacade.RedisCache.Enabled {
+			return fmt.Errorf("IPFS cache requires Redis")
+		}
+		if ucfg.Workspace.RegistryFacade.LazyPull.Enabled && !ucfg.Workspace.RegistryFacade.IPFSCache.Enabled {
+			return fmt.Errorf("lazy pulling requires the IPFS cache")
+		}
+
+		if ucfg.Workspace.RegistryFacade.IPFSCache.Enabled {
+			envvars = []corev1.EnvVar{
+				{
+					Name: "IPFS_HOST",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "status.hostIP",
+						},
+					},
+				},
+			}
+		}
+
+		if ucfg.Workspace.RegistryFacade.RedisCache.Enabled {
+			if scr := ucfg.Workspace.RegistryFacade.RedisCache.PasswordSecret; scr != "" {
+				envvars = append(envvars, corev1.EnvVar{
+					Name: "REDIS_PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: scr,
+							},
+							Key: "password",
+						},
+					},
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return envvars, nil
+}
@@ -13,6 +13,7 @@ import (
 var Objects = common.CompositeRenderFunc(
 	clusterrole,
 	configmap,
+	cronjob,
 	daemonset,
 	networkpolicy,
 	rolebinding,
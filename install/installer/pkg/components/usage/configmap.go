@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
+	"github.com/gitpod-io/gitpod/usage/pkg/ledger"
 	"github.com/gitpod-io/gitpod/usage/pkg/server"
 	"github.com/gitpod-io/gitpod/usage/pkg/stripe"
 
@@ -89,6 +90,14 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		return nil
 	})
 
+	if _, mount, ok := getInternalLedgerConfig(expUsageConfig); ok {
+		// the internal ledger replaces Stripe and settles usage itself
+		cfg.InternalLedger = &ledger.Config{Directory: mount.MountPath}
+		cfg.StatementsSchedule = expUsageConfig.InternalLedger.StatementsSchedule
+		cfg.StripeCredentialsFile = ""
+		cfg.ResetUsageSchedule = ""
+	}
+
 	serialized, err := common.ToJSONString(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal usage config: %w", err)
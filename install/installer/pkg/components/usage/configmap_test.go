@@ -5,9 +5,12 @@
 package usage
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
+	"github.com/gitpod-io/gitpod/usage/pkg/ledger"
+	"github.com/gitpod-io/gitpod/usage/pkg/server"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -56,3 +59,25 @@ func TestConfigMap_ContainsSchedule(t *testing.T) {
 		cfgmap.Data[configJSONFilename],
 	)
 }
+
+func TestConfigMap_ConfiguresInternalLedger(t *testing.T) {
+	ctx := renderContextWithUsageConfig(t, &experimental.UsageConfig{
+		Enabled:            true,
+		Schedule:           "2m",
+		ResetUsageSchedule: "5m",
+		InternalLedger:     &experimental.UsageInternalLedger{StatementsSchedule: "1h"},
+	})
+
+	objs, err := configmap(ctx)
+	require.NoError(t, err)
+
+	cfgmap, ok := objs[0].(*corev1.ConfigMap)
+	require.True(t, ok)
+
+	var cfg server.Config
+	require.NoError(t, json.Unmarshal([]byte(cfgmap.Data[configJSONFilename]), &cfg))
+	require.Equal(t, &ledger.Config{Directory: ledgerMountPath}, cfg.InternalLedger)
+	require.Equal(t, "1h", cfg.StatementsSchedule)
+	require.Empty(t, cfg.ResetUsageSchedule, "the internal ledger settles usage itself")
+	require.Empty(t, cfg.StripeCredentialsFile, "the internal ledger replaces Stripe")
+}
@@ -12,4 +12,7 @@ const (
 	stripeSecretMountPath = "stripe-secret"
 	stripeKeyFilename     = "apikeys"
 	configJSONFilename    = "config.json"
+	ledgerVolumeName      = "usage-ledger"
+	ledgerMountPath       = "/ledger"
+	ledgerDefaultSize     = "1Gi"
 )
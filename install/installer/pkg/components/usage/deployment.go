@@ -63,6 +63,10 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 		volumeMounts = append(volumeMounts, mount)
 		return nil
 	})
+	if volume, mount, ok := getInternalLedgerConfig(getExperimentalUsageConfig(ctx)); ok {
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, mount)
+	}
 
 	configHash, err := common.ObjectHash(configmap(ctx))
 	if err != nil {
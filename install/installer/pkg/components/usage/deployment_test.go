@@ -7,9 +7,11 @@ package usage
 import (
 	"testing"
 
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestDeployment_ContainsDBEnvVars(t *testing.T) {
@@ -100,3 +102,34 @@ func TestDeployment_DisablesPaymentWhenAStripeSecretIsNotPresent(t *testing.T) {
 		require.NotContains(t, arg, "--stripe-secret-path")
 	}
 }
+
+func TestDeployment_MountsInternalLedgerVolume(t *testing.T) {
+	ctx := renderContextWithUsageConfig(t, &experimental.UsageConfig{Enabled: true, InternalLedger: &experimental.UsageInternalLedger{StorageClass: "nfs"}})
+
+	objs, err := deployment(ctx)
+	require.NoError(t, err)
+
+	dpl, ok := objs[0].(*appsv1.Deployment)
+	require.True(t, ok)
+
+	require.Contains(t, dpl.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: ledgerVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: ledgerVolumeName,
+			},
+		},
+	})
+	require.Contains(t, dpl.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      ledgerVolumeName,
+		MountPath: ledgerMountPath,
+	})
+
+	objs, err = ledgerPersistentVolumeClaim(ctx)
+	require.NoError(t, err)
+	pvc, ok := objs[0].(*corev1.PersistentVolumeClaim)
+	require.True(t, ok)
+	require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	require.Equal(t, "nfs", *pvc.Spec.StorageClassName)
+	require.Equal(t, resource.MustParse(ledgerDefaultSize), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package usage
+
+import (
+	"fmt"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// getInternalLedgerConfig produces the volume which keeps the internal ledger's state, which all usage replicas share
+func getInternalLedgerConfig(cfg *experimental.UsageConfig) (corev1.Volume, corev1.VolumeMount, bool) {
+	var volume corev1.Volume
+	var mount corev1.VolumeMount
+
+	if cfg == nil || cfg.InternalLedger == nil {
+		return volume, mount, false
+	}
+
+	volume = corev1.Volume{
+		Name: ledgerVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: ledgerVolumeName,
+			},
+		},
+	}
+
+	mount = corev1.VolumeMount{
+		Name:      ledgerVolumeName,
+		MountPath: ledgerMountPath,
+	}
+
+	return volume, mount, true
+}
+
+func ledgerPersistentVolumeClaim(ctx *common.RenderContext) ([]runtime.Object, error) {
+	cfg := getExperimentalUsageConfig(ctx)
+	if cfg == nil || cfg.InternalLedger == nil {
+		return nil, nil
+	}
+
+	size := cfg.InternalLedger.Size
+	if size == "" {
+		size = ledgerDefaultSize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid internal ledger volume size %s: %w", size, err)
+	}
+
+	var storageClass *string
+	if cfg.InternalLedger.StorageClass != "" {
+		storageClass = &cfg.InternalLedger.StorageClass
+	}
+
+	return []runtime.Object{
+		&corev1.PersistentVolumeClaim{
+			TypeMeta: common.TypeMetaPersistentVolumeClaim,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ledgerVolumeName,
+				Namespace:   ctx.Namespace,
+				Labels:      common.CustomizeLabel(ctx, Component, common.TypeMetaPersistentVolumeClaim),
+				Annotations: common.CustomizeAnnotation(ctx, Component, common.TypeMetaPersistentVolumeClaim),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteMany,
+				},
+				StorageClassName: storageClass,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: quantity,
+					},
+				},
+			},
+		},
+	}, nil
+}
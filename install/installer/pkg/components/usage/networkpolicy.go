@@ -6,6 +6,7 @@ package usage
 
 import (
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	contentservice "github.com/gitpod-io/gitpod/installer/pkg/components/content-service"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,6 +51,14 @@ func networkpolicy(ctx *common.RenderContext) ([]runtime.Object, error) {
 									},
 								},
 							},
+							{
+								// content-service reports the storage of retained backups
+								PodSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{
+										"component": contentservice.Component,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -22,6 +22,7 @@ func Objects(ctx *common.RenderContext) ([]runtime.Object, error) {
 		deployment,
 		rolebinding,
 		configmap,
+		ledgerPersistentVolumeClaim,
 		common.DefaultServiceAccount(Component),
 		service,
 		networkpolicy,
@@ -16,6 +16,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	storageconfig "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	contentservice "github.com/gitpod-io/gitpod/installer/pkg/components/content-service"
 	configv1 "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
 	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	"github.com/gitpod-io/gitpod/ws-manager/api/config"
@@ -251,6 +252,9 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			TargetAddr: fmt.Sprintf("%s.%s.svc.cluster.local:%d", common.ImageBuilderComponent, ctx.Namespace, common.ImageBuilderRPCPort),
 			TLS:        imageBuilderTLS,
 		},
+		ContentService: struct {
+			Addr string `json:"addr"`
+		}{Addr: common.ClusterAddress(contentservice.Component, ctx.Namespace, contentservice.RPCPort)},
 		PProf: struct {
 			Addr string `json:"addr"`
 		}{Addr: common.LocalhostPprofAddr()},
@@ -18,4 +18,12 @@ const (
 	VolumeWorkspaceTemplate    = "workspace-template"
 	WorkspaceTemplatePath      = "/workspace-templates"
 	WorkspaceTemplateConfigMap = "workspace-templates"
+
+	// The workspace admission webhook is served on controller-runtime's default port from its default certificate directory
+	WebhookPort          = 9443
+	WebhookPortName      = "webhook"
+	WebhookServicePort   = 443
+	WebhookTLSSecretName = "ws-manager-mk2-webhook-tls"
+	WebhookCertDir       = "/tmp/k8s-webhook-server/serving-certs"
+	VolumeWebhookCerts   = "webhook-certs"
 )
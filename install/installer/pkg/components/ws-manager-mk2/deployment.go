@@ -93,6 +93,10 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 					Name:          RPCPortName,
 					ContainerPort: RPCPort,
 				},
+				{
+					Name:          WebhookPortName,
+					ContainerPort: WebhookPort,
+				},
 			},
 			SecurityContext: &corev1.SecurityContext{
 				Privileged: pointer.Bool(false),
@@ -123,6 +127,11 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 					MountPath: "/certs",
 					ReadOnly:  true,
 				},
+				{
+					Name:      VolumeWebhookCerts,
+					MountPath: WebhookCertDir,
+					ReadOnly:  true,
+				},
 				common.CAVolumeMount(),
 			}, volumeMounts...),
 		},
@@ -157,6 +166,12 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 					Secret: &corev1.SecretVolumeSource{SecretName: TLSSecretNameSecret},
 				},
 			},
+			{
+				Name: VolumeWebhookCerts,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: WebhookTLSSecretName},
+				},
+			},
 			common.CAVolume(),
 		}, volumes...),
 	}
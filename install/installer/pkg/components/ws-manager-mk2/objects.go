@@ -25,8 +25,14 @@ var Objects common.RenderFunc = func(cfg *common.RenderContext) ([]runtime.Objec
 				ContainerPort: RPCPort,
 				ServicePort:   RPCPort,
 			},
+			{
+				Name:          WebhookPortName,
+				ContainerPort: WebhookPort,
+				ServicePort:   WebhookServicePort,
+			},
 		}),
 		tlssecret,
+		webhook,
 		unprivilegedRolebinding,
 	)(cfg)
 }
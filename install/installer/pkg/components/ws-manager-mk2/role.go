@@ -58,6 +58,27 @@ var controllerRules = []rbacv1.PolicyRule{
 			"update",
 		},
 	},
+	{
+		APIGroups: []string{"workspace.gitpod.io"},
+		Resources: []string{"workspacepools"},
+		Verbs: []string{
+			"create",
+			"delete",
+			"get",
+			"list",
+			"update",
+			"watch",
+		},
+	},
+	{
+		APIGroups: []string{"workspace.gitpod.io"},
+		Resources: []string{"workspacepools/status"},
+		Verbs: []string{
+			"get",
+			"patch",
+			"update",
+		},
+	},
 	{
 		APIGroups: []string{"workspace.gitpod.io"},
 		Resources: []string{"snapshots"},
@@ -0,0 +1,111 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package wsmanagermk2
+
+import (
+	"fmt"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+// webhook renders the workspace admission webhook, which defaults and validates all workspaces in the namespace,
+// no matter whether they were created through ws-manager or directly. cert-manager injects the CA bundle.
+func webhook(ctx *common.RenderContext) ([]runtime.Object, error) {
+	labels := common.DefaultLabels(Component)
+	annotations := map[string]string{
+		"cert-manager.io/inject-ca-from": fmt.Sprintf("%s/%s", ctx.Namespace, WebhookTLSSecretName),
+	}
+
+	var (
+		failurePolicy     = admissionregistrationv1.Fail
+		sideEffects       = admissionregistrationv1.SideEffectClassNone
+		namespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": ctx.Namespace},
+		}
+		rules = []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"workspace.gitpod.io"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"workspaces"},
+			},
+		}}
+	)
+	clientConfig := func(path string) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: ctx.Namespace,
+				Name:      Component,
+				Path:      pointer.String(path),
+				Port:      pointer.Int32(WebhookServicePort),
+			},
+		}
+	}
+
+	return []runtime.Object{
+		&certmanagerv1.Certificate{
+			TypeMeta: common.TypeMetaCertificate,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      WebhookTLSSecretName,
+				Namespace: ctx.Namespace,
+				Labels:    labels,
+			},
+			Spec: certmanagerv1.CertificateSpec{
+				Duration:   common.InternalCertDuration,
+				SecretName: WebhookTLSSecretName,
+				DNSNames: []string{
+					fmt.Sprintf("%s.%s.svc", Component, ctx.Namespace),
+					fmt.Sprintf("%s.%s.svc.cluster.local", Component, ctx.Namespace),
+				},
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  common.CertManagerCAIssuer,
+					Kind:  certmanagerv1.ClusterIssuerKind,
+					Group: "cert-manager.io",
+				},
+			},
+		},
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			TypeMeta: common.TypeMetaMutatingWebhookConfiguration,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        Component,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{{
+				Name:                    "mworkspace.kb.io",
+				ClientConfig:            clientConfig("/mutate-workspace-gitpod-io-v1-workspace"),
+				Rules:                   rules,
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				NamespaceSelector:       namespaceSelector,
+				AdmissionReviewVersions: []string{"v1"},
+			}},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			TypeMeta: common.TypeMetaValidatingWebhookConfiguration,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        Component,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name:                    "vworkspace.kb.io",
+				ClientConfig:            clientConfig("/validate-workspace-gitpod-io-v1-workspace"),
+				Rules:                   rules,
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				NamespaceSelector:       namespaceSelector,
+				AdmissionReviewVersions: []string{"v1"},
+			}},
+		},
+	}, nil
+}
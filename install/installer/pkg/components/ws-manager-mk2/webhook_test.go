@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package wsmanagermk2
+
+import (
+	"testing"
+
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	config "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+)
+
+func TestWebhook(t *testing.T) {
+	ctx, err := common.NewRenderContext(config.Config{
+		Domain: "example.com",
+		ObjectStorage: config.ObjectStorage{
+			InCluster: pointer.Bool(true),
+		},
+	}, versions.Manifest{}, "test_namespace")
+	require.NoError(t, err)
+
+	objs, err := webhook(ctx)
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+
+	cert, ok := objs[0].(*certmanagerv1.Certificate)
+	require.True(t, ok, "expected a certificate")
+	require.Equal(t, WebhookTLSSecretName, cert.Spec.SecretName)
+	require.Contains(t, cert.Spec.DNSNames, "ws-manager-mk2.test_namespace.svc")
+
+	mutating, ok := objs[1].(*admissionregistrationv1.MutatingWebhookConfiguration)
+	require.True(t, ok, "expected a mutating webhook configuration")
+	validating, ok := objs[2].(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	require.True(t, ok, "expected a validating webhook configuration")
+
+	for _, annotations := range []map[string]string{mutating.Annotations, validating.Annotations} {
+		require.Equal(t, "test_namespace/"+WebhookTLSSecretName, annotations["cert-manager.io/inject-ca-from"])
+	}
+
+	require.Len(t, mutating.Webhooks, 1)
+	require.Equal(t, &admissionregistrationv1.ServiceReference{
+		Namespace: "test_namespace",
+		Name:      Component,
+		Path:      pointer.String("/mutate-workspace-gitpod-io-v1-workspace"),
+		Port:      pointer.Int32(WebhookServicePort),
+	}, mutating.Webhooks[0].ClientConfig.Service)
+	require.Equal(t, admissionregistrationv1.Fail, *mutating.Webhooks[0].FailurePolicy)
+
+	require.Len(t, validating.Webhooks, 1)
+	require.Equal(t, "/validate-workspace-gitpod-io-v1-workspace", *validating.Webhooks[0].ClientConfig.Service.Path)
+	require.Equal(t, admissionregistrationv1.Fail, *validating.Webhooks[0].FailurePolicy)
+}
@@ -109,6 +109,14 @@ type WorkspaceConfig struct {
 			UseTLS             bool   `json:"useTLS"`
 			InsecureSkipVerify bool   `json:"insecureSkipVerify"`
 		} `json:"redisCache"`
+		// LazyPull requires the IPFS cache, which stores the converted layers
+		LazyPull struct {
+			Enabled bool `json:"enabled"`
+			// Images are converted to eStargz periodically, such that workspaces can pull them lazily
+			Images []string `json:"images"`
+			// Schedule is the cron schedule of the conversion job. Defaults to daily.
+			Schedule string `json:"schedule"`
+		} `json:"lazyPull"`
 	} `json:"registryFacade"`
 
 	WSDaemon struct {
@@ -299,6 +307,18 @@ type UsageConfig struct {
 	BillInstancesAfter               *time.Time               `json:"billInstancesAfter"`
 	DefaultSpendingLimit             *db.DefaultSpendingLimit `json:"defaultSpendingLimit"`
 	CreditsPerMinuteByWorkspaceClass map[string]float64       `json:"creditsPerMinuteByWorkspaceClass"`
+	InternalLedger                   *UsageInternalLedger     `json:"internalLedger,omitempty"`
+}
+
+// UsageInternalLedger charges back usage through monthly statements instead of billing it through Stripe
+type UsageInternalLedger struct {
+	// StatementsSchedule determines how frequently to finalize the invoices of past months
+	StatementsSchedule string `json:"statementsSchedule"`
+	// StorageClass of the volume which keeps the ledger state. All usage replicas mount the volume,
+	// hence it must support ReadWriteMany.
+	StorageClass string `json:"storageClass"`
+	// Size of the volume which keeps the ledger state. Defaults to 1Gi.
+	Size string `json:"size"`
 }
 
 type WebAppWorkspaceClass struct {